@@ -3,13 +3,19 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
 	"sync"
+	"time"
 
 	"github.com/SeaCodeBase/urlshortener/internal/cache"
 	"github.com/SeaCodeBase/urlshortener/internal/config"
 	"github.com/SeaCodeBase/urlshortener/internal/database"
 	"github.com/SeaCodeBase/urlshortener/internal/handler"
 	"github.com/SeaCodeBase/urlshortener/internal/middleware"
+	"github.com/SeaCodeBase/urlshortener/internal/model"
 	"github.com/SeaCodeBase/urlshortener/internal/repository"
 	"github.com/SeaCodeBase/urlshortener/internal/service"
 	"github.com/SeaCodeBase/urlshortener/internal/util"
@@ -70,30 +76,137 @@ func main() {
 	clickRepo := repository.NewClickRepository(db)
 	passkeyRepo := repository.NewPasskeyRepository(db)
 	domainRepo := repository.NewDomainRepository(db)
+	certificateRepo := repository.NewDomainCertificateRepository(db)
+	campaignRepo := repository.NewCampaignRepository(db)
+	aliasRepo := repository.NewLinkAliasRepository(db)
+	historyRepo := repository.NewLinkHistoryRepository(db)
+	transferRepo := repository.NewLinkTransferRepository(db)
+	sequenceRepo := repository.NewCodeSequenceRepository(db)
+	reservedRepo := repository.NewReservedCodeRepository(db)
+	apiKeyRepo := repository.NewAPIKeyRepository(db)
+	sessionRepo := repository.NewSessionRepository(db)
+	workspaceRepo := repository.NewWorkspaceRepository(db)
+	invitationRepo := repository.NewWorkspaceInvitationRepository(db)
+	auditRepo := repository.NewAuditRepository(db)
+	webhookRepo := repository.NewWebhookRepository(db)
+	deliveryRepo := repository.NewWebhookDeliveryRepository(db)
+	// Created ahead of the other services: the click flusher sends click batches to webhooks
+	webhookService := service.NewWebhookService(webhookRepo, deliveryRepo, linkRepo)
 
 	// Start click flusher worker
-	clickFlusher := worker.NewClickFlusher(rdb, clickRepo)
+	clickFlusher := worker.NewClickFlusher(rdb, clickRepo, webhookService)
 	clickFlusher.Start()
 	defer clickFlusher.Stop()
 
+	// Start webhook workers
+	webhookDispatcher := worker.NewWebhookDispatcher(webhookRepo, deliveryRepo)
+	webhookDispatcher.Start()
+	defer webhookDispatcher.Stop()
+	linkExpiryNotifier := worker.NewLinkExpiryNotifier(rdb, webhookService)
+	linkExpiryNotifier.Start()
+	defer linkExpiryNotifier.Stop()
+
+	// Start trash purger worker
+	trashPurger := worker.NewTrashPurger(linkRepo, time.Duration(cfg.Links.TrashRetentionDays)*24*time.Hour)
+	trashPurger.Start()
+	defer trashPurger.Stop()
+
 	// Setup services
-	authService := service.NewAuthService(userRepo, cfg.JWT.Secret)
-	shortCodeSvc := service.NewShortCodeService(linkRepo)
-	linkService := service.NewLinkService(linkRepo, shortCodeSvc)
-	statsService := service.NewStatsService(clickRepo, linkRepo)
-	passkeyService, err := service.NewPasskeyService(passkeyRepo, userRepo, cfg.WebAuthn.RPID, cfg.WebAuthn.RPOrigin, "URL Shortener")
+	auditService := service.NewAuditService(auditRepo, workspaceRepo)
+	authService := service.NewAuthService(userRepo, sessionRepo, auditService, rdb, service.TokenConfig{
+		Secret:     cfg.JWT.Secret,
+		AccessTTL:  time.Duration(cfg.JWT.AccessTokenMinutes) * time.Minute,
+		RefreshTTL: time.Duration(cfg.JWT.RefreshTokenDays) * 24 * time.Hour,
+	})
+	apiKeyService := service.NewAPIKeyService(apiKeyRepo)
+	workspaceService := service.NewWorkspaceService(workspaceRepo, invitationRepo, userRepo)
+	codeSequence := service.NewBlockSequence(sequenceRepo, uint64(cfg.Links.SequenceBlockSize))
+	shortCodeSvc := service.NewShortCodeService(linkRepo, domainRepo, reservedRepo, service.NewCodeGenerators(codeSequence, cfg.Links.HashidsSalt),
+		service.ShortCodeConfig{Strategy: cfg.Links.CodeStrategy, Length: cfg.Links.CodeLength, Reserved: cfg.Links.ReservedCodes})
+	linkService := service.NewLinkService(linkRepo, campaignRepo, aliasRepo, historyRepo, domainRepo, shortCodeSvc, auditService, webhookService,
+		time.Duration(cfg.Links.CodeGraceDays)*24*time.Hour)
+	// The service's own host cannot be claimed as a custom domain
+	var baseHost string
+	if baseURL, err := url.Parse(cfg.URLs.BaseURL); err == nil {
+		baseHost = baseURL.Host
+	}
+	// Give links stored before destinations were hashed their hashes
+	if filled, err := linkRepo.FillDestHashes(ctx, 500); err != nil {
+		logger.Error(ctx, "failed to hash stored link destinations", zap.Error(err))
+	} else if filled > 0 {
+		logger.Info(ctx, "hashed stored link destinations", zap.Int("count", filled))
+	}
+	domainService := service.NewDomainService(domainRepo, linkRepo, aliasRepo, shortCodeSvc, auditService, net.DefaultResolver, baseHost)
+	// Bring domains stored before names were validated into canonical form
+	if renamed, err := domainService.NormalizeExisting(ctx); err != nil {
+		logger.Error(ctx, "failed to normalize stored domains", zap.Error(err))
+	} else if renamed > 0 {
+		logger.Info(ctx, "normalized stored domains", zap.Int("count", renamed))
+	}
+
+	// Start domain verifier worker
+	domainVerifier := worker.NewDomainVerifier(domainService)
+	domainVerifier.Start()
+	defer domainVerifier.Stop()
+
+	// Certificates for HTTPS on custom domains; private keys are stored encrypted
+	tlsCfg := cfg.Server.TLS
+	var secretCipher *util.SecretCipher
+	if tlsCfg.EncryptionKey != "" {
+		secretCipher, err = util.NewSecretCipher(tlsCfg.EncryptionKey)
+		if err != nil {
+			logger.Fatal(ctx, "failed to create secret cipher", zap.Error(err))
+		}
+	}
+	var acmeClient service.ACMEClient
+	if tlsCfg.ACMEDirectory != "" {
+		acmeClient = service.NewACMEClient(tlsCfg.ACMEDirectory, tlsCfg.ACMEEmail, tlsCfg.ACMEAccountKey)
+	}
+	var fallbackCert *tls.Certificate
+	if tlsCfg.CertFile != "" {
+		pair, err := tls.LoadX509KeyPair(tlsCfg.CertFile, tlsCfg.KeyFile)
+		if err != nil {
+			logger.Fatal(ctx, "failed to load TLS certificate", zap.Error(err))
+		}
+		fallbackCert = &pair
+	}
+	certificateService := service.NewCertificateService(certificateRepo, domainRepo, secretCipher, acmeClient,
+		service.NewRedisChallengeStore(rdb), auditService, service.CertificateConfig{
+			Fallback:    fallbackCert,
+			BaseHost:    baseHost,
+			RenewBefore: time.Duration(tlsCfg.RenewBeforeDays) * 24 * time.Hour,
+		})
+	if tlsCfg.Enabled {
+		// Start certificate renewer worker
+		certificateRenewer := worker.NewCertificateRenewer(certificateService)
+		certificateRenewer.Start()
+		defer certificateRenewer.Stop()
+	}
+	statsService := service.NewStatsService(clickRepo, linkRepo, campaignRepo, domainRepo)
+	campaignService := service.NewCampaignService(campaignRepo, linkRepo)
+	transferService := service.NewTransferService(transferRepo, linkRepo, userRepo, domainRepo)
+	reservedCodeService := service.NewReservedCodeService(reservedRepo, domainRepo)
+	passkeyService, err := service.NewPasskeyService(passkeyRepo, userRepo, auditService, cfg.WebAuthn.RPID, cfg.WebAuthn.RPOrigin, "URL Shortener")
 	if err != nil {
 		logger.Fatal(ctx, "failed to create passkey service", zap.Error(err))
 	}
-	redirectService := service.NewRedirectService(linkRepo, domainRepo, rdb)
+	redirectService := service.NewRedirectService(linkRepo, domainRepo, campaignRepo, aliasRepo, rdb)
 
 	// Setup handlers
 	authHandler := handler.NewAuthHandler(authService, passkeyService, cfg)
 	linkHandler := handler.NewLinkHandler(linkService, redirectService, domainRepo, cfg)
 	statsHandler := handler.NewStatsHandler(statsService)
-	passkeyHandler := handler.NewPasskeyHandler(passkeyService)
+	passkeyHandler := handler.NewPasskeyHandler(passkeyService, authService)
 	passkeyVerifyHandler := handler.NewPasskeyVerifyHandler(passkeyService, authService)
-	domainHandler := handler.NewDomainHandler(domainRepo)
+	domainHandler := handler.NewDomainHandler(domainService, redirectService)
+	certificateHandler := handler.NewCertificateHandler(certificateService)
+	campaignHandler := handler.NewCampaignHandler(campaignService, redirectService)
+	transferHandler := handler.NewTransferHandler(transferService, redirectService)
+	reservedCodeHandler := handler.NewReservedCodeHandler(reservedCodeService)
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyService)
+	workspaceHandler := handler.NewWorkspaceHandler(workspaceService)
+	auditHandler := handler.NewAuditHandler(auditService)
+	webhookHandler := handler.NewWebhookHandler(webhookService)
 
 	// Click service
 	clickService := service.NewClickService(rdb)
@@ -106,10 +219,16 @@ func main() {
 	redirectRouter.Use(gin.Recovery())
 	redirectRouter.Use(otelgin.Middleware("redirect-server"))
 	redirectRouter.Use(middleware.LogMiddleware())
-	redirectRouter.GET("/:code", redirectHandler.Redirect)
+	if tlsCfg.Enabled && tlsCfg.RedirectHTTP {
+		redirectRouter.Use(middleware.HTTPSRedirectMiddleware(certificateService))
+	}
+	redirectRouter.GET("/.well-known/acme-challenge/:token", certificateHandler.Challenge)
 	redirectRouter.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{"status": "ok", "server": "redirect"})
 	})
+	// Domains can put their codes under a path prefix, so short links are
+	// matched by host and path rather than by route
+	redirectRouter.NoRoute(redirectHandler.Redirect)
 
 	// API Router (authenticated, full functionality)
 	apiRouter := gin.New()
@@ -117,6 +236,7 @@ func main() {
 	apiRouter.Use(otelgin.Middleware("api-server"))
 	apiRouter.Use(middleware.LogMiddleware())
 	apiRouter.Use(middleware.CORSMiddleware(cfg.Server.AllowOrigins))
+	apiRouter.Use(middleware.RequestMetaMiddleware())
 
 	apiRouter.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{"status": "ok", "server": "api"})
@@ -125,15 +245,30 @@ func main() {
 	// API Routes
 	api := apiRouter.Group("/api")
 	{
-		authMiddleware := middleware.AuthMiddleware(authService)
+		// authMiddleware only accepts JWTs; API keys need a scoped middleware
+		authMiddleware := middleware.AuthMiddleware(authService, apiKeyService, middleware.Scopes{})
+		linksAuth := middleware.AuthMiddleware(authService, apiKeyService,
+			middleware.Scopes{Read: model.ScopeLinksRead, Write: model.ScopeLinksWrite})
+		statsAuth := middleware.AuthMiddleware(authService, apiKeyService, middleware.Scopes{Read: model.ScopeStatsRead})
+		domainsAuth := middleware.AuthMiddleware(authService, apiKeyService,
+			middleware.Scopes{Read: model.ScopeDomainsRead, Write: model.ScopeDomainsWrite})
+		// workspaceMiddleware resolves X-Workspace-ID for workspace-owned resources
+		workspaceMiddleware := middleware.WorkspaceMiddleware(workspaceService)
 		auth := api.Group("/auth")
 		{
 			auth.POST("/register", authHandler.Register)
 			auth.POST("/login", authHandler.Login)
+			auth.POST("/refresh", authHandler.Refresh)
+			auth.POST("/logout", authMiddleware, authHandler.Logout)
 			auth.GET("/me", authMiddleware, authHandler.Me)
 			auth.PUT("/me", authMiddleware, authHandler.UpdateMe)
 			auth.PUT("/password", authMiddleware, authHandler.ChangePassword)
 
+			// Session routes (protected)
+			auth.GET("/sessions", authMiddleware, authHandler.ListSessions)
+			auth.DELETE("/sessions", authMiddleware, authHandler.RevokeAllSessions)
+			auth.DELETE("/sessions/:id", authMiddleware, authHandler.RevokeSession)
+
 			// Passkey routes (protected)
 			auth.GET("/passkeys", authMiddleware, passkeyHandler.List)
 			auth.POST("/passkeys/register/begin", authMiddleware, passkeyHandler.BeginRegistration)
@@ -141,6 +276,11 @@ func main() {
 			auth.PUT("/passkeys/:id", authMiddleware, passkeyHandler.Rename)
 			auth.DELETE("/passkeys/:id", authMiddleware, passkeyHandler.Delete)
 
+			// API key management (protected, not available to API keys)
+			auth.GET("/keys", authMiddleware, apiKeyHandler.List)
+			auth.POST("/keys", authMiddleware, apiKeyHandler.Create)
+			auth.DELETE("/keys/:id", authMiddleware, apiKeyHandler.Delete)
+
 			// Passkey verification routes (public - used during login flow)
 			auth.POST("/passkeys/verify/begin", passkeyVerifyHandler.BeginVerify)
 			auth.POST("/passkeys/verify/finish", passkeyVerifyHandler.FinishVerify)
@@ -148,23 +288,119 @@ func main() {
 
 		// Link routes (protected)
 		links := api.Group("/links")
-		links.Use(authMiddleware)
+		links.Use(linksAuth, workspaceMiddleware)
 		{
 			links.POST("", linkHandler.Create)
 			links.GET("", linkHandler.List)
+			links.GET("/trash", linkHandler.ListTrash)
+			links.GET("/duplicates", linkHandler.ListDuplicates)
 			links.GET("/:id", linkHandler.Get)
 			links.PUT("/:id", linkHandler.Update)
 			links.DELETE("/:id", linkHandler.Delete)
-			links.GET("/:id/stats", statsHandler.GetLinkStats)
+			links.POST("/:id/restore", linkHandler.Restore)
+			links.GET("/:id/aliases", linkHandler.ListAliases)
+			links.POST("/:id/aliases", linkHandler.AddAlias)
+			links.DELETE("/:id/aliases/:aliasId", linkHandler.DeleteAlias)
+			links.GET("/:id/history", linkHandler.ListHistory)
+			links.POST("/:id/revert/:version", linkHandler.Revert)
 		}
 
 		// Domain routes (protected)
 		domains := api.Group("/domains")
-		domains.Use(authMiddleware)
+		domains.Use(domainsAuth, workspaceMiddleware)
 		{
 			domains.GET("", domainHandler.List)
 			domains.POST("", domainHandler.Create)
+			domains.PUT("/:id", domainHandler.Update)
 			domains.DELETE("/:id", domainHandler.Delete)
+			domains.POST("/:id/verify", domainHandler.Verify)
+			domains.GET("/:id/certificate", certificateHandler.Get)
+			domains.PUT("/:id/certificate", certificateHandler.Upload)
+			domains.DELETE("/:id/certificate", certificateHandler.Delete)
+			domains.POST("/:id/certificate/acme", certificateHandler.Request)
+			domains.GET("/:id/reserved-codes", reservedCodeHandler.ListDomain)
+			domains.POST("/:id/reserved-codes", reservedCodeHandler.AddDomain)
+			domains.DELETE("/:id/reserved-codes/:codeId", reservedCodeHandler.DeleteDomain)
+		}
+
+		// Campaign routes (protected)
+		campaigns := api.Group("/campaigns")
+		campaigns.Use(authMiddleware, workspaceMiddleware)
+		{
+			campaigns.POST("", campaignHandler.Create)
+			campaigns.GET("", campaignHandler.List)
+			campaigns.GET("/:id", campaignHandler.Get)
+			campaigns.PUT("/:id", campaignHandler.Update)
+			campaigns.DELETE("/:id", campaignHandler.Delete)
+			campaigns.GET("/:id/links", campaignHandler.ListLinks)
+		}
+
+		// Stats routes (protected)
+		stats := api.Group("")
+		stats.Use(statsAuth, workspaceMiddleware)
+		{
+			stats.GET("/links/:id/stats", statsHandler.GetLinkStats)
+			stats.GET("/campaigns/:id/stats", statsHandler.GetCampaignStats)
+			stats.GET("/domains/:id/stats", statsHandler.GetDomainStats)
+			stats.GET("/stats/overview", statsHandler.GetOverview)
+		}
+
+		// Link ownership transfer routes (protected)
+		transfers := api.Group("/transfers")
+		transfers.Use(authMiddleware, workspaceMiddleware)
+		{
+			transfers.POST("", transferHandler.Create)
+			transfers.GET("", transferHandler.List)
+			transfers.POST("/:id/accept", transferHandler.Accept)
+			transfers.POST("/:id/decline", transferHandler.Decline)
+			transfers.DELETE("/:id", transferHandler.Cancel)
+		}
+
+		// Workspace routes (protected)
+		workspaces := api.Group("/workspaces")
+		workspaces.Use(authMiddleware)
+		{
+			workspaces.GET("", workspaceHandler.List)
+			workspaces.POST("", workspaceHandler.Create)
+			workspaces.PUT("/:id", workspaceHandler.Update)
+			workspaces.GET("/:id/members", workspaceHandler.ListMembers)
+			workspaces.PUT("/:id/members/:userId", workspaceHandler.UpdateMember)
+			workspaces.DELETE("/:id/members/:userId", workspaceHandler.RemoveMember)
+			workspaces.GET("/:id/invitations", workspaceHandler.ListInvitations)
+			workspaces.POST("/:id/invitations", workspaceHandler.Invite)
+			workspaces.DELETE("/:id/invitations/:invitationId", workspaceHandler.RevokeInvitation)
+		}
+		api.POST("/invitations/accept", authMiddleware, workspaceHandler.AcceptInvitation)
+
+		// Audit log routes (protected, workspace admins only)
+		audit := api.Group("/audit")
+		audit.Use(authMiddleware, workspaceMiddleware)
+		{
+			audit.GET("", auditHandler.List)
+			audit.GET("/export", auditHandler.Export)
+		}
+
+		// Webhook routes (protected)
+		webhooks := api.Group("/webhooks")
+		webhooks.Use(authMiddleware, workspaceMiddleware)
+		{
+			webhooks.GET("", webhookHandler.List)
+			webhooks.POST("", webhookHandler.Create)
+			webhooks.PUT("/:id", webhookHandler.Update)
+			webhooks.DELETE("/:id", webhookHandler.Delete)
+			webhooks.GET("/:id/deliveries", webhookHandler.ListDeliveries)
+			webhooks.POST("/:id/deliveries/:deliveryId/replay", webhookHandler.Replay)
+		}
+
+		// Admin routes (protected, admins only)
+		admin := api.Group("/admin")
+		admin.Use(authMiddleware, middleware.AdminMiddleware(authService))
+		{
+			admin.GET("/reserved-codes", reservedCodeHandler.ListGlobal)
+			admin.POST("/reserved-codes", reservedCodeHandler.AddGlobal)
+			admin.DELETE("/reserved-codes/:id", reservedCodeHandler.DeleteGlobal)
+			admin.GET("/audit", auditHandler.ListAll)
+			admin.GET("/audit/export", auditHandler.ExportAll)
 		}
 	}
 
@@ -172,6 +408,25 @@ func main() {
 	var wg sync.WaitGroup
 	wg.Add(2)
 
+	if tlsCfg.Enabled {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			server := &http.Server{
+				Addr:    ":" + tlsCfg.Port,
+				Handler: redirectRouter,
+				TLSConfig: &tls.Config{
+					GetCertificate: certificateService.GetCertificate,
+					MinVersion:     tls.VersionTLS12,
+				},
+			}
+			logger.Info(ctx, "starting HTTPS redirect server", zap.String("addr", server.Addr))
+			if err := server.ListenAndServeTLS("", ""); err != nil {
+				logger.Fatal(ctx, "HTTPS redirect server failed", zap.Error(err))
+			}
+		}()
+	}
+
 	go func() {
 		defer wg.Done()
 		addr := ":" + cfg.Server.RedirectPort
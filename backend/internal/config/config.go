@@ -10,11 +10,26 @@ import (
 
 // ServerConfig holds server-related configuration
 type ServerConfig struct {
-	Port              string   `yaml:"port"`               // Legacy, kept for backwards compat
-	RedirectPort      string   `yaml:"redirect_port"`      // Port for redirect server
-	APIPort           string   `yaml:"api_port"`           // Port for API server
-	AllowRegistration *bool    `yaml:"allow_registration"`
-	AllowOrigins      []string `yaml:"allow_origins"`
+	Port              string    `yaml:"port"`          // Legacy, kept for backwards compat
+	RedirectPort      string    `yaml:"redirect_port"` // Port for redirect server
+	APIPort           string    `yaml:"api_port"`      // Port for API server
+	AllowRegistration *bool     `yaml:"allow_registration"`
+	AllowOrigins      []string  `yaml:"allow_origins"`
+	TLS               TLSConfig `yaml:"tls"`
+}
+
+// TLSConfig holds HTTPS settings for the redirect server
+type TLSConfig struct {
+	Enabled         bool   `yaml:"enabled"`
+	Port            string `yaml:"port"`              // HTTPS port for the redirect server
+	RedirectHTTP    bool   `yaml:"redirect_http"`     // Redirect plain HTTP to HTTPS on hosts with a certificate
+	CertFile        string `yaml:"cert_file"`         // Certificate for the service's own host and unknown names
+	KeyFile         string `yaml:"key_file"`          // Private key for cert_file
+	EncryptionKey   string `yaml:"encryption_key"`    // Key for stored private keys; required when enabled
+	ACMEDirectory   string `yaml:"acme_directory"`    // ACME directory URL; empty disables requesting certificates
+	ACMEEmail       string `yaml:"acme_email"`        // Contact address for the ACME account
+	ACMEAccountKey  string `yaml:"acme_account_key"`  // File holding the ACME account key; created when missing
+	RenewBeforeDays int    `yaml:"renew_before_days"` // Days before expiry a certificate is renewed or reported
 }
 
 // DatabaseConfig holds database connection configuration
@@ -35,7 +50,9 @@ type RedisConfig struct {
 
 // JWTConfig holds JWT authentication configuration
 type JWTConfig struct {
-	Secret string `yaml:"secret"`
+	Secret             string `yaml:"secret"`
+	AccessTokenMinutes int    `yaml:"access_token_minutes"` // Lifetime of access tokens
+	RefreshTokenDays   int    `yaml:"refresh_token_days"`   // Days an unused session stays signed in
 }
 
 // WebAuthnConfig holds WebAuthn/passkey configuration
@@ -49,6 +66,17 @@ type URLsConfig struct {
 	BaseURL string `yaml:"base_url"`
 }
 
+// LinksConfig holds link management configuration
+type LinksConfig struct {
+	CodeGraceDays      int      `yaml:"code_grace_days"`      // Days a renamed link's old code keeps redirecting
+	TrashRetentionDays int      `yaml:"trash_retention_days"` // Days a deleted link stays restorable before it is purged
+	CodeStrategy       string   `yaml:"code_strategy"`        // Default code generator: random, no-lookalike, sequential, hashids or words
+	CodeLength         int      `yaml:"code_length"`          // Default generated code length
+	HashidsSalt        string   `yaml:"hashids_salt"`         // Salt for the hashids strategy; the strategy is unavailable without it
+	SequenceBlockSize  int      `yaml:"sequence_block_size"`  // Sequence values each instance reserves at once for sequential/hashids codes
+	ReservedCodes      []string `yaml:"reserved_codes"`       // Codes nobody may claim, in addition to the built-in list
+}
+
 // GeoIPConfig holds GeoIP database configuration
 type GeoIPConfig struct {
 	Path string `yaml:"path"`
@@ -62,6 +90,7 @@ type Config struct {
 	JWT      JWTConfig      `yaml:"jwt"`
 	WebAuthn WebAuthnConfig `yaml:"webauthn"`
 	URLs     URLsConfig     `yaml:"urls"`
+	Links    LinksConfig    `yaml:"links"`
 	GeoIP    GeoIPConfig    `yaml:"geoip"`
 }
 
@@ -109,6 +138,15 @@ func applyDefaults(cfg *Config) {
 	if cfg.Server.APIPort == "" {
 		cfg.Server.APIPort = "8081"
 	}
+	if cfg.Server.TLS.Port == "" {
+		cfg.Server.TLS.Port = "8443"
+	}
+	if cfg.Server.TLS.ACMEAccountKey == "" {
+		cfg.Server.TLS.ACMEAccountKey = "acme-account.key"
+	}
+	if cfg.Server.TLS.RenewBeforeDays <= 0 {
+		cfg.Server.TLS.RenewBeforeDays = 30
+	}
 	if cfg.Server.AllowRegistration == nil {
 		allowReg := true
 		cfg.Server.AllowRegistration = &allowReg
@@ -140,6 +178,27 @@ func applyDefaults(cfg *Config) {
 	if cfg.URLs.BaseURL == "" {
 		cfg.URLs.BaseURL = "http://localhost:8080"
 	}
+	if cfg.JWT.AccessTokenMinutes <= 0 {
+		cfg.JWT.AccessTokenMinutes = 15
+	}
+	if cfg.JWT.RefreshTokenDays <= 0 {
+		cfg.JWT.RefreshTokenDays = 30
+	}
+	if cfg.Links.CodeGraceDays <= 0 {
+		cfg.Links.CodeGraceDays = 30
+	}
+	if cfg.Links.TrashRetentionDays <= 0 {
+		cfg.Links.TrashRetentionDays = 30
+	}
+	if cfg.Links.CodeStrategy == "" {
+		cfg.Links.CodeStrategy = "random"
+	}
+	if cfg.Links.CodeLength <= 0 {
+		cfg.Links.CodeLength = 7
+	}
+	if cfg.Links.SequenceBlockSize <= 0 {
+		cfg.Links.SequenceBlockSize = 100
+	}
 	if cfg.WebAuthn.RPID == "" {
 		cfg.WebAuthn.RPID = "localhost"
 	}
@@ -153,5 +212,14 @@ func validate(cfg *Config) error {
 	if cfg.JWT.Secret == "" {
 		return errors.New("jwt.secret is required in config.yaml")
 	}
+	if (cfg.Server.TLS.CertFile == "") != (cfg.Server.TLS.KeyFile == "") {
+		return errors.New("server.tls.cert_file and server.tls.key_file must be set together")
+	}
+	if cfg.Links.CodeStrategy == "hashids" && cfg.Links.HashidsSalt == "" {
+		return errors.New("links.hashids_salt is required for the hashids code strategy")
+	}
+	if cfg.Server.TLS.Enabled && cfg.Server.TLS.EncryptionKey == "" {
+		return errors.New("server.tls.encryption_key is required when server.tls.enabled is set")
+	}
 	return nil
 }
@@ -75,6 +75,41 @@ jwt:
 	assert.Contains(t, err.Error(), "jwt.secret")
 }
 
+func TestLoadYAML_HashidsRequiresSalt(t *testing.T) {
+	content := `
+links:
+  code_strategy: "hashids"
+jwt:
+  secret: "minimum-required-secret-for-test!"
+`
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	err := os.WriteFile(configPath, []byte(content), 0644)
+	require.NoError(t, err)
+
+	_, err = LoadFromYAML(configPath)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "links.hashids_salt")
+}
+
+func TestLoadYAML_TLSRequiresEncryptionKey(t *testing.T) {
+	content := `
+server:
+  tls:
+    enabled: true
+jwt:
+  secret: "minimum-required-secret-for-test!"
+`
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	err := os.WriteFile(configPath, []byte(content), 0644)
+	require.NoError(t, err)
+
+	_, err = LoadFromYAML(configPath)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "server.tls.encryption_key")
+}
+
 func TestLoadYAML_FileNotFound(t *testing.T) {
 	_, err := LoadFromYAML("/nonexistent/config.yaml")
 	assert.Error(t, err)
@@ -116,4 +151,11 @@ jwt:
 	assert.Equal(t, "http://localhost:8080", cfg.URLs.BaseURL)
 	assert.Equal(t, "localhost", cfg.WebAuthn.RPID)
 	assert.Equal(t, "http://localhost:3000", cfg.WebAuthn.RPOrigin)
+	assert.Equal(t, 15, cfg.JWT.AccessTokenMinutes)
+	assert.Equal(t, 30, cfg.JWT.RefreshTokenDays)
+	assert.Equal(t, 30, cfg.Links.CodeGraceDays)
+	assert.Equal(t, 30, cfg.Links.TrashRetentionDays)
+	assert.Equal(t, "random", cfg.Links.CodeStrategy)
+	assert.Equal(t, 7, cfg.Links.CodeLength)
+	assert.Equal(t, 100, cfg.Links.SequenceBlockSize)
 }
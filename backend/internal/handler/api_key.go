@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SeaCodeBase/urlshortener/internal/middleware"
+	"github.com/SeaCodeBase/urlshortener/internal/service"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type APIKeyHandler struct {
+	apiKeyService service.APIKeyService
+}
+
+func NewAPIKeyHandler(apiKeyService service.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{apiKeyService: apiKeyService}
+}
+
+func (h *APIKeyHandler) List(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := middleware.GetUserID(c)
+
+	keys, err := h.apiKeyService.List(ctx, userID)
+	if err != nil {
+		logger.Error(ctx, "list-api-keys: failed",
+			zap.Uint64("user_id", userID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list api keys"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"keys": keys})
+}
+
+// Create issues a key. The response is the only time the plaintext key is shown.
+func (h *APIKeyHandler) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := middleware.GetUserID(c)
+
+	var input service.CreateAPIKeyInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		logger.Warn(ctx, "create-api-key: invalid request body",
+			zap.Uint64("user_id", userID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	created, err := h.apiKeyService.Create(ctx, userID, input)
+	if errors.Is(err, service.ErrUnknownScope) || errors.Is(err, service.ErrAPIKeyExpiresAt) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "create-api-key: failed",
+			zap.Uint64("user_id", userID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create api key"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+func (h *APIKeyHandler) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := middleware.GetUserID(c)
+
+	keyID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		logger.Warn(ctx, "delete-api-key: invalid key ID",
+			zap.String("key_id_param", c.Param("id")),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid api key ID"})
+		return
+	}
+
+	err = h.apiKeyService.Delete(ctx, userID, keyID)
+	if errors.Is(err, service.ErrAPIKeyNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "api key not found"})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "delete-api-key: failed",
+			zap.Uint64("key_id", keyID),
+			zap.Uint64("user_id", userID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete api key"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "api key deleted"})
+}
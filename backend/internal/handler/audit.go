@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/SeaCodeBase/urlshortener/internal/middleware"
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/internal/service"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type AuditHandler struct {
+	auditService service.AuditService
+}
+
+func NewAuditHandler(auditService service.AuditService) *AuditHandler {
+	return &AuditHandler{auditService: auditService}
+}
+
+// List returns a page of the current workspace's audit log, newest first.
+// Pass the last entry's id as before_id to fetch the next page.
+func (h *AuditHandler) List(c *gin.Context) {
+	actor := middleware.GetActor(c)
+	h.list(c, func(query service.AuditQuery) ([]model.AuditEntry, error) {
+		return h.auditService.List(c.Request.Context(), actor, query)
+	})
+}
+
+// Export streams the current workspace's audit log as NDJSON
+func (h *AuditHandler) Export(c *gin.Context) {
+	actor := middleware.GetActor(c)
+	h.export(c, func(query service.AuditQuery) ([]model.AuditEntry, error) {
+		return h.auditService.List(c.Request.Context(), actor, query)
+	})
+}
+
+// ListAll returns a page of the whole audit log (admin only)
+func (h *AuditHandler) ListAll(c *gin.Context) {
+	h.list(c, func(query service.AuditQuery) ([]model.AuditEntry, error) {
+		return h.auditService.ListAll(c.Request.Context(), query)
+	})
+}
+
+// ExportAll streams the whole audit log as NDJSON (admin only)
+func (h *AuditHandler) ExportAll(c *gin.Context) {
+	h.export(c, func(query service.AuditQuery) ([]model.AuditEntry, error) {
+		return h.auditService.ListAll(c.Request.Context(), query)
+	})
+}
+
+type auditLister func(query service.AuditQuery) ([]model.AuditEntry, error)
+
+func (h *AuditHandler) list(c *gin.Context, lister auditLister) {
+	ctx := c.Request.Context()
+	query, ok := parseAuditQuery(c)
+	if !ok {
+		return
+	}
+
+	entries, err := lister(query)
+	if errors.Is(err, service.ErrInsufficientRole) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "list-audit: failed",
+			zap.Uint64("user_id", middleware.GetUserID(c)),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list audit log"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"entries": entries})
+}
+
+// export pages through every entry matching the query, writing one JSON
+// object per line. Errors after the first line can only end the stream.
+func (h *AuditHandler) export(c *gin.Context, lister auditLister) {
+	ctx := c.Request.Context()
+	query, ok := parseAuditQuery(c)
+	if !ok {
+		return
+	}
+	query.Limit = service.MaxAuditPageSize
+
+	entries, err := lister(query)
+	if errors.Is(err, service.ErrInsufficientRole) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "export-audit: failed",
+			zap.Uint64("user_id", middleware.GetUserID(c)),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export audit log"})
+		return
+	}
+
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Header("Content-Disposition", `attachment; filename="audit.ndjson"`)
+	c.Status(http.StatusOK)
+	encoder := json.NewEncoder(c.Writer)
+	for len(entries) > 0 {
+		for i := range entries {
+			if err := encoder.Encode(entries[i]); err != nil {
+				logger.Warn(ctx, "export-audit: client went away", zap.Error(err))
+				return
+			}
+		}
+		c.Writer.Flush()
+		if len(entries) < query.Limit {
+			return
+		}
+		query.BeforeID = entries[len(entries)-1].ID
+		if entries, err = lister(query); err != nil {
+			logger.Error(ctx, "export-audit: failed mid-stream",
+				zap.Uint64("before_id", query.BeforeID),
+				zap.Error(err),
+			)
+			return
+		}
+	}
+}
+
+// parseAuditQuery reads the audit filters from the query string, responding
+// with 400 and returning false when one is malformed.
+func parseAuditQuery(c *gin.Context) (service.AuditQuery, bool) {
+	query := service.AuditQuery{
+		Action:     c.Query("action"),
+		TargetType: c.Query("target_type"),
+	}
+	ids := map[string]*uint64{
+		"actor_id":  &query.ActorUserID,
+		"target_id": &query.TargetID,
+		"before_id": &query.BeforeID,
+	}
+	for name, dst := range ids {
+		if raw := c.Query(name); raw != "" {
+			id, err := strconv.ParseUint(raw, 10, 64)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + name})
+				return query, false
+			}
+			*dst = id
+		}
+	}
+	times := map[string]**time.Time{
+		"since": &query.Since,
+		"until": &query.Until,
+	}
+	for name, dst := range times {
+		if raw := c.Query(name); raw != "" {
+			t, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": name + " must be an RFC 3339 timestamp"})
+				return query, false
+			}
+			*dst = &t
+		}
+	}
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return query, false
+		}
+		query.Limit = limit
+	}
+	return query, true
+}
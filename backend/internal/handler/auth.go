@@ -4,6 +4,7 @@ package handler
 import (
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/SeaCodeBase/urlshortener/internal/config"
 	"github.com/SeaCodeBase/urlshortener/internal/middleware"
@@ -46,7 +47,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.authService.Register(ctx, input)
+	resp, err := h.authService.Register(ctx, input, sessionMeta(c))
 	if errors.Is(err, service.ErrEmailTaken) {
 		logger.Warn(ctx, "register: email already taken",
 			zap.String("email", input.Email),
@@ -84,7 +85,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.authService.Login(ctx, input)
+	user, err := h.authService.Login(ctx, input)
 	if errors.Is(err, service.ErrInvalidCredentials) {
 		logger.Warn(ctx, "login: invalid credentials",
 			zap.String("email", input.Email),
@@ -101,10 +102,10 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	hasPasskeys, err := h.passkeyService.HasPasskeys(ctx, resp.User.ID)
+	hasPasskeys, err := h.passkeyService.HasPasskeys(ctx, user.ID)
 	if err != nil {
 		logger.Error(ctx, "login: failed to check passkeys",
-			zap.Uint64("user_id", resp.User.ID),
+			zap.Uint64("user_id", user.ID),
 			zap.Error(err),
 		)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check passkeys"})
@@ -114,14 +115,140 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	if hasPasskeys {
 		c.JSON(http.StatusOK, LoginResponse{
 			RequiresPasskey: true,
-			UserID:          resp.User.ID,
+			UserID:          user.ID,
 		})
 		return
 	}
 
+	resp, err := h.authService.StartSession(ctx, user.ID, sessionMeta(c))
+	if err != nil {
+		logger.Error(ctx, "login: failed to start session",
+			zap.Uint64("user_id", user.ID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to login"})
+		return
+	}
+
 	c.JSON(http.StatusOK, resp)
 }
 
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn(ctx, "refresh: invalid request body",
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.authService.Refresh(ctx, req.RefreshToken, sessionMeta(c))
+	if errors.Is(err, service.ErrInvalidRefreshToken) {
+		logger.Warn(ctx, "refresh: invalid refresh token")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired refresh token"})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "refresh: failed to refresh session",
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to refresh session"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := middleware.GetUserID(c)
+	sessions, err := h.authService.ListSessions(ctx, userID, middleware.GetSessionID(c))
+	if err != nil {
+		logger.Error(ctx, "list-sessions: failed to list sessions",
+			zap.Uint64("user_id", userID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list sessions"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"sessions": sessions})
+}
+
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := middleware.GetUserID(c)
+	sessionID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		logger.Warn(ctx, "revoke-session: invalid session ID",
+			zap.String("session_id_param", c.Param("id")),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid session id"})
+		return
+	}
+
+	err = h.authService.RevokeSession(ctx, userID, sessionID)
+	if errors.Is(err, service.ErrSessionNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "session not found"})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "revoke-session: failed to revoke session",
+			zap.Uint64("user_id", userID),
+			zap.Uint64("session_id", sessionID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to revoke session"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "session revoked"})
+}
+
+// Logout ends the session the request was made with.
+func (h *AuthHandler) Logout(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := middleware.GetUserID(c)
+	err := h.authService.RevokeSession(ctx, userID, middleware.GetSessionID(c))
+	if err != nil && !errors.Is(err, service.ErrSessionNotFound) {
+		logger.Error(ctx, "logout: failed to revoke session",
+			zap.Uint64("user_id", userID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to logout"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "logged out"})
+}
+
+// RevokeAllSessions logs the user out everywhere, including this session.
+func (h *AuthHandler) RevokeAllSessions(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := middleware.GetUserID(c)
+	if err := h.authService.RevokeAllSessions(ctx, userID, 0); err != nil {
+		logger.Error(ctx, "revoke-all-sessions: failed to revoke sessions",
+			zap.Uint64("user_id", userID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to revoke sessions"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "all sessions revoked"})
+}
+
+// sessionMeta records the device a session is used from.
+func sessionMeta(c *gin.Context) service.SessionMeta {
+	return service.SessionMeta{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+}
+
 func (h *AuthHandler) Me(c *gin.Context) {
 	ctx := c.Request.Context()
 	userID := middleware.GetUserID(c)
@@ -152,7 +279,7 @@ func (h *AuthHandler) ChangePassword(c *gin.Context) {
 		return
 	}
 
-	err := h.authService.ChangePassword(ctx, userID, input)
+	err := h.authService.ChangePassword(ctx, userID, middleware.GetSessionID(c), input)
 	if errors.Is(err, service.ErrWrongPassword) {
 		logger.Warn(ctx, "change-password: wrong current password",
 			zap.Uint64("user_id", userID),
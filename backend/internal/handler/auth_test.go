@@ -17,17 +17,25 @@ import (
 
 // MockAuthService implements service.AuthService for testing
 type MockAuthService struct {
-	RegisterFunc func(ctx context.Context, input service.RegisterInput) (*service.AuthResponse, error)
+	RegisterFunc func(ctx context.Context, input service.RegisterInput, meta service.SessionMeta) (*service.AuthResponse, error)
 }
 
-func (m *MockAuthService) Register(ctx context.Context, input service.RegisterInput) (*service.AuthResponse, error) {
+func (m *MockAuthService) Register(ctx context.Context, input service.RegisterInput, meta service.SessionMeta) (*service.AuthResponse, error) {
 	if m.RegisterFunc != nil {
-		return m.RegisterFunc(ctx, input)
+		return m.RegisterFunc(ctx, input, meta)
 	}
 	return &service.AuthResponse{Token: "test-token"}, nil
 }
 
-func (m *MockAuthService) Login(ctx context.Context, input service.LoginInput) (*service.AuthResponse, error) {
+func (m *MockAuthService) Login(ctx context.Context, input service.LoginInput) (*model.User, error) {
+	return nil, nil
+}
+
+func (m *MockAuthService) StartSession(ctx context.Context, userID uint64, meta service.SessionMeta) (*service.AuthResponse, error) {
+	return nil, nil
+}
+
+func (m *MockAuthService) Refresh(ctx context.Context, refreshToken string, meta service.SessionMeta) (*service.AuthResponse, error) {
 	return nil, nil
 }
 
@@ -35,11 +43,11 @@ func (m *MockAuthService) GetUserByID(ctx context.Context, userID uint64) (*mode
 	return nil, nil
 }
 
-func (m *MockAuthService) ValidateToken(tokenString string) (uint64, error) {
-	return 0, nil
+func (m *MockAuthService) ValidateToken(ctx context.Context, tokenString string) (*service.TokenClaims, error) {
+	return nil, nil
 }
 
-func (m *MockAuthService) ChangePassword(ctx context.Context, userID uint64, input service.ChangePasswordInput) error {
+func (m *MockAuthService) ChangePassword(ctx context.Context, userID, currentSessionID uint64, input service.ChangePasswordInput) error {
 	return nil
 }
 
@@ -47,8 +55,16 @@ func (m *MockAuthService) UpdateDisplayName(ctx context.Context, userID uint64,
 	return nil
 }
 
-func (m *MockAuthService) GenerateToken(userID uint64) (string, error) {
-	return "", nil
+func (m *MockAuthService) ListSessions(ctx context.Context, userID, currentSessionID uint64) ([]model.Session, error) {
+	return nil, nil
+}
+
+func (m *MockAuthService) RevokeSession(ctx context.Context, userID, sessionID uint64) error {
+	return nil
+}
+
+func (m *MockAuthService) RevokeAllSessions(ctx context.Context, userID, exceptSessionID uint64) error {
+	return nil
 }
 
 // MockPasskeyService implements service.PasskeyService for testing
@@ -132,7 +148,7 @@ func TestRegisterEnabled(t *testing.T) {
 
 	registerCalled := false
 	mockAuthService := &MockAuthService{
-		RegisterFunc: func(ctx context.Context, input service.RegisterInput) (*service.AuthResponse, error) {
+		RegisterFunc: func(ctx context.Context, input service.RegisterInput, meta service.SessionMeta) (*service.AuthResponse, error) {
 			registerCalled = true
 			return &service.AuthResponse{
 				Token: "test-token",
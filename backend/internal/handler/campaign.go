@@ -0,0 +1,264 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SeaCodeBase/urlshortener/internal/middleware"
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/internal/service"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type CampaignHandler struct {
+	campaignService service.CampaignService
+	redirectService *service.RedirectService
+}
+
+func NewCampaignHandler(campaignService service.CampaignService, redirectService *service.RedirectService) *CampaignHandler {
+	return &CampaignHandler{
+		campaignService: campaignService,
+		redirectService: redirectService,
+	}
+}
+
+// invalidateMemberCaches drops cached redirects for the campaign's links so
+// changed defaults take effect immediately.
+func (h *CampaignHandler) invalidateMemberCaches(ctx context.Context, links []model.Link) {
+	for _, link := range links {
+		if err := h.redirectService.InvalidateLinkCaches(ctx, &link); err != nil {
+			logger.Warn(ctx, "campaign-handler: failed to invalidate cache",
+				zap.Uint64("link_id", link.ID),
+				zap.String("short_code", link.ShortCode),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+func (h *CampaignHandler) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+
+	var input service.CreateCampaignInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		logger.Warn(ctx, "create-campaign: invalid request body",
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	campaign, err := h.campaignService.Create(ctx, actor, input)
+	if errors.Is(err, service.ErrInvalidCampaignDates) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, service.ErrInsufficientRole) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "create-campaign: failed",
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create campaign"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, campaign)
+}
+
+func (h *CampaignHandler) List(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+
+	campaigns, err := h.campaignService.List(ctx, actor)
+	if err != nil {
+		logger.Error(ctx, "list-campaigns: failed",
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list campaigns"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"campaigns": campaigns})
+}
+
+func (h *CampaignHandler) Get(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+	campaignID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		logger.Warn(ctx, "get-campaign: invalid campaign ID",
+			zap.String("campaign_id_param", c.Param("id")),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid campaign ID"})
+		return
+	}
+
+	campaign, err := h.campaignService.GetByID(ctx, actor, campaignID)
+	if errors.Is(err, service.ErrCampaignNotFound) || errors.Is(err, service.ErrNotCampaignOwner) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "campaign not found"})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "get-campaign: failed",
+			zap.Uint64("campaign_id", campaignID),
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get campaign"})
+		return
+	}
+
+	c.JSON(http.StatusOK, campaign)
+}
+
+func (h *CampaignHandler) Update(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+	campaignID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		logger.Warn(ctx, "update-campaign: invalid campaign ID",
+			zap.String("campaign_id_param", c.Param("id")),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid campaign ID"})
+		return
+	}
+
+	var input service.UpdateCampaignInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		logger.Warn(ctx, "update-campaign: invalid request body",
+			zap.Uint64("campaign_id", campaignID),
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	campaign, err := h.campaignService.Update(ctx, actor, campaignID, input)
+	if errors.Is(err, service.ErrCampaignNotFound) || errors.Is(err, service.ErrNotCampaignOwner) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "campaign not found"})
+		return
+	}
+	if errors.Is(err, service.ErrInvalidCampaignDates) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, service.ErrInsufficientRole) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "update-campaign: failed",
+			zap.Uint64("campaign_id", campaignID),
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update campaign"})
+		return
+	}
+
+	// Member links inherit UTM defaults and expiry, so their cached redirects are stale
+	links, err := h.campaignService.ListLinks(ctx, actor, campaignID)
+	if err != nil {
+		logger.Warn(ctx, "update-campaign: failed to list links for cache invalidation",
+			zap.Uint64("campaign_id", campaignID),
+			zap.Error(err),
+		)
+	} else {
+		h.invalidateMemberCaches(ctx, links)
+	}
+
+	c.JSON(http.StatusOK, campaign)
+}
+
+func (h *CampaignHandler) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+	campaignID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		logger.Warn(ctx, "delete-campaign: invalid campaign ID",
+			zap.String("campaign_id_param", c.Param("id")),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid campaign ID"})
+		return
+	}
+
+	// Collect members before the FK detaches them
+	links, err := h.campaignService.ListLinks(ctx, actor, campaignID)
+	if errors.Is(err, service.ErrCampaignNotFound) || errors.Is(err, service.ErrNotCampaignOwner) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "campaign not found"})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "delete-campaign: failed to list links",
+			zap.Uint64("campaign_id", campaignID),
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete campaign"})
+		return
+	}
+
+	err = h.campaignService.Delete(ctx, actor, campaignID)
+	if errors.Is(err, service.ErrInsufficientRole) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "delete-campaign: failed",
+			zap.Uint64("campaign_id", campaignID),
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete campaign"})
+		return
+	}
+
+	h.invalidateMemberCaches(ctx, links)
+	c.Status(http.StatusNoContent)
+}
+
+func (h *CampaignHandler) ListLinks(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+	campaignID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		logger.Warn(ctx, "list-campaign-links: invalid campaign ID",
+			zap.String("campaign_id_param", c.Param("id")),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid campaign ID"})
+		return
+	}
+
+	links, err := h.campaignService.ListLinks(ctx, actor, campaignID)
+	if errors.Is(err, service.ErrCampaignNotFound) || errors.Is(err, service.ErrNotCampaignOwner) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "campaign not found"})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "list-campaign-links: failed",
+			zap.Uint64("campaign_id", campaignID),
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list campaign links"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"links": links})
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/SeaCodeBase/urlshortener/internal/middleware"
+	"github.com/SeaCodeBase/urlshortener/internal/service"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type CertificateHandler struct {
+	certificateService service.CertificateService
+}
+
+func NewCertificateHandler(certificateService service.CertificateService) *CertificateHandler {
+	return &CertificateHandler{certificateService: certificateService}
+}
+
+func (h *CertificateHandler) Get(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+	id, ok := parseDomainID(c)
+	if !ok {
+		return
+	}
+
+	cert, err := h.certificateService.Get(ctx, actor, id)
+	if err != nil {
+		h.handleError(c, "get certificate", err, "Failed to get certificate")
+		return
+	}
+
+	c.JSON(http.StatusOK, cert)
+}
+
+// Upload sets the domain's certificate from a PEM chain and private key
+func (h *CertificateHandler) Upload(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+	id, ok := parseDomainID(c)
+	if !ok {
+		return
+	}
+
+	var input service.UploadCertificateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		logger.Warn(ctx, "certificate-handler: invalid request body",
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	cert, err := h.certificateService.Upload(ctx, actor, id, input)
+	if err != nil {
+		h.handleError(c, "upload certificate", err, "Failed to upload certificate")
+		return
+	}
+
+	c.JSON(http.StatusOK, cert)
+}
+
+// Request obtains a certificate for the domain from the ACME certificate
+// authority. The domain must already point at the redirect server.
+func (h *CertificateHandler) Request(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+	id, ok := parseDomainID(c)
+	if !ok {
+		return
+	}
+
+	cert, err := h.certificateService.Request(ctx, actor, id)
+	if err != nil {
+		h.handleError(c, "request certificate", err, "Failed to request certificate")
+		return
+	}
+
+	c.JSON(http.StatusOK, cert)
+}
+
+func (h *CertificateHandler) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+	id, ok := parseDomainID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.certificateService.Delete(ctx, actor, id); err != nil {
+		h.handleError(c, "delete certificate", err, "Failed to delete certificate")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Certificate deleted"})
+}
+
+// Challenge answers the certificate authority's HTTP-01 challenge on the
+// redirect server
+func (h *CertificateHandler) Challenge(c *gin.Context) {
+	keyAuth, err := h.certificateService.ChallengeResponse(c.Request.Context(), c.Param("token"))
+	if errors.Is(err, service.ErrChallengeNotFound) {
+		c.String(http.StatusNotFound, "not found")
+		return
+	}
+	if err != nil {
+		logger.Error(c.Request.Context(), "certificate-handler: failed to get acme challenge",
+			zap.Error(err),
+		)
+		c.String(http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	c.String(http.StatusOK, keyAuth)
+}
+
+func (h *CertificateHandler) handleError(c *gin.Context, op string, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrDomainNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Domain not found"})
+	case errors.Is(err, service.ErrCertificateNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Certificate not found"})
+	case errors.Is(err, service.ErrInsufficientRole):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Managing certificates requires the admin role"})
+	case errors.Is(err, service.ErrDomainNotVerified):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Domain is not verified"})
+	case errors.Is(err, service.ErrInvalidCertificate):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrACMEWildcard):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Wildcard certificates must be uploaded"})
+	case errors.Is(err, service.ErrTLSDisabled):
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "HTTPS is not configured"})
+	case errors.Is(err, service.ErrACMEDisabled):
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "Certificate requests are not configured"})
+	case errors.Is(err, service.ErrACMEFailed):
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+	default:
+		logger.Error(c.Request.Context(), "certificate-handler: failed to "+op,
+			zap.Uint64("workspace_id", middleware.GetActor(c).WorkspaceID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
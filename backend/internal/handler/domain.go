@@ -7,30 +7,32 @@ import (
 
 	"github.com/SeaCodeBase/urlshortener/internal/middleware"
 	"github.com/SeaCodeBase/urlshortener/internal/model"
-	"github.com/SeaCodeBase/urlshortener/internal/repository"
+	"github.com/SeaCodeBase/urlshortener/internal/service"
 	"github.com/SeaCodeBase/urlshortener/pkg/logger"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
 
 type DomainHandler struct {
-	domainRepo repository.DomainRepository
+	domainService   service.DomainService
+	redirectService *service.RedirectService
 }
 
-func NewDomainHandler(domainRepo repository.DomainRepository) *DomainHandler {
-	return &DomainHandler{domainRepo: domainRepo}
-}
-
-type CreateDomainRequest struct {
-	Domain string `json:"domain" binding:"required"`
+func NewDomainHandler(domainService service.DomainService, redirectService *service.RedirectService) *DomainHandler {
+	return &DomainHandler{
+		domainService:   domainService,
+		redirectService: redirectService,
+	}
 }
 
+// Create adds a pending domain. The response carries the token to publish
+// in a TXT record at _urlshortener-challenge.<domain> before calling Verify.
 func (h *DomainHandler) Create(c *gin.Context) {
 	ctx := c.Request.Context()
-	userID := middleware.GetUserID(c)
+	actor := middleware.GetActor(c)
 
-	var req CreateDomainRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	var input service.CreateDomainInput
+	if err := c.ShouldBindJSON(&input); err != nil {
 		logger.Warn(ctx, "domain-handler: invalid request body",
 			zap.Error(err),
 		)
@@ -38,21 +40,9 @@ func (h *DomainHandler) Create(c *gin.Context) {
 		return
 	}
 
-	domain := &model.Domain{
-		UserID: userID,
-		Domain: req.Domain,
-	}
-
-	if err := h.domainRepo.Create(ctx, domain); err != nil {
-		if errors.Is(err, repository.ErrDomainExists) {
-			c.JSON(http.StatusConflict, gin.H{"error": "Domain already in use"})
-			return
-		}
-		logger.Error(ctx, "domain-handler: failed to create domain",
-			zap.String("domain", req.Domain),
-			zap.Error(err),
-		)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create domain"})
+	domain, err := h.domainService.Create(ctx, actor, input)
+	if err != nil {
+		h.handleError(c, "create domain", err, "Failed to create domain")
 		return
 	}
 
@@ -61,12 +51,12 @@ func (h *DomainHandler) Create(c *gin.Context) {
 
 func (h *DomainHandler) List(c *gin.Context) {
 	ctx := c.Request.Context()
-	userID := middleware.GetUserID(c)
+	actor := middleware.GetActor(c)
 
-	domains, err := h.domainRepo.ListByUserID(ctx, userID)
+	domains, err := h.domainService.List(ctx, actor)
 	if err != nil {
 		logger.Error(ctx, "domain-handler: failed to list domains",
-			zap.Uint64("userID", userID),
+			zap.Uint64("workspace_id", actor.WorkspaceID),
 			zap.Error(err),
 		)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list domains"})
@@ -76,45 +66,155 @@ func (h *DomainHandler) List(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"domains": domains})
 }
 
-func (h *DomainHandler) Delete(c *gin.Context) {
+// Update changes the domain's settings: its root, not-found and expired-link
+// redirects, HTTPS and redirect status, code strategy and length, the expiry
+// and UTM defaults of new links, and whether it is the workspace default
+func (h *DomainHandler) Update(c *gin.Context) {
 	ctx := c.Request.Context()
-	userID := middleware.GetUserID(c)
+	actor := middleware.GetActor(c)
+	id, ok := parseDomainID(c)
+	if !ok {
+		return
+	}
 
-	idParam := c.Param("id")
-	id, err := strconv.ParseUint(idParam, 10, 64)
+	var input service.UpdateDomainInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		logger.Warn(ctx, "domain-handler: invalid request body",
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	domain, err := h.domainService.Update(ctx, actor, id, input)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid domain ID"})
+		h.handleError(c, "update domain", err, "Failed to update domain")
+		return
+	}
+	h.invalidateCache(c, domain)
+
+	c.JSON(http.StatusOK, domain)
+}
+
+// Delete removes the domain. The strategy query parameter says what happens
+// to its links: block refuses while there are any, move puts them on
+// target_domain_id (0 for the service's own domain) and archive trashes them.
+func (h *DomainHandler) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+	id, ok := parseDomainID(c)
+	if !ok {
+		return
+	}
+
+	var input service.DeleteDomainInput
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "strategy must be block, move or archive"})
 		return
 	}
 
-	// Check ownership
-	domain, err := h.domainRepo.GetByID(ctx, id)
+	domain, err := h.domainService.Get(ctx, actor, id)
+	if err != nil {
+		h.handleError(c, "delete domain", err, "Failed to delete domain")
+		return
+	}
+	result, err := h.domainService.Delete(ctx, actor, id, input)
 	if err != nil {
-		if errors.Is(err, repository.ErrDomainNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Domain not found"})
+		var conflict *service.DomainMoveConflictError
+		if errors.As(err, &conflict) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":     "Some short codes are taken or not allowed on the target domain",
+				"conflicts": conflict.Conflicts,
+			})
 			return
 		}
-		logger.Error(ctx, "domain-handler: failed to get domain",
-			zap.Uint64("id", id),
+		h.handleError(c, "delete domain", err, "Failed to delete domain")
+		return
+	}
+	// Moved or archived links must stop resolving under the old host
+	if err := h.redirectService.InvalidateHostCache(ctx, domain); err != nil {
+		logger.Warn(ctx, "domain-handler: failed to invalidate cache",
+			zap.Uint64("domain_id", domain.ID),
 			zap.Error(err),
 		)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get domain"})
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Verify checks DNS for the domain's verification TXT record
+func (h *DomainHandler) Verify(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+	id, ok := parseDomainID(c)
+	if !ok {
 		return
 	}
 
-	if domain.UserID != userID {
-		c.JSON(http.StatusForbidden, gin.H{"error": "You don't own this domain"})
+	domain, err := h.domainService.Verify(ctx, actor, id)
+	if err != nil {
+		h.handleError(c, "verify domain", err, "Failed to verify domain")
 		return
 	}
+	h.invalidateCache(c, domain)
+
+	c.JSON(http.StatusOK, domain)
+}
 
-	if err := h.domainRepo.Delete(ctx, id); err != nil {
-		logger.Error(ctx, "domain-handler: failed to delete domain",
-			zap.Uint64("id", id),
+// invalidateCache drops the redirect server's cached lookup of the domain
+func (h *DomainHandler) invalidateCache(c *gin.Context, domain *model.Domain) {
+	if err := h.redirectService.InvalidateDomainCache(c.Request.Context(), domain.Domain); err != nil {
+		logger.Warn(c.Request.Context(), "domain-handler: failed to invalidate cache",
+			zap.Uint64("domain_id", domain.ID),
 			zap.Error(err),
 		)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete domain"})
-		return
 	}
+}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Domain deleted"})
+func (h *DomainHandler) handleError(c *gin.Context, op string, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrDomainNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Domain not found"})
+	case errors.Is(err, service.ErrInsufficientRole):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Managing domains requires the admin role"})
+	case errors.Is(err, service.ErrDomainExists):
+		c.JSON(http.StatusConflict, gin.H{"error": "Domain already in use"})
+	case errors.Is(err, service.ErrInvalidDomain):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidRedirectURL), errors.Is(err, service.ErrNotFoundPageClash):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrReservedDomain):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Domain is reserved by this service"})
+	case errors.Is(err, service.ErrUnknownCodeStrategy):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown code strategy"})
+	case errors.Is(err, service.ErrUnknownCodeCharset):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown code charset"})
+	case errors.Is(err, service.ErrCodeLengthRange):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Code min length exceeds max length"})
+	case errors.Is(err, service.ErrUnknownDeleteStrategy), errors.Is(err, service.ErrMoveTargetRequired):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrDomainNotVerified):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Domain is not verified"})
+	case errors.Is(err, service.ErrDomainHasLinks):
+		c.JSON(http.StatusConflict, gin.H{"error": "Domain still has links; move or archive them"})
+	case errors.Is(err, service.ErrDomainTokenNotFound):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Verification TXT record not found"})
+	case errors.Is(err, service.ErrDomainLookupFailed):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "DNS lookup failed, try again later"})
+	default:
+		logger.Error(c.Request.Context(), "domain-handler: failed to "+op,
+			zap.Uint64("workspace_id", middleware.GetActor(c).WorkspaceID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+func parseDomainID(c *gin.Context) (uint64, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid domain ID"})
+		return 0, false
+	}
+	return id, true
 }
@@ -45,23 +45,28 @@ type listLinksResponse struct {
 	TotalPages int            `json:"total_pages"`
 }
 
-func (h *LinkHandler) buildShortURL(link *model.Link, domainMap map[uint64]string) string {
+type duplicateGroupResponse struct {
+	Destination string         `json:"destination"`
+	Links       []linkResponse `json:"links"`
+}
+
+func (h *LinkHandler) buildShortURL(link *model.Link, domainMap map[uint64]*model.Domain) string {
 	if link.DomainID != nil {
 		if domain, ok := domainMap[*link.DomainID]; ok {
-			return "http://" + domain + "/" + link.ShortCode
+			return domain.ShortURL(link.ShortCode)
 		}
 	}
 	return h.baseURL + "/" + link.ShortCode
 }
 
-func (h *LinkHandler) toResponse(link *model.Link, domainMap map[uint64]string) linkResponse {
+func (h *LinkHandler) toResponse(link *model.Link, domainMap map[uint64]*model.Domain) linkResponse {
 	return linkResponse{
 		Link:     link,
 		ShortURL: h.buildShortURL(link, domainMap),
 	}
 }
 
-func (h *LinkHandler) toListResponse(result *service.ListLinksResult, domainMap map[uint64]string) listLinksResponse {
+func (h *LinkHandler) toListResponse(result *service.ListLinksResult, domainMap map[uint64]*model.Domain) listLinksResponse {
 	links := make([]linkResponse, len(result.Links))
 	for i := range result.Links {
 		links[i] = h.toResponse(&result.Links[i], domainMap)
@@ -74,69 +79,98 @@ func (h *LinkHandler) toListResponse(result *service.ListLinksResult, domainMap
 	}
 }
 
-func (h *LinkHandler) loadDomainMap(ctx context.Context, userID uint64) map[uint64]string {
-	domains, err := h.domainRepo.ListByUserID(ctx, userID)
+func (h *LinkHandler) loadDomainMap(ctx context.Context, workspaceID uint64) map[uint64]*model.Domain {
+	domains, err := h.domainRepo.ListByWorkspaceID(ctx, workspaceID)
 	if err != nil {
-		logger.Warn(ctx, "link-handler: failed to load domains for user",
-			zap.Uint64("user_id", userID),
+		logger.Warn(ctx, "link-handler: failed to load domains for workspace",
+			zap.Uint64("workspace_id", workspaceID),
 			zap.Error(err),
 		)
-		return make(map[uint64]string)
+		return make(map[uint64]*model.Domain)
 	}
-	domainMap := make(map[uint64]string, len(domains))
+	domainMap := make(map[uint64]*model.Domain, len(domains))
 	for _, d := range domains {
-		domainMap[d.ID] = d.Domain
+		domainMap[d.ID] = d
 	}
 	return domainMap
 }
 
+func (h *LinkHandler) invalidateCache(ctx context.Context, domainID *uint64, code string) {
+	if err := h.redirectService.InvalidateLinkCache(ctx, domainID, code); err != nil {
+		logger.Warn(ctx, "link-handler: failed to invalidate cache",
+			zap.String("short_code", code),
+			zap.Error(err),
+		)
+	}
+}
+
 func (h *LinkHandler) Create(c *gin.Context) {
 	ctx := c.Request.Context()
-	userID := middleware.GetUserID(c)
+	actor := middleware.GetActor(c)
 
 	var input service.CreateLinkInput
 	if err := c.ShouldBindJSON(&input); err != nil {
 		logger.Warn(ctx, "create-link: invalid request body",
-			zap.Uint64("user_id", userID),
+			zap.Uint64("user_id", actor.UserID),
 			zap.Error(err),
 		)
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	link, err := h.linkService.Create(ctx, userID, input)
+	link, err := h.linkService.Create(ctx, actor, input)
 	if errors.Is(err, service.ErrInvalidShortCode) {
 		logger.Warn(ctx, "create-link: invalid custom code",
-			zap.Uint64("user_id", userID),
+			zap.Uint64("user_id", actor.UserID),
 			zap.String("custom_code", input.CustomCode),
 		)
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid custom code"})
 		return
 	}
+	if errors.Is(err, service.ErrUnknownCodeStrategy) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unknown code strategy"})
+		return
+	}
 	if errors.Is(err, service.ErrShortCodeTaken) {
 		logger.Warn(ctx, "create-link: short code already taken",
-			zap.Uint64("user_id", userID),
+			zap.Uint64("user_id", actor.UserID),
 			zap.String("custom_code", input.CustomCode),
 		)
 		c.JSON(http.StatusConflict, gin.H{"error": "short code already taken"})
 		return
 	}
+	if errors.Is(err, service.ErrDomainNotFound) || errors.Is(err, service.ErrDomainNotVerified) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, service.ErrCampaignNotFound) || errors.Is(err, service.ErrNotCampaignOwner) {
+		logger.Warn(ctx, "create-link: invalid campaign",
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid campaign"})
+		return
+	}
+	if errors.Is(err, service.ErrInsufficientRole) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		logger.Error(ctx, "create-link: failed",
-			zap.Uint64("user_id", userID),
+			zap.Uint64("user_id", actor.UserID),
 			zap.Error(err),
 		)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create link"})
 		return
 	}
 
-	domainMap := h.loadDomainMap(ctx, userID)
+	domainMap := h.loadDomainMap(ctx, actor.WorkspaceID)
 	c.JSON(http.StatusCreated, h.toResponse(link, domainMap))
 }
 
 func (h *LinkHandler) Get(c *gin.Context) {
 	ctx := c.Request.Context()
-	userID := middleware.GetUserID(c)
+	actor := middleware.GetActor(c)
 	linkID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
 		logger.Warn(ctx, "get-link: invalid link ID",
@@ -147,11 +181,11 @@ func (h *LinkHandler) Get(c *gin.Context) {
 		return
 	}
 
-	link, err := h.linkService.GetByID(ctx, userID, linkID)
+	link, err := h.linkService.GetByID(ctx, actor, linkID)
 	if errors.Is(err, service.ErrLinkNotFound) || errors.Is(err, service.ErrNotLinkOwner) {
 		logger.Warn(ctx, "get-link: not found",
 			zap.Uint64("link_id", linkID),
-			zap.Uint64("user_id", userID),
+			zap.Uint64("user_id", actor.UserID),
 		)
 		c.JSON(http.StatusNotFound, gin.H{"error": "link not found"})
 		return
@@ -159,20 +193,20 @@ func (h *LinkHandler) Get(c *gin.Context) {
 	if err != nil {
 		logger.Error(ctx, "get-link: failed",
 			zap.Uint64("link_id", linkID),
-			zap.Uint64("user_id", userID),
+			zap.Uint64("user_id", actor.UserID),
 			zap.Error(err),
 		)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get link"})
 		return
 	}
 
-	domainMap := h.loadDomainMap(ctx, userID)
+	domainMap := h.loadDomainMap(ctx, actor.WorkspaceID)
 	c.JSON(http.StatusOK, h.toResponse(link, domainMap))
 }
 
 func (h *LinkHandler) List(c *gin.Context) {
 	ctx := c.Request.Context()
-	userID := middleware.GetUserID(c)
+	actor := middleware.GetActor(c)
 
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
@@ -182,23 +216,23 @@ func (h *LinkHandler) List(c *gin.Context) {
 		Limit: limit,
 	}
 
-	result, err := h.linkService.List(ctx, userID, params)
+	result, err := h.linkService.List(ctx, actor, params)
 	if err != nil {
 		logger.Error(ctx, "list-links: failed",
-			zap.Uint64("user_id", userID),
+			zap.Uint64("user_id", actor.UserID),
 			zap.Error(err),
 		)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list links"})
 		return
 	}
 
-	domainMap := h.loadDomainMap(ctx, userID)
+	domainMap := h.loadDomainMap(ctx, actor.WorkspaceID)
 	c.JSON(http.StatusOK, h.toListResponse(result, domainMap))
 }
 
 func (h *LinkHandler) Update(c *gin.Context) {
 	ctx := c.Request.Context()
-	userID := middleware.GetUserID(c)
+	actor := middleware.GetActor(c)
 	linkID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
 		logger.Warn(ctx, "update-link: invalid link ID",
@@ -213,55 +247,101 @@ func (h *LinkHandler) Update(c *gin.Context) {
 	if err := c.ShouldBindJSON(&input); err != nil {
 		logger.Warn(ctx, "update-link: invalid request body",
 			zap.Uint64("link_id", linkID),
-			zap.Uint64("user_id", userID),
+			zap.Uint64("user_id", actor.UserID),
 			zap.Error(err),
 		)
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	link, err := h.linkService.Update(ctx, userID, linkID, input)
+	// Remember the current code so its cached redirect can be dropped after a rename or move
+	previous, err := h.linkService.GetByID(ctx, actor, linkID)
 	if errors.Is(err, service.ErrLinkNotFound) || errors.Is(err, service.ErrNotLinkOwner) {
 		logger.Warn(ctx, "update-link: not found",
 			zap.Uint64("link_id", linkID),
-			zap.Uint64("user_id", userID),
+			zap.Uint64("user_id", actor.UserID),
 		)
 		c.JSON(http.StatusNotFound, gin.H{"error": "link not found"})
 		return
 	}
 	if err != nil {
-		logger.Error(ctx, "update-link: failed",
+		logger.Error(ctx, "update-link: failed to get link",
 			zap.Uint64("link_id", linkID),
-			zap.Uint64("user_id", userID),
+			zap.Uint64("user_id", actor.UserID),
 			zap.Error(err),
 		)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update link"})
 		return
 	}
 
-	domainMap := h.loadDomainMap(ctx, userID)
+	link, err := h.linkService.Update(ctx, actor, linkID, input)
+	if errors.Is(err, service.ErrInvalidShortCode) {
+		logger.Warn(ctx, "update-link: invalid short code",
+			zap.Uint64("link_id", linkID),
+			zap.String("short_code", input.ShortCode),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid short code"})
+		return
+	}
+	if errors.Is(err, service.ErrShortCodeTaken) {
+		logger.Warn(ctx, "update-link: short code already taken",
+			zap.Uint64("link_id", linkID),
+			zap.String("short_code", input.ShortCode),
+		)
+		c.JSON(http.StatusConflict, gin.H{"error": "short code already taken"})
+		return
+	}
+	if errors.Is(err, service.ErrLinkNotFound) || errors.Is(err, service.ErrNotLinkOwner) {
+		logger.Warn(ctx, "update-link: not found",
+			zap.Uint64("link_id", linkID),
+			zap.Uint64("user_id", actor.UserID),
+		)
+		c.JSON(http.StatusNotFound, gin.H{"error": "link not found"})
+		return
+	}
+	if errors.Is(err, service.ErrDomainNotFound) || errors.Is(err, service.ErrDomainNotVerified) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, service.ErrCampaignNotFound) || errors.Is(err, service.ErrNotCampaignOwner) {
+		logger.Warn(ctx, "update-link: invalid campaign",
+			zap.Uint64("link_id", linkID),
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid campaign"})
+		return
+	}
+	if errors.Is(err, service.ErrInsufficientRole) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "update-link: failed",
+			zap.Uint64("link_id", linkID),
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update link"})
+		return
+	}
 
-	// Invalidate cache for custom domain links
-	// Note: Default domain links cannot be reliably invalidated as the host varies
-	if link.DomainID != nil {
-		if domain, ok := domainMap[*link.DomainID]; ok {
-			if err := h.redirectService.InvalidateCache(ctx, domain, link.ShortCode); err != nil {
-				logger.Warn(ctx, "update-link: failed to invalidate cache",
-					zap.Uint64("link_id", link.ID),
-					zap.String("short_code", link.ShortCode),
-					zap.String("domain", domain),
-					zap.Error(err),
-				)
-			}
-		}
+	// Invalidate the old cache key (it differs after a rename or move) and every current code
+	h.invalidateCache(ctx, previous.DomainID, previous.ShortCode)
+	if err := h.redirectService.InvalidateLinkCaches(ctx, link); err != nil {
+		logger.Warn(ctx, "update-link: failed to invalidate cache",
+			zap.Uint64("link_id", link.ID),
+			zap.Error(err),
+		)
 	}
 
+	domainMap := h.loadDomainMap(ctx, actor.WorkspaceID)
 	c.JSON(http.StatusOK, h.toResponse(link, domainMap))
 }
 
 func (h *LinkHandler) Delete(c *gin.Context) {
 	ctx := c.Request.Context()
-	userID := middleware.GetUserID(c)
+	actor := middleware.GetActor(c)
 	linkID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
 		logger.Warn(ctx, "delete-link: invalid link ID",
@@ -272,24 +352,402 @@ func (h *LinkHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	err = h.linkService.Delete(ctx, userID, linkID)
+	link, err := h.linkService.Delete(ctx, actor, linkID)
 	if errors.Is(err, service.ErrLinkNotFound) || errors.Is(err, service.ErrNotLinkOwner) {
 		logger.Warn(ctx, "delete-link: not found",
 			zap.Uint64("link_id", linkID),
-			zap.Uint64("user_id", userID),
+			zap.Uint64("user_id", actor.UserID),
 		)
 		c.JSON(http.StatusNotFound, gin.H{"error": "link not found"})
 		return
 	}
+	if errors.Is(err, service.ErrInsufficientRole) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		logger.Error(ctx, "delete-link: failed",
 			zap.Uint64("link_id", linkID),
-			zap.Uint64("user_id", userID),
+			zap.Uint64("user_id", actor.UserID),
 			zap.Error(err),
 		)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete link"})
 		return
 	}
 
+	// Stop serving cached redirects for the trashed link
+	if err := h.redirectService.InvalidateLinkCaches(ctx, link); err != nil {
+		logger.Warn(ctx, "delete-link: failed to invalidate cache",
+			zap.Uint64("link_id", linkID),
+			zap.Error(err),
+		)
+	}
+
 	c.Status(http.StatusNoContent)
 }
+
+func (h *LinkHandler) ListDuplicates(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+	ignoreTracking := c.Query("ignore_tracking") == "true"
+
+	groups, err := h.linkService.FindDuplicates(ctx, actor, ignoreTracking)
+	if err != nil {
+		logger.Error(ctx, "list-duplicates: failed",
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to find duplicate links"})
+		return
+	}
+
+	domainMap := h.loadDomainMap(ctx, actor.WorkspaceID)
+	response := make([]duplicateGroupResponse, len(groups))
+	for i, group := range groups {
+		links := make([]linkResponse, len(group.Links))
+		for j := range group.Links {
+			links[j] = h.toResponse(&group.Links[j], domainMap)
+		}
+		response[i] = duplicateGroupResponse{Destination: group.Destination, Links: links}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"groups": response})
+}
+
+func (h *LinkHandler) ListTrash(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+
+	links, err := h.linkService.ListTrash(ctx, actor)
+	if err != nil {
+		logger.Error(ctx, "list-trash: failed",
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list trash"})
+		return
+	}
+
+	domainMap := h.loadDomainMap(ctx, actor.WorkspaceID)
+	responses := make([]linkResponse, len(links))
+	for i := range links {
+		responses[i] = h.toResponse(&links[i], domainMap)
+	}
+	c.JSON(http.StatusOK, gin.H{"links": responses})
+}
+
+func (h *LinkHandler) Restore(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+	linkID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		logger.Warn(ctx, "restore-link: invalid link ID",
+			zap.String("link_id_param", c.Param("id")),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid link ID"})
+		return
+	}
+
+	link, err := h.linkService.Restore(ctx, actor, linkID)
+	if errors.Is(err, service.ErrLinkNotFound) || errors.Is(err, service.ErrNotLinkOwner) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "link not found"})
+		return
+	}
+	if errors.Is(err, service.ErrLinkNotInTrash) {
+		c.JSON(http.StatusConflict, gin.H{"error": "link is not in the trash"})
+		return
+	}
+	if errors.Is(err, service.ErrDomainNotFound) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "link's domain has been deleted"})
+		return
+	}
+	if errors.Is(err, service.ErrInsufficientRole) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "restore-link: failed",
+			zap.Uint64("link_id", linkID),
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to restore link"})
+		return
+	}
+
+	domainMap := h.loadDomainMap(ctx, actor.WorkspaceID)
+	c.JSON(http.StatusOK, h.toResponse(link, domainMap))
+}
+
+func (h *LinkHandler) ListAliases(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+	linkID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		logger.Warn(ctx, "list-aliases: invalid link ID",
+			zap.String("link_id_param", c.Param("id")),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid link ID"})
+		return
+	}
+
+	aliases, err := h.linkService.ListAliases(ctx, actor, linkID)
+	if errors.Is(err, service.ErrLinkNotFound) || errors.Is(err, service.ErrNotLinkOwner) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "link not found"})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "list-aliases: failed",
+			zap.Uint64("link_id", linkID),
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list aliases"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"aliases": aliases})
+}
+
+func (h *LinkHandler) AddAlias(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+	linkID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		logger.Warn(ctx, "add-alias: invalid link ID",
+			zap.String("link_id_param", c.Param("id")),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid link ID"})
+		return
+	}
+
+	var input service.AddAliasInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		logger.Warn(ctx, "add-alias: invalid request body",
+			zap.Uint64("link_id", linkID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	alias, err := h.linkService.AddAlias(ctx, actor, linkID, input)
+	if errors.Is(err, service.ErrLinkNotFound) || errors.Is(err, service.ErrNotLinkOwner) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "link not found"})
+		return
+	}
+	if errors.Is(err, service.ErrInvalidShortCode) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid alias code"})
+		return
+	}
+	if errors.Is(err, service.ErrShortCodeTaken) {
+		c.JSON(http.StatusConflict, gin.H{"error": "short code already taken"})
+		return
+	}
+	if errors.Is(err, service.ErrDomainNotFound) || errors.Is(err, service.ErrDomainNotVerified) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, service.ErrInsufficientRole) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "add-alias: failed",
+			zap.Uint64("link_id", linkID),
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add alias"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, alias)
+}
+
+func (h *LinkHandler) DeleteAlias(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+	linkID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		logger.Warn(ctx, "delete-alias: invalid link ID",
+			zap.String("link_id_param", c.Param("id")),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid link ID"})
+		return
+	}
+	aliasID, err := strconv.ParseUint(c.Param("aliasId"), 10, 64)
+	if err != nil {
+		logger.Warn(ctx, "delete-alias: invalid alias ID",
+			zap.String("alias_id_param", c.Param("aliasId")),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid alias ID"})
+		return
+	}
+
+	// Find the alias first so its cached redirect can be dropped after deletion
+	aliases, err := h.linkService.ListAliases(ctx, actor, linkID)
+	if errors.Is(err, service.ErrLinkNotFound) || errors.Is(err, service.ErrNotLinkOwner) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "link not found"})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "delete-alias: failed to list aliases",
+			zap.Uint64("link_id", linkID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete alias"})
+		return
+	}
+
+	err = h.linkService.DeleteAlias(ctx, actor, linkID, aliasID)
+	if errors.Is(err, service.ErrAliasNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "alias not found"})
+		return
+	}
+	if errors.Is(err, service.ErrInsufficientRole) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "delete-alias: failed",
+			zap.Uint64("link_id", linkID),
+			zap.Uint64("alias_id", aliasID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete alias"})
+		return
+	}
+
+	for _, alias := range aliases {
+		if alias.ID == aliasID {
+			h.invalidateCache(ctx, alias.DomainID, alias.Code)
+		}
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *LinkHandler) ListHistory(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+	linkID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		logger.Warn(ctx, "list-history: invalid link ID",
+			zap.String("link_id_param", c.Param("id")),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid link ID"})
+		return
+	}
+
+	revisions, err := h.linkService.ListHistory(ctx, actor, linkID)
+	if errors.Is(err, service.ErrLinkNotFound) || errors.Is(err, service.ErrNotLinkOwner) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "link not found"})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "list-history: failed",
+			zap.Uint64("link_id", linkID),
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get link history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"history": revisions})
+}
+
+func (h *LinkHandler) Revert(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+	linkID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		logger.Warn(ctx, "revert-link: invalid link ID",
+			zap.String("link_id_param", c.Param("id")),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid link ID"})
+		return
+	}
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version < 1 {
+		logger.Warn(ctx, "revert-link: invalid version",
+			zap.String("version_param", c.Param("version")),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid version"})
+		return
+	}
+
+	// Remember the current code so its cached redirect can be dropped if the revert changes it
+	previous, err := h.linkService.GetByID(ctx, actor, linkID)
+	if errors.Is(err, service.ErrLinkNotFound) || errors.Is(err, service.ErrNotLinkOwner) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "link not found"})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "revert-link: failed to get link",
+			zap.Uint64("link_id", linkID),
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to revert link"})
+		return
+	}
+
+	link, err := h.linkService.Revert(ctx, actor, linkID, version)
+	if errors.Is(err, service.ErrRevisionNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "version not found"})
+		return
+	}
+	if errors.Is(err, service.ErrInvalidShortCode) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "previous short code is not allowed on this domain"})
+		return
+	}
+	if errors.Is(err, service.ErrShortCodeTaken) {
+		logger.Warn(ctx, "revert-link: previous short code is now taken",
+			zap.Uint64("link_id", linkID),
+			zap.Int("version", version),
+		)
+		c.JSON(http.StatusConflict, gin.H{"error": "short code already taken"})
+		return
+	}
+	if errors.Is(err, service.ErrDomainNotFound) || errors.Is(err, service.ErrDomainNotVerified) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, service.ErrCampaignNotFound) || errors.Is(err, service.ErrNotCampaignOwner) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid campaign"})
+		return
+	}
+	if errors.Is(err, service.ErrInsufficientRole) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "revert-link: failed",
+			zap.Uint64("link_id", linkID),
+			zap.Int("version", version),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to revert link"})
+		return
+	}
+
+	h.invalidateCache(ctx, previous.DomainID, previous.ShortCode)
+	if err := h.redirectService.InvalidateLinkCaches(ctx, link); err != nil {
+		logger.Warn(ctx, "revert-link: failed to invalidate cache",
+			zap.Uint64("link_id", link.ID),
+			zap.Error(err),
+		)
+	}
+
+	domainMap := h.loadDomainMap(ctx, actor.WorkspaceID)
+	c.JSON(http.StatusOK, h.toResponse(link, domainMap))
+}
@@ -16,10 +16,11 @@ import (
 
 type PasskeyHandler struct {
 	passkeyService service.PasskeyService
+	authService    service.AuthService
 }
 
-func NewPasskeyHandler(passkeyService service.PasskeyService) *PasskeyHandler {
-	return &PasskeyHandler{passkeyService: passkeyService}
+func NewPasskeyHandler(passkeyService service.PasskeyService, authService service.AuthService) *PasskeyHandler {
+	return &PasskeyHandler{passkeyService: passkeyService, authService: authService}
 }
 
 type BeginRegistrationResponse struct {
@@ -190,5 +191,14 @@ func (h *PasskeyHandler) Delete(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete passkey"})
 		return
 	}
+	// A removed passkey may have been compromised; keep only this session
+	if err := h.authService.RevokeAllSessions(ctx, userID, middleware.GetSessionID(c)); err != nil {
+		logger.Error(ctx, "passkey-delete: failed to revoke other sessions",
+			zap.Uint64("user_id", userID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "passkey deleted but failed to revoke other sessions"})
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{"message": "passkey deleted"})
 }
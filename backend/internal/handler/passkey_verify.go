@@ -100,27 +100,14 @@ func (h *PasskeyVerifyHandler) FinishVerify(c *gin.Context) {
 		return
 	}
 
-	// Generate token after successful passkey verification
-	user, err := h.authService.GetUserByID(ctx, wrapper.UserID)
+	resp, err := h.authService.StartSession(ctx, wrapper.UserID, sessionMeta(c))
 	if err != nil {
-		logger.Error(ctx, "passkey-verify-finish: failed to get user",
+		logger.Error(ctx, "passkey-verify-finish: failed to start session",
 			zap.Uint64("user_id", wrapper.UserID),
 			zap.Error(err),
 		)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get user"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to start session"})
 		return
 	}
-	token, err := h.authService.GenerateToken(user.ID)
-	if err != nil {
-		logger.Error(ctx, "passkey-verify-finish: failed to generate token",
-			zap.Uint64("user_id", wrapper.UserID),
-			zap.Error(err),
-		)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate token"})
-		return
-	}
-	c.JSON(http.StatusOK, gin.H{
-		"token": token,
-		"user":  user,
-	})
+	c.JSON(http.StatusOK, resp)
 }
@@ -7,8 +7,10 @@ import (
 	"encoding/hex"
 	"errors"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/SeaCodeBase/urlshortener/internal/model"
 	"github.com/SeaCodeBase/urlshortener/internal/service"
 	"github.com/SeaCodeBase/urlshortener/pkg/logger"
 	"github.com/gin-gonic/gin"
@@ -35,20 +37,49 @@ func (h *RedirectHandler) hashIP(ip string) string {
 	return hex.EncodeToString(hasher.Sum(nil))
 }
 
+// Redirect serves every path the redirect server has no other route for. The
+// host and path pick the domain, and what follows its path prefix is the
+// short code; a bare domain gets its root redirect.
 func (h *RedirectHandler) Redirect(c *gin.Context) {
-	code := c.Param("code")
+	if c.Request.Method != http.MethodGet {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+
+	domain, code, err := h.redirectService.Route(c.Request.Context(), c.Request.Host, c.Request.URL.Path)
+	if err != nil {
+		logger.Error(c.Request.Context(), "failed to look up domain",
+			zap.String("host", c.Request.Host),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to resolve link"})
+		return
+	}
 	if code == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid short code"})
+		h.root(c, domain)
+		return
+	}
+	// Codes are a single path segment
+	if strings.Contains(code, "/") {
+		h.recordMiss(domain, model.MissNotFound, code)
+		h.notFound(c, domain)
 		return
 	}
 
-	host := c.Request.Host
-	originalURL, linkID, err := h.redirectService.Resolve(c.Request.Context(), host, code)
+	resolved, err := h.redirectService.Resolve(c.Request.Context(), domain, code)
 	if errors.Is(err, service.ErrLinkNotFound) {
-		c.JSON(http.StatusNotFound, gin.H{"error": "link not found"})
+		h.recordMiss(domain, model.MissNotFound, code)
+		h.notFound(c, domain)
 		return
 	}
-	if errors.Is(err, service.ErrLinkExpired) || errors.Is(err, service.ErrLinkInactive) {
+	if errors.Is(err, service.ErrLinkExpired) {
+		h.recordMiss(domain, model.MissExpired, code)
+		if domain != nil && domain.ExpiredURL != nil {
+			c.Redirect(http.StatusFound, *domain.ExpiredURL)
+			return
+		}
+	}
+	if errors.Is(err, service.ErrLinkExpired) || errors.Is(err, service.ErrLinkInactive) || errors.Is(err, service.ErrLinkDeleted) {
 		c.JSON(http.StatusGone, gin.H{"error": "link is no longer available"})
 		return
 	}
@@ -63,7 +94,8 @@ func (h *RedirectHandler) Redirect(c *gin.Context) {
 		defer cancel()
 
 		event := service.ClickEvent{
-			LinkID:      linkID,
+			LinkID:      resolved.LinkID,
+			AliasID:     resolved.AliasID,
 			ClickedAt:   time.Now().UTC(),
 			IPHash:      h.hashIP(c.ClientIP()),
 			IPAddress:   c.ClientIP(),
@@ -75,11 +107,54 @@ func (h *RedirectHandler) Redirect(c *gin.Context) {
 		}
 		if err := h.clickService.RecordClick(ctx, event); err != nil {
 			logger.Warn(ctx, "failed to record click",
-				zap.Uint64("link_id", linkID),
+				zap.Uint64("link_id", resolved.LinkID),
+				zap.Error(err),
+			)
+		}
+	}()
+
+	c.Redirect(resolved.StatusCode, resolved.URL)
+}
+
+// root serves a request for the bare domain, sending it to the domain's root
+// redirect URL when one is set.
+func (h *RedirectHandler) root(c *gin.Context, domain *model.Domain) {
+	if domain != nil && domain.RootRedirectURL != nil {
+		c.Redirect(http.StatusFound, *domain.RootRedirectURL)
+		return
+	}
+	c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+}
+
+// recordMiss counts a request on a custom domain that found no link to
+// follow, asynchronously; misses on the default domain are not counted.
+func (h *RedirectHandler) recordMiss(domain *model.Domain, reason, code string) {
+	if domain == nil {
+		return
+	}
+	event := service.MissEvent{DomainID: domain.ID, Reason: reason, Code: code, At: time.Now().UTC()}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := h.clickService.RecordMiss(ctx, event); err != nil {
+			logger.Warn(ctx, "failed to record miss",
+				zap.Uint64("domain_id", event.DomainID),
 				zap.Error(err),
 			)
 		}
 	}()
+}
 
-	c.Redirect(http.StatusFound, originalURL)
+// notFound answers an unknown code with the domain's not-found redirect or
+// page, falling back to a JSON 404.
+func (h *RedirectHandler) notFound(c *gin.Context, domain *model.Domain) {
+	switch {
+	case domain != nil && domain.NotFoundURL != nil:
+		c.Redirect(http.StatusFound, *domain.NotFoundURL)
+	case domain != nil && domain.NotFoundHTML != nil:
+		c.Data(http.StatusNotFound, "text/html; charset=utf-8", []byte(*domain.NotFoundHTML))
+	default:
+		c.JSON(http.StatusNotFound, gin.H{"error": "link not found"})
+	}
 }
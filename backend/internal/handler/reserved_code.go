@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SeaCodeBase/urlshortener/internal/middleware"
+	"github.com/SeaCodeBase/urlshortener/internal/service"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ReservedCodeHandler struct {
+	reservedService service.ReservedCodeService
+}
+
+func NewReservedCodeHandler(reservedService service.ReservedCodeService) *ReservedCodeHandler {
+	return &ReservedCodeHandler{reservedService: reservedService}
+}
+
+// ListGlobal lists the reserved and blocked codes that apply to every domain (admin only)
+func (h *ReservedCodeHandler) ListGlobal(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	codes, err := h.reservedService.ListGlobal(ctx)
+	if err != nil {
+		logger.Error(ctx, "list-reserved-codes: failed",
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list reserved codes"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"codes": codes})
+}
+
+// AddGlobal reserves or blocks a code on every domain (admin only)
+func (h *ReservedCodeHandler) AddGlobal(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := middleware.GetUserID(c)
+
+	var input service.ReserveCodeInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		logger.Warn(ctx, "add-reserved-code: invalid request body",
+			zap.Uint64("user_id", userID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reserved, err := h.reservedService.AddGlobal(ctx, userID, input)
+	if err != nil {
+		h.handleAddError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, reserved)
+}
+
+// DeleteGlobal removes a code from the global list (admin only)
+func (h *ReservedCodeHandler) DeleteGlobal(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, ok := parseReservedCodeID(c, "id")
+	if !ok {
+		return
+	}
+
+	err := h.reservedService.DeleteGlobal(ctx, id)
+	if errors.Is(err, service.ErrReservedCodeNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "reserved code not found"})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "delete-reserved-code: failed",
+			zap.Uint64("reserved_code_id", id),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete reserved code"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "reserved code deleted"})
+}
+
+// ListDomain lists the codes the domain owner reserved on a domain
+func (h *ReservedCodeHandler) ListDomain(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+	domainID, ok := parseReservedCodeID(c, "id")
+	if !ok {
+		return
+	}
+
+	codes, err := h.reservedService.ListForDomain(ctx, actor, domainID)
+	if errors.Is(err, service.ErrDomainNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "domain not found"})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "list-domain-reserved-codes: failed",
+			zap.Uint64("domain_id", domainID),
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list reserved codes"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"codes": codes})
+}
+
+// AddDomain reserves a code on a domain for its owner, or blocks it there
+func (h *ReservedCodeHandler) AddDomain(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+	domainID, ok := parseReservedCodeID(c, "id")
+	if !ok {
+		return
+	}
+
+	var input service.ReserveCodeInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		logger.Warn(ctx, "add-domain-reserved-code: invalid request body",
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reserved, err := h.reservedService.AddForDomain(ctx, actor, domainID, input)
+	if errors.Is(err, service.ErrDomainNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "domain not found"})
+		return
+	}
+	if err != nil {
+		h.handleAddError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, reserved)
+}
+
+// DeleteDomain removes one of a domain's reservations
+func (h *ReservedCodeHandler) DeleteDomain(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+	domainID, ok := parseReservedCodeID(c, "id")
+	if !ok {
+		return
+	}
+	id, ok := parseReservedCodeID(c, "codeId")
+	if !ok {
+		return
+	}
+
+	err := h.reservedService.DeleteForDomain(ctx, actor, domainID, id)
+	if errors.Is(err, service.ErrDomainNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "domain not found"})
+		return
+	}
+	if errors.Is(err, service.ErrReservedCodeNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "reserved code not found"})
+		return
+	}
+	if errors.Is(err, service.ErrInsufficientRole) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "delete-domain-reserved-code: failed",
+			zap.Uint64("domain_id", domainID),
+			zap.Uint64("reserved_code_id", id),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete reserved code"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "reserved code deleted"})
+}
+
+func (h *ReservedCodeHandler) handleAddError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidShortCode), errors.Is(err, service.ErrInvalidReservedKind):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrReservedCodeExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInsufficientRole):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		logger.Error(c.Request.Context(), "add-reserved-code: failed",
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to reserve code"})
+	}
+}
+
+func parseReservedCodeID(c *gin.Context, param string) (uint64, bool) {
+	id, err := strconv.ParseUint(c.Param(param), 10, 64)
+	if err != nil {
+		logger.Warn(c.Request.Context(), "reserved-code: invalid ID",
+			zap.String(param, c.Param(param)),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return 0, false
+	}
+	return id, true
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/SeaCodeBase/urlshortener/internal/middleware"
 	"github.com/SeaCodeBase/urlshortener/internal/repository"
@@ -25,7 +26,7 @@ func NewStatsHandler(statsService service.StatsService) *StatsHandler {
 
 func (h *StatsHandler) GetLinkStats(c *gin.Context) {
 	ctx := c.Request.Context()
-	userID := middleware.GetUserID(c)
+	actor := middleware.GetActor(c)
 	linkID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
 		logger.Warn(ctx, "stats: invalid link ID",
@@ -36,11 +37,11 @@ func (h *StatsHandler) GetLinkStats(c *gin.Context) {
 		return
 	}
 
-	stats, err := h.statsService.GetLinkStats(ctx, userID, linkID)
+	stats, err := h.statsService.GetLinkStats(ctx, actor, linkID)
 	if errors.Is(err, repository.ErrLinkNotFound) || errors.Is(err, service.ErrNotLinkOwner) {
 		logger.Warn(ctx, "stats: link not found",
 			zap.Uint64("link_id", linkID),
-			zap.Uint64("user_id", userID),
+			zap.Uint64("user_id", actor.UserID),
 		)
 		c.JSON(http.StatusNotFound, gin.H{"error": "link not found"})
 		return
@@ -48,7 +49,116 @@ func (h *StatsHandler) GetLinkStats(c *gin.Context) {
 	if err != nil {
 		logger.Error(ctx, "stats: failed to get stats",
 			zap.Uint64("link_id", linkID),
-			zap.Uint64("user_id", userID),
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get stats"})
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+func (h *StatsHandler) GetCampaignStats(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+	campaignID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		logger.Warn(ctx, "campaign-stats: invalid campaign ID",
+			zap.String("campaign_id_param", c.Param("id")),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid campaign ID"})
+		return
+	}
+
+	stats, err := h.statsService.GetCampaignStats(ctx, actor, campaignID)
+	if errors.Is(err, repository.ErrCampaignNotFound) || errors.Is(err, service.ErrNotCampaignOwner) {
+		logger.Warn(ctx, "campaign-stats: campaign not found",
+			zap.Uint64("campaign_id", campaignID),
+			zap.Uint64("user_id", actor.UserID),
+		)
+		c.JSON(http.StatusNotFound, gin.H{"error": "campaign not found"})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "campaign-stats: failed to get stats",
+			zap.Uint64("campaign_id", campaignID),
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get stats"})
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+func (h *StatsHandler) GetDomainStats(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+	domainID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		logger.Warn(ctx, "domain-stats: invalid domain ID",
+			zap.String("domain_id_param", c.Param("id")),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid domain ID"})
+		return
+	}
+
+	stats, err := h.statsService.GetDomainStats(ctx, actor, domainID)
+	if errors.Is(err, service.ErrDomainNotFound) {
+		logger.Warn(ctx, "domain-stats: domain not found",
+			zap.Uint64("domain_id", domainID),
+			zap.Uint64("user_id", actor.UserID),
+		)
+		c.JSON(http.StatusNotFound, gin.H{"error": "domain not found"})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "domain-stats: failed to get stats",
+			zap.Uint64("domain_id", domainID),
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get stats"})
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// GetOverview returns the workspace's stats across all links for the range
+// given by from and to (RFC 3339) and the series interval (day or hour).
+func (h *StatsHandler) GetOverview(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+	query := service.OverviewQuery{Interval: c.Query("interval")}
+	times := map[string]*time.Time{
+		"from": &query.From,
+		"to":   &query.To,
+	}
+	for name, dst := range times {
+		if raw := c.Query(name); raw != "" {
+			t, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": name + " must be an RFC 3339 timestamp"})
+				return
+			}
+			*dst = t
+		}
+	}
+
+	stats, err := h.statsService.GetOverview(ctx, actor, query)
+	if errors.Is(err, service.ErrInvalidStatsRange) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "stats-overview: failed to get stats",
+			zap.Uint64("workspace_id", actor.WorkspaceID),
+			zap.Uint64("user_id", actor.UserID),
 			zap.Error(err),
 		)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get stats"})
@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SeaCodeBase/urlshortener/internal/middleware"
+	"github.com/SeaCodeBase/urlshortener/internal/service"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type TransferHandler struct {
+	transferService service.TransferService
+	redirectService *service.RedirectService
+}
+
+func NewTransferHandler(transferService service.TransferService, redirectService *service.RedirectService) *TransferHandler {
+	return &TransferHandler{
+		transferService: transferService,
+		redirectService: redirectService,
+	}
+}
+
+func (h *TransferHandler) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+
+	var input service.CreateTransferInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		logger.Warn(ctx, "create-transfer: invalid request body",
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	transfer, err := h.transferService.Create(ctx, actor, input)
+	if errors.Is(err, service.ErrNoLinksToTransfer) || errors.Is(err, service.ErrSelfTransfer) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, service.ErrRecipientNotFound) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "recipient not found"})
+		return
+	}
+	if errors.Is(err, service.ErrLinkNotFound) || errors.Is(err, service.ErrNotLinkOwner) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "link not found"})
+		return
+	}
+	if errors.Is(err, service.ErrInsufficientRole) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "create-transfer: failed",
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create transfer"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, transfer)
+}
+
+func (h *TransferHandler) List(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := middleware.GetUserID(c)
+
+	transfers, err := h.transferService.ListPending(ctx, userID)
+	if err != nil {
+		logger.Error(ctx, "list-transfers: failed",
+			zap.Uint64("user_id", userID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list transfers"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"transfers": transfers})
+}
+
+func (h *TransferHandler) Accept(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+	transferID, ok := parseTransferID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.transferService.Accept(ctx, actor, transferID)
+	if errors.Is(err, service.ErrTransferNotFound) || errors.Is(err, service.ErrTransferNotAllowed) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "transfer not found"})
+		return
+	}
+	if errors.Is(err, service.ErrInsufficientRole) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "accept-transfer: failed",
+			zap.Uint64("transfer_id", transferID),
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to accept transfer"})
+		return
+	}
+
+	// Moved links lose their campaign defaults, so cached destinations may be stale
+	for i := range result.Links {
+		if err := h.redirectService.InvalidateLinkCaches(ctx, &result.Links[i]); err != nil {
+			logger.Warn(ctx, "accept-transfer: failed to invalidate cache",
+				zap.Uint64("link_id", result.Links[i].ID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *TransferHandler) Decline(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := middleware.GetUserID(c)
+	transferID, ok := parseTransferID(c)
+	if !ok {
+		return
+	}
+
+	err := h.transferService.Decline(ctx, userID, transferID)
+	if errors.Is(err, service.ErrTransferNotFound) || errors.Is(err, service.ErrTransferNotAllowed) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "transfer not found"})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "decline-transfer: failed",
+			zap.Uint64("transfer_id", transferID),
+			zap.Uint64("user_id", userID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to decline transfer"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *TransferHandler) Cancel(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := middleware.GetUserID(c)
+	transferID, ok := parseTransferID(c)
+	if !ok {
+		return
+	}
+
+	err := h.transferService.Cancel(ctx, userID, transferID)
+	if errors.Is(err, service.ErrTransferNotFound) || errors.Is(err, service.ErrTransferNotAllowed) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "transfer not found"})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "cancel-transfer: failed",
+			zap.Uint64("transfer_id", transferID),
+			zap.Uint64("user_id", userID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to cancel transfer"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func parseTransferID(c *gin.Context) (uint64, bool) {
+	transferID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		logger.Warn(c.Request.Context(), "transfer: invalid transfer ID",
+			zap.String("transfer_id_param", c.Param("id")),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid transfer ID"})
+		return 0, false
+	}
+	return transferID, true
+}
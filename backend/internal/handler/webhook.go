@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SeaCodeBase/urlshortener/internal/middleware"
+	"github.com/SeaCodeBase/urlshortener/internal/service"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type WebhookHandler struct {
+	webhookService service.WebhookService
+}
+
+func NewWebhookHandler(webhookService service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{webhookService: webhookService}
+}
+
+func (h *WebhookHandler) List(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+
+	webhooks, err := h.webhookService.List(ctx, actor)
+	if err != nil {
+		h.handleError(c, "list-webhooks", err, "failed to list webhooks")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"webhooks": webhooks})
+}
+
+// Create registers a webhook. The response is the only time the signing
+// secret is shown.
+func (h *WebhookHandler) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+
+	var input service.CreateWebhookInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		logger.Warn(ctx, "create-webhook: invalid request body",
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	created, err := h.webhookService.Create(ctx, actor, input)
+	if err != nil {
+		h.handleError(c, "create-webhook", err, "failed to create webhook")
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+func (h *WebhookHandler) Update(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+	id, ok := parseWebhookID(c, "id")
+	if !ok {
+		return
+	}
+
+	var input service.UpdateWebhookInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	webhook, err := h.webhookService.Update(ctx, actor, id, input)
+	if err != nil {
+		h.handleError(c, "update-webhook", err, "failed to update webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+func (h *WebhookHandler) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+	id, ok := parseWebhookID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.webhookService.Delete(ctx, actor, id); err != nil {
+		h.handleError(c, "delete-webhook", err, "failed to delete webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "webhook deleted"})
+}
+
+// ListDeliveries returns the webhook's delivery log, newest first
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+	id, ok := parseWebhookID(c, "id")
+	if !ok {
+		return
+	}
+
+	deliveries, err := h.webhookService.ListDeliveries(ctx, actor, id)
+	if err != nil {
+		h.handleError(c, "list-webhook-deliveries", err, "failed to list deliveries")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deliveries": deliveries})
+}
+
+// Replay queues the payload of an earlier delivery to be sent again
+func (h *WebhookHandler) Replay(c *gin.Context) {
+	ctx := c.Request.Context()
+	actor := middleware.GetActor(c)
+	id, ok := parseWebhookID(c, "id")
+	if !ok {
+		return
+	}
+	deliveryID, ok := parseWebhookID(c, "deliveryId")
+	if !ok {
+		return
+	}
+
+	delivery, err := h.webhookService.Replay(ctx, actor, id, deliveryID)
+	if err != nil {
+		h.handleError(c, "replay-webhook-delivery", err, "failed to replay delivery")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, delivery)
+}
+
+func (h *WebhookHandler) handleError(c *gin.Context, op string, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrWebhookNotFound), errors.Is(err, service.ErrDeliveryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidWebhookURL), errors.Is(err, service.ErrUnknownWebhookEvent):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		logger.Error(c.Request.Context(), op+": failed",
+			zap.Uint64("user_id", middleware.GetUserID(c)),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+func parseWebhookID(c *gin.Context, param string) (uint64, bool) {
+	id, err := strconv.ParseUint(c.Param(param), 10, 64)
+	if err != nil {
+		logger.Warn(c.Request.Context(), "webhook: invalid ID",
+			zap.String(param, c.Param(param)),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return 0, false
+	}
+	return id, true
+}
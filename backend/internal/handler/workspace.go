@@ -0,0 +1,273 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SeaCodeBase/urlshortener/internal/middleware"
+	"github.com/SeaCodeBase/urlshortener/internal/service"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type WorkspaceHandler struct {
+	workspaceService service.WorkspaceService
+}
+
+func NewWorkspaceHandler(workspaceService service.WorkspaceService) *WorkspaceHandler {
+	return &WorkspaceHandler{workspaceService: workspaceService}
+}
+
+type UpdateMemberRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type AcceptInvitationRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+func (h *WorkspaceHandler) List(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := middleware.GetUserID(c)
+
+	workspaces, err := h.workspaceService.List(ctx, userID)
+	if err != nil {
+		logger.Error(ctx, "list-workspaces: failed",
+			zap.Uint64("user_id", userID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list workspaces"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"workspaces": workspaces})
+}
+
+func (h *WorkspaceHandler) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := middleware.GetUserID(c)
+
+	var input service.CreateWorkspaceInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		logger.Warn(ctx, "create-workspace: invalid request body",
+			zap.Uint64("user_id", userID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	workspace, err := h.workspaceService.Create(ctx, userID, input)
+	if err != nil {
+		logger.Error(ctx, "create-workspace: failed",
+			zap.Uint64("user_id", userID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create workspace"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, workspace)
+}
+
+func (h *WorkspaceHandler) Update(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := middleware.GetUserID(c)
+	workspaceID, ok := parseWorkspaceID(c, "id")
+	if !ok {
+		return
+	}
+
+	var input service.CreateWorkspaceInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	workspace, err := h.workspaceService.Rename(ctx, userID, workspaceID, input)
+	if err != nil {
+		h.handleError(c, "update-workspace", err, "failed to update workspace")
+		return
+	}
+
+	c.JSON(http.StatusOK, workspace)
+}
+
+func (h *WorkspaceHandler) ListMembers(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := middleware.GetUserID(c)
+	workspaceID, ok := parseWorkspaceID(c, "id")
+	if !ok {
+		return
+	}
+
+	members, err := h.workspaceService.ListMembers(ctx, userID, workspaceID)
+	if err != nil {
+		h.handleError(c, "list-workspace-members", err, "failed to list members")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"members": members})
+}
+
+func (h *WorkspaceHandler) UpdateMember(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := middleware.GetUserID(c)
+	workspaceID, ok := parseWorkspaceID(c, "id")
+	if !ok {
+		return
+	}
+	memberID, ok := parseWorkspaceID(c, "userId")
+	if !ok {
+		return
+	}
+
+	var req UpdateMemberRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	member, err := h.workspaceService.UpdateMemberRole(ctx, userID, workspaceID, memberID, req.Role)
+	if err != nil {
+		h.handleError(c, "update-workspace-member", err, "failed to update member")
+		return
+	}
+
+	c.JSON(http.StatusOK, member)
+}
+
+func (h *WorkspaceHandler) RemoveMember(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := middleware.GetUserID(c)
+	workspaceID, ok := parseWorkspaceID(c, "id")
+	if !ok {
+		return
+	}
+	memberID, ok := parseWorkspaceID(c, "userId")
+	if !ok {
+		return
+	}
+
+	if err := h.workspaceService.RemoveMember(ctx, userID, workspaceID, memberID); err != nil {
+		h.handleError(c, "remove-workspace-member", err, "failed to remove member")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Invite creates an invitation. The response is the only time the token is shown.
+func (h *WorkspaceHandler) Invite(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := middleware.GetUserID(c)
+	workspaceID, ok := parseWorkspaceID(c, "id")
+	if !ok {
+		return
+	}
+
+	var input service.InviteMemberInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	created, err := h.workspaceService.Invite(ctx, userID, workspaceID, input)
+	if err != nil {
+		h.handleError(c, "invite-workspace-member", err, "failed to create invitation")
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+func (h *WorkspaceHandler) ListInvitations(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := middleware.GetUserID(c)
+	workspaceID, ok := parseWorkspaceID(c, "id")
+	if !ok {
+		return
+	}
+
+	invitations, err := h.workspaceService.ListInvitations(ctx, userID, workspaceID)
+	if err != nil {
+		h.handleError(c, "list-workspace-invitations", err, "failed to list invitations")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"invitations": invitations})
+}
+
+func (h *WorkspaceHandler) RevokeInvitation(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := middleware.GetUserID(c)
+	workspaceID, ok := parseWorkspaceID(c, "id")
+	if !ok {
+		return
+	}
+	invitationID, ok := parseWorkspaceID(c, "invitationId")
+	if !ok {
+		return
+	}
+
+	if err := h.workspaceService.RevokeInvitation(ctx, userID, workspaceID, invitationID); err != nil {
+		h.handleError(c, "revoke-workspace-invitation", err, "failed to revoke invitation")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *WorkspaceHandler) AcceptInvitation(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := middleware.GetUserID(c)
+
+	var req AcceptInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	workspace, err := h.workspaceService.AcceptInvitation(ctx, userID, req.Token)
+	if err != nil {
+		h.handleError(c, "accept-workspace-invitation", err, "failed to accept invitation")
+		return
+	}
+
+	c.JSON(http.StatusOK, workspace)
+}
+
+func (h *WorkspaceHandler) handleError(c *gin.Context, op string, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrWorkspaceNotFound), errors.Is(err, service.ErrMemberNotFound),
+		errors.Is(err, service.ErrInvitationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInsufficientRole), errors.Is(err, service.ErrInvitationEmail):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidRole), errors.Is(err, service.ErrPersonalWorkspace):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrLastOwner):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		logger.Error(c.Request.Context(), op+": failed",
+			zap.Uint64("user_id", middleware.GetUserID(c)),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+func parseWorkspaceID(c *gin.Context, param string) (uint64, bool) {
+	id, err := strconv.ParseUint(c.Param(param), 10, 64)
+	if err != nil {
+		logger.Warn(c.Request.Context(), "workspace: invalid ID",
+			zap.String(param, c.Param(param)),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return 0, false
+	}
+	return id, true
+}
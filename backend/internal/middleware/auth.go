@@ -2,6 +2,7 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
@@ -11,7 +12,17 @@ import (
 	"go.uber.org/zap"
 )
 
-func AuthMiddleware(authService service.AuthService) gin.HandlerFunc {
+// Scopes names the API key scopes a route group accepts: Read for GET and
+// HEAD requests, Write for the rest. An empty scope refuses API keys for
+// those methods. JWT sessions are not limited by scopes.
+type Scopes struct {
+	Read  string
+	Write string
+}
+
+// AuthMiddleware authenticates a bearer JWT or API key and stores the user ID
+// in the context. API keys must hold the scope the route group requires.
+func AuthMiddleware(authService service.AuthService, apiKeyService service.APIKeyService, scopes Scopes) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		ctx := c.Request.Context()
 		authHeader := c.GetHeader("Authorization")
@@ -27,20 +38,71 @@ func AuthMiddleware(authService service.AuthService) gin.HandlerFunc {
 		}
 
 		token := parts[1]
-		userID, err := authService.ValidateToken(token)
-		if err != nil {
+		if service.IsAPIKey(token) {
+			authenticateAPIKey(c, apiKeyService, token, scopes)
+			return
+		}
+
+		claims, err := authService.ValidateToken(ctx, token)
+		if errors.Is(err, service.ErrInvalidToken) {
 			logger.Warn(ctx, "auth: invalid or expired token",
 				zap.Error(err),
 			)
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
 			return
 		}
+		if err != nil {
+			logger.Error(ctx, "auth: failed to validate token",
+				zap.Error(err),
+			)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "failed to authenticate"})
+			return
+		}
 
-		c.Set("user_id", userID)
+		c.Set("user_id", claims.UserID)
+		c.Set("session_id", claims.SessionID)
 		c.Next()
 	}
 }
 
+func authenticateAPIKey(c *gin.Context, apiKeyService service.APIKeyService, token string, scopes Scopes) {
+	ctx := c.Request.Context()
+	key, err := apiKeyService.Authenticate(ctx, token)
+	if errors.Is(err, service.ErrInvalidAPIKey) {
+		logger.Warn(ctx, "auth: invalid or expired api key")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired api key"})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "auth: failed to authenticate api key",
+			zap.Error(err),
+		)
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "failed to authenticate"})
+		return
+	}
+
+	required := scopes.Write
+	if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+		required = scopes.Read
+	}
+	if required == "" {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "api keys cannot access this endpoint"})
+		return
+	}
+	if !key.Scopes.Has(required) {
+		logger.Warn(ctx, "auth: api key lacks required scope",
+			zap.Uint64("api_key_id", key.ID),
+			zap.String("scope", required),
+		)
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "api key lacks the " + required + " scope"})
+		return
+	}
+
+	c.Set("user_id", key.UserID)
+	c.Set("api_key_id", key.ID)
+	c.Next()
+}
+
 func GetUserID(c *gin.Context) uint64 {
 	userID, exists := c.Get("user_id")
 	if !exists {
@@ -48,3 +110,43 @@ func GetUserID(c *gin.Context) uint64 {
 	}
 	return userID.(uint64)
 }
+
+// GetSessionID returns the session of the access token that authenticated the
+// request, or 0 for API keys.
+func GetSessionID(c *gin.Context) uint64 {
+	sessionID, exists := c.Get("session_id")
+	if !exists {
+		return 0
+	}
+	return sessionID.(uint64)
+}
+
+// GetAPIKeyID returns the ID of the API key that authenticated the request,
+// or 0 for JWT sessions.
+func GetAPIKeyID(c *gin.Context) uint64 {
+	keyID, exists := c.Get("api_key_id")
+	if !exists {
+		return 0
+	}
+	return keyID.(uint64)
+}
+
+// AdminMiddleware only lets administrators through. It must run after AuthMiddleware.
+func AdminMiddleware(authService service.AuthService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		user, err := authService.GetUserByID(ctx, GetUserID(c))
+		if err != nil {
+			logger.Warn(ctx, "auth: failed to load user for admin check",
+				zap.Error(err),
+			)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
+			return
+		}
+		if !user.IsAdmin {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin access required"})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/internal/service"
+	"github.com/SeaCodeBase/urlshortener/internal/service/mocks"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuthMiddleware_APIKeyScopes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const key = service.APIKeyPrefix + "secret"
+
+	tests := []struct {
+		name    string
+		method  string
+		scopes  Scopes
+		granted model.APIKeyScopes
+		status  int
+	}{
+		{"read scope allows GET", http.MethodGet, Scopes{Read: model.ScopeLinksRead, Write: model.ScopeLinksWrite}, model.APIKeyScopes{model.ScopeLinksRead}, http.StatusOK},
+		{"read scope refuses POST", http.MethodPost, Scopes{Read: model.ScopeLinksRead, Write: model.ScopeLinksWrite}, model.APIKeyScopes{model.ScopeLinksRead}, http.StatusForbidden},
+		{"write scope implies read", http.MethodGet, Scopes{Read: model.ScopeLinksRead, Write: model.ScopeLinksWrite}, model.APIKeyScopes{model.ScopeLinksWrite}, http.StatusOK},
+		{"other scope refused", http.MethodGet, Scopes{Read: model.ScopeLinksRead}, model.APIKeyScopes{model.ScopeStatsRead}, http.StatusForbidden},
+		{"JWT-only group refuses keys", http.MethodGet, Scopes{}, model.APIKeyScopes{model.ScopeLinksWrite}, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiKeys := mocks.NewMockAPIKeyService(ctrl)
+			apiKeys.EXPECT().Authenticate(gomock.Any(), key).Return(&model.APIKey{ID: 3, UserID: 7, Scopes: tt.granted}, nil)
+
+			router := gin.New()
+			router.Handle(tt.method, "/", AuthMiddleware(mocks.NewMockAuthService(ctrl), apiKeys, tt.scopes), func(c *gin.Context) {
+				if GetUserID(c) != 7 || GetAPIKeyID(c) != 3 {
+					t.Errorf("unexpected user %d / key %d", GetUserID(c), GetAPIKeyID(c))
+				}
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(tt.method, "/", nil)
+			req.Header.Set("Authorization", "Bearer "+key)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.status {
+				t.Errorf("expected status %d, got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_InvalidAPIKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiKeys := mocks.NewMockAPIKeyService(ctrl)
+	apiKeys.EXPECT().Authenticate(gomock.Any(), gomock.Any()).Return(nil, service.ErrInvalidAPIKey)
+
+	router := gin.New()
+	router.GET("/", AuthMiddleware(mocks.NewMockAuthService(ctrl), apiKeys, Scopes{Read: model.ScopeLinksRead}), func(c *gin.Context) {
+		t.Error("handler should not run")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+service.APIKeyPrefix+"revoked")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
+
+func TestAuthMiddleware_AccessToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		claims *service.TokenClaims
+		err    error
+		status int
+	}{
+		{"valid token", &service.TokenClaims{UserID: 7, SessionID: 9}, nil, http.StatusOK},
+		{"revoked session", nil, service.ErrInvalidToken, http.StatusUnauthorized},
+		{"revocation check fails", nil, errors.New("redis down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			auth := mocks.NewMockAuthService(ctrl)
+			auth.EXPECT().ValidateToken(gomock.Any(), "jwt").Return(tt.claims, tt.err)
+
+			router := gin.New()
+			router.GET("/", AuthMiddleware(auth, mocks.NewMockAPIKeyService(ctrl), Scopes{}), func(c *gin.Context) {
+				if GetUserID(c) != 7 || GetSessionID(c) != 9 {
+					t.Errorf("unexpected user %d / session %d", GetUserID(c), GetSessionID(c))
+				}
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Authorization", "Bearer jwt")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.status {
+				t.Errorf("expected status %d, got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/SeaCodeBase/urlshortener/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// acmeChallengePath must stay reachable over plain HTTP for certificates to
+// be issued
+const acmeChallengePath = "/.well-known/acme-challenge/"
+
+// HTTPSRedirectMiddleware sends plain HTTP requests to HTTPS on the default
+// port when the host is served with its own certificate. Other hosts stay on
+// HTTP rather than being sent to a certificate warning.
+func HTTPSRedirectMiddleware(certificateService service.CertificateService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.TLS != nil || strings.HasPrefix(c.Request.URL.Path, acmeChallengePath) {
+			c.Next()
+			return
+		}
+		if !certificateService.HasCertificate(c.Request.Context(), c.Request.Host) {
+			c.Next()
+			return
+		}
+
+		host := c.Request.Host
+		if i := strings.LastIndex(host, ":"); i > strings.LastIndex(host, "]") {
+			host = host[:i]
+		}
+		target := "https://" + host + c.Request.URL.RequestURI()
+		// 308 keeps the method of non-GET requests
+		status := http.StatusMovedPermanently
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			status = http.StatusPermanentRedirect
+		}
+		c.Redirect(status, target)
+		c.Abort()
+	}
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SeaCodeBase/urlshortener/internal/service/mocks"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHTTPSRedirectMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		method   string
+		target   string
+		tls      bool
+		hasCert  bool
+		status   int
+		location string
+	}{
+		{"host with a certificate", http.MethodGet, "http://go.example.com:8080/abc?x=1", false, true, http.StatusMovedPermanently, "https://go.example.com/abc?x=1"},
+		{"non-GET keeps its method", http.MethodPost, "http://go.example.com/abc", false, true, http.StatusPermanentRedirect, "https://go.example.com/abc"},
+		{"host without a certificate", http.MethodGet, "http://plain.example.com/abc", false, false, http.StatusOK, ""},
+		{"already HTTPS", http.MethodGet, "https://go.example.com/abc", true, true, http.StatusOK, ""},
+		{"ACME challenge", http.MethodGet, "http://go.example.com/.well-known/acme-challenge/token", false, true, http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			certificates := mocks.NewMockCertificateService(ctrl)
+			certificates.EXPECT().HasCertificate(gomock.Any(), gomock.Any()).Return(tt.hasCert).AnyTimes()
+
+			router := gin.New()
+			router.Use(HTTPSRedirectMiddleware(certificates))
+			router.Any("/*path", func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(tt.method, tt.target, nil)
+			if tt.tls {
+				req.TLS = &tls.ConnectionState{}
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, w.Code)
+			}
+			if got := w.Header().Get("Location"); got != tt.location {
+				t.Errorf("expected location %q, got %q", tt.location, got)
+			}
+		})
+	}
+}
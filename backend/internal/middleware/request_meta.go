@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"github.com/SeaCodeBase/urlshortener/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// RequestMetaMiddleware stores the client's IP address and user agent in the
+// request context so services can attribute audit entries to them.
+func RequestMetaMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := service.WithRequestMeta(c.Request.Context(), service.SessionMeta{
+			UserAgent: c.Request.UserAgent(),
+			IPAddress: c.ClientIP(),
+		})
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SeaCodeBase/urlshortener/internal/service"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// WorkspaceHeader selects the workspace a request acts in. Without it the
+// request acts in the user's personal workspace.
+const WorkspaceHeader = "X-Workspace-ID"
+
+// WorkspaceMiddleware resolves the workspace the request targets and the
+// user's role in it. It must run after AuthMiddleware.
+func WorkspaceMiddleware(workspaceService service.WorkspaceService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		var workspaceID uint64
+		if header := c.GetHeader(WorkspaceHeader); header != "" {
+			id, err := strconv.ParseUint(header, 10, 64)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+				return
+			}
+			workspaceID = id
+		}
+
+		actor, err := workspaceService.Resolve(ctx, GetUserID(c), workspaceID)
+		if errors.Is(err, service.ErrWorkspaceNotFound) {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "workspace not found"})
+			return
+		}
+		if err != nil {
+			logger.Error(ctx, "workspace: failed to resolve workspace",
+				zap.Uint64("workspace_id", workspaceID),
+				zap.Error(err),
+			)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "failed to resolve workspace"})
+			return
+		}
+
+		c.Set("workspace_id", actor.WorkspaceID)
+		c.Set("workspace_role", actor.Role)
+		c.Next()
+	}
+}
+
+// GetActor returns the user and workspace the request acts for. It must be
+// used behind WorkspaceMiddleware.
+func GetActor(c *gin.Context) service.Actor {
+	actor := service.Actor{UserID: GetUserID(c)}
+	if workspaceID, exists := c.Get("workspace_id"); exists {
+		actor.WorkspaceID = workspaceID.(uint64)
+	}
+	if role, exists := c.Get("workspace_role"); exists {
+		actor.Role = role.(string)
+	}
+	return actor
+}
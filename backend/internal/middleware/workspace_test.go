@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/internal/service"
+	"github.com/SeaCodeBase/urlshortener/internal/service/mocks"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWorkspaceMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name        string
+		header      string
+		workspaceID uint64
+		err         error
+		status      int
+	}{
+		{"no header uses the personal workspace", "", 0, nil, http.StatusOK},
+		{"header selects a workspace", "4", 4, nil, http.StatusOK},
+		{"non-member gets not found", "9", 9, service.ErrWorkspaceNotFound, http.StatusNotFound},
+		{"malformed header", "abc", 0, nil, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			workspaces := mocks.NewMockWorkspaceService(ctrl)
+			if tt.status != http.StatusBadRequest {
+				actor := service.Actor{UserID: 7, WorkspaceID: tt.workspaceID, Role: model.RoleEditor}
+				if tt.workspaceID == 0 {
+					actor.WorkspaceID = 2
+				}
+				workspaces.EXPECT().Resolve(gomock.Any(), uint64(7), tt.workspaceID).Return(actor, tt.err)
+			}
+
+			router := gin.New()
+			router.GET("/", func(c *gin.Context) {
+				c.Set("user_id", uint64(7))
+			}, WorkspaceMiddleware(workspaces), func(c *gin.Context) {
+				actor := GetActor(c)
+				if actor.UserID != 7 || actor.WorkspaceID == 0 || actor.Role != model.RoleEditor {
+					t.Errorf("unexpected actor %+v", actor)
+				}
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(WorkspaceHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.status {
+				t.Errorf("expected status %d, got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// API key scopes. A write scope includes the matching read scope.
+const (
+	ScopeLinksRead    = "links:read"
+	ScopeLinksWrite   = "links:write"
+	ScopeStatsRead    = "stats:read"
+	ScopeDomainsRead  = "domains:read"
+	ScopeDomainsWrite = "domains:write"
+)
+
+// scopeImplies lists the scopes granted along with a scope
+var scopeImplies = map[string][]string{
+	ScopeLinksWrite:   {ScopeLinksRead},
+	ScopeDomainsWrite: {ScopeDomainsRead},
+}
+
+// IsScope reports whether name is a known API key scope.
+func IsScope(name string) bool {
+	switch name {
+	case ScopeLinksRead, ScopeLinksWrite, ScopeStatsRead, ScopeDomainsRead, ScopeDomainsWrite:
+		return true
+	}
+	return false
+}
+
+// APIKeyScopes is stored as a JSON array in api_keys.
+type APIKeyScopes []string
+
+// Has reports whether the scopes grant scope, directly or through a write scope.
+func (s APIKeyScopes) Has(scope string) bool {
+	for _, granted := range s {
+		if granted == scope {
+			return true
+		}
+		for _, implied := range scopeImplies[granted] {
+			if implied == scope {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s APIKeyScopes) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+func (s *APIKeyScopes) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, s)
+	case string:
+		return json.Unmarshal([]byte(v), s)
+	default:
+		return errors.New("api key scopes: unsupported scan type")
+	}
+}
+
+// APIKey is a long-lived credential a user creates for scripts and CI.
+type APIKey struct {
+	ID         uint64       `db:"id" json:"id"`
+	UserID     uint64       `db:"user_id" json:"user_id"`
+	Name       string       `db:"name" json:"name"`
+	Prefix     string       `db:"prefix" json:"prefix"`
+	KeyHash    string       `db:"key_hash" json:"-"`
+	Scopes     APIKeyScopes `db:"scopes" json:"scopes"`
+	ExpiresAt  *time.Time   `db:"expires_at" json:"expires_at,omitempty"`
+	LastUsedAt *time.Time   `db:"last_used_at" json:"last_used_at,omitempty"`
+	CreatedAt  time.Time    `db:"created_at" json:"created_at"`
+}
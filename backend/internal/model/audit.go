@@ -0,0 +1,122 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"time"
+)
+
+// Audit actions. Names are "<target>.<event>" so they can be filtered by prefix.
+const (
+	AuditUserRegistered    = "user.registered"
+	AuditLoginSucceeded    = "auth.login"
+	AuditLoginFailed       = "auth.login_failed"
+	AuditPasswordChanged   = "auth.password_changed"
+	AuditSessionRevoked    = "auth.session_revoked"
+	AuditSessionsRevoked   = "auth.sessions_revoked"
+	AuditRefreshReused     = "auth.refresh_reused"
+	AuditPasskeyRegistered = "passkey.registered"
+	AuditPasskeyRenamed    = "passkey.renamed"
+	AuditPasskeyDeleted    = "passkey.deleted"
+	AuditLinkCreated       = "link.created"
+	AuditLinkUpdated       = "link.updated"
+	AuditLinkDisabled      = "link.disabled"
+	AuditLinkEnabled       = "link.enabled"
+	AuditLinkReverted      = "link.reverted"
+	AuditLinkDeleted       = "link.deleted"
+	AuditLinkRestored      = "link.restored"
+	AuditAliasAdded        = "link.alias_added"
+	AuditAliasDeleted      = "link.alias_deleted"
+	AuditDomainCreated     = "domain.created"
+	AuditDomainUpdated     = "domain.updated"
+	AuditDomainDeleted     = "domain.deleted"
+	AuditDomainVerified    = "domain.verified"
+	// AuditDomainCertificateSet covers uploads, ACME requests and renewals
+	AuditDomainCertificateSet     = "domain.certificate_set"
+	AuditDomainCertificateDeleted = "domain.certificate_deleted"
+	// AuditDomainUnverified is recorded by the recheck worker, with no actor
+	AuditDomainUnverified = "domain.unverified"
+)
+
+// Audit target types.
+const (
+	AuditTargetUser    = "user"
+	AuditTargetSession = "session"
+	AuditTargetPasskey = "passkey"
+	AuditTargetLink    = "link"
+	AuditTargetDomain  = "domain"
+)
+
+// AuditChanges holds the fields an event changed, with their values before
+// and after. It is stored as a JSON column in audit_log.
+type AuditChanges struct {
+	Before map[string]any `json:"before,omitempty"`
+	After  map[string]any `json:"after,omitempty"`
+}
+
+// DiffOf returns the JSON fields that differ between before and after; either
+// may be nil for objects that were created or removed. It returns nil when
+// nothing changed.
+func DiffOf(before, after any) *AuditChanges {
+	old, new := jsonFields(before), jsonFields(after)
+	changes := &AuditChanges{Before: map[string]any{}, After: map[string]any{}}
+	for key, value := range old {
+		if other, ok := new[key]; !ok || !reflect.DeepEqual(value, other) {
+			changes.Before[key] = value
+		}
+	}
+	for key, value := range new {
+		if other, ok := old[key]; !ok || !reflect.DeepEqual(value, other) {
+			changes.After[key] = value
+		}
+	}
+	if len(changes.Before) == 0 && len(changes.After) == 0 {
+		return nil
+	}
+	return changes
+}
+
+func jsonFields(v any) map[string]any {
+	fields := map[string]any{}
+	if v == nil || (reflect.ValueOf(v).Kind() == reflect.Pointer && reflect.ValueOf(v).IsNil()) {
+		return fields
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fields
+	}
+	_ = json.Unmarshal(data, &fields)
+	return fields
+}
+
+func (c AuditChanges) Value() (driver.Value, error) {
+	return json.Marshal(c)
+}
+
+func (c *AuditChanges) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, c)
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	default:
+		return errors.New("audit changes: unsupported scan type")
+	}
+}
+
+// AuditEntry is one append-only audit log record: who did what to which
+// object, from where.
+type AuditEntry struct {
+	ID          uint64        `db:"id" json:"id"`
+	WorkspaceID *uint64       `db:"workspace_id" json:"workspace_id,omitempty"`
+	ActorUserID *uint64       `db:"actor_user_id" json:"actor_user_id,omitempty"`
+	Action      string        `db:"action" json:"action"`
+	TargetType  string        `db:"target_type" json:"target_type"`
+	TargetID    *uint64       `db:"target_id" json:"target_id,omitempty"`
+	Changes     *AuditChanges `db:"changes" json:"changes,omitempty"`
+	IPAddress   string        `db:"ip_address" json:"ip_address"`
+	UserAgent   string        `db:"user_agent" json:"user_agent"`
+	CreatedAt   time.Time     `db:"created_at" json:"created_at"`
+}
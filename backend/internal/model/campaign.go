@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// Campaign groups links for a marketing effort and supplies defaults
+// (UTM parameters and expiry) that member links inherit unless they override them.
+type Campaign struct {
+	ID          uint64    `db:"id" json:"id"`
+	UserID      uint64    `db:"user_id" json:"user_id"`
+	WorkspaceID uint64    `db:"workspace_id" json:"workspace_id"`
+	Name        string    `db:"name" json:"name"`
+	StartsAt    NullTime  `db:"starts_at" json:"starts_at"`
+	EndsAt      NullTime  `db:"ends_at" json:"ends_at"`
+	BudgetNotes *string   `db:"budget_notes" json:"budget_notes,omitempty"`
+	UTMSource   *string   `db:"utm_source" json:"utm_source,omitempty"`
+	UTMMedium   *string   `db:"utm_medium" json:"utm_medium,omitempty"`
+	UTMCampaign *string   `db:"utm_campaign" json:"utm_campaign,omitempty"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+}
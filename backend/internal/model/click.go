@@ -6,6 +6,7 @@ import "time"
 type Click struct {
 	ID          uint64    `db:"id" json:"id"`
 	LinkID      uint64    `db:"link_id" json:"link_id"`
+	AliasID     *uint64   `db:"alias_id" json:"alias_id,omitempty"`
 	ClickedAt   time.Time `db:"clicked_at" json:"clicked_at"`
 	IPHash      string    `db:"ip_hash" json:"-"`
 	IPAddress   string    `db:"ip_address" json:"-"`
@@ -1,11 +1,93 @@
 package model
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
-// Domain represents a custom domain bound to a user
+// Domain represents a custom domain bound to a workspace
 type Domain struct {
-	ID        uint64    `json:"id" db:"id"`
-	UserID    uint64    `json:"user_id" db:"user_id"`
-	Domain    string    `json:"domain" db:"domain"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	ID          uint64 `json:"id" db:"id"`
+	UserID      uint64 `json:"user_id" db:"user_id"`
+	WorkspaceID uint64 `json:"workspace_id" db:"workspace_id"`
+	// Domain is the host, or a wildcard pattern such as *.links.example.com
+	// that matches any one label in place of the "*"; every label shares the
+	// domain's codes
+	Domain string `json:"domain" db:"domain"`
+	// PathPrefix puts the domain's codes under a path, e.g. "go" for
+	// example.com/go/<code>; empty means codes sit at the root
+	PathPrefix string `json:"path_prefix" db:"path_prefix"`
+	// VerificationToken must be published in a TXT record to verify the domain.
+	// Domains added before verification existed have none.
+	VerificationToken string     `json:"verification_token,omitempty" db:"verification_token"`
+	VerifiedAt        *time.Time `json:"verified_at,omitempty" db:"verified_at"`
+	LastCheckedAt     *time.Time `json:"last_checked_at,omitempty" db:"last_checked_at"`
+	// CheckFailures counts consecutive rechecks that did not find the token
+	CheckFailures int `json:"-" db:"check_failures"`
+	// CodeStrategy and CodeLength override the default code generation for links on this domain
+	CodeStrategy *string `json:"code_strategy,omitempty" db:"code_strategy"`
+	CodeLength   *int    `json:"code_length,omitempty" db:"code_length"`
+	// Code policy: case folding, allowed characters and length of codes on this domain
+	CaseInsensitiveCodes bool    `json:"case_insensitive_codes" db:"case_insensitive_codes"`
+	CodeCharset          *string `json:"code_charset,omitempty" db:"code_charset"`
+	CodeMinLength        *int    `json:"code_min_length,omitempty" db:"code_min_length"`
+	CodeMaxLength        *int    `json:"code_max_length,omitempty" db:"code_max_length"`
+	// UseHTTPS makes short URLs on this domain https://; otherwise they are http://
+	UseHTTPS bool `json:"use_https" db:"use_https"`
+	// RedirectStatus is the HTTP status of redirects on this domain; nil means 302
+	RedirectStatus *int `json:"redirect_status,omitempty" db:"redirect_status"`
+	// Defaults for new links on this domain: an expiry, and UTM parameters
+	// filled in when neither the link nor its campaign sets them
+	DefaultExpiryDays  *int    `json:"default_expiry_days,omitempty" db:"default_expiry_days"`
+	DefaultUTMSource   *string `json:"default_utm_source,omitempty" db:"default_utm_source"`
+	DefaultUTMMedium   *string `json:"default_utm_medium,omitempty" db:"default_utm_medium"`
+	DefaultUTMCampaign *string `json:"default_utm_campaign,omitempty" db:"default_utm_campaign"`
+	// IsDefault marks the workspace's domain for new links that name none
+	IsDefault bool `json:"is_default" db:"is_default"`
+	// Fallbacks served by the redirect server; nil keeps its default response
+	RootRedirectURL *string   `json:"root_redirect_url,omitempty" db:"root_redirect_url"`
+	NotFoundURL     *string   `json:"not_found_url,omitempty" db:"not_found_url"`
+	NotFoundHTML    *string   `json:"not_found_html,omitempty" db:"not_found_html"`
+	ExpiredURL      *string   `json:"expired_url,omitempty" db:"expired_url"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+}
+
+// Key identifies the domain among those sharing its host: the host, followed
+// by "/" and the path prefix when it has one.
+func (d *Domain) Key() string {
+	if d.PathPrefix == "" {
+		return d.Domain
+	}
+	return d.Domain + "/" + d.PathPrefix
+}
+
+// IsWildcard reports whether the domain is a pattern such as *.links.example.com.
+func (d *Domain) IsWildcard() bool {
+	return strings.HasPrefix(d.Domain, "*.")
+}
+
+// VerificationHost is the name the verification TXT record is published
+// under; a wildcard is verified on the name below its "*".
+func (d *Domain) VerificationHost() string {
+	return strings.TrimPrefix(d.Domain, "*.")
+}
+
+// ShortURL returns the short URL of a code on the domain. On a wildcard
+// domain it keeps the "*" for the caller to fill in with any label.
+func (d *Domain) ShortURL(code string) string {
+	return d.Scheme() + "://" + d.Key() + "/" + code
+}
+
+// Scheme returns the scheme of short URLs on the domain.
+func (d *Domain) Scheme() string {
+	if d.UseHTTPS {
+		return "https"
+	}
+	return "http"
+}
+
+// IsVerified reports whether the domain's ownership has been proven. Only
+// verified domains serve redirects and take new links.
+func (d *Domain) IsVerified() bool {
+	return d.VerifiedAt != nil
 }
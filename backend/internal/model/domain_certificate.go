@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// Certificate sources
+const (
+	CertificateUploaded = "upload"
+	CertificateACME     = "acme"
+)
+
+// DomainCertificate is the TLS certificate the redirect server presents for
+// a custom domain
+type DomainCertificate struct {
+	ID       uint64 `json:"id" db:"id"`
+	DomainID uint64 `json:"domain_id" db:"domain_id"`
+	Source   string `json:"source" db:"source"`
+	// CertificatePEM is the leaf certificate followed by its chain
+	CertificatePEM string `json:"certificate_pem" db:"certificate_pem"`
+	// PrivateKey is the PEM private key encrypted with util.SecretCipher
+	PrivateKey []byte    `json:"-" db:"private_key"`
+	Issuer     string    `json:"issuer" db:"issuer"`
+	NotBefore  time.Time `json:"not_before" db:"not_before"`
+	NotAfter   time.Time `json:"not_after" db:"not_after"`
+	// LastError is the latest failed renewal of an ACME certificate
+	LastError string    `json:"last_error,omitempty" db:"last_error"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// Reasons a request on a custom domain found no link to follow
+const (
+	MissNotFound = "not_found"
+	MissExpired  = "expired"
+)
+
+// DomainMiss counts the requests for a code on a domain that missed for the
+// same reason on the same day.
+type DomainMiss struct {
+	DomainID uint64    `db:"domain_id" json:"domain_id"`
+	Date     time.Time `db:"date" json:"date"`
+	Reason   string    `db:"reason" json:"reason"`
+	Code     string    `db:"code" json:"code"`
+	Count    int64     `db:"count" json:"count"`
+}
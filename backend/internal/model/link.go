@@ -7,12 +7,18 @@ import (
 type Link struct {
 	ID          uint64    `db:"id" json:"id"`
 	UserID      uint64    `db:"user_id" json:"user_id"`
+	WorkspaceID uint64    `db:"workspace_id" json:"workspace_id"`
 	ShortCode   string    `db:"short_code" json:"short_code"`
 	OriginalURL string    `db:"original_url" json:"original_url"`
 	Title       *string   `db:"title" json:"title,omitempty"`
 	ExpiresAt   NullTime  `db:"expires_at" json:"expires_at"`
 	IsActive    bool      `db:"is_active" json:"is_active"`
 	DomainID    *uint64   `db:"domain_id" json:"domain_id,omitempty"`
+	CampaignID  *uint64   `db:"campaign_id" json:"campaign_id,omitempty"`
+	UTMSource   *string   `db:"utm_source" json:"utm_source,omitempty"`
+	UTMMedium   *string   `db:"utm_medium" json:"utm_medium,omitempty"`
+	UTMCampaign *string   `db:"utm_campaign" json:"utm_campaign,omitempty"`
+	DeletedAt   NullTime  `db:"deleted_at" json:"deleted_at"`
 	CreatedAt   time.Time `db:"created_at" json:"created_at"`
 	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
 }
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// LinkAlias is an additional short code that resolves to an existing link.
+// Aliases with an expiry are left behind when a link's code is changed and
+// stop resolving once the grace period ends.
+type LinkAlias struct {
+	ID        uint64    `db:"id" json:"id"`
+	LinkID    uint64    `db:"link_id" json:"link_id"`
+	DomainID  *uint64   `db:"domain_id" json:"domain_id,omitempty"`
+	Code      string    `db:"code" json:"code"`
+	ExpiresAt NullTime  `db:"expires_at" json:"expires_at"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
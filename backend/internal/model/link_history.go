@@ -0,0 +1,80 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// LinkSnapshot holds the user-editable fields of a link at one point in time.
+// It is stored as a JSON column in link_history.
+type LinkSnapshot struct {
+	ShortCode   string   `json:"short_code"`
+	OriginalURL string   `json:"original_url"`
+	Title       *string  `json:"title"`
+	ExpiresAt   NullTime `json:"expires_at"`
+	IsActive    bool     `json:"is_active"`
+	DomainID    *uint64  `json:"domain_id"`
+	CampaignID  *uint64  `json:"campaign_id"`
+	UTMSource   *string  `json:"utm_source"`
+	UTMMedium   *string  `json:"utm_medium"`
+	UTMCampaign *string  `json:"utm_campaign"`
+}
+
+// SnapshotOf captures the editable fields of a link.
+func SnapshotOf(link *Link) LinkSnapshot {
+	return LinkSnapshot{
+		ShortCode:   link.ShortCode,
+		OriginalURL: link.OriginalURL,
+		Title:       link.Title,
+		ExpiresAt:   link.ExpiresAt,
+		IsActive:    link.IsActive,
+		DomainID:    link.DomainID,
+		CampaignID:  link.CampaignID,
+		UTMSource:   link.UTMSource,
+		UTMMedium:   link.UTMMedium,
+		UTMCampaign: link.UTMCampaign,
+	}
+}
+
+// ApplyTo copies the snapshot's fields onto a link.
+func (s LinkSnapshot) ApplyTo(link *Link) {
+	link.ShortCode = s.ShortCode
+	link.OriginalURL = s.OriginalURL
+	link.Title = s.Title
+	link.ExpiresAt = s.ExpiresAt
+	link.IsActive = s.IsActive
+	link.DomainID = s.DomainID
+	link.CampaignID = s.CampaignID
+	link.UTMSource = s.UTMSource
+	link.UTMMedium = s.UTMMedium
+	link.UTMCampaign = s.UTMCampaign
+}
+
+func (s LinkSnapshot) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+func (s *LinkSnapshot) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, s)
+	case string:
+		return json.Unmarshal([]byte(v), s)
+	default:
+		return errors.New("link snapshot: unsupported scan type")
+	}
+}
+
+// LinkRevision is one versioned edit of a link: who changed it, when, and
+// the link's state before and after the change.
+type LinkRevision struct {
+	ID        uint64       `db:"id" json:"id"`
+	LinkID    uint64       `db:"link_id" json:"link_id"`
+	Version   int          `db:"version" json:"version"`
+	ChangedBy uint64       `db:"changed_by" json:"changed_by"`
+	OldValues LinkSnapshot `db:"old_values" json:"old_values"`
+	NewValues LinkSnapshot `db:"new_values" json:"new_values"`
+	CreatedAt time.Time    `db:"created_at" json:"created_at"`
+}
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+const (
+	TransferStatusPending   = "pending"
+	TransferStatusAccepted  = "accepted"
+	TransferStatusDeclined  = "declined"
+	TransferStatusCancelled = "cancelled"
+)
+
+// LinkTransfer is a request to move links from one user to another. It takes
+// effect only once the recipient accepts it, into the workspace they accept from.
+type LinkTransfer struct {
+	ID              uint64 `db:"id" json:"id"`
+	FromUserID      uint64 `db:"from_user_id" json:"from_user_id"`
+	ToUserID        uint64 `db:"to_user_id" json:"to_user_id"`
+	FromWorkspaceID uint64 `db:"from_workspace_id" json:"from_workspace_id"`
+	// AllLinks transfers every link the sender owns at the time of acceptance;
+	// otherwise only LinkIDs are transferred.
+	AllLinks   bool      `db:"all_links" json:"all_links"`
+	LinkIDs    []uint64  `db:"-" json:"link_ids,omitempty"`
+	Status     string    `db:"status" json:"status"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+	ResolvedAt NullTime  `db:"resolved_at" json:"resolved_at"`
+}
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// Reserved code kinds
+const (
+	// ReservedCodeKindReserved codes are held back for the system or, on a
+	// domain, for the domain owner.
+	ReservedCodeKindReserved = "reserved"
+	// ReservedCodeKindBlocked codes may not be used by anyone.
+	ReservedCodeKindBlocked = "blocked"
+)
+
+// ReservedCode is a short code that may not be claimed by links or aliases.
+// DomainID nil means the entry applies to every domain.
+type ReservedCode struct {
+	ID        uint64    `db:"id" json:"id"`
+	DomainID  *uint64   `db:"domain_id" json:"domain_id,omitempty"`
+	Code      string    `db:"code" json:"code"`
+	Kind      string    `db:"kind" json:"kind"`
+	Reason    *string   `db:"reason" json:"reason,omitempty"`
+	CreatedBy *uint64   `db:"created_by" json:"created_by,omitempty"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
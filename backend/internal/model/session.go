@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// Session is a login on one device. Access tokens name their session, and the
+// session's refresh token is rotated every time it is used.
+type Session struct {
+	ID           uint64     `db:"id" json:"id"`
+	UserID       uint64     `db:"user_id" json:"user_id"`
+	RefreshHash  string     `db:"refresh_hash" json:"-"`
+	PreviousHash *string    `db:"previous_hash" json:"-"`
+	UserAgent    string     `db:"user_agent" json:"user_agent"`
+	IPAddress    string     `db:"ip_address" json:"ip_address"`
+	CreatedAt    time.Time  `db:"created_at" json:"created_at"`
+	LastUsedAt   time.Time  `db:"last_used_at" json:"last_used_at"`
+	ExpiresAt    time.Time  `db:"expires_at" json:"expires_at"`
+	RevokedAt    *time.Time `db:"revoked_at" json:"-"`
+	// Current marks the session the listing request was made with
+	Current bool `db:"-" json:"current"`
+}
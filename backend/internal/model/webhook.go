@@ -0,0 +1,96 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Webhook event types
+const (
+	WebhookLinkCreated = "link.created"
+	WebhookLinkUpdated = "link.updated"
+	WebhookLinkExpired = "link.expired"
+	// WebhookLinkClicked is sent in batches, one delivery per click flush
+	WebhookLinkClicked = "link.clicked"
+)
+
+// IsWebhookEvent reports whether name is a known webhook event type.
+func IsWebhookEvent(name string) bool {
+	switch name {
+	case WebhookLinkCreated, WebhookLinkUpdated, WebhookLinkExpired, WebhookLinkClicked:
+		return true
+	}
+	return false
+}
+
+// Webhook delivery statuses
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+// WebhookEvents is stored as a JSON array in webhooks.
+type WebhookEvents []string
+
+// Has reports whether the webhook is subscribed to event.
+func (e WebhookEvents) Has(event string) bool {
+	for _, subscribed := range e {
+		if subscribed == event {
+			return true
+		}
+	}
+	return false
+}
+
+func (e WebhookEvents) Value() (driver.Value, error) {
+	return json.Marshal(e)
+}
+
+func (e *WebhookEvents) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, e)
+	case string:
+		return json.Unmarshal([]byte(v), e)
+	default:
+		return errors.New("webhook events: unsupported scan type")
+	}
+}
+
+// Webhook is an endpoint a user registers to hear about a workspace's links.
+type Webhook struct {
+	ID          uint64        `db:"id" json:"id"`
+	UserID      uint64        `db:"user_id" json:"user_id"`
+	WorkspaceID uint64        `db:"workspace_id" json:"workspace_id"`
+	URL         string        `db:"url" json:"url"`
+	Secret      string        `db:"secret" json:"-"`
+	Events      WebhookEvents `db:"events" json:"events"`
+	IsActive    bool          `db:"is_active" json:"is_active"`
+	CreatedAt   time.Time     `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time     `db:"updated_at" json:"updated_at"`
+}
+
+// WebhookPayload is the JSON body POSTed to a webhook.
+type WebhookPayload struct {
+	Event     string    `json:"event"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+// WebhookDelivery is one queued or attempted send of a payload to a webhook.
+type WebhookDelivery struct {
+	ID             uint64          `db:"id" json:"id"`
+	WebhookID      uint64          `db:"webhook_id" json:"webhook_id"`
+	Event          string          `db:"event" json:"event"`
+	Payload        json.RawMessage `db:"payload" json:"payload"`
+	Status         string          `db:"status" json:"status"`
+	Attempts       int             `db:"attempts" json:"attempts"`
+	NextAttemptAt  time.Time       `db:"next_attempt_at" json:"next_attempt_at"`
+	ResponseStatus *int            `db:"response_status" json:"response_status,omitempty"`
+	LastError      string          `db:"last_error" json:"last_error,omitempty"`
+	DeliveredAt    *time.Time      `db:"delivered_at" json:"delivered_at,omitempty"`
+	CreatedAt      time.Time       `db:"created_at" json:"created_at"`
+}
@@ -0,0 +1,68 @@
+package model
+
+import "time"
+
+// Workspace roles, from most to least privileged
+const (
+	RoleOwner  = "owner"
+	RoleAdmin  = "admin"
+	RoleEditor = "editor"
+	RoleViewer = "viewer"
+)
+
+var roleRank = map[string]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleAdmin:  3,
+	RoleOwner:  4,
+}
+
+// IsRole reports whether name is a known workspace role.
+func IsRole(name string) bool {
+	return roleRank[name] > 0
+}
+
+// RoleAtLeast reports whether role grants everything min does.
+func RoleAtLeast(role, min string) bool {
+	return roleRank[role] > 0 && roleRank[role] >= roleRank[min]
+}
+
+// Workspace owns links, domains and campaigns shared by its members. A
+// personal workspace belongs to one user and cannot take other members.
+type Workspace struct {
+	ID             uint64    `db:"id" json:"id"`
+	Name           string    `db:"name" json:"name"`
+	PersonalUserID *uint64   `db:"personal_user_id" json:"personal_user_id,omitempty"`
+	CreatedAt      time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt      time.Time `db:"updated_at" json:"updated_at"`
+	// Role is the requesting user's role, filled in when listing their workspaces
+	Role string `db:"role" json:"role,omitempty"`
+}
+
+// IsPersonal reports whether the workspace is a user's personal workspace.
+func (w *Workspace) IsPersonal() bool {
+	return w.PersonalUserID != nil
+}
+
+type WorkspaceMember struct {
+	WorkspaceID uint64    `db:"workspace_id" json:"workspace_id"`
+	UserID      uint64    `db:"user_id" json:"user_id"`
+	Role        string    `db:"role" json:"role"`
+	Email       string    `db:"email" json:"email"`
+	DisplayName *string   `db:"display_name" json:"display_name,omitempty"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at"`
+}
+
+// WorkspaceInvitation lets whoever holds its token and signs in with Email
+// join the workspace with Role.
+type WorkspaceInvitation struct {
+	ID          uint64     `db:"id" json:"id"`
+	WorkspaceID uint64     `db:"workspace_id" json:"workspace_id"`
+	Email       string     `db:"email" json:"email"`
+	Role        string     `db:"role" json:"role"`
+	TokenHash   string     `db:"token_hash" json:"-"`
+	InvitedBy   *uint64    `db:"invited_by" json:"invited_by,omitempty"`
+	ExpiresAt   time.Time  `db:"expires_at" json:"expires_at"`
+	AcceptedAt  *time.Time `db:"accepted_at" json:"accepted_at,omitempty"`
+	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+var ErrAPIKeyNotFound = errors.New("api key not found")
+
+// Compile-time check: APIKeyRepositoryImpl implements APIKeyRepository
+var _ APIKeyRepository = (*APIKeyRepositoryImpl)(nil)
+
+type APIKeyRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+func NewAPIKeyRepository(db *sqlx.DB) *APIKeyRepositoryImpl {
+	return &APIKeyRepositoryImpl{db: db}
+}
+
+func (r *APIKeyRepositoryImpl) Create(ctx context.Context, key *model.APIKey) error {
+	query := `INSERT INTO api_keys (user_id, name, prefix, key_hash, scopes, expires_at) VALUES (?, ?, ?, ?, ?, ?)`
+	result, err := r.db.ExecContext(ctx, query, key.UserID, key.Name, key.Prefix, key.KeyHash, key.Scopes, key.ExpiresAt)
+	if err != nil {
+		logger.Error(ctx, "api-key-repo: failed to create api key",
+			zap.Uint64("user_id", key.UserID),
+			zap.Error(err),
+		)
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error(ctx, "api-key-repo: failed to get last insert ID",
+			zap.Error(err),
+		)
+		return err
+	}
+	key.ID = uint64(id)
+	return nil
+}
+
+func (r *APIKeyRepositoryImpl) GetByID(ctx context.Context, id uint64) (*model.APIKey, error) {
+	var key model.APIKey
+	query := `SELECT id, user_id, name, prefix, key_hash, scopes, expires_at, last_used_at, created_at
+			  FROM api_keys WHERE id = ?`
+	err := r.db.GetContext(ctx, &key, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAPIKeyNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, "api-key-repo: failed to get api key by ID",
+			zap.Uint64("id", id),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *APIKeyRepositoryImpl) GetByHash(ctx context.Context, keyHash string) (*model.APIKey, error) {
+	var key model.APIKey
+	query := `SELECT id, user_id, name, prefix, key_hash, scopes, expires_at, last_used_at, created_at
+			  FROM api_keys WHERE key_hash = ?`
+	err := r.db.GetContext(ctx, &key, query, keyHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAPIKeyNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, "api-key-repo: failed to get api key by hash",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *APIKeyRepositoryImpl) ListByUserID(ctx context.Context, userID uint64) ([]model.APIKey, error) {
+	var keys []model.APIKey
+	query := `SELECT id, user_id, name, prefix, key_hash, scopes, expires_at, last_used_at, created_at
+			  FROM api_keys WHERE user_id = ? ORDER BY created_at DESC`
+	err := r.db.SelectContext(ctx, &keys, query, userID)
+	if err != nil {
+		logger.Error(ctx, "api-key-repo: failed to list api keys",
+			zap.Uint64("user_id", userID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if keys == nil {
+		keys = []model.APIKey{}
+	}
+	return keys, nil
+}
+
+func (r *APIKeyRepositoryImpl) UpdateLastUsedAt(ctx context.Context, id uint64) error {
+	query := `UPDATE api_keys SET last_used_at = NOW() WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		logger.Error(ctx, "api-key-repo: failed to update last used",
+			zap.Uint64("id", id),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
+
+func (r *APIKeyRepositoryImpl) Delete(ctx context.Context, id uint64) error {
+	query := `DELETE FROM api_keys WHERE id = ?`
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		logger.Error(ctx, "api-key-repo: failed to delete api key",
+			zap.Uint64("id", id),
+			zap.Error(err),
+		)
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		logger.Error(ctx, "api-key-repo: failed to get rows affected on delete",
+			zap.Uint64("id", id),
+			zap.Error(err),
+		)
+		return err
+	}
+	if rows == 0 {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
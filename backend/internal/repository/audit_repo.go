@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+// Compile-time check: AuditRepositoryImpl implements AuditRepository
+var _ AuditRepository = (*AuditRepositoryImpl)(nil)
+
+type AuditRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+func NewAuditRepository(db *sqlx.DB) *AuditRepositoryImpl {
+	return &AuditRepositoryImpl{db: db}
+}
+
+func (r *AuditRepositoryImpl) Create(ctx context.Context, entry *model.AuditEntry) error {
+	query := `INSERT INTO audit_log (workspace_id, actor_user_id, action, target_type, target_id, changes, ip_address, user_agent)
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	result, err := r.db.ExecContext(ctx, query, entry.WorkspaceID, entry.ActorUserID, entry.Action, entry.TargetType,
+		entry.TargetID, entry.Changes, entry.IPAddress, entry.UserAgent)
+	if err != nil {
+		logger.Error(ctx, "audit-repo: failed to create entry",
+			zap.String("action", entry.Action),
+			zap.Error(err),
+		)
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error(ctx, "audit-repo: failed to get last insert ID",
+			zap.Error(err),
+		)
+		return err
+	}
+	entry.ID = uint64(id)
+	return nil
+}
+
+// List returns the entries matching filter, newest first.
+func (r *AuditRepositoryImpl) List(ctx context.Context, filter AuditFilter) ([]model.AuditEntry, error) {
+	where, args := auditFilter(filter)
+	query := `SELECT id, workspace_id, actor_user_id, action, target_type, target_id, changes, ip_address, user_agent, created_at
+			  FROM audit_log ` + where + ` ORDER BY id DESC LIMIT ?`
+	args = append(args, filter.Limit)
+
+	entries := []model.AuditEntry{}
+	if err := r.db.SelectContext(ctx, &entries, query, args...); err != nil {
+		logger.Error(ctx, "audit-repo: failed to list entries",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return entries, nil
+}
+
+// auditFilter returns the WHERE clause selecting the entries matched by
+// filter, along with its bind arguments.
+func auditFilter(filter AuditFilter) (string, []interface{}) {
+	conditions := []string{}
+	args := []interface{}{}
+	switch {
+	case filter.WorkspaceID != 0 && filter.AccountOf != 0:
+		conditions = append(conditions, "(workspace_id = ? OR (workspace_id IS NULL AND actor_user_id = ?))")
+		args = append(args, filter.WorkspaceID, filter.AccountOf)
+	case filter.WorkspaceID != 0:
+		conditions = append(conditions, "workspace_id = ?")
+		args = append(args, filter.WorkspaceID)
+	case filter.AccountOf != 0:
+		conditions = append(conditions, "workspace_id IS NULL AND actor_user_id = ?")
+		args = append(args, filter.AccountOf)
+	}
+	if filter.ActorUserID != 0 {
+		conditions = append(conditions, "actor_user_id = ?")
+		args = append(args, filter.ActorUserID)
+	}
+	if filter.Action != "" {
+		// A trailing dot matches every action of a target, e.g. "link."
+		if strings.HasSuffix(filter.Action, ".") {
+			conditions = append(conditions, "action LIKE ?")
+			args = append(args, filter.Action+"%")
+		} else {
+			conditions = append(conditions, "action = ?")
+			args = append(args, filter.Action)
+		}
+	}
+	if filter.TargetType != "" {
+		conditions = append(conditions, "target_type = ?")
+		args = append(args, filter.TargetType)
+	}
+	if filter.TargetID != 0 {
+		conditions = append(conditions, "target_id = ?")
+		args = append(args, filter.TargetID)
+	}
+	if filter.Since != nil {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, *filter.Since)
+	}
+	if filter.Until != nil {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, *filter.Until)
+	}
+	if filter.BeforeID != 0 {
+		conditions = append(conditions, "id < ?")
+		args = append(args, filter.BeforeID)
+	}
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+var ErrCampaignNotFound = errors.New("campaign not found")
+
+// Compile-time check: CampaignRepositoryImpl implements CampaignRepository
+var _ CampaignRepository = (*CampaignRepositoryImpl)(nil)
+
+type CampaignRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+func NewCampaignRepository(db *sqlx.DB) *CampaignRepositoryImpl {
+	return &CampaignRepositoryImpl{db: db}
+}
+
+func (r *CampaignRepositoryImpl) Create(ctx context.Context, campaign *model.Campaign) error {
+	query := `INSERT INTO campaigns (user_id, workspace_id, name, starts_at, ends_at, budget_notes, utm_source, utm_medium, utm_campaign)
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	result, err := r.db.ExecContext(ctx, query,
+		campaign.UserID, campaign.WorkspaceID, campaign.Name, campaign.StartsAt, campaign.EndsAt, campaign.BudgetNotes,
+		campaign.UTMSource, campaign.UTMMedium, campaign.UTMCampaign)
+	if err != nil {
+		logger.Error(ctx, "campaign-repo: failed to create campaign",
+			zap.Uint64("user_id", campaign.UserID),
+			zap.Error(err),
+		)
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error(ctx, "campaign-repo: failed to get last insert ID",
+			zap.Error(err),
+		)
+		return err
+	}
+	campaign.ID = uint64(id)
+
+	// Fetch the created record to get DB-generated timestamps
+	created, err := r.GetByID(ctx, campaign.ID)
+	if err != nil {
+		return err
+	}
+	campaign.CreatedAt = created.CreatedAt
+	campaign.UpdatedAt = created.UpdatedAt
+
+	return nil
+}
+
+func (r *CampaignRepositoryImpl) GetByID(ctx context.Context, id uint64) (*model.Campaign, error) {
+	var campaign model.Campaign
+	query := `SELECT id, user_id, workspace_id, name, starts_at, ends_at, budget_notes, utm_source, utm_medium, utm_campaign, created_at, updated_at
+			  FROM campaigns WHERE id = ?`
+	err := r.db.GetContext(ctx, &campaign, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCampaignNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, "campaign-repo: failed to get campaign by ID",
+			zap.Uint64("campaign_id", id),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return &campaign, nil
+}
+
+func (r *CampaignRepositoryImpl) ListByWorkspaceID(ctx context.Context, workspaceID uint64) ([]model.Campaign, error) {
+	var campaigns []model.Campaign
+	query := `SELECT id, user_id, workspace_id, name, starts_at, ends_at, budget_notes, utm_source, utm_medium, utm_campaign, created_at, updated_at
+			  FROM campaigns WHERE workspace_id = ? ORDER BY created_at DESC`
+	err := r.db.SelectContext(ctx, &campaigns, query, workspaceID)
+	if err != nil {
+		logger.Error(ctx, "campaign-repo: failed to list campaigns by workspace ID",
+			zap.Uint64("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if campaigns == nil {
+		campaigns = []model.Campaign{}
+	}
+	return campaigns, nil
+}
+
+func (r *CampaignRepositoryImpl) Update(ctx context.Context, campaign *model.Campaign) error {
+	query := `UPDATE campaigns SET name = ?, starts_at = ?, ends_at = ?, budget_notes = ?,
+			  utm_source = ?, utm_medium = ?, utm_campaign = ?, updated_at = NOW()
+			  WHERE id = ?`
+	result, err := r.db.ExecContext(ctx, query,
+		campaign.Name, campaign.StartsAt, campaign.EndsAt, campaign.BudgetNotes,
+		campaign.UTMSource, campaign.UTMMedium, campaign.UTMCampaign, campaign.ID)
+	if err != nil {
+		logger.Error(ctx, "campaign-repo: failed to update campaign",
+			zap.Uint64("campaign_id", campaign.ID),
+			zap.Error(err),
+		)
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		logger.Error(ctx, "campaign-repo: failed to get rows affected",
+			zap.Uint64("campaign_id", campaign.ID),
+			zap.Error(err),
+		)
+		return err
+	}
+	if rows == 0 {
+		return ErrCampaignNotFound
+	}
+	return nil
+}
+
+func (r *CampaignRepositoryImpl) Delete(ctx context.Context, id uint64) error {
+	query := `DELETE FROM campaigns WHERE id = ?`
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		logger.Error(ctx, "campaign-repo: failed to delete campaign",
+			zap.Uint64("campaign_id", id),
+			zap.Error(err),
+		)
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		logger.Error(ctx, "campaign-repo: failed to get rows affected on delete",
+			zap.Uint64("campaign_id", id),
+			zap.Error(err),
+		)
+		return err
+	}
+	if rows == 0 {
+		return ErrCampaignNotFound
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"strings"
 
 	"github.com/SeaCodeBase/urlshortener/internal/model"
 	"github.com/SeaCodeBase/urlshortener/pkg/logger"
@@ -27,8 +28,8 @@ func (r *ClickRepositoryImpl) BatchInsert(ctx context.Context, clicks []model.Cl
 
 	// INSERT IGNORE skips rows with invalid link_id (e.g., deleted links still in Redis queue)
 	// This prevents the entire batch from failing due to a few invalid records
-	query := `INSERT IGNORE INTO clicks (link_id, clicked_at, ip_hash, ip_address, user_agent, referrer, country, city, device_type, browser, utm_source, utm_medium, utm_campaign)
-			  VALUES (:link_id, :clicked_at, :ip_hash, :ip_address, :user_agent, :referrer, :country, :city, :device_type, :browser, :utm_source, :utm_medium, :utm_campaign)`
+	query := `INSERT IGNORE INTO clicks (link_id, alias_id, clicked_at, ip_hash, ip_address, user_agent, referrer, country, city, device_type, browser, utm_source, utm_medium, utm_campaign)
+			  VALUES (:link_id, :alias_id, :clicked_at, :ip_hash, :ip_address, :user_agent, :referrer, :country, :city, :device_type, :browser, :utm_source, :utm_medium, :utm_campaign)`
 
 	_, err := r.db.NamedExecContext(ctx, query, clicks)
 	if err != nil {
@@ -155,3 +156,254 @@ func (r *ClickRepositoryImpl) GetCityStats(ctx context.Context, linkID uint64, l
 	}
 	return stats, nil
 }
+
+func (r *ClickRepositoryImpl) GetCodeStats(ctx context.Context, linkID uint64) ([]CodeStats, error) {
+	var stats []CodeStats
+	// Clicks without an alias came through the link's primary code
+	query := `SELECT c.alias_id, COALESCE(a.code, IF(c.alias_id IS NULL, l.short_code, '(deleted alias)')) as code, COUNT(*) as count
+			  FROM clicks c
+			  JOIN links l ON l.id = c.link_id
+			  LEFT JOIN link_aliases a ON a.id = c.alias_id
+			  WHERE c.link_id = ? GROUP BY c.alias_id, a.code, l.short_code ORDER BY count DESC`
+	err := r.db.SelectContext(ctx, &stats, query, linkID)
+	if err != nil {
+		logger.Error(ctx, "click-repo: failed to get code stats",
+			zap.Uint64("link_id", linkID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return stats, nil
+}
+
+// scopeFilter returns the FROM/JOIN/WHERE fragment restricting clicks (aliased c)
+// to the links selected by scope and its time range, along with its bind arguments.
+func scopeFilter(scope ClickScope) (string, []interface{}) {
+	conditions := []string{}
+	args := []interface{}{}
+	if scope.CampaignID != 0 {
+		conditions = append(conditions, "l.campaign_id = ?")
+		args = append(args, scope.CampaignID)
+	}
+	if scope.DomainID != 0 {
+		conditions = append(conditions, "l.domain_id = ?")
+		args = append(args, scope.DomainID)
+	}
+	if scope.WorkspaceID != 0 {
+		conditions = append(conditions, "l.workspace_id = ?")
+		args = append(args, scope.WorkspaceID)
+	}
+	if len(conditions) == 0 {
+		// An empty scope must never aggregate the whole table
+		conditions = append(conditions, "1 = 0")
+	}
+	if !scope.Since.IsZero() {
+		conditions = append(conditions, "c.clicked_at >= ?")
+		args = append(args, scope.Since)
+	}
+	if !scope.Until.IsZero() {
+		conditions = append(conditions, "c.clicked_at < ?")
+		args = append(args, scope.Until)
+	}
+	return "FROM clicks c JOIN links l ON l.id = c.link_id WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func (r *ClickRepositoryImpl) GetScopedStats(ctx context.Context, scope ClickScope) (*ClickStats, error) {
+	var stats ClickStats
+	filter, args := scopeFilter(scope)
+	query := `SELECT COUNT(*) as total_clicks, COUNT(DISTINCT c.ip_hash) as unique_visitors ` + filter
+	err := r.db.GetContext(ctx, &stats, query, args...)
+	if err != nil {
+		logger.Error(ctx, "click-repo: failed to get scoped click stats",
+			zap.Any("scope", scope),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return &stats, nil
+}
+
+func (r *ClickRepositoryImpl) GetScopedDailyStats(ctx context.Context, scope ClickScope, days int) ([]DailyClickStats, error) {
+	var stats []DailyClickStats
+	filter, args := scopeFilter(scope)
+	query := `SELECT DATE(c.clicked_at) as date, COUNT(*) as clicks ` + filter +
+		` AND c.clicked_at >= DATE_SUB(NOW(), INTERVAL ? DAY) GROUP BY DATE(c.clicked_at) ORDER BY date DESC`
+	err := r.db.SelectContext(ctx, &stats, query, append(args, days)...)
+	if err != nil {
+		logger.Error(ctx, "click-repo: failed to get scoped daily stats",
+			zap.Any("scope", scope),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (r *ClickRepositoryImpl) GetScopedTopReferrers(ctx context.Context, scope ClickScope, limit int) ([]ReferrerStats, error) {
+	var stats []ReferrerStats
+	filter, args := scopeFilter(scope)
+	query := `SELECT COALESCE(NULLIF(c.referrer, ''), 'Direct') as referrer, COUNT(*) as count ` + filter +
+		` GROUP BY c.referrer ORDER BY count DESC LIMIT ?`
+	err := r.db.SelectContext(ctx, &stats, query, append(args, limit)...)
+	if err != nil {
+		logger.Error(ctx, "click-repo: failed to get scoped top referrers",
+			zap.Any("scope", scope),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (r *ClickRepositoryImpl) GetScopedDeviceStats(ctx context.Context, scope ClickScope) ([]DeviceStats, error) {
+	var stats []DeviceStats
+	filter, args := scopeFilter(scope)
+	query := `SELECT c.device_type, COUNT(*) as count ` + filter + ` GROUP BY c.device_type ORDER BY count DESC`
+	err := r.db.SelectContext(ctx, &stats, query, args...)
+	if err != nil {
+		logger.Error(ctx, "click-repo: failed to get scoped device stats",
+			zap.Any("scope", scope),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (r *ClickRepositoryImpl) GetScopedCountryStats(ctx context.Context, scope ClickScope, limit int) ([]CountryStats, error) {
+	var stats []CountryStats
+	filter, args := scopeFilter(scope)
+	query := `SELECT COALESCE(NULLIF(c.country, ''), 'Unknown') as country, COUNT(*) as count ` + filter +
+		` GROUP BY c.country ORDER BY count DESC LIMIT ?`
+	err := r.db.SelectContext(ctx, &stats, query, append(args, limit)...)
+	if err != nil {
+		logger.Error(ctx, "click-repo: failed to get scoped country stats",
+			zap.Any("scope", scope),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (r *ClickRepositoryImpl) GetScopedTopLinks(ctx context.Context, scope ClickScope, limit int) ([]LinkClickStats, error) {
+	var stats []LinkClickStats
+	filter, args := scopeFilter(scope)
+	query := `SELECT l.id as link_id, l.short_code, COUNT(*) as count ` + filter +
+		` GROUP BY l.id, l.short_code ORDER BY count DESC LIMIT ?`
+	err := r.db.SelectContext(ctx, &stats, query, append(args, limit)...)
+	if err != nil {
+		logger.Error(ctx, "click-repo: failed to get scoped top links",
+			zap.Any("scope", scope),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (r *ClickRepositoryImpl) RecordMisses(ctx context.Context, misses []model.DomainMiss) error {
+	if len(misses) == 0 {
+		return nil
+	}
+
+	// INSERT IGNORE skips misses of domains deleted since they were buffered
+	query := `INSERT IGNORE INTO domain_misses (domain_id, date, reason, code, count)
+			  VALUES (:domain_id, :date, :reason, :code, :count)
+			  ON DUPLICATE KEY UPDATE count = count + VALUES(count)`
+	_, err := r.db.NamedExecContext(ctx, query, misses)
+	if err != nil {
+		logger.Error(ctx, "click-repo: failed to record domain misses",
+			zap.Int("count", len(misses)),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
+
+func (r *ClickRepositoryImpl) GetMissTotals(ctx context.Context, domainID uint64) (*MissTotals, error) {
+	var totals MissTotals
+	query := `SELECT COALESCE(SUM(IF(reason = 'not_found', count, 0)), 0) as not_found,
+			  COALESCE(SUM(IF(reason = 'expired', count, 0)), 0) as expired
+			  FROM domain_misses WHERE domain_id = ?`
+	err := r.db.GetContext(ctx, &totals, query, domainID)
+	if err != nil {
+		logger.Error(ctx, "click-repo: failed to get miss totals",
+			zap.Uint64("domain_id", domainID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return &totals, nil
+}
+
+func (r *ClickRepositoryImpl) GetTopMisses(ctx context.Context, domainID uint64, limit int) ([]MissStats, error) {
+	var stats []MissStats
+	query := `SELECT code, reason, SUM(count) as count, MAX(date) as last_seen
+			  FROM domain_misses WHERE domain_id = ?
+			  GROUP BY code, reason ORDER BY count DESC LIMIT ?`
+	err := r.db.SelectContext(ctx, &stats, query, domainID, limit)
+	if err != nil {
+		logger.Error(ctx, "click-repo: failed to get top misses",
+			zap.Uint64("domain_id", domainID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (r *ClickRepositoryImpl) GetScopedBrowserStats(ctx context.Context, scope ClickScope, limit int) ([]BrowserStats, error) {
+	var stats []BrowserStats
+	filter, args := scopeFilter(scope)
+	query := `SELECT COALESCE(NULLIF(c.browser, ''), 'Unknown') as browser, COUNT(*) as count ` + filter +
+		` GROUP BY c.browser ORDER BY count DESC LIMIT ?`
+	err := r.db.SelectContext(ctx, &stats, query, append(args, limit)...)
+	if err != nil {
+		logger.Error(ctx, "click-repo: failed to get scoped browser stats",
+			zap.Any("scope", scope),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (r *ClickRepositoryImpl) GetScopedCityStats(ctx context.Context, scope ClickScope, limit int) ([]CityStats, error) {
+	var stats []CityStats
+	filter, args := scopeFilter(scope)
+	query := `SELECT COALESCE(NULLIF(c.city, ''), 'Unknown') as city,
+			  COALESCE(NULLIF(c.country, ''), 'Unknown') as country, COUNT(*) as count ` + filter +
+		` GROUP BY c.city, c.country ORDER BY count DESC LIMIT ?`
+	err := r.db.SelectContext(ctx, &stats, query, append(args, limit)...)
+	if err != nil {
+		logger.Error(ctx, "click-repo: failed to get scoped city stats",
+			zap.Any("scope", scope),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (r *ClickRepositoryImpl) GetScopedSeries(ctx context.Context, scope ClickScope, interval string) ([]ClickSeriesPoint, error) {
+	format := "%Y-%m-%d"
+	if interval == SeriesHour {
+		format = "%Y-%m-%d %H:00"
+	}
+	var stats []ClickSeriesPoint
+	filter, args := scopeFilter(scope)
+	query := `SELECT DATE_FORMAT(c.clicked_at, '` + format + `') as period, COUNT(*) as clicks ` + filter +
+		` GROUP BY period ORDER BY period`
+	err := r.db.SelectContext(ctx, &stats, query, args...)
+	if err != nil {
+		logger.Error(ctx, "click-repo: failed to get scoped click series",
+			zap.Any("scope", scope),
+			zap.String("interval", interval),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return stats, nil
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+// Compile-time check: CodeSequenceRepositoryImpl implements CodeSequenceRepository
+var _ CodeSequenceRepository = (*CodeSequenceRepositoryImpl)(nil)
+
+type CodeSequenceRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+func NewCodeSequenceRepository(db *sqlx.DB) *CodeSequenceRepositoryImpl {
+	return &CodeSequenceRepositoryImpl{db: db}
+}
+
+func (r *CodeSequenceRepositoryImpl) Reserve(ctx context.Context, scope string, n uint64) (uint64, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error(ctx, "code-sequence-repo: failed to begin transaction",
+			zap.Error(err),
+		)
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `INSERT IGNORE INTO code_sequences (scope, next_value) VALUES (?, 1)`, scope); err != nil {
+		logger.Error(ctx, "code-sequence-repo: failed to initialize sequence",
+			zap.String("scope", scope),
+			zap.Error(err),
+		)
+		return 0, err
+	}
+
+	var first uint64
+	if err := tx.GetContext(ctx, &first, `SELECT next_value FROM code_sequences WHERE scope = ? FOR UPDATE`, scope); err != nil {
+		logger.Error(ctx, "code-sequence-repo: failed to read sequence",
+			zap.String("scope", scope),
+			zap.Error(err),
+		)
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE code_sequences SET next_value = next_value + ? WHERE scope = ?`, n, scope); err != nil {
+		logger.Error(ctx, "code-sequence-repo: failed to advance sequence",
+			zap.String("scope", scope),
+			zap.Error(err),
+		)
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error(ctx, "code-sequence-repo: failed to commit sequence reservation",
+			zap.String("scope", scope),
+			zap.Error(err),
+		)
+		return 0, err
+	}
+	return first, nil
+}
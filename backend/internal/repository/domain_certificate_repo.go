@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+var ErrCertificateNotFound = errors.New("certificate not found")
+
+// Compile-time check: DomainCertificateRepositoryImpl implements DomainCertificateRepository
+var _ DomainCertificateRepository = (*DomainCertificateRepositoryImpl)(nil)
+
+type DomainCertificateRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+func NewDomainCertificateRepository(db *sqlx.DB) *DomainCertificateRepositoryImpl {
+	return &DomainCertificateRepositoryImpl{db: db}
+}
+
+const certificateColumns = `c.id, c.domain_id, c.source, c.certificate_pem, c.private_key, c.issuer, c.not_before, c.not_after,
+	c.last_error, c.created_at, c.updated_at`
+
+func (r *DomainCertificateRepositoryImpl) Save(ctx context.Context, cert *model.DomainCertificate) error {
+	// LAST_INSERT_ID(id) makes the replaced row's ID the insert ID
+	query := `INSERT INTO domain_certificates (domain_id, source, certificate_pem, private_key, issuer, not_before, not_after)
+			  VALUES (?, ?, ?, ?, ?, ?, ?)
+			  ON DUPLICATE KEY UPDATE id = LAST_INSERT_ID(id), source = VALUES(source),
+			  certificate_pem = VALUES(certificate_pem), private_key = VALUES(private_key), issuer = VALUES(issuer),
+			  not_before = VALUES(not_before), not_after = VALUES(not_after), last_error = ''`
+	result, err := r.db.ExecContext(ctx, query, cert.DomainID, cert.Source, cert.CertificatePEM, cert.PrivateKey,
+		cert.Issuer, cert.NotBefore, cert.NotAfter)
+	if err != nil {
+		logger.Error(ctx, "certificate-repo: failed to save certificate",
+			zap.Uint64("domain_id", cert.DomainID),
+			zap.Error(err),
+		)
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error(ctx, "certificate-repo: failed to get last insert ID",
+			zap.Error(err),
+		)
+		return err
+	}
+	cert.ID = uint64(id)
+	cert.LastError = ""
+	return nil
+}
+
+func (r *DomainCertificateRepositoryImpl) GetByDomainID(ctx context.Context, domainID uint64) (*model.DomainCertificate, error) {
+	var cert model.DomainCertificate
+	query := `SELECT ` + certificateColumns + ` FROM domain_certificates c WHERE c.domain_id = ?`
+	err := r.db.GetContext(ctx, &cert, query, domainID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCertificateNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, "certificate-repo: failed to get certificate by domain ID",
+			zap.Uint64("domain_id", domainID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return &cert, nil
+}
+
+func (r *DomainCertificateRepositoryImpl) GetByHost(ctx context.Context, host string) (*model.DomainCertificate, error) {
+	var cert model.DomainCertificate
+	query := `SELECT ` + certificateColumns + ` FROM domain_certificates c
+			  JOIN domains d ON d.id = c.domain_id
+			  WHERE d.domain = ? AND d.verified_domain IS NOT NULL
+			  ORDER BY d.path_prefix = '' DESC, c.not_after DESC LIMIT 1`
+	err := r.db.GetContext(ctx, &cert, query, host)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCertificateNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, "certificate-repo: failed to get certificate by host",
+			zap.String("host", host),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return &cert, nil
+}
+
+func (r *DomainCertificateRepositoryImpl) DeleteByDomainID(ctx context.Context, domainID uint64) error {
+	query := `DELETE FROM domain_certificates WHERE domain_id = ?`
+	result, err := r.db.ExecContext(ctx, query, domainID)
+	if err != nil {
+		logger.Error(ctx, "certificate-repo: failed to delete certificate",
+			zap.Uint64("domain_id", domainID),
+			zap.Error(err),
+		)
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrCertificateNotFound
+	}
+	return nil
+}
+
+func (r *DomainCertificateRepositoryImpl) ListExpiring(ctx context.Context, before time.Time, limit int) ([]*model.DomainCertificate, error) {
+	var certs []*model.DomainCertificate
+	query := `SELECT ` + certificateColumns + ` FROM domain_certificates c
+			  JOIN domains d ON d.id = c.domain_id
+			  WHERE c.not_after < ? AND d.verified_domain IS NOT NULL
+			  ORDER BY c.not_after LIMIT ?`
+	err := r.db.SelectContext(ctx, &certs, query, before, limit)
+	if err != nil {
+		logger.Error(ctx, "certificate-repo: failed to list expiring certificates",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return certs, nil
+}
+
+func (r *DomainCertificateRepositoryImpl) SetError(ctx context.Context, id uint64, message string) error {
+	if len(message) > 500 {
+		message = message[:500]
+	}
+	query := `UPDATE domain_certificates SET last_error = ? WHERE id = ?`
+	if _, err := r.db.ExecContext(ctx, query, message, id); err != nil {
+		logger.Error(ctx, "certificate-repo: failed to record certificate error",
+			zap.Uint64("id", id),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/SeaCodeBase/urlshortener/internal/model"
 	"github.com/SeaCodeBase/urlshortener/pkg/logger"
@@ -15,11 +16,18 @@ import (
 var (
 	ErrDomainNotFound = errors.New("domain not found")
 	ErrDomainExists   = errors.New("domain already exists")
+	ErrDomainInUse    = errors.New("domain still has links")
 )
 
 // Compile-time check: DomainRepositoryImpl implements DomainRepository
 var _ DomainRepository = (*DomainRepositoryImpl)(nil)
 
+// domainColumns is the column list selected for every model.Domain query
+const domainColumns = `id, user_id, workspace_id, domain, path_prefix, verification_token, verified_at, last_checked_at, check_failures,
+	code_strategy, code_length, case_insensitive_codes, code_charset, code_min_length, code_max_length,
+	use_https, redirect_status, default_expiry_days, default_utm_source, default_utm_medium, default_utm_campaign,
+	is_default, root_redirect_url, not_found_url, not_found_html, expired_url, created_at`
+
 type DomainRepositoryImpl struct {
 	db *sqlx.DB
 }
@@ -29,8 +37,10 @@ func NewDomainRepository(db *sqlx.DB) *DomainRepositoryImpl {
 }
 
 func (r *DomainRepositoryImpl) Create(ctx context.Context, domain *model.Domain) error {
-	query := `INSERT INTO domains (user_id, domain) VALUES (?, ?)`
-	result, err := r.db.ExecContext(ctx, query, domain.UserID, domain.Domain)
+	query := `INSERT INTO domains (user_id, workspace_id, domain, path_prefix, verification_token, code_strategy, code_length,
+			  case_insensitive_codes, code_charset, code_min_length, code_max_length) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	result, err := r.db.ExecContext(ctx, query, domain.UserID, domain.WorkspaceID, domain.Domain, domain.PathPrefix, domain.VerificationToken,
+		domain.CodeStrategy, domain.CodeLength, domain.CaseInsensitiveCodes, domain.CodeCharset, domain.CodeMinLength, domain.CodeMaxLength)
 	if err != nil {
 		if strings.Contains(err.Error(), "Duplicate entry") {
 			return ErrDomainExists
@@ -55,7 +65,7 @@ func (r *DomainRepositoryImpl) Create(ctx context.Context, domain *model.Domain)
 
 func (r *DomainRepositoryImpl) GetByID(ctx context.Context, id uint64) (*model.Domain, error) {
 	var domain model.Domain
-	query := `SELECT id, user_id, domain, created_at FROM domains WHERE id = ?`
+	query := `SELECT ` + domainColumns + ` FROM domains WHERE id = ? AND deleted_at IS NULL`
 	err := r.db.GetContext(ctx, &domain, query, id)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, ErrDomainNotFound
@@ -70,16 +80,16 @@ func (r *DomainRepositoryImpl) GetByID(ctx context.Context, id uint64) (*model.D
 	return &domain, nil
 }
 
-func (r *DomainRepositoryImpl) GetByDomain(ctx context.Context, domainName string) (*model.Domain, error) {
+func (r *DomainRepositoryImpl) GetVerifiedByDomain(ctx context.Context, key string) (*model.Domain, error) {
 	var domain model.Domain
-	query := `SELECT id, user_id, domain, created_at FROM domains WHERE domain = ?`
-	err := r.db.GetContext(ctx, &domain, query, domainName)
+	query := `SELECT ` + domainColumns + ` FROM domains WHERE verified_domain = ?`
+	err := r.db.GetContext(ctx, &domain, query, key)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, ErrDomainNotFound
 	}
 	if err != nil {
 		logger.Error(ctx, "domain-repo: failed to get domain by name",
-			zap.String("domain", domainName),
+			zap.String("domain", key),
 			zap.Error(err),
 		)
 		return nil, err
@@ -87,13 +97,30 @@ func (r *DomainRepositoryImpl) GetByDomain(ctx context.Context, domainName strin
 	return &domain, nil
 }
 
-func (r *DomainRepositoryImpl) ListByUserID(ctx context.Context, userID uint64) ([]*model.Domain, error) {
+func (r *DomainRepositoryImpl) ListVerifiedByPatterns(ctx context.Context, patterns []string) ([]*model.Domain, error) {
+	query, args, err := sqlx.In(`SELECT `+domainColumns+` FROM domains
+			  WHERE domain IN (?) AND verified_domain IS NOT NULL`, patterns)
+	if err != nil {
+		return nil, err
+	}
 	var domains []*model.Domain
-	query := `SELECT id, user_id, domain, created_at FROM domains WHERE user_id = ? ORDER BY created_at DESC`
-	err := r.db.SelectContext(ctx, &domains, query, userID)
+	if err := r.db.SelectContext(ctx, &domains, r.db.Rebind(query), args...); err != nil {
+		logger.Error(ctx, "domain-repo: failed to list domains by pattern",
+			zap.Strings("patterns", patterns),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return domains, nil
+}
+
+func (r *DomainRepositoryImpl) ListByWorkspaceID(ctx context.Context, workspaceID uint64) ([]*model.Domain, error) {
+	var domains []*model.Domain
+	query := `SELECT ` + domainColumns + ` FROM domains WHERE workspace_id = ? AND deleted_at IS NULL ORDER BY created_at DESC`
+	err := r.db.SelectContext(ctx, &domains, query, workspaceID)
 	if err != nil {
-		logger.Error(ctx, "domain-repo: failed to list domains by user ID",
-			zap.Uint64("userID", userID),
+		logger.Error(ctx, "domain-repo: failed to list domains by workspace ID",
+			zap.Uint64("workspace_id", workspaceID),
 			zap.Error(err),
 		)
 		return nil, err
@@ -105,6 +132,10 @@ func (r *DomainRepositoryImpl) Delete(ctx context.Context, id uint64) error {
 	query := `DELETE FROM domains WHERE id = ?`
 	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
+		// links.domain_id is ON DELETE RESTRICT
+		if strings.Contains(err.Error(), "foreign key constraint fails") {
+			return ErrDomainInUse
+		}
 		logger.Error(ctx, "domain-repo: failed to delete domain",
 			zap.Uint64("id", id),
 			zap.Error(err),
@@ -121,3 +152,159 @@ func (r *DomainRepositoryImpl) Delete(ctx context.Context, id uint64) error {
 	}
 	return nil
 }
+
+func (r *DomainRepositoryImpl) Archive(ctx context.Context, id uint64) error {
+	query := `UPDATE domains SET deleted_at = NOW(), is_default = FALSE WHERE id = ? AND deleted_at IS NULL`
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		logger.Error(ctx, "domain-repo: failed to archive domain",
+			zap.Uint64("id", id),
+			zap.Error(err),
+		)
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrDomainNotFound
+	}
+	return nil
+}
+
+func (r *DomainRepositoryImpl) Update(ctx context.Context, domain *model.Domain) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error(ctx, "domain-repo: failed to begin transaction",
+			zap.Error(err),
+		)
+		return err
+	}
+	defer tx.Rollback()
+
+	// A workspace has one default domain; taking the flag clears it elsewhere
+	if domain.IsDefault {
+		_, err := tx.ExecContext(ctx, `UPDATE domains SET is_default = FALSE WHERE workspace_id = ? AND id <> ?`,
+			domain.WorkspaceID, domain.ID)
+		if err != nil {
+			logger.Error(ctx, "domain-repo: failed to clear default domain",
+				zap.Uint64("workspace_id", domain.WorkspaceID),
+				zap.Error(err),
+			)
+			return err
+		}
+	}
+
+	query := `UPDATE domains SET code_strategy = ?, code_length = ?, use_https = ?, redirect_status = ?,
+			  default_expiry_days = ?, default_utm_source = ?, default_utm_medium = ?, default_utm_campaign = ?,
+			  is_default = ?, root_redirect_url = ?, not_found_url = ?, not_found_html = ?, expired_url = ?
+			  WHERE id = ?`
+	// No rows-affected check: saving unchanged settings affects none
+	_, err = tx.ExecContext(ctx, query, domain.CodeStrategy, domain.CodeLength, domain.UseHTTPS, domain.RedirectStatus,
+		domain.DefaultExpiryDays, domain.DefaultUTMSource, domain.DefaultUTMMedium, domain.DefaultUTMCampaign,
+		domain.IsDefault, domain.RootRedirectURL, domain.NotFoundURL, domain.NotFoundHTML, domain.ExpiredURL, domain.ID)
+	if err != nil {
+		logger.Error(ctx, "domain-repo: failed to update domain",
+			zap.Uint64("id", domain.ID),
+			zap.Error(err),
+		)
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetDefaultByWorkspaceID returns the workspace's default domain for new links.
+func (r *DomainRepositoryImpl) GetDefaultByWorkspaceID(ctx context.Context, workspaceID uint64) (*model.Domain, error) {
+	var domain model.Domain
+	query := `SELECT ` + domainColumns + ` FROM domains WHERE default_workspace_id = ?`
+	err := r.db.GetContext(ctx, &domain, query, workspaceID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrDomainNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, "domain-repo: failed to get default domain",
+			zap.Uint64("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return &domain, nil
+}
+
+func (r *DomainRepositoryImpl) UpdateVerification(ctx context.Context, domain *model.Domain) error {
+	query := `UPDATE domains SET verified_at = ?, last_checked_at = ?, check_failures = ? WHERE id = ?`
+	result, err := r.db.ExecContext(ctx, query, domain.VerifiedAt, domain.LastCheckedAt, domain.CheckFailures, domain.ID)
+	if err != nil {
+		// Another workspace verified the same domain first
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return ErrDomainExists
+		}
+		logger.Error(ctx, "domain-repo: failed to update domain verification",
+			zap.Uint64("id", domain.ID),
+			zap.Error(err),
+		)
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrDomainNotFound
+	}
+	return nil
+}
+
+func (r *DomainRepositoryImpl) ListDueForRecheck(ctx context.Context, checkedBefore time.Time, limit int) ([]*model.Domain, error) {
+	var domains []*model.Domain
+	query := `SELECT ` + domainColumns + ` FROM domains
+			  WHERE verified_at IS NOT NULL AND verification_token <> '' AND deleted_at IS NULL
+			  AND (last_checked_at IS NULL OR last_checked_at < ?)
+			  ORDER BY last_checked_at LIMIT ?`
+	err := r.db.SelectContext(ctx, &domains, query, checkedBefore, limit)
+	if err != nil {
+		logger.Error(ctx, "domain-repo: failed to list domains due for recheck",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return domains, nil
+}
+
+func (r *DomainRepositoryImpl) ListAll(ctx context.Context) ([]*model.Domain, error) {
+	var domains []*model.Domain
+	query := `SELECT ` + domainColumns + ` FROM domains WHERE deleted_at IS NULL ORDER BY id`
+	err := r.db.SelectContext(ctx, &domains, query)
+	if err != nil {
+		logger.Error(ctx, "domain-repo: failed to list domains",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return domains, nil
+}
+
+func (r *DomainRepositoryImpl) Rename(ctx context.Context, id uint64, name string) error {
+	query := `UPDATE domains SET domain = ? WHERE id = ?`
+	result, err := r.db.ExecContext(ctx, query, name, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return ErrDomainExists
+		}
+		logger.Error(ctx, "domain-repo: failed to rename domain",
+			zap.Uint64("id", id),
+			zap.String("domain", name),
+			zap.Error(err),
+		)
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrDomainNotFound
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/SeaCodeBase/urlshortener/internal/model"
 )
@@ -21,15 +22,177 @@ type LinkRepository interface {
 	Create(ctx context.Context, link *model.Link) error
 	GetByID(ctx context.Context, id uint64) (*model.Link, error)
 	// GetByDomainAndShortCode finds a link by domain_id and short_code combination.
+	// Codes match exactly, so shortCode must be in the domain's canonical form.
 	// domainID nil means the default domain (domain_id IS NULL)
 	GetByDomainAndShortCode(ctx context.Context, domainID *uint64, shortCode string) (*model.Link, error)
-	ListByUserID(ctx context.Context, userID uint64, limit, offset int) ([]model.Link, error)
-	CountByUserID(ctx context.Context, userID uint64) (int64, error)
+	// ListByWorkspaceID and CountByWorkspaceID exclude trashed links
+	ListByWorkspaceID(ctx context.Context, workspaceID uint64, limit, offset int) ([]model.Link, error)
+	CountByWorkspaceID(ctx context.Context, workspaceID uint64) (int64, error)
+	// ListAllByWorkspaceID returns every non-trashed link of a workspace, newest first.
+	ListAllByWorkspaceID(ctx context.Context, workspaceID uint64) ([]model.Link, error)
+	// ListByDestHash returns a workspace's non-trashed links whose destination
+	// hashes to hash (util.DestinationHash), newest first.
+	ListByDestHash(ctx context.Context, workspaceID uint64, hash string, ignoreTracking bool) ([]model.Link, error)
+	// ListDuplicateDestinations returns a workspace's non-trashed links sharing
+	// their destination hash with another, newest first.
+	ListDuplicateDestinations(ctx context.Context, workspaceID uint64, ignoreTracking bool) ([]model.Link, error)
+	// FillDestHashes computes the destination hashes of links stored without
+	// them, batchSize at a time, and returns how many were filled.
+	FillDestHashes(ctx context.Context, batchSize int) (int, error)
 	Update(ctx context.Context, link *model.Link) error
+	// SaveEdit updates a link together with the alias and history writes of
+	// the edit, in one transaction. It returns ErrShortCodeExists when the
+	// link's new code is taken.
+	SaveEdit(ctx context.Context, link *model.Link, edit LinkEdit) error
+	// Delete permanently removes a link together with its clicks and aliases.
 	Delete(ctx context.Context, id uint64) error
-	// ShortCodeExistsInDomain checks if a short code exists within a specific domain.
+	// Trash soft-deletes a link; it keeps its short code until purged.
+	Trash(ctx context.Context, id uint64) error
+	// Restore moves a trashed link back out of the trash.
+	Restore(ctx context.Context, id uint64) error
+	ListTrashedByWorkspaceID(ctx context.Context, workspaceID uint64) ([]model.Link, error)
+	// PurgeTrashed permanently deletes up to limit links trashed before the given time.
+	PurgeTrashed(ctx context.Context, before time.Time, limit int) (int64, error)
+	// ShortCodeExistsInDomain checks if a short code is taken within a specific domain,
+	// either by a link (including trashed links) or by an alias that has not expired.
 	// domainID nil means the default domain (domain_id IS NULL)
 	ShortCodeExistsInDomain(ctx context.Context, domainID *uint64, shortCode string) (bool, error)
+	ListByCampaignID(ctx context.Context, campaignID uint64) ([]model.Link, error)
+	// CountByCampaignID counts a campaign's links, excluding trashed ones
+	CountByCampaignID(ctx context.Context, campaignID uint64) (int64, error)
+	// GetByIDs returns the links with the given IDs, trashed or not, in no particular order.
+	GetByIDs(ctx context.Context, ids []uint64) ([]model.Link, error)
+	// ListExpiredBetween returns non-trashed links whose expiry falls in (from, to].
+	ListExpiredBetween(ctx context.Context, from, to time.Time) ([]model.Link, error)
+	// CountCreatedBetween counts a workspace's non-trashed links created in [from, to).
+	CountCreatedBetween(ctx context.Context, workspaceID uint64, from, to time.Time) (int64, error)
+	// CountExpiringBetween counts a workspace's non-trashed links whose expiry falls in (from, to].
+	CountExpiringBetween(ctx context.Context, workspaceID uint64, from, to time.Time) (int64, error)
+	// ListByDomainID returns every link on a domain, trashed or not.
+	ListByDomainID(ctx context.Context, domainID uint64) ([]model.Link, error)
+	// MoveDomain moves every link on fromDomainID, trashed or not, and every
+	// active alias on it to toDomainID (nil is the default domain) in one
+	// transaction; expired aliases are dropped. It returns ErrShortCodeExists
+	// when a code is already taken there, and how many links were moved.
+	MoveDomain(ctx context.Context, fromDomainID uint64, toDomainID *uint64) (int64, error)
+	// TrashByDomainID moves every non-trashed link on a domain to the trash.
+	TrashByDomainID(ctx context.Context, domainID uint64) (int64, error)
+}
+
+//go:generate mockgen -destination=mocks/mock_link_alias_repo.go -package=mocks . LinkAliasRepository
+type LinkAliasRepository interface {
+	Create(ctx context.Context, alias *model.LinkAlias) error
+	// GetActiveByDomainAndCode finds an alias that has not expired.
+	// domainID nil means the default domain (domain_id IS NULL)
+	GetActiveByDomainAndCode(ctx context.Context, domainID *uint64, code string) (*model.LinkAlias, error)
+	GetByID(ctx context.Context, id uint64) (*model.LinkAlias, error)
+	ListByLinkID(ctx context.Context, linkID uint64) ([]model.LinkAlias, error)
+	// ListActiveByDomainID lists the unexpired aliases on a domain, whichever
+	// domain their links are on.
+	ListActiveByDomainID(ctx context.Context, domainID uint64) ([]model.LinkAlias, error)
+	Delete(ctx context.Context, id uint64) error
+}
+
+//go:generate mockgen -destination=mocks/mock_link_history_repo.go -package=mocks . LinkHistoryRepository
+type LinkHistoryRepository interface {
+	// Create stores a revision and assigns it the next version number for its link.
+	Create(ctx context.Context, rev *model.LinkRevision) error
+	GetByVersion(ctx context.Context, linkID uint64, version int) (*model.LinkRevision, error)
+	// ListByLinkID returns revisions newest first.
+	ListByLinkID(ctx context.Context, linkID uint64) ([]model.LinkRevision, error)
+}
+
+//go:generate mockgen -destination=mocks/mock_link_transfer_repo.go -package=mocks . LinkTransferRepository
+type LinkTransferRepository interface {
+	// Create stores a pending transfer together with its selected links.
+	Create(ctx context.Context, transfer *model.LinkTransfer) error
+	GetByID(ctx context.Context, id uint64) (*model.LinkTransfer, error)
+	// ListPendingByUserID returns pending transfers sent or received by the user.
+	ListPendingByUserID(ctx context.Context, userID uint64) ([]model.LinkTransfer, error)
+	// Accept marks a pending transfer accepted and moves the given non-trashed
+	// links of its sender's workspace to another workspace and creator, in one
+	// transaction. It returns ErrTransferNotFound when the transfer is no
+	// longer pending, and how many links were moved.
+	Accept(ctx context.Context, transfer *model.LinkTransfer, toWorkspaceID, toUserID uint64, linkIDs []uint64) (int64, error)
+	// Resolve moves a pending transfer to its final status.
+	Resolve(ctx context.Context, id uint64, status string) error
+}
+
+//go:generate mockgen -destination=mocks/mock_code_sequence_repo.go -package=mocks . CodeSequenceRepository
+type CodeSequenceRepository interface {
+	// Reserve reserves n consecutive values of the named counter and returns the first one.
+	Reserve(ctx context.Context, scope string, n uint64) (uint64, error)
+}
+
+//go:generate mockgen -destination=mocks/mock_reserved_code_repo.go -package=mocks . ReservedCodeRepository
+type ReservedCodeRepository interface {
+	Create(ctx context.Context, reserved *model.ReservedCode) error
+	GetByID(ctx context.Context, id uint64) (*model.ReservedCode, error)
+	// ListByDomainID lists a domain's reservations; domainID nil lists the global entries
+	ListByDomainID(ctx context.Context, domainID *uint64) ([]model.ReservedCode, error)
+	// Match returns the global entries and the domain's entries for a code.
+	// Codes are stored case-folded, so code must be folded too.
+	Match(ctx context.Context, domainID *uint64, code string) ([]model.ReservedCode, error)
+	Delete(ctx context.Context, id uint64) error
+}
+
+//go:generate mockgen -destination=mocks/mock_api_key_repo.go -package=mocks . APIKeyRepository
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *model.APIKey) error
+	GetByID(ctx context.Context, id uint64) (*model.APIKey, error)
+	// GetByHash finds a key by the SHA-256 hex digest of its secret
+	GetByHash(ctx context.Context, keyHash string) (*model.APIKey, error)
+	ListByUserID(ctx context.Context, userID uint64) ([]model.APIKey, error)
+	UpdateLastUsedAt(ctx context.Context, id uint64) error
+	Delete(ctx context.Context, id uint64) error
+}
+
+//go:generate mockgen -destination=mocks/mock_session_repo.go -package=mocks . SessionRepository
+type SessionRepository interface {
+	Create(ctx context.Context, session *model.Session) error
+	GetByID(ctx context.Context, id uint64) (*model.Session, error)
+	// GetByRefreshHash finds the session whose current or previous refresh
+	// token has the given SHA-256 hex digest
+	GetByRefreshHash(ctx context.Context, refreshHash string) (*model.Session, error)
+	// Rotate replaces the refresh token of an active session, but only if
+	// oldHash is still its current one; otherwise it returns ErrSessionNotFound
+	Rotate(ctx context.Context, id uint64, oldHash, newHash string, expiresAt time.Time, userAgent, ipAddress string) error
+	// ListActiveByUserID returns sessions that are neither revoked nor expired
+	ListActiveByUserID(ctx context.Context, userID uint64) ([]model.Session, error)
+	Revoke(ctx context.Context, id uint64) error
+	// RevokeByUserID revokes every session of a user except exceptID (0 for none)
+	RevokeByUserID(ctx context.Context, userID, exceptID uint64) error
+}
+
+//go:generate mockgen -destination=mocks/mock_workspace_repo.go -package=mocks . WorkspaceRepository
+type WorkspaceRepository interface {
+	// Create stores a workspace and makes ownerID its owner
+	Create(ctx context.Context, workspace *model.Workspace, ownerID uint64) error
+	GetByID(ctx context.Context, id uint64) (*model.Workspace, error)
+	GetPersonal(ctx context.Context, userID uint64) (*model.Workspace, error)
+	// ListByUserID returns the user's workspaces with their role, personal first
+	ListByUserID(ctx context.Context, userID uint64) ([]model.Workspace, error)
+	UpdateName(ctx context.Context, id uint64, name string) error
+	GetMember(ctx context.Context, workspaceID, userID uint64) (*model.WorkspaceMember, error)
+	ListMembers(ctx context.Context, workspaceID uint64) ([]model.WorkspaceMember, error)
+	AddMember(ctx context.Context, workspaceID, userID uint64, role string) error
+	UpdateMemberRole(ctx context.Context, workspaceID, userID uint64, role string) error
+	RemoveMember(ctx context.Context, workspaceID, userID uint64) error
+	CountOwners(ctx context.Context, workspaceID uint64) (int, error)
+}
+
+//go:generate mockgen -destination=mocks/mock_workspace_invitation_repo.go -package=mocks . WorkspaceInvitationRepository
+type WorkspaceInvitationRepository interface {
+	Create(ctx context.Context, invitation *model.WorkspaceInvitation) error
+	GetByID(ctx context.Context, id uint64) (*model.WorkspaceInvitation, error)
+	// GetByTokenHash finds an invitation by the SHA-256 hex digest of its token
+	GetByTokenHash(ctx context.Context, tokenHash string) (*model.WorkspaceInvitation, error)
+	// ListPendingByWorkspaceID returns invitations neither accepted nor expired
+	ListPendingByWorkspaceID(ctx context.Context, workspaceID uint64) ([]model.WorkspaceInvitation, error)
+	// Accept marks the invitation accepted and adds the user with its role,
+	// unless already a member; an accepted invitation gives ErrInvitationNotFound
+	Accept(ctx context.Context, id, userID uint64) error
+	Delete(ctx context.Context, id uint64) error
 }
 
 //go:generate mockgen -destination=mocks/mock_passkey_repo.go -package=mocks . PasskeyRepository
@@ -48,8 +211,58 @@ type PasskeyRepository interface {
 type DomainRepository interface {
 	Create(ctx context.Context, domain *model.Domain) error
 	GetByID(ctx context.Context, id uint64) (*model.Domain, error)
-	GetByDomain(ctx context.Context, domain string) (*model.Domain, error)
-	ListByUserID(ctx context.Context, userID uint64) ([]*model.Domain, error)
+	// GetVerifiedByDomain finds the verified domain with the given key (see
+	// model.Domain.Key); pending claims on it are ignored
+	GetVerifiedByDomain(ctx context.Context, key string) (*model.Domain, error)
+	// ListVerifiedByPatterns returns the verified domains whose host or
+	// wildcard pattern is one of patterns, with any path prefix
+	ListVerifiedByPatterns(ctx context.Context, patterns []string) ([]*model.Domain, error)
+	ListByWorkspaceID(ctx context.Context, workspaceID uint64) ([]*model.Domain, error)
+	// Delete removes a domain; it returns ErrDomainInUse while links are on it
+	Delete(ctx context.Context, id uint64) error
+	// Archive turns a domain into a tombstone that keeps its links' domain_id
+	// but is no longer found by any other method
+	Archive(ctx context.Context, id uint64) error
+	// Update saves the domain's settings and defaults. Marking it the
+	// default domain clears the flag on the workspace's other domains.
+	Update(ctx context.Context, domain *model.Domain) error
+	GetDefaultByWorkspaceID(ctx context.Context, workspaceID uint64) (*model.Domain, error)
+	// UpdateVerification saves verified_at, last_checked_at and check_failures.
+	// It returns ErrDomainExists when another workspace holds the name verified.
+	UpdateVerification(ctx context.Context, domain *model.Domain) error
+	// ListDueForRecheck returns verified domains with a token that were last
+	// checked before checkedBefore, least recently checked first
+	ListDueForRecheck(ctx context.Context, checkedBefore time.Time, limit int) ([]*model.Domain, error)
+	ListAll(ctx context.Context) ([]*model.Domain, error)
+	// Rename changes a domain's name. It returns ErrDomainExists when the
+	// new name collides with another domain of the workspace or with a
+	// verified domain.
+	Rename(ctx context.Context, id uint64, name string) error
+}
+
+//go:generate mockgen -destination=mocks/mock_domain_certificate_repo.go -package=mocks . DomainCertificateRepository
+type DomainCertificateRepository interface {
+	// Save stores the domain's certificate, replacing any it had, and clears
+	// the last renewal error
+	Save(ctx context.Context, cert *model.DomainCertificate) error
+	GetByDomainID(ctx context.Context, domainID uint64) (*model.DomainCertificate, error)
+	// GetByHost finds the certificate of a verified domain on the host or
+	// wildcard pattern, preferring the domain without a path prefix
+	GetByHost(ctx context.Context, host string) (*model.DomainCertificate, error)
+	DeleteByDomainID(ctx context.Context, domainID uint64) error
+	// ListExpiring returns certificates of verified domains that expire
+	// before the given time, soonest first
+	ListExpiring(ctx context.Context, before time.Time, limit int) ([]*model.DomainCertificate, error)
+	// SetError records a failed renewal
+	SetError(ctx context.Context, id uint64, message string) error
+}
+
+//go:generate mockgen -destination=mocks/mock_campaign_repo.go -package=mocks . CampaignRepository
+type CampaignRepository interface {
+	Create(ctx context.Context, campaign *model.Campaign) error
+	GetByID(ctx context.Context, id uint64) (*model.Campaign, error)
+	ListByWorkspaceID(ctx context.Context, workspaceID uint64) ([]model.Campaign, error)
+	Update(ctx context.Context, campaign *model.Campaign) error
 	Delete(ctx context.Context, id uint64) error
 }
 
@@ -64,8 +277,46 @@ type ClickRepository interface {
 	GetBrowserStats(ctx context.Context, linkID uint64) ([]BrowserStats, error)
 	GetCountryStats(ctx context.Context, linkID uint64, limit int) ([]CountryStats, error)
 	GetCityStats(ctx context.Context, linkID uint64, limit int) ([]CityStats, error)
+	// GetCodeStats breaks a link's clicks down by the code (primary or alias) used.
+	GetCodeStats(ctx context.Context, linkID uint64) ([]CodeStats, error)
+
+	// Scoped aggregates combine clicks across every link matched by the scope.
+	GetScopedStats(ctx context.Context, scope ClickScope) (*ClickStats, error)
+	GetScopedDailyStats(ctx context.Context, scope ClickScope, days int) ([]DailyClickStats, error)
+	GetScopedTopReferrers(ctx context.Context, scope ClickScope, limit int) ([]ReferrerStats, error)
+	GetScopedDeviceStats(ctx context.Context, scope ClickScope) ([]DeviceStats, error)
+	GetScopedCountryStats(ctx context.Context, scope ClickScope, limit int) ([]CountryStats, error)
+	GetScopedBrowserStats(ctx context.Context, scope ClickScope, limit int) ([]BrowserStats, error)
+	GetScopedCityStats(ctx context.Context, scope ClickScope, limit int) ([]CityStats, error)
+	// GetScopedTopLinks returns the links in the scope with the most clicks.
+	GetScopedTopLinks(ctx context.Context, scope ClickScope, limit int) ([]LinkClickStats, error)
+	// GetScopedSeries counts clicks per SeriesDay or SeriesHour, oldest first.
+	GetScopedSeries(ctx context.Context, scope ClickScope, interval string) ([]ClickSeriesPoint, error)
+
+	// RecordMisses adds counted misses onto a domain's totals for their day.
+	RecordMisses(ctx context.Context, misses []model.DomainMiss) error
+	// GetMissTotals counts a domain's misses by reason.
+	GetMissTotals(ctx context.Context, domainID uint64) (*MissTotals, error)
+	// GetTopMisses returns the codes that missed most often on a domain.
+	GetTopMisses(ctx context.Context, domainID uint64, limit int) ([]MissStats, error)
 }
 
+// ClickScope selects the set of links whose clicks are aggregated together,
+// optionally limited to clicks in [Since, Until). Set fields are combined.
+type ClickScope struct {
+	CampaignID  uint64
+	DomainID    uint64
+	WorkspaceID uint64
+	Since       time.Time
+	Until       time.Time
+}
+
+// Intervals of a click series
+const (
+	SeriesDay  = "day"
+	SeriesHour = "hour"
+)
+
 // Stats types used by ClickRepository
 type ClickStats struct {
 	TotalClicks    int64 `db:"total_clicks"`
@@ -99,9 +350,99 @@ type CountryStats struct {
 	Percentage  float64 `json:"percentage"`
 }
 
+type CodeStats struct {
+	AliasID *uint64 `db:"alias_id" json:"alias_id,omitempty"`
+	Code    string  `db:"code" json:"code"`
+	Count   int64   `db:"count" json:"clicks"`
+}
+
+type LinkClickStats struct {
+	LinkID    uint64 `db:"link_id" json:"link_id"`
+	ShortCode string `db:"short_code" json:"short_code"`
+	Count     int64  `db:"count" json:"clicks"`
+}
+
+// ClickSeriesPoint counts the clicks in the day or hour starting at Period,
+// formatted as "2006-01-02" or "2006-01-02 15:00".
+type ClickSeriesPoint struct {
+	Period string `db:"period" json:"period"`
+	Clicks int64  `db:"clicks" json:"clicks"`
+}
+
+type MissTotals struct {
+	NotFound int64 `db:"not_found" json:"not_found"`
+	Expired  int64 `db:"expired" json:"expired"`
+}
+
+type MissStats struct {
+	Code     string    `db:"code" json:"code"`
+	Reason   string    `db:"reason" json:"reason"`
+	Count    int64     `db:"count" json:"count"`
+	LastSeen time.Time `db:"last_seen" json:"last_seen"`
+}
+
 type CityStats struct {
 	City       string  `db:"city" json:"name"`
 	Country    string  `db:"country" json:"country"`
 	Count      int64   `db:"count" json:"clicks"`
 	Percentage float64 `json:"percentage"`
 }
+
+//go:generate mockgen -destination=mocks/mock_audit_repo.go -package=mocks . AuditRepository
+type AuditRepository interface {
+	// Create appends an entry; the log has no update or delete
+	Create(ctx context.Context, entry *model.AuditEntry) error
+	// List returns up to filter.Limit matching entries, newest first
+	List(ctx context.Context, filter AuditFilter) ([]model.AuditEntry, error)
+}
+
+// AuditFilter selects audit entries. Zero fields do not filter; an empty
+// filter matches the whole log.
+type AuditFilter struct {
+	// WorkspaceID selects a workspace's entries
+	WorkspaceID uint64
+	// AccountOf selects the account-level entries (no workspace) of a user;
+	// combined with WorkspaceID it matches either
+	AccountOf   uint64
+	ActorUserID uint64
+	// Action matches exactly, or by prefix when it ends in "."
+	Action     string
+	TargetType string
+	TargetID   uint64
+	Since      *time.Time
+	Until      *time.Time
+	// BeforeID pages backwards: only entries older than this ID
+	BeforeID uint64
+	Limit    int
+}
+
+//go:generate mockgen -destination=mocks/mock_webhook_repo.go -package=mocks . WebhookRepository
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *model.Webhook) error
+	GetByID(ctx context.Context, id uint64) (*model.Webhook, error)
+	// ListByUser returns the user's webhooks on a workspace
+	ListByUser(ctx context.Context, userID, workspaceID uint64) ([]model.Webhook, error)
+	// ListSubscribed returns the active webhooks on a workspace that subscribe
+	// to event, skipping those whose owner is no longer a member
+	ListSubscribed(ctx context.Context, workspaceID uint64, event string) ([]model.Webhook, error)
+	// Update saves the URL, events and active flag
+	Update(ctx context.Context, webhook *model.Webhook) error
+	// Delete removes a webhook together with its deliveries
+	Delete(ctx context.Context, id uint64) error
+}
+
+//go:generate mockgen -destination=mocks/mock_webhook_delivery_repo.go -package=mocks . WebhookDeliveryRepository
+type WebhookDeliveryRepository interface {
+	Create(ctx context.Context, delivery *model.WebhookDelivery) error
+	GetByID(ctx context.Context, id uint64) (*model.WebhookDelivery, error)
+	// ListByWebhook returns a webhook's most recent deliveries, newest first
+	ListByWebhook(ctx context.Context, webhookID uint64, limit int) ([]model.WebhookDelivery, error)
+	// ListDue returns pending deliveries whose next attempt is due
+	ListDue(ctx context.Context, now time.Time, limit int) ([]model.WebhookDelivery, error)
+	// Claim counts an attempt and pushes the next one out to leaseUntil, so
+	// other dispatchers leave the delivery alone while it is being sent. It
+	// reports false when another dispatcher claimed the delivery first.
+	Claim(ctx context.Context, delivery *model.WebhookDelivery, leaseUntil time.Time) (bool, error)
+	// Update records the outcome of an attempt
+	Update(ctx context.Context, delivery *model.WebhookDelivery) error
+}
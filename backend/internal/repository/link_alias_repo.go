@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+var ErrAliasNotFound = errors.New("alias not found")
+
+// Compile-time check: LinkAliasRepositoryImpl implements LinkAliasRepository
+var _ LinkAliasRepository = (*LinkAliasRepositoryImpl)(nil)
+
+type LinkAliasRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+func NewLinkAliasRepository(db *sqlx.DB) *LinkAliasRepositoryImpl {
+	return &LinkAliasRepositoryImpl{db: db}
+}
+
+func (r *LinkAliasRepositoryImpl) Create(ctx context.Context, alias *model.LinkAlias) error {
+	return insertAlias(ctx, r.db, alias)
+}
+
+// insertAlias stores an alias through db, which may be a transaction.
+func insertAlias(ctx context.Context, db sqlx.ExecerContext, alias *model.LinkAlias) error {
+	// Expired aliases no longer reserve their code, so clear any that would collide
+	var cleanup string
+	var cleanupArgs []interface{}
+	if alias.DomainID == nil {
+		cleanup = `DELETE FROM link_aliases WHERE domain_id IS NULL AND code = ? AND expires_at IS NOT NULL AND expires_at <= NOW()`
+		cleanupArgs = []interface{}{alias.Code}
+	} else {
+		cleanup = `DELETE FROM link_aliases WHERE domain_id = ? AND code = ? AND expires_at IS NOT NULL AND expires_at <= NOW()`
+		cleanupArgs = []interface{}{*alias.DomainID, alias.Code}
+	}
+	if _, err := db.ExecContext(ctx, cleanup, cleanupArgs...); err != nil {
+		logger.Error(ctx, "alias-repo: failed to clean up expired aliases",
+			zap.String("code", alias.Code),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	query := `INSERT INTO link_aliases (link_id, domain_id, code, expires_at) VALUES (?, ?, ?, ?)`
+	result, err := db.ExecContext(ctx, query, alias.LinkID, alias.DomainID, alias.Code, alias.ExpiresAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return ErrShortCodeExists
+		}
+		logger.Error(ctx, "alias-repo: failed to create alias",
+			zap.Uint64("link_id", alias.LinkID),
+			zap.String("code", alias.Code),
+			zap.Error(err),
+		)
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error(ctx, "alias-repo: failed to get last insert ID",
+			zap.Error(err),
+		)
+		return err
+	}
+	alias.ID = uint64(id)
+	return nil
+}
+
+func (r *LinkAliasRepositoryImpl) GetActiveByDomainAndCode(ctx context.Context, domainID *uint64, code string) (*model.LinkAlias, error) {
+	var alias model.LinkAlias
+	var query string
+	var err error
+
+	if domainID == nil {
+		query = `SELECT id, link_id, domain_id, code, expires_at, created_at FROM link_aliases
+				 WHERE domain_id IS NULL AND code = ? AND (expires_at IS NULL OR expires_at > NOW())`
+		err = r.db.GetContext(ctx, &alias, query, code)
+	} else {
+		query = `SELECT id, link_id, domain_id, code, expires_at, created_at FROM link_aliases
+				 WHERE domain_id = ? AND code = ? AND (expires_at IS NULL OR expires_at > NOW())`
+		err = r.db.GetContext(ctx, &alias, query, *domainID, code)
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAliasNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, "alias-repo: failed to get alias by domain and code",
+			zap.String("code", code),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return &alias, nil
+}
+
+func (r *LinkAliasRepositoryImpl) GetByID(ctx context.Context, id uint64) (*model.LinkAlias, error) {
+	var alias model.LinkAlias
+	query := `SELECT id, link_id, domain_id, code, expires_at, created_at FROM link_aliases WHERE id = ?`
+	err := r.db.GetContext(ctx, &alias, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAliasNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, "alias-repo: failed to get alias by ID",
+			zap.Uint64("alias_id", id),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return &alias, nil
+}
+
+func (r *LinkAliasRepositoryImpl) ListByLinkID(ctx context.Context, linkID uint64) ([]model.LinkAlias, error) {
+	var aliases []model.LinkAlias
+	query := `SELECT id, link_id, domain_id, code, expires_at, created_at FROM link_aliases
+			  WHERE link_id = ? AND (expires_at IS NULL OR expires_at > NOW()) ORDER BY created_at`
+	err := r.db.SelectContext(ctx, &aliases, query, linkID)
+	if err != nil {
+		logger.Error(ctx, "alias-repo: failed to list aliases by link ID",
+			zap.Uint64("link_id", linkID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if aliases == nil {
+		aliases = []model.LinkAlias{}
+	}
+	return aliases, nil
+}
+
+func (r *LinkAliasRepositoryImpl) ListActiveByDomainID(ctx context.Context, domainID uint64) ([]model.LinkAlias, error) {
+	var aliases []model.LinkAlias
+	query := `SELECT id, link_id, domain_id, code, expires_at, created_at FROM link_aliases
+			  WHERE domain_id = ? AND (expires_at IS NULL OR expires_at > NOW()) ORDER BY id`
+	err := r.db.SelectContext(ctx, &aliases, query, domainID)
+	if err != nil {
+		logger.Error(ctx, "alias-repo: failed to list aliases by domain ID",
+			zap.Uint64("domain_id", domainID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if aliases == nil {
+		aliases = []model.LinkAlias{}
+	}
+	return aliases, nil
+}
+
+func (r *LinkAliasRepositoryImpl) Delete(ctx context.Context, id uint64) error {
+	query := `DELETE FROM link_aliases WHERE id = ?`
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		logger.Error(ctx, "alias-repo: failed to delete alias",
+			zap.Uint64("alias_id", id),
+			zap.Error(err),
+		)
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		logger.Error(ctx, "alias-repo: failed to get rows affected on delete",
+			zap.Uint64("alias_id", id),
+			zap.Error(err),
+		)
+		return err
+	}
+	if rows == 0 {
+		return ErrAliasNotFound
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+var ErrRevisionNotFound = errors.New("revision not found")
+
+// Compile-time check: LinkHistoryRepositoryImpl implements LinkHistoryRepository
+var _ LinkHistoryRepository = (*LinkHistoryRepositoryImpl)(nil)
+
+type LinkHistoryRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+func NewLinkHistoryRepository(db *sqlx.DB) *LinkHistoryRepositoryImpl {
+	return &LinkHistoryRepositoryImpl{db: db}
+}
+
+func (r *LinkHistoryRepositoryImpl) Create(ctx context.Context, rev *model.LinkRevision) error {
+	return insertRevision(ctx, r.db, rev)
+}
+
+// insertRevision stores a revision through db, which may be a transaction.
+func insertRevision(ctx context.Context, db sqlx.ExtContext, rev *model.LinkRevision) error {
+	// Versions are numbered per link; the unique index rejects concurrent writers
+	query := `INSERT INTO link_history (link_id, version, changed_by, old_values, new_values)
+			  SELECT ?, COALESCE(MAX(version), 0) + 1, ?, ?, ? FROM link_history WHERE link_id = ?`
+	result, err := db.ExecContext(ctx, query, rev.LinkID, rev.ChangedBy, rev.OldValues, rev.NewValues, rev.LinkID)
+	if err != nil {
+		logger.Error(ctx, "link-history-repo: failed to create revision",
+			zap.Uint64("link_id", rev.LinkID),
+			zap.Error(err),
+		)
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error(ctx, "link-history-repo: failed to get last insert ID",
+			zap.Error(err),
+		)
+		return err
+	}
+	rev.ID = uint64(id)
+
+	if err := sqlx.GetContext(ctx, db, &rev.Version, `SELECT version FROM link_history WHERE id = ?`, rev.ID); err != nil {
+		logger.Error(ctx, "link-history-repo: failed to read revision version",
+			zap.Uint64("revision_id", rev.ID),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
+
+func (r *LinkHistoryRepositoryImpl) GetByVersion(ctx context.Context, linkID uint64, version int) (*model.LinkRevision, error) {
+	var rev model.LinkRevision
+	query := `SELECT id, link_id, version, changed_by, old_values, new_values, created_at
+			  FROM link_history WHERE link_id = ? AND version = ?`
+	err := r.db.GetContext(ctx, &rev, query, linkID, version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrRevisionNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, "link-history-repo: failed to get revision",
+			zap.Uint64("link_id", linkID),
+			zap.Int("version", version),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return &rev, nil
+}
+
+func (r *LinkHistoryRepositoryImpl) ListByLinkID(ctx context.Context, linkID uint64) ([]model.LinkRevision, error) {
+	var revs []model.LinkRevision
+	query := `SELECT id, link_id, version, changed_by, old_values, new_values, created_at
+			  FROM link_history WHERE link_id = ? ORDER BY version DESC`
+	err := r.db.SelectContext(ctx, &revs, query, linkID)
+	if err != nil {
+		logger.Error(ctx, "link-history-repo: failed to list revisions",
+			zap.Uint64("link_id", linkID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if revs == nil {
+		revs = []model.LinkRevision{}
+	}
+	return revs, nil
+}
@@ -5,8 +5,10 @@ import (
 	"database/sql"
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/internal/util"
 	"github.com/SeaCodeBase/urlshortener/pkg/logger"
 	"github.com/jmoiron/sqlx"
 	"go.uber.org/zap"
@@ -18,6 +20,10 @@ var ErrShortCodeExists = errors.New("short code already exists")
 // Compile-time check: LinkRepositoryImpl implements LinkRepository
 var _ LinkRepository = (*LinkRepositoryImpl)(nil)
 
+// linkColumns is the column list selected for every model.Link query
+const linkColumns = `id, user_id, workspace_id, short_code, original_url, title, expires_at, is_active, domain_id,
+	campaign_id, utm_source, utm_medium, utm_campaign, deleted_at, created_at, updated_at`
+
 type LinkRepositoryImpl struct {
 	db *sqlx.DB
 }
@@ -27,10 +33,14 @@ func NewLinkRepository(db *sqlx.DB) *LinkRepositoryImpl {
 }
 
 func (r *LinkRepositoryImpl) Create(ctx context.Context, link *model.Link) error {
-	query := `INSERT INTO links (user_id, short_code, original_url, title, expires_at, is_active, domain_id)
-			  VALUES (?, ?, ?, ?, ?, ?, ?)`
+	query := `INSERT INTO links (user_id, workspace_id, short_code, original_url, dest_hash, dest_hash_untracked, title, expires_at, is_active, domain_id,
+			  campaign_id, utm_source, utm_medium, utm_campaign)
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 	result, err := r.db.ExecContext(ctx, query,
-		link.UserID, link.ShortCode, link.OriginalURL, link.Title, link.ExpiresAt, link.IsActive, link.DomainID)
+		link.UserID, link.WorkspaceID, link.ShortCode, link.OriginalURL,
+		util.DestinationHash(link.OriginalURL, false), util.DestinationHash(link.OriginalURL, true),
+		link.Title, link.ExpiresAt, link.IsActive, link.DomainID,
+		link.CampaignID, link.UTMSource, link.UTMMedium, link.UTMCampaign)
 	if err != nil {
 		if strings.Contains(err.Error(), "Duplicate entry") {
 			return ErrShortCodeExists
@@ -63,7 +73,7 @@ func (r *LinkRepositoryImpl) Create(ctx context.Context, link *model.Link) error
 
 func (r *LinkRepositoryImpl) GetByID(ctx context.Context, id uint64) (*model.Link, error) {
 	var link model.Link
-	query := `SELECT id, user_id, short_code, original_url, title, expires_at, is_active, domain_id, created_at, updated_at
+	query := `SELECT ` + linkColumns + `
 			  FROM links WHERE id = ?`
 	err := r.db.GetContext(ctx, &link, query, id)
 	if errors.Is(err, sql.ErrNoRows) {
@@ -79,14 +89,29 @@ func (r *LinkRepositoryImpl) GetByID(ctx context.Context, id uint64) (*model.Lin
 	return &link, nil
 }
 
-func (r *LinkRepositoryImpl) ListByUserID(ctx context.Context, userID uint64, limit, offset int) ([]model.Link, error) {
+func (r *LinkRepositoryImpl) ListByWorkspaceID(ctx context.Context, workspaceID uint64, limit, offset int) ([]model.Link, error) {
+	var links []model.Link
+	query := `SELECT ` + linkColumns + `
+			  FROM links WHERE workspace_id = ? AND deleted_at IS NULL ORDER BY created_at DESC LIMIT ? OFFSET ?`
+	err := r.db.SelectContext(ctx, &links, query, workspaceID, limit, offset)
+	if err != nil {
+		logger.Error(ctx, "link-repo: failed to list links by workspace ID",
+			zap.Uint64("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return links, nil
+}
+
+func (r *LinkRepositoryImpl) ListAllByWorkspaceID(ctx context.Context, workspaceID uint64) ([]model.Link, error) {
 	var links []model.Link
-	query := `SELECT id, user_id, short_code, original_url, title, expires_at, is_active, domain_id, created_at, updated_at
-			  FROM links WHERE user_id = ? ORDER BY created_at DESC LIMIT ? OFFSET ?`
-	err := r.db.SelectContext(ctx, &links, query, userID, limit, offset)
+	query := `SELECT ` + linkColumns + `
+			  FROM links WHERE workspace_id = ? AND deleted_at IS NULL ORDER BY created_at DESC`
+	err := r.db.SelectContext(ctx, &links, query, workspaceID)
 	if err != nil {
-		logger.Error(ctx, "link-repo: failed to list links by user ID",
-			zap.Uint64("user_id", userID),
+		logger.Error(ctx, "link-repo: failed to list all links by workspace ID",
+			zap.Uint64("workspace_id", workspaceID),
 			zap.Error(err),
 		)
 		return nil, err
@@ -94,11 +119,149 @@ func (r *LinkRepositoryImpl) ListByUserID(ctx context.Context, userID uint64, li
 	return links, nil
 }
 
+// destHashColumn is the hash column matching the tracking parameter mode.
+func destHashColumn(ignoreTracking bool) string {
+	if ignoreTracking {
+		return "dest_hash_untracked"
+	}
+	return "dest_hash"
+}
+
+func (r *LinkRepositoryImpl) ListByDestHash(ctx context.Context, workspaceID uint64, hash string, ignoreTracking bool) ([]model.Link, error) {
+	var links []model.Link
+	query := `SELECT ` + linkColumns + ` FROM links
+			  WHERE workspace_id = ? AND ` + destHashColumn(ignoreTracking) + ` = ? AND deleted_at IS NULL ORDER BY created_at DESC`
+	if err := r.db.SelectContext(ctx, &links, query, workspaceID, hash); err != nil {
+		logger.Error(ctx, "link-repo: failed to list links by destination",
+			zap.Uint64("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return links, nil
+}
+
+func (r *LinkRepositoryImpl) ListDuplicateDestinations(ctx context.Context, workspaceID uint64, ignoreTracking bool) ([]model.Link, error) {
+	column := destHashColumn(ignoreTracking)
+	var links []model.Link
+	query := `SELECT ` + linkColumns + ` FROM links
+			  WHERE workspace_id = ? AND deleted_at IS NULL AND ` + column + ` IN (
+				  SELECT ` + column + ` FROM links WHERE workspace_id = ? AND deleted_at IS NULL
+				  GROUP BY ` + column + ` HAVING COUNT(*) > 1)
+			  ORDER BY created_at DESC`
+	if err := r.db.SelectContext(ctx, &links, query, workspaceID, workspaceID); err != nil {
+		logger.Error(ctx, "link-repo: failed to list duplicate destinations",
+			zap.Uint64("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return links, nil
+}
+
+func (r *LinkRepositoryImpl) FillDestHashes(ctx context.Context, batchSize int) (int, error) {
+	type pending struct {
+		ID          uint64 `db:"id"`
+		OriginalURL string `db:"original_url"`
+	}
+	filled := 0
+	for {
+		var batch []pending
+		query := `SELECT id, original_url FROM links WHERE dest_hash IS NULL ORDER BY id LIMIT ?`
+		if err := r.db.SelectContext(ctx, &batch, query, batchSize); err != nil {
+			logger.Error(ctx, "link-repo: failed to list links without destination hashes",
+				zap.Error(err),
+			)
+			return filled, err
+		}
+		for _, link := range batch {
+			_, err := r.db.ExecContext(ctx, `UPDATE links SET dest_hash = ?, dest_hash_untracked = ? WHERE id = ?`,
+				util.DestinationHash(link.OriginalURL, false), util.DestinationHash(link.OriginalURL, true), link.ID)
+			if err != nil {
+				logger.Error(ctx, "link-repo: failed to fill destination hash",
+					zap.Uint64("link_id", link.ID),
+					zap.Error(err),
+				)
+				return filled, err
+			}
+			filled++
+		}
+		if len(batch) < batchSize {
+			return filled, nil
+		}
+	}
+}
+
 func (r *LinkRepositoryImpl) Update(ctx context.Context, link *model.Link) error {
-	query := `UPDATE links SET original_url = ?, title = ?, expires_at = ?, is_active = ?, domain_id = ?, updated_at = NOW()
+	return updateLink(ctx, r.db, link)
+}
+
+// LinkEdit is what an edit writes besides the link's row.
+type LinkEdit struct {
+	// ReclaimedAliasID is the link's own alias whose code it takes back; 0 for none
+	ReclaimedAliasID uint64
+	// GraceAlias keeps a changed code redirecting; nil when the code is unchanged
+	GraceAlias *model.LinkAlias
+	// Revision records the change; nil when nothing the history tracks changed
+	Revision *model.LinkRevision
+}
+
+func (r *LinkRepositoryImpl) SaveEdit(ctx context.Context, link *model.Link, edit LinkEdit) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error(ctx, "link-repo: failed to begin transaction",
+			zap.Error(err),
+		)
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := updateLink(ctx, tx, link); err != nil {
+		return err
+	}
+	if edit.ReclaimedAliasID != 0 {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM link_aliases WHERE id = ?`, edit.ReclaimedAliasID); err != nil {
+			logger.Error(ctx, "link-repo: failed to remove reclaimed alias",
+				zap.Uint64("link_id", link.ID),
+				zap.Uint64("alias_id", edit.ReclaimedAliasID),
+				zap.Error(err),
+			)
+			return err
+		}
+	}
+	if edit.GraceAlias != nil {
+		if err := insertAlias(ctx, tx, edit.GraceAlias); err != nil {
+			return err
+		}
+	}
+	if edit.Revision != nil {
+		if err := insertRevision(ctx, tx, edit.Revision); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error(ctx, "link-repo: failed to commit link edit",
+			zap.Uint64("link_id", link.ID),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
+
+func updateLink(ctx context.Context, db sqlx.ExecerContext, link *model.Link) error {
+	query := `UPDATE links SET short_code = ?, original_url = ?, dest_hash = ?, dest_hash_untracked = ?, title = ?, expires_at = ?, is_active = ?, domain_id = ?,
+			  campaign_id = ?, utm_source = ?, utm_medium = ?, utm_campaign = ?, updated_at = NOW()
 			  WHERE id = ?`
-	result, err := r.db.ExecContext(ctx, query, link.OriginalURL, link.Title, link.ExpiresAt, link.IsActive, link.DomainID, link.ID)
+	result, err := db.ExecContext(ctx, query, link.ShortCode, link.OriginalURL,
+		util.DestinationHash(link.OriginalURL, false), util.DestinationHash(link.OriginalURL, true),
+		link.Title, link.ExpiresAt, link.IsActive, link.DomainID,
+		link.CampaignID, link.UTMSource, link.UTMMedium, link.UTMCampaign, link.ID)
 	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return ErrShortCodeExists
+		}
 		logger.Error(ctx, "link-repo: failed to update link",
 			zap.Uint64("link_id", link.ID),
 			zap.Error(err),
@@ -143,17 +306,108 @@ func (r *LinkRepositoryImpl) Delete(ctx context.Context, id uint64) error {
 	return nil
 }
 
+func (r *LinkRepositoryImpl) Trash(ctx context.Context, id uint64) error {
+	query := `UPDATE links SET deleted_at = NOW() WHERE id = ? AND deleted_at IS NULL`
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		logger.Error(ctx, "link-repo: failed to move link to trash",
+			zap.Uint64("link_id", id),
+			zap.Error(err),
+		)
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		logger.Error(ctx, "link-repo: failed to get rows affected on trash",
+			zap.Uint64("link_id", id),
+			zap.Error(err),
+		)
+		return err
+	}
+	if rows == 0 {
+		return ErrLinkNotFound
+	}
+	return nil
+}
+
+func (r *LinkRepositoryImpl) Restore(ctx context.Context, id uint64) error {
+	query := `UPDATE links SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL`
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		logger.Error(ctx, "link-repo: failed to restore link",
+			zap.Uint64("link_id", id),
+			zap.Error(err),
+		)
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		logger.Error(ctx, "link-repo: failed to get rows affected on restore",
+			zap.Uint64("link_id", id),
+			zap.Error(err),
+		)
+		return err
+	}
+	if rows == 0 {
+		return ErrLinkNotFound
+	}
+	return nil
+}
+
+func (r *LinkRepositoryImpl) ListTrashedByWorkspaceID(ctx context.Context, workspaceID uint64) ([]model.Link, error) {
+	var links []model.Link
+	query := `SELECT ` + linkColumns + `
+			  FROM links WHERE workspace_id = ? AND deleted_at IS NOT NULL ORDER BY deleted_at DESC`
+	err := r.db.SelectContext(ctx, &links, query, workspaceID)
+	if err != nil {
+		logger.Error(ctx, "link-repo: failed to list trashed links",
+			zap.Uint64("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if links == nil {
+		links = []model.Link{}
+	}
+	return links, nil
+}
+
+func (r *LinkRepositoryImpl) PurgeTrashed(ctx context.Context, before time.Time, limit int) (int64, error) {
+	query := `DELETE FROM links WHERE deleted_at IS NOT NULL AND deleted_at < ? ORDER BY deleted_at LIMIT ?`
+	result, err := r.db.ExecContext(ctx, query, before, limit)
+	if err != nil {
+		logger.Error(ctx, "link-repo: failed to purge trashed links",
+			zap.Time("before", before),
+			zap.Error(err),
+		)
+		return 0, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		logger.Error(ctx, "link-repo: failed to get rows affected on purge",
+			zap.Error(err),
+		)
+		return 0, err
+	}
+	return rows, nil
+}
+
 func (r *LinkRepositoryImpl) ShortCodeExistsInDomain(ctx context.Context, domainID *uint64, code string) (bool, error) {
 	var count int
 	var query string
 	var err error
 
+	// Aliases (including old codes of renamed links in their grace period) reserve codes too
 	if domainID == nil {
-		query = `SELECT COUNT(*) FROM links WHERE domain_id IS NULL AND short_code = ?`
-		err = r.db.GetContext(ctx, &count, query, code)
+		query = `SELECT (SELECT COUNT(*) FROM links WHERE domain_id IS NULL AND short_code = ?) +
+				 (SELECT COUNT(*) FROM link_aliases WHERE domain_id IS NULL AND code = ?
+				  AND (expires_at IS NULL OR expires_at > NOW()))`
+		err = r.db.GetContext(ctx, &count, query, code, code)
 	} else {
-		query = `SELECT COUNT(*) FROM links WHERE domain_id = ? AND short_code = ?`
-		err = r.db.GetContext(ctx, &count, query, *domainID, code)
+		query = `SELECT (SELECT COUNT(*) FROM links WHERE domain_id = ? AND short_code = ?) +
+				 (SELECT COUNT(*) FROM link_aliases WHERE domain_id = ? AND code = ?
+				  AND (expires_at IS NULL OR expires_at > NOW()))`
+		err = r.db.GetContext(ctx, &count, query, *domainID, code, *domainID, code)
 	}
 
 	if err != nil {
@@ -172,11 +426,11 @@ func (r *LinkRepositoryImpl) GetByDomainAndShortCode(ctx context.Context, domain
 	var err error
 
 	if domainID == nil {
-		query = `SELECT id, user_id, short_code, original_url, title, expires_at, is_active, domain_id, created_at, updated_at
+		query = `SELECT ` + linkColumns + `
 				 FROM links WHERE domain_id IS NULL AND short_code = ?`
 		err = r.db.GetContext(ctx, &link, query, code)
 	} else {
-		query = `SELECT id, user_id, short_code, original_url, title, expires_at, is_active, domain_id, created_at, updated_at
+		query = `SELECT ` + linkColumns + `
 				 FROM links WHERE domain_id = ? AND short_code = ?`
 		err = r.db.GetContext(ctx, &link, query, *domainID, code)
 	}
@@ -194,16 +448,201 @@ func (r *LinkRepositoryImpl) GetByDomainAndShortCode(ctx context.Context, domain
 	return &link, nil
 }
 
-func (r *LinkRepositoryImpl) CountByUserID(ctx context.Context, userID uint64) (int64, error) {
+func (r *LinkRepositoryImpl) CountByWorkspaceID(ctx context.Context, workspaceID uint64) (int64, error) {
+	var count int64
+	query := `SELECT COUNT(*) FROM links WHERE workspace_id = ? AND deleted_at IS NULL`
+	err := r.db.GetContext(ctx, &count, query, workspaceID)
+	if err != nil {
+		logger.Error(ctx, "link-repo: failed to count links by workspace ID",
+			zap.Uint64("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *LinkRepositoryImpl) ListByCampaignID(ctx context.Context, campaignID uint64) ([]model.Link, error) {
+	var links []model.Link
+	query := `SELECT ` + linkColumns + `
+			  FROM links WHERE campaign_id = ? AND deleted_at IS NULL ORDER BY created_at DESC`
+	err := r.db.SelectContext(ctx, &links, query, campaignID)
+	if err != nil {
+		logger.Error(ctx, "link-repo: failed to list links by campaign ID",
+			zap.Uint64("campaign_id", campaignID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return links, nil
+}
+
+func (r *LinkRepositoryImpl) CountByCampaignID(ctx context.Context, campaignID uint64) (int64, error) {
+	var count int64
+	query := `SELECT COUNT(*) FROM links WHERE campaign_id = ? AND deleted_at IS NULL`
+	err := r.db.GetContext(ctx, &count, query, campaignID)
+	if err != nil {
+		logger.Error(ctx, "link-repo: failed to count links by campaign ID",
+			zap.Uint64("campaign_id", campaignID),
+			zap.Error(err),
+		)
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *LinkRepositoryImpl) GetByIDs(ctx context.Context, ids []uint64) ([]model.Link, error) {
+	if len(ids) == 0 {
+		return []model.Link{}, nil
+	}
+	query, args, err := sqlx.In(`SELECT `+linkColumns+` FROM links WHERE id IN (?)`, ids)
+	if err != nil {
+		return nil, err
+	}
+	var links []model.Link
+	if err := r.db.SelectContext(ctx, &links, r.db.Rebind(query), args...); err != nil {
+		logger.Error(ctx, "link-repo: failed to get links by IDs",
+			zap.Int("count", len(ids)),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return links, nil
+}
+
+func (r *LinkRepositoryImpl) ListExpiredBetween(ctx context.Context, from, to time.Time) ([]model.Link, error) {
+	var links []model.Link
+	query := `SELECT ` + linkColumns + `
+			  FROM links WHERE expires_at > ? AND expires_at <= ? AND deleted_at IS NULL ORDER BY expires_at`
+	err := r.db.SelectContext(ctx, &links, query, from, to)
+	if err != nil {
+		logger.Error(ctx, "link-repo: failed to list expired links",
+			zap.Time("from", from),
+			zap.Time("to", to),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return links, nil
+}
+
+func (r *LinkRepositoryImpl) CountCreatedBetween(ctx context.Context, workspaceID uint64, from, to time.Time) (int64, error) {
+	var count int64
+	query := `SELECT COUNT(*) FROM links
+			  WHERE workspace_id = ? AND created_at >= ? AND created_at < ? AND deleted_at IS NULL`
+	err := r.db.GetContext(ctx, &count, query, workspaceID, from, to)
+	if err != nil {
+		logger.Error(ctx, "link-repo: failed to count created links",
+			zap.Uint64("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *LinkRepositoryImpl) CountExpiringBetween(ctx context.Context, workspaceID uint64, from, to time.Time) (int64, error) {
 	var count int64
-	query := `SELECT COUNT(*) FROM links WHERE user_id = ?`
-	err := r.db.GetContext(ctx, &count, query, userID)
+	query := `SELECT COUNT(*) FROM links
+			  WHERE workspace_id = ? AND expires_at > ? AND expires_at <= ? AND deleted_at IS NULL`
+	err := r.db.GetContext(ctx, &count, query, workspaceID, from, to)
 	if err != nil {
-		logger.Error(ctx, "link-repo: failed to count links by user ID",
-			zap.Uint64("user_id", userID),
+		logger.Error(ctx, "link-repo: failed to count expiring links",
+			zap.Uint64("workspace_id", workspaceID),
 			zap.Error(err),
 		)
 		return 0, err
 	}
 	return count, nil
 }
+
+func (r *LinkRepositoryImpl) ListByDomainID(ctx context.Context, domainID uint64) ([]model.Link, error) {
+	var links []model.Link
+	query := `SELECT ` + linkColumns + ` FROM links WHERE domain_id = ? ORDER BY id`
+	if err := r.db.SelectContext(ctx, &links, query, domainID); err != nil {
+		logger.Error(ctx, "link-repo: failed to list links by domain",
+			zap.Uint64("domain_id", domainID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return links, nil
+}
+
+func (r *LinkRepositoryImpl) MoveDomain(ctx context.Context, fromDomainID uint64, toDomainID *uint64) (int64, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error(ctx, "link-repo: failed to begin transaction",
+			zap.Error(err),
+		)
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `UPDATE links SET domain_id = ?, updated_at = NOW() WHERE domain_id = ?`, toDomainID, fromDomainID)
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return 0, ErrShortCodeExists
+		}
+		logger.Error(ctx, "link-repo: failed to move links to another domain",
+			zap.Uint64("from_domain_id", fromDomainID),
+			zap.Error(err),
+		)
+		return 0, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		logger.Error(ctx, "link-repo: failed to get rows affected on domain move",
+			zap.Error(err),
+		)
+		return 0, err
+	}
+
+	// Expired aliases no longer hold their codes, so they are not carried over
+	if _, err := tx.ExecContext(ctx, `DELETE FROM link_aliases WHERE domain_id = ? AND expires_at IS NOT NULL AND expires_at <= NOW()`, fromDomainID); err != nil {
+		logger.Error(ctx, "link-repo: failed to drop expired aliases on domain move",
+			zap.Uint64("from_domain_id", fromDomainID),
+			zap.Error(err),
+		)
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE link_aliases SET domain_id = ? WHERE domain_id = ?`, toDomainID, fromDomainID); err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return 0, ErrShortCodeExists
+		}
+		logger.Error(ctx, "link-repo: failed to move aliases to another domain",
+			zap.Uint64("from_domain_id", fromDomainID),
+			zap.Error(err),
+		)
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error(ctx, "link-repo: failed to commit domain move",
+			zap.Uint64("from_domain_id", fromDomainID),
+			zap.Error(err),
+		)
+		return 0, err
+	}
+	return rows, nil
+}
+
+func (r *LinkRepositoryImpl) TrashByDomainID(ctx context.Context, domainID uint64) (int64, error) {
+	query := `UPDATE links SET deleted_at = NOW() WHERE domain_id = ? AND deleted_at IS NULL`
+	result, err := r.db.ExecContext(ctx, query, domainID)
+	if err != nil {
+		logger.Error(ctx, "link-repo: failed to trash links by domain",
+			zap.Uint64("domain_id", domainID),
+			zap.Error(err),
+		)
+		return 0, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		logger.Error(ctx, "link-repo: failed to get rows affected on domain trash",
+			zap.Error(err),
+		)
+		return 0, err
+	}
+	return rows, nil
+}
@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+var ErrTransferNotFound = errors.New("transfer not found")
+
+// Compile-time check: LinkTransferRepositoryImpl implements LinkTransferRepository
+var _ LinkTransferRepository = (*LinkTransferRepositoryImpl)(nil)
+
+const transferColumns = `id, from_user_id, to_user_id, from_workspace_id, all_links, status, created_at, resolved_at`
+
+type LinkTransferRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+func NewLinkTransferRepository(db *sqlx.DB) *LinkTransferRepositoryImpl {
+	return &LinkTransferRepositoryImpl{db: db}
+}
+
+func (r *LinkTransferRepositoryImpl) Create(ctx context.Context, transfer *model.LinkTransfer) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error(ctx, "transfer-repo: failed to begin transaction",
+			zap.Error(err),
+		)
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO link_transfers (from_user_id, to_user_id, from_workspace_id, all_links, status) VALUES (?, ?, ?, ?, ?)`
+	result, err := tx.ExecContext(ctx, query, transfer.FromUserID, transfer.ToUserID, transfer.FromWorkspaceID, transfer.AllLinks, model.TransferStatusPending)
+	if err != nil {
+		logger.Error(ctx, "transfer-repo: failed to create transfer",
+			zap.Uint64("from_user_id", transfer.FromUserID),
+			zap.Error(err),
+		)
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error(ctx, "transfer-repo: failed to get last insert ID",
+			zap.Error(err),
+		)
+		return err
+	}
+
+	for _, linkID := range transfer.LinkIDs {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO link_transfer_links (transfer_id, link_id) VALUES (?, ?)`, id, linkID); err != nil {
+			logger.Error(ctx, "transfer-repo: failed to add link to transfer",
+				zap.Int64("transfer_id", id),
+				zap.Uint64("link_id", linkID),
+				zap.Error(err),
+			)
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error(ctx, "transfer-repo: failed to commit transfer",
+			zap.Error(err),
+		)
+		return err
+	}
+
+	created, err := r.GetByID(ctx, uint64(id))
+	if err != nil {
+		return err
+	}
+	*transfer = *created
+	return nil
+}
+
+func (r *LinkTransferRepositoryImpl) GetByID(ctx context.Context, id uint64) (*model.LinkTransfer, error) {
+	var transfer model.LinkTransfer
+	query := `SELECT ` + transferColumns + ` FROM link_transfers WHERE id = ?`
+	err := r.db.GetContext(ctx, &transfer, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTransferNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, "transfer-repo: failed to get transfer by ID",
+			zap.Uint64("transfer_id", id),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	if err := r.db.SelectContext(ctx, &transfer.LinkIDs,
+		`SELECT link_id FROM link_transfer_links WHERE transfer_id = ? ORDER BY link_id`, id); err != nil {
+		logger.Error(ctx, "transfer-repo: failed to get transfer links",
+			zap.Uint64("transfer_id", id),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+func (r *LinkTransferRepositoryImpl) ListPendingByUserID(ctx context.Context, userID uint64) ([]model.LinkTransfer, error) {
+	var ids []uint64
+	query := `SELECT id FROM link_transfers
+			  WHERE (from_user_id = ? OR to_user_id = ?) AND status = ? ORDER BY created_at DESC`
+	if err := r.db.SelectContext(ctx, &ids, query, userID, userID, model.TransferStatusPending); err != nil {
+		logger.Error(ctx, "transfer-repo: failed to list pending transfers",
+			zap.Uint64("user_id", userID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	transfers := make([]model.LinkTransfer, 0, len(ids))
+	for _, id := range ids {
+		transfer, err := r.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, *transfer)
+	}
+	return transfers, nil
+}
+
+func (r *LinkTransferRepositoryImpl) Accept(ctx context.Context, transfer *model.LinkTransfer, toWorkspaceID, toUserID uint64, linkIDs []uint64) (int64, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error(ctx, "transfer-repo: failed to begin transaction",
+			zap.Error(err),
+		)
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// Claiming the pending transfer first keeps two accepts from both moving links
+	result, err := tx.ExecContext(ctx, `UPDATE link_transfers SET status = ?, resolved_at = NOW() WHERE id = ? AND status = ?`,
+		model.TransferStatusAccepted, transfer.ID, model.TransferStatusPending)
+	if err != nil {
+		logger.Error(ctx, "transfer-repo: failed to accept transfer",
+			zap.Uint64("transfer_id", transfer.ID),
+			zap.Error(err),
+		)
+		return 0, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		logger.Error(ctx, "transfer-repo: failed to get rows affected on accept",
+			zap.Uint64("transfer_id", transfer.ID),
+			zap.Error(err),
+		)
+		return 0, err
+	}
+	if rows == 0 {
+		return 0, ErrTransferNotFound
+	}
+
+	var moved int64
+	if len(linkIDs) > 0 {
+		// The sender's campaigns stay behind, so moved links are detached from them
+		query, args, err := sqlx.In(`UPDATE links SET workspace_id = ?, user_id = ?, campaign_id = NULL, updated_at = NOW()
+				  WHERE workspace_id = ? AND deleted_at IS NULL AND id IN (?)`, toWorkspaceID, toUserID, transfer.FromWorkspaceID, linkIDs)
+		if err != nil {
+			return 0, err
+		}
+		result, err := tx.ExecContext(ctx, tx.Rebind(query), args...)
+		if err != nil {
+			logger.Error(ctx, "transfer-repo: failed to move links",
+				zap.Uint64("transfer_id", transfer.ID),
+				zap.Error(err),
+			)
+			return 0, err
+		}
+		moved, err = result.RowsAffected()
+		if err != nil {
+			logger.Error(ctx, "transfer-repo: failed to get rows affected on link move",
+				zap.Uint64("transfer_id", transfer.ID),
+				zap.Error(err),
+			)
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error(ctx, "transfer-repo: failed to commit accepted transfer",
+			zap.Uint64("transfer_id", transfer.ID),
+			zap.Error(err),
+		)
+		return 0, err
+	}
+	return moved, nil
+}
+
+func (r *LinkTransferRepositoryImpl) Resolve(ctx context.Context, id uint64, status string) error {
+	// Only pending transfers can be resolved, so a transfer is never accepted twice
+	query := `UPDATE link_transfers SET status = ?, resolved_at = NOW() WHERE id = ? AND status = ?`
+	result, err := r.db.ExecContext(ctx, query, status, id, model.TransferStatusPending)
+	if err != nil {
+		logger.Error(ctx, "transfer-repo: failed to resolve transfer",
+			zap.Uint64("transfer_id", id),
+			zap.String("status", status),
+			zap.Error(err),
+		)
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		logger.Error(ctx, "transfer-repo: failed to get rows affected on resolve",
+			zap.Uint64("transfer_id", id),
+			zap.Error(err),
+		)
+		return err
+	}
+	if rows == 0 {
+		return ErrTransferNotFound
+	}
+	return nil
+}
@@ -86,6 +86,21 @@ func (mr *MockClickRepositoryMockRecorder) GetCityStats(ctx, linkID, limit any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCityStats", reflect.TypeOf((*MockClickRepository)(nil).GetCityStats), ctx, linkID, limit)
 }
 
+// GetCodeStats mocks base method.
+func (m *MockClickRepository) GetCodeStats(ctx context.Context, linkID uint64) ([]repository.CodeStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCodeStats", ctx, linkID)
+	ret0, _ := ret[0].([]repository.CodeStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCodeStats indicates an expected call of GetCodeStats.
+func (mr *MockClickRepositoryMockRecorder) GetCodeStats(ctx, linkID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCodeStats", reflect.TypeOf((*MockClickRepository)(nil).GetCodeStats), ctx, linkID)
+}
+
 // GetCountryStats mocks base method.
 func (m *MockClickRepository) GetCountryStats(ctx context.Context, linkID uint64, limit int) ([]repository.CountryStats, error) {
 	m.ctrl.T.Helper()
@@ -131,6 +146,156 @@ func (mr *MockClickRepositoryMockRecorder) GetDeviceStats(ctx, linkID any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeviceStats", reflect.TypeOf((*MockClickRepository)(nil).GetDeviceStats), ctx, linkID)
 }
 
+// GetMissTotals mocks base method.
+func (m *MockClickRepository) GetMissTotals(ctx context.Context, domainID uint64) (*repository.MissTotals, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMissTotals", ctx, domainID)
+	ret0, _ := ret[0].(*repository.MissTotals)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMissTotals indicates an expected call of GetMissTotals.
+func (mr *MockClickRepositoryMockRecorder) GetMissTotals(ctx, domainID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMissTotals", reflect.TypeOf((*MockClickRepository)(nil).GetMissTotals), ctx, domainID)
+}
+
+// GetScopedBrowserStats mocks base method.
+func (m *MockClickRepository) GetScopedBrowserStats(ctx context.Context, scope repository.ClickScope, limit int) ([]repository.BrowserStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScopedBrowserStats", ctx, scope, limit)
+	ret0, _ := ret[0].([]repository.BrowserStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScopedBrowserStats indicates an expected call of GetScopedBrowserStats.
+func (mr *MockClickRepositoryMockRecorder) GetScopedBrowserStats(ctx, scope, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScopedBrowserStats", reflect.TypeOf((*MockClickRepository)(nil).GetScopedBrowserStats), ctx, scope, limit)
+}
+
+// GetScopedCityStats mocks base method.
+func (m *MockClickRepository) GetScopedCityStats(ctx context.Context, scope repository.ClickScope, limit int) ([]repository.CityStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScopedCityStats", ctx, scope, limit)
+	ret0, _ := ret[0].([]repository.CityStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScopedCityStats indicates an expected call of GetScopedCityStats.
+func (mr *MockClickRepositoryMockRecorder) GetScopedCityStats(ctx, scope, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScopedCityStats", reflect.TypeOf((*MockClickRepository)(nil).GetScopedCityStats), ctx, scope, limit)
+}
+
+// GetScopedCountryStats mocks base method.
+func (m *MockClickRepository) GetScopedCountryStats(ctx context.Context, scope repository.ClickScope, limit int) ([]repository.CountryStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScopedCountryStats", ctx, scope, limit)
+	ret0, _ := ret[0].([]repository.CountryStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScopedCountryStats indicates an expected call of GetScopedCountryStats.
+func (mr *MockClickRepositoryMockRecorder) GetScopedCountryStats(ctx, scope, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScopedCountryStats", reflect.TypeOf((*MockClickRepository)(nil).GetScopedCountryStats), ctx, scope, limit)
+}
+
+// GetScopedDailyStats mocks base method.
+func (m *MockClickRepository) GetScopedDailyStats(ctx context.Context, scope repository.ClickScope, days int) ([]repository.DailyClickStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScopedDailyStats", ctx, scope, days)
+	ret0, _ := ret[0].([]repository.DailyClickStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScopedDailyStats indicates an expected call of GetScopedDailyStats.
+func (mr *MockClickRepositoryMockRecorder) GetScopedDailyStats(ctx, scope, days any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScopedDailyStats", reflect.TypeOf((*MockClickRepository)(nil).GetScopedDailyStats), ctx, scope, days)
+}
+
+// GetScopedDeviceStats mocks base method.
+func (m *MockClickRepository) GetScopedDeviceStats(ctx context.Context, scope repository.ClickScope) ([]repository.DeviceStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScopedDeviceStats", ctx, scope)
+	ret0, _ := ret[0].([]repository.DeviceStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScopedDeviceStats indicates an expected call of GetScopedDeviceStats.
+func (mr *MockClickRepositoryMockRecorder) GetScopedDeviceStats(ctx, scope any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScopedDeviceStats", reflect.TypeOf((*MockClickRepository)(nil).GetScopedDeviceStats), ctx, scope)
+}
+
+// GetScopedSeries mocks base method.
+func (m *MockClickRepository) GetScopedSeries(ctx context.Context, scope repository.ClickScope, interval string) ([]repository.ClickSeriesPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScopedSeries", ctx, scope, interval)
+	ret0, _ := ret[0].([]repository.ClickSeriesPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScopedSeries indicates an expected call of GetScopedSeries.
+func (mr *MockClickRepositoryMockRecorder) GetScopedSeries(ctx, scope, interval any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScopedSeries", reflect.TypeOf((*MockClickRepository)(nil).GetScopedSeries), ctx, scope, interval)
+}
+
+// GetScopedStats mocks base method.
+func (m *MockClickRepository) GetScopedStats(ctx context.Context, scope repository.ClickScope) (*repository.ClickStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScopedStats", ctx, scope)
+	ret0, _ := ret[0].(*repository.ClickStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScopedStats indicates an expected call of GetScopedStats.
+func (mr *MockClickRepositoryMockRecorder) GetScopedStats(ctx, scope any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScopedStats", reflect.TypeOf((*MockClickRepository)(nil).GetScopedStats), ctx, scope)
+}
+
+// GetScopedTopLinks mocks base method.
+func (m *MockClickRepository) GetScopedTopLinks(ctx context.Context, scope repository.ClickScope, limit int) ([]repository.LinkClickStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScopedTopLinks", ctx, scope, limit)
+	ret0, _ := ret[0].([]repository.LinkClickStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScopedTopLinks indicates an expected call of GetScopedTopLinks.
+func (mr *MockClickRepositoryMockRecorder) GetScopedTopLinks(ctx, scope, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScopedTopLinks", reflect.TypeOf((*MockClickRepository)(nil).GetScopedTopLinks), ctx, scope, limit)
+}
+
+// GetScopedTopReferrers mocks base method.
+func (m *MockClickRepository) GetScopedTopReferrers(ctx context.Context, scope repository.ClickScope, limit int) ([]repository.ReferrerStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScopedTopReferrers", ctx, scope, limit)
+	ret0, _ := ret[0].([]repository.ReferrerStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScopedTopReferrers indicates an expected call of GetScopedTopReferrers.
+func (mr *MockClickRepositoryMockRecorder) GetScopedTopReferrers(ctx, scope, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScopedTopReferrers", reflect.TypeOf((*MockClickRepository)(nil).GetScopedTopReferrers), ctx, scope, limit)
+}
+
 // GetStatsByLinkID mocks base method.
 func (m *MockClickRepository) GetStatsByLinkID(ctx context.Context, linkID uint64) (*repository.ClickStats, error) {
 	m.ctrl.T.Helper()
@@ -146,6 +311,21 @@ func (mr *MockClickRepositoryMockRecorder) GetStatsByLinkID(ctx, linkID any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatsByLinkID", reflect.TypeOf((*MockClickRepository)(nil).GetStatsByLinkID), ctx, linkID)
 }
 
+// GetTopMisses mocks base method.
+func (m *MockClickRepository) GetTopMisses(ctx context.Context, domainID uint64, limit int) ([]repository.MissStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTopMisses", ctx, domainID, limit)
+	ret0, _ := ret[0].([]repository.MissStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTopMisses indicates an expected call of GetTopMisses.
+func (mr *MockClickRepositoryMockRecorder) GetTopMisses(ctx, domainID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopMisses", reflect.TypeOf((*MockClickRepository)(nil).GetTopMisses), ctx, domainID, limit)
+}
+
 // GetTopReferrers mocks base method.
 func (m *MockClickRepository) GetTopReferrers(ctx context.Context, linkID uint64, limit int) ([]repository.ReferrerStats, error) {
 	m.ctrl.T.Helper()
@@ -175,3 +355,17 @@ func (mr *MockClickRepositoryMockRecorder) GetTotalByLinkID(ctx, linkID any) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTotalByLinkID", reflect.TypeOf((*MockClickRepository)(nil).GetTotalByLinkID), ctx, linkID)
 }
+
+// RecordMisses mocks base method.
+func (m *MockClickRepository) RecordMisses(ctx context.Context, misses []model.DomainMiss) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordMisses", ctx, misses)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordMisses indicates an expected call of RecordMisses.
+func (mr *MockClickRepositoryMockRecorder) RecordMisses(ctx, misses any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordMisses", reflect.TypeOf((*MockClickRepository)(nil).RecordMisses), ctx, misses)
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/SeaCodeBase/urlshortener/internal/repository (interfaces: CodeSequenceRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mock_code_sequence_repo.go -package=mocks . CodeSequenceRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCodeSequenceRepository is a mock of CodeSequenceRepository interface.
+type MockCodeSequenceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCodeSequenceRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCodeSequenceRepositoryMockRecorder is the mock recorder for MockCodeSequenceRepository.
+type MockCodeSequenceRepositoryMockRecorder struct {
+	mock *MockCodeSequenceRepository
+}
+
+// NewMockCodeSequenceRepository creates a new mock instance.
+func NewMockCodeSequenceRepository(ctrl *gomock.Controller) *MockCodeSequenceRepository {
+	mock := &MockCodeSequenceRepository{ctrl: ctrl}
+	mock.recorder = &MockCodeSequenceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCodeSequenceRepository) EXPECT() *MockCodeSequenceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Reserve mocks base method.
+func (m *MockCodeSequenceRepository) Reserve(ctx context.Context, scope string, n uint64) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, scope, n)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockCodeSequenceRepositoryMockRecorder) Reserve(ctx, scope, n any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockCodeSequenceRepository)(nil).Reserve), ctx, scope, n)
+}
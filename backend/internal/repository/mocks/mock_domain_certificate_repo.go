@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/SeaCodeBase/urlshortener/internal/repository (interfaces: DomainCertificateRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mock_domain_certificate_repo.go -package=mocks . DomainCertificateRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	model "github.com/SeaCodeBase/urlshortener/internal/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDomainCertificateRepository is a mock of DomainCertificateRepository interface.
+type MockDomainCertificateRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDomainCertificateRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockDomainCertificateRepositoryMockRecorder is the mock recorder for MockDomainCertificateRepository.
+type MockDomainCertificateRepositoryMockRecorder struct {
+	mock *MockDomainCertificateRepository
+}
+
+// NewMockDomainCertificateRepository creates a new mock instance.
+func NewMockDomainCertificateRepository(ctrl *gomock.Controller) *MockDomainCertificateRepository {
+	mock := &MockDomainCertificateRepository{ctrl: ctrl}
+	mock.recorder = &MockDomainCertificateRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDomainCertificateRepository) EXPECT() *MockDomainCertificateRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteByDomainID mocks base method.
+func (m *MockDomainCertificateRepository) DeleteByDomainID(ctx context.Context, domainID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByDomainID", ctx, domainID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByDomainID indicates an expected call of DeleteByDomainID.
+func (mr *MockDomainCertificateRepositoryMockRecorder) DeleteByDomainID(ctx, domainID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByDomainID", reflect.TypeOf((*MockDomainCertificateRepository)(nil).DeleteByDomainID), ctx, domainID)
+}
+
+// GetByDomainID mocks base method.
+func (m *MockDomainCertificateRepository) GetByDomainID(ctx context.Context, domainID uint64) (*model.DomainCertificate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByDomainID", ctx, domainID)
+	ret0, _ := ret[0].(*model.DomainCertificate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByDomainID indicates an expected call of GetByDomainID.
+func (mr *MockDomainCertificateRepositoryMockRecorder) GetByDomainID(ctx, domainID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDomainID", reflect.TypeOf((*MockDomainCertificateRepository)(nil).GetByDomainID), ctx, domainID)
+}
+
+// GetByHost mocks base method.
+func (m *MockDomainCertificateRepository) GetByHost(ctx context.Context, host string) (*model.DomainCertificate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHost", ctx, host)
+	ret0, _ := ret[0].(*model.DomainCertificate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHost indicates an expected call of GetByHost.
+func (mr *MockDomainCertificateRepositoryMockRecorder) GetByHost(ctx, host any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHost", reflect.TypeOf((*MockDomainCertificateRepository)(nil).GetByHost), ctx, host)
+}
+
+// ListExpiring mocks base method.
+func (m *MockDomainCertificateRepository) ListExpiring(ctx context.Context, before time.Time, limit int) ([]*model.DomainCertificate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiring", ctx, before, limit)
+	ret0, _ := ret[0].([]*model.DomainCertificate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiring indicates an expected call of ListExpiring.
+func (mr *MockDomainCertificateRepositoryMockRecorder) ListExpiring(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiring", reflect.TypeOf((*MockDomainCertificateRepository)(nil).ListExpiring), ctx, before, limit)
+}
+
+// Save mocks base method.
+func (m *MockDomainCertificateRepository) Save(ctx context.Context, cert *model.DomainCertificate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, cert)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockDomainCertificateRepositoryMockRecorder) Save(ctx, cert any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockDomainCertificateRepository)(nil).Save), ctx, cert)
+}
+
+// SetError mocks base method.
+func (m *MockDomainCertificateRepository) SetError(ctx context.Context, id uint64, message string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetError", ctx, id, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetError indicates an expected call of SetError.
+func (mr *MockDomainCertificateRepositoryMockRecorder) SetError(ctx, id, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetError", reflect.TypeOf((*MockDomainCertificateRepository)(nil).SetError), ctx, id, message)
+}
@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	model "github.com/SeaCodeBase/urlshortener/internal/model"
 	gomock "go.uber.org/mock/gomock"
@@ -41,6 +42,20 @@ func (m *MockDomainRepository) EXPECT() *MockDomainRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Archive mocks base method.
+func (m *MockDomainRepository) Archive(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Archive", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Archive indicates an expected call of Archive.
+func (mr *MockDomainRepositoryMockRecorder) Archive(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Archive", reflect.TypeOf((*MockDomainRepository)(nil).Archive), ctx, id)
+}
+
 // Create mocks base method.
 func (m *MockDomainRepository) Create(ctx context.Context, domain *model.Domain) error {
 	m.ctrl.T.Helper()
@@ -69,47 +84,149 @@ func (mr *MockDomainRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDomainRepository)(nil).Delete), ctx, id)
 }
 
-// GetByDomain mocks base method.
-func (m *MockDomainRepository) GetByDomain(ctx context.Context, domain string) (*model.Domain, error) {
+// GetByID mocks base method.
+func (m *MockDomainRepository) GetByID(ctx context.Context, id uint64) (*model.Domain, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByDomain", ctx, domain)
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
 	ret0, _ := ret[0].(*model.Domain)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByDomain indicates an expected call of GetByDomain.
-func (mr *MockDomainRepositoryMockRecorder) GetByDomain(ctx, domain any) *gomock.Call {
+// GetByID indicates an expected call of GetByID.
+func (mr *MockDomainRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDomain", reflect.TypeOf((*MockDomainRepository)(nil).GetByDomain), ctx, domain)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockDomainRepository)(nil).GetByID), ctx, id)
 }
 
-// GetByID mocks base method.
-func (m *MockDomainRepository) GetByID(ctx context.Context, id uint64) (*model.Domain, error) {
+// GetDefaultByWorkspaceID mocks base method.
+func (m *MockDomainRepository) GetDefaultByWorkspaceID(ctx context.Context, workspaceID uint64) (*model.Domain, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret := m.ctrl.Call(m, "GetDefaultByWorkspaceID", ctx, workspaceID)
 	ret0, _ := ret[0].(*model.Domain)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByID indicates an expected call of GetByID.
-func (mr *MockDomainRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+// GetDefaultByWorkspaceID indicates an expected call of GetDefaultByWorkspaceID.
+func (mr *MockDomainRepositoryMockRecorder) GetDefaultByWorkspaceID(ctx, workspaceID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockDomainRepository)(nil).GetByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultByWorkspaceID", reflect.TypeOf((*MockDomainRepository)(nil).GetDefaultByWorkspaceID), ctx, workspaceID)
+}
+
+// GetVerifiedByDomain mocks base method.
+func (m *MockDomainRepository) GetVerifiedByDomain(ctx context.Context, key string) (*model.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVerifiedByDomain", ctx, key)
+	ret0, _ := ret[0].(*model.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVerifiedByDomain indicates an expected call of GetVerifiedByDomain.
+func (mr *MockDomainRepositoryMockRecorder) GetVerifiedByDomain(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVerifiedByDomain", reflect.TypeOf((*MockDomainRepository)(nil).GetVerifiedByDomain), ctx, key)
+}
+
+// ListAll mocks base method.
+func (m *MockDomainRepository) ListAll(ctx context.Context) ([]*model.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*model.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockDomainRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockDomainRepository)(nil).ListAll), ctx)
+}
+
+// ListByWorkspaceID mocks base method.
+func (m *MockDomainRepository) ListByWorkspaceID(ctx context.Context, workspaceID uint64) ([]*model.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByWorkspaceID", ctx, workspaceID)
+	ret0, _ := ret[0].([]*model.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByWorkspaceID indicates an expected call of ListByWorkspaceID.
+func (mr *MockDomainRepositoryMockRecorder) ListByWorkspaceID(ctx, workspaceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByWorkspaceID", reflect.TypeOf((*MockDomainRepository)(nil).ListByWorkspaceID), ctx, workspaceID)
+}
+
+// ListDueForRecheck mocks base method.
+func (m *MockDomainRepository) ListDueForRecheck(ctx context.Context, checkedBefore time.Time, limit int) ([]*model.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueForRecheck", ctx, checkedBefore, limit)
+	ret0, _ := ret[0].([]*model.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueForRecheck indicates an expected call of ListDueForRecheck.
+func (mr *MockDomainRepositoryMockRecorder) ListDueForRecheck(ctx, checkedBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueForRecheck", reflect.TypeOf((*MockDomainRepository)(nil).ListDueForRecheck), ctx, checkedBefore, limit)
 }
 
-// ListByUserID mocks base method.
-func (m *MockDomainRepository) ListByUserID(ctx context.Context, userID uint64) ([]*model.Domain, error) {
+// ListVerifiedByPatterns mocks base method.
+func (m *MockDomainRepository) ListVerifiedByPatterns(ctx context.Context, patterns []string) ([]*model.Domain, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListByUserID", ctx, userID)
+	ret := m.ctrl.Call(m, "ListVerifiedByPatterns", ctx, patterns)
 	ret0, _ := ret[0].([]*model.Domain)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListByUserID indicates an expected call of ListByUserID.
-func (mr *MockDomainRepositoryMockRecorder) ListByUserID(ctx, userID any) *gomock.Call {
+// ListVerifiedByPatterns indicates an expected call of ListVerifiedByPatterns.
+func (mr *MockDomainRepositoryMockRecorder) ListVerifiedByPatterns(ctx, patterns any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVerifiedByPatterns", reflect.TypeOf((*MockDomainRepository)(nil).ListVerifiedByPatterns), ctx, patterns)
+}
+
+// Rename mocks base method.
+func (m *MockDomainRepository) Rename(ctx context.Context, id uint64, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rename", ctx, id, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rename indicates an expected call of Rename.
+func (mr *MockDomainRepositoryMockRecorder) Rename(ctx, id, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rename", reflect.TypeOf((*MockDomainRepository)(nil).Rename), ctx, id, name)
+}
+
+// Update mocks base method.
+func (m *MockDomainRepository) Update(ctx context.Context, domain *model.Domain) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, domain)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockDomainRepositoryMockRecorder) Update(ctx, domain any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDomainRepository)(nil).Update), ctx, domain)
+}
+
+// UpdateVerification mocks base method.
+func (m *MockDomainRepository) UpdateVerification(ctx context.Context, domain *model.Domain) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVerification", ctx, domain)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateVerification indicates an expected call of UpdateVerification.
+func (mr *MockDomainRepositoryMockRecorder) UpdateVerification(ctx, domain any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUserID", reflect.TypeOf((*MockDomainRepository)(nil).ListByUserID), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVerification", reflect.TypeOf((*MockDomainRepository)(nil).UpdateVerification), ctx, domain)
 }
@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/SeaCodeBase/urlshortener/internal/repository (interfaces: LinkAliasRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mock_link_alias_repo.go -package=mocks . LinkAliasRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/SeaCodeBase/urlshortener/internal/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLinkAliasRepository is a mock of LinkAliasRepository interface.
+type MockLinkAliasRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLinkAliasRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockLinkAliasRepositoryMockRecorder is the mock recorder for MockLinkAliasRepository.
+type MockLinkAliasRepositoryMockRecorder struct {
+	mock *MockLinkAliasRepository
+}
+
+// NewMockLinkAliasRepository creates a new mock instance.
+func NewMockLinkAliasRepository(ctrl *gomock.Controller) *MockLinkAliasRepository {
+	mock := &MockLinkAliasRepository{ctrl: ctrl}
+	mock.recorder = &MockLinkAliasRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLinkAliasRepository) EXPECT() *MockLinkAliasRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockLinkAliasRepository) Create(ctx context.Context, alias *model.LinkAlias) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, alias)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLinkAliasRepositoryMockRecorder) Create(ctx, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLinkAliasRepository)(nil).Create), ctx, alias)
+}
+
+// Delete mocks base method.
+func (m *MockLinkAliasRepository) Delete(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockLinkAliasRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockLinkAliasRepository)(nil).Delete), ctx, id)
+}
+
+// GetActiveByDomainAndCode mocks base method.
+func (m *MockLinkAliasRepository) GetActiveByDomainAndCode(ctx context.Context, domainID *uint64, code string) (*model.LinkAlias, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveByDomainAndCode", ctx, domainID, code)
+	ret0, _ := ret[0].(*model.LinkAlias)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveByDomainAndCode indicates an expected call of GetActiveByDomainAndCode.
+func (mr *MockLinkAliasRepositoryMockRecorder) GetActiveByDomainAndCode(ctx, domainID, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveByDomainAndCode", reflect.TypeOf((*MockLinkAliasRepository)(nil).GetActiveByDomainAndCode), ctx, domainID, code)
+}
+
+// GetByID mocks base method.
+func (m *MockLinkAliasRepository) GetByID(ctx context.Context, id uint64) (*model.LinkAlias, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*model.LinkAlias)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockLinkAliasRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockLinkAliasRepository)(nil).GetByID), ctx, id)
+}
+
+// ListActiveByDomainID mocks base method.
+func (m *MockLinkAliasRepository) ListActiveByDomainID(ctx context.Context, domainID uint64) ([]model.LinkAlias, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveByDomainID", ctx, domainID)
+	ret0, _ := ret[0].([]model.LinkAlias)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveByDomainID indicates an expected call of ListActiveByDomainID.
+func (mr *MockLinkAliasRepositoryMockRecorder) ListActiveByDomainID(ctx, domainID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveByDomainID", reflect.TypeOf((*MockLinkAliasRepository)(nil).ListActiveByDomainID), ctx, domainID)
+}
+
+// ListByLinkID mocks base method.
+func (m *MockLinkAliasRepository) ListByLinkID(ctx context.Context, linkID uint64) ([]model.LinkAlias, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByLinkID", ctx, linkID)
+	ret0, _ := ret[0].([]model.LinkAlias)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByLinkID indicates an expected call of ListByLinkID.
+func (mr *MockLinkAliasRepositoryMockRecorder) ListByLinkID(ctx, linkID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByLinkID", reflect.TypeOf((*MockLinkAliasRepository)(nil).ListByLinkID), ctx, linkID)
+}
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/SeaCodeBase/urlshortener/internal/repository (interfaces: LinkHistoryRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mock_link_history_repo.go -package=mocks . LinkHistoryRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/SeaCodeBase/urlshortener/internal/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLinkHistoryRepository is a mock of LinkHistoryRepository interface.
+type MockLinkHistoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLinkHistoryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockLinkHistoryRepositoryMockRecorder is the mock recorder for MockLinkHistoryRepository.
+type MockLinkHistoryRepositoryMockRecorder struct {
+	mock *MockLinkHistoryRepository
+}
+
+// NewMockLinkHistoryRepository creates a new mock instance.
+func NewMockLinkHistoryRepository(ctrl *gomock.Controller) *MockLinkHistoryRepository {
+	mock := &MockLinkHistoryRepository{ctrl: ctrl}
+	mock.recorder = &MockLinkHistoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLinkHistoryRepository) EXPECT() *MockLinkHistoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockLinkHistoryRepository) Create(ctx context.Context, rev *model.LinkRevision) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, rev)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLinkHistoryRepositoryMockRecorder) Create(ctx, rev any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLinkHistoryRepository)(nil).Create), ctx, rev)
+}
+
+// GetByVersion mocks base method.
+func (m *MockLinkHistoryRepository) GetByVersion(ctx context.Context, linkID uint64, version int) (*model.LinkRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByVersion", ctx, linkID, version)
+	ret0, _ := ret[0].(*model.LinkRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByVersion indicates an expected call of GetByVersion.
+func (mr *MockLinkHistoryRepositoryMockRecorder) GetByVersion(ctx, linkID, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByVersion", reflect.TypeOf((*MockLinkHistoryRepository)(nil).GetByVersion), ctx, linkID, version)
+}
+
+// ListByLinkID mocks base method.
+func (m *MockLinkHistoryRepository) ListByLinkID(ctx context.Context, linkID uint64) ([]model.LinkRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByLinkID", ctx, linkID)
+	ret0, _ := ret[0].([]model.LinkRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByLinkID indicates an expected call of ListByLinkID.
+func (mr *MockLinkHistoryRepositoryMockRecorder) ListByLinkID(ctx, linkID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByLinkID", reflect.TypeOf((*MockLinkHistoryRepository)(nil).ListByLinkID), ctx, linkID)
+}
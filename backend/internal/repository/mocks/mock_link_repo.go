@@ -12,8 +12,10 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	model "github.com/SeaCodeBase/urlshortener/internal/model"
+	repository "github.com/SeaCodeBase/urlshortener/internal/repository"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,19 +43,64 @@ func (m *MockLinkRepository) EXPECT() *MockLinkRepositoryMockRecorder {
 	return m.recorder
 }
 
-// CountByUserID mocks base method.
-func (m *MockLinkRepository) CountByUserID(ctx context.Context, userID uint64) (int64, error) {
+// CountByCampaignID mocks base method.
+func (m *MockLinkRepository) CountByCampaignID(ctx context.Context, campaignID uint64) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountByUserID", ctx, userID)
+	ret := m.ctrl.Call(m, "CountByCampaignID", ctx, campaignID)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CountByUserID indicates an expected call of CountByUserID.
-func (mr *MockLinkRepositoryMockRecorder) CountByUserID(ctx, userID any) *gomock.Call {
+// CountByCampaignID indicates an expected call of CountByCampaignID.
+func (mr *MockLinkRepositoryMockRecorder) CountByCampaignID(ctx, campaignID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByUserID", reflect.TypeOf((*MockLinkRepository)(nil).CountByUserID), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByCampaignID", reflect.TypeOf((*MockLinkRepository)(nil).CountByCampaignID), ctx, campaignID)
+}
+
+// CountByWorkspaceID mocks base method.
+func (m *MockLinkRepository) CountByWorkspaceID(ctx context.Context, workspaceID uint64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByWorkspaceID", ctx, workspaceID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByWorkspaceID indicates an expected call of CountByWorkspaceID.
+func (mr *MockLinkRepositoryMockRecorder) CountByWorkspaceID(ctx, workspaceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByWorkspaceID", reflect.TypeOf((*MockLinkRepository)(nil).CountByWorkspaceID), ctx, workspaceID)
+}
+
+// CountCreatedBetween mocks base method.
+func (m *MockLinkRepository) CountCreatedBetween(ctx context.Context, workspaceID uint64, from, to time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCreatedBetween", ctx, workspaceID, from, to)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCreatedBetween indicates an expected call of CountCreatedBetween.
+func (mr *MockLinkRepositoryMockRecorder) CountCreatedBetween(ctx, workspaceID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCreatedBetween", reflect.TypeOf((*MockLinkRepository)(nil).CountCreatedBetween), ctx, workspaceID, from, to)
+}
+
+// CountExpiringBetween mocks base method.
+func (m *MockLinkRepository) CountExpiringBetween(ctx context.Context, workspaceID uint64, from, to time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountExpiringBetween", ctx, workspaceID, from, to)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountExpiringBetween indicates an expected call of CountExpiringBetween.
+func (mr *MockLinkRepositoryMockRecorder) CountExpiringBetween(ctx, workspaceID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountExpiringBetween", reflect.TypeOf((*MockLinkRepository)(nil).CountExpiringBetween), ctx, workspaceID, from, to)
 }
 
 // Create mocks base method.
@@ -84,6 +131,21 @@ func (mr *MockLinkRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockLinkRepository)(nil).Delete), ctx, id)
 }
 
+// FillDestHashes mocks base method.
+func (m *MockLinkRepository) FillDestHashes(ctx context.Context, batchSize int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FillDestHashes", ctx, batchSize)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FillDestHashes indicates an expected call of FillDestHashes.
+func (mr *MockLinkRepositoryMockRecorder) FillDestHashes(ctx, batchSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FillDestHashes", reflect.TypeOf((*MockLinkRepository)(nil).FillDestHashes), ctx, batchSize)
+}
+
 // GetByDomainAndShortCode mocks base method.
 func (m *MockLinkRepository) GetByDomainAndShortCode(ctx context.Context, domainID *uint64, shortCode string) (*model.Link, error) {
 	m.ctrl.T.Helper()
@@ -114,19 +176,197 @@ func (mr *MockLinkRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockLinkRepository)(nil).GetByID), ctx, id)
 }
 
-// ListByUserID mocks base method.
-func (m *MockLinkRepository) ListByUserID(ctx context.Context, userID uint64, limit, offset int) ([]model.Link, error) {
+// GetByIDs mocks base method.
+func (m *MockLinkRepository) GetByIDs(ctx context.Context, ids []uint64) ([]model.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ctx, ids)
+	ret0, _ := ret[0].([]model.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockLinkRepositoryMockRecorder) GetByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockLinkRepository)(nil).GetByIDs), ctx, ids)
+}
+
+// ListAllByWorkspaceID mocks base method.
+func (m *MockLinkRepository) ListAllByWorkspaceID(ctx context.Context, workspaceID uint64) ([]model.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllByWorkspaceID", ctx, workspaceID)
+	ret0, _ := ret[0].([]model.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllByWorkspaceID indicates an expected call of ListAllByWorkspaceID.
+func (mr *MockLinkRepositoryMockRecorder) ListAllByWorkspaceID(ctx, workspaceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllByWorkspaceID", reflect.TypeOf((*MockLinkRepository)(nil).ListAllByWorkspaceID), ctx, workspaceID)
+}
+
+// ListByCampaignID mocks base method.
+func (m *MockLinkRepository) ListByCampaignID(ctx context.Context, campaignID uint64) ([]model.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByCampaignID", ctx, campaignID)
+	ret0, _ := ret[0].([]model.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByCampaignID indicates an expected call of ListByCampaignID.
+func (mr *MockLinkRepositoryMockRecorder) ListByCampaignID(ctx, campaignID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByCampaignID", reflect.TypeOf((*MockLinkRepository)(nil).ListByCampaignID), ctx, campaignID)
+}
+
+// ListByDestHash mocks base method.
+func (m *MockLinkRepository) ListByDestHash(ctx context.Context, workspaceID uint64, hash string, ignoreTracking bool) ([]model.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByDestHash", ctx, workspaceID, hash, ignoreTracking)
+	ret0, _ := ret[0].([]model.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByDestHash indicates an expected call of ListByDestHash.
+func (mr *MockLinkRepositoryMockRecorder) ListByDestHash(ctx, workspaceID, hash, ignoreTracking any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByDestHash", reflect.TypeOf((*MockLinkRepository)(nil).ListByDestHash), ctx, workspaceID, hash, ignoreTracking)
+}
+
+// ListByDomainID mocks base method.
+func (m *MockLinkRepository) ListByDomainID(ctx context.Context, domainID uint64) ([]model.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByDomainID", ctx, domainID)
+	ret0, _ := ret[0].([]model.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByDomainID indicates an expected call of ListByDomainID.
+func (mr *MockLinkRepositoryMockRecorder) ListByDomainID(ctx, domainID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByDomainID", reflect.TypeOf((*MockLinkRepository)(nil).ListByDomainID), ctx, domainID)
+}
+
+// ListByWorkspaceID mocks base method.
+func (m *MockLinkRepository) ListByWorkspaceID(ctx context.Context, workspaceID uint64, limit, offset int) ([]model.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByWorkspaceID", ctx, workspaceID, limit, offset)
+	ret0, _ := ret[0].([]model.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByWorkspaceID indicates an expected call of ListByWorkspaceID.
+func (mr *MockLinkRepositoryMockRecorder) ListByWorkspaceID(ctx, workspaceID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByWorkspaceID", reflect.TypeOf((*MockLinkRepository)(nil).ListByWorkspaceID), ctx, workspaceID, limit, offset)
+}
+
+// ListDuplicateDestinations mocks base method.
+func (m *MockLinkRepository) ListDuplicateDestinations(ctx context.Context, workspaceID uint64, ignoreTracking bool) ([]model.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDuplicateDestinations", ctx, workspaceID, ignoreTracking)
+	ret0, _ := ret[0].([]model.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDuplicateDestinations indicates an expected call of ListDuplicateDestinations.
+func (mr *MockLinkRepositoryMockRecorder) ListDuplicateDestinations(ctx, workspaceID, ignoreTracking any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDuplicateDestinations", reflect.TypeOf((*MockLinkRepository)(nil).ListDuplicateDestinations), ctx, workspaceID, ignoreTracking)
+}
+
+// ListExpiredBetween mocks base method.
+func (m *MockLinkRepository) ListExpiredBetween(ctx context.Context, from, to time.Time) ([]model.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredBetween", ctx, from, to)
+	ret0, _ := ret[0].([]model.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredBetween indicates an expected call of ListExpiredBetween.
+func (mr *MockLinkRepositoryMockRecorder) ListExpiredBetween(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredBetween", reflect.TypeOf((*MockLinkRepository)(nil).ListExpiredBetween), ctx, from, to)
+}
+
+// ListTrashedByWorkspaceID mocks base method.
+func (m *MockLinkRepository) ListTrashedByWorkspaceID(ctx context.Context, workspaceID uint64) ([]model.Link, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListByUserID", ctx, userID, limit, offset)
+	ret := m.ctrl.Call(m, "ListTrashedByWorkspaceID", ctx, workspaceID)
 	ret0, _ := ret[0].([]model.Link)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListByUserID indicates an expected call of ListByUserID.
-func (mr *MockLinkRepositoryMockRecorder) ListByUserID(ctx, userID, limit, offset any) *gomock.Call {
+// ListTrashedByWorkspaceID indicates an expected call of ListTrashedByWorkspaceID.
+func (mr *MockLinkRepositoryMockRecorder) ListTrashedByWorkspaceID(ctx, workspaceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrashedByWorkspaceID", reflect.TypeOf((*MockLinkRepository)(nil).ListTrashedByWorkspaceID), ctx, workspaceID)
+}
+
+// MoveDomain mocks base method.
+func (m *MockLinkRepository) MoveDomain(ctx context.Context, fromDomainID uint64, toDomainID *uint64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveDomain", ctx, fromDomainID, toDomainID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveDomain indicates an expected call of MoveDomain.
+func (mr *MockLinkRepositoryMockRecorder) MoveDomain(ctx, fromDomainID, toDomainID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUserID", reflect.TypeOf((*MockLinkRepository)(nil).ListByUserID), ctx, userID, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveDomain", reflect.TypeOf((*MockLinkRepository)(nil).MoveDomain), ctx, fromDomainID, toDomainID)
+}
+
+// PurgeTrashed mocks base method.
+func (m *MockLinkRepository) PurgeTrashed(ctx context.Context, before time.Time, limit int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTrashed", ctx, before, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeTrashed indicates an expected call of PurgeTrashed.
+func (mr *MockLinkRepositoryMockRecorder) PurgeTrashed(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTrashed", reflect.TypeOf((*MockLinkRepository)(nil).PurgeTrashed), ctx, before, limit)
+}
+
+// Restore mocks base method.
+func (m *MockLinkRepository) Restore(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockLinkRepositoryMockRecorder) Restore(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockLinkRepository)(nil).Restore), ctx, id)
+}
+
+// SaveEdit mocks base method.
+func (m *MockLinkRepository) SaveEdit(ctx context.Context, link *model.Link, edit repository.LinkEdit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveEdit", ctx, link, edit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveEdit indicates an expected call of SaveEdit.
+func (mr *MockLinkRepositoryMockRecorder) SaveEdit(ctx, link, edit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveEdit", reflect.TypeOf((*MockLinkRepository)(nil).SaveEdit), ctx, link, edit)
 }
 
 // ShortCodeExistsInDomain mocks base method.
@@ -144,6 +384,35 @@ func (mr *MockLinkRepositoryMockRecorder) ShortCodeExistsInDomain(ctx, domainID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShortCodeExistsInDomain", reflect.TypeOf((*MockLinkRepository)(nil).ShortCodeExistsInDomain), ctx, domainID, shortCode)
 }
 
+// Trash mocks base method.
+func (m *MockLinkRepository) Trash(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trash", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Trash indicates an expected call of Trash.
+func (mr *MockLinkRepositoryMockRecorder) Trash(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trash", reflect.TypeOf((*MockLinkRepository)(nil).Trash), ctx, id)
+}
+
+// TrashByDomainID mocks base method.
+func (m *MockLinkRepository) TrashByDomainID(ctx context.Context, domainID uint64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrashByDomainID", ctx, domainID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TrashByDomainID indicates an expected call of TrashByDomainID.
+func (mr *MockLinkRepositoryMockRecorder) TrashByDomainID(ctx, domainID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrashByDomainID", reflect.TypeOf((*MockLinkRepository)(nil).TrashByDomainID), ctx, domainID)
+}
+
 // Update mocks base method.
 func (m *MockLinkRepository) Update(ctx context.Context, link *model.Link) error {
 	m.ctrl.T.Helper()
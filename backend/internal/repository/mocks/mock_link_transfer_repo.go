@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/SeaCodeBase/urlshortener/internal/repository (interfaces: LinkTransferRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mock_link_transfer_repo.go -package=mocks . LinkTransferRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/SeaCodeBase/urlshortener/internal/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLinkTransferRepository is a mock of LinkTransferRepository interface.
+type MockLinkTransferRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLinkTransferRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockLinkTransferRepositoryMockRecorder is the mock recorder for MockLinkTransferRepository.
+type MockLinkTransferRepositoryMockRecorder struct {
+	mock *MockLinkTransferRepository
+}
+
+// NewMockLinkTransferRepository creates a new mock instance.
+func NewMockLinkTransferRepository(ctrl *gomock.Controller) *MockLinkTransferRepository {
+	mock := &MockLinkTransferRepository{ctrl: ctrl}
+	mock.recorder = &MockLinkTransferRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLinkTransferRepository) EXPECT() *MockLinkTransferRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockLinkTransferRepository) Accept(ctx context.Context, transfer *model.LinkTransfer, toWorkspaceID, toUserID uint64, linkIDs []uint64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", ctx, transfer, toWorkspaceID, toUserID, linkIDs)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockLinkTransferRepositoryMockRecorder) Accept(ctx, transfer, toWorkspaceID, toUserID, linkIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockLinkTransferRepository)(nil).Accept), ctx, transfer, toWorkspaceID, toUserID, linkIDs)
+}
+
+// Create mocks base method.
+func (m *MockLinkTransferRepository) Create(ctx context.Context, transfer *model.LinkTransfer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, transfer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLinkTransferRepositoryMockRecorder) Create(ctx, transfer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLinkTransferRepository)(nil).Create), ctx, transfer)
+}
+
+// GetByID mocks base method.
+func (m *MockLinkTransferRepository) GetByID(ctx context.Context, id uint64) (*model.LinkTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*model.LinkTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockLinkTransferRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockLinkTransferRepository)(nil).GetByID), ctx, id)
+}
+
+// ListPendingByUserID mocks base method.
+func (m *MockLinkTransferRepository) ListPendingByUserID(ctx context.Context, userID uint64) ([]model.LinkTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingByUserID", ctx, userID)
+	ret0, _ := ret[0].([]model.LinkTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingByUserID indicates an expected call of ListPendingByUserID.
+func (mr *MockLinkTransferRepositoryMockRecorder) ListPendingByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingByUserID", reflect.TypeOf((*MockLinkTransferRepository)(nil).ListPendingByUserID), ctx, userID)
+}
+
+// Resolve mocks base method.
+func (m *MockLinkTransferRepository) Resolve(ctx context.Context, id uint64, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", ctx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockLinkTransferRepositoryMockRecorder) Resolve(ctx, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockLinkTransferRepository)(nil).Resolve), ctx, id, status)
+}
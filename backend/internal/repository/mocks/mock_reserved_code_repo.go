@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/SeaCodeBase/urlshortener/internal/repository (interfaces: ReservedCodeRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mock_reserved_code_repo.go -package=mocks . ReservedCodeRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/SeaCodeBase/urlshortener/internal/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReservedCodeRepository is a mock of ReservedCodeRepository interface.
+type MockReservedCodeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReservedCodeRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockReservedCodeRepositoryMockRecorder is the mock recorder for MockReservedCodeRepository.
+type MockReservedCodeRepositoryMockRecorder struct {
+	mock *MockReservedCodeRepository
+}
+
+// NewMockReservedCodeRepository creates a new mock instance.
+func NewMockReservedCodeRepository(ctrl *gomock.Controller) *MockReservedCodeRepository {
+	mock := &MockReservedCodeRepository{ctrl: ctrl}
+	mock.recorder = &MockReservedCodeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReservedCodeRepository) EXPECT() *MockReservedCodeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockReservedCodeRepository) Create(ctx context.Context, reserved *model.ReservedCode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, reserved)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReservedCodeRepositoryMockRecorder) Create(ctx, reserved any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReservedCodeRepository)(nil).Create), ctx, reserved)
+}
+
+// Delete mocks base method.
+func (m *MockReservedCodeRepository) Delete(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockReservedCodeRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockReservedCodeRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockReservedCodeRepository) GetByID(ctx context.Context, id uint64) (*model.ReservedCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*model.ReservedCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockReservedCodeRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockReservedCodeRepository)(nil).GetByID), ctx, id)
+}
+
+// ListByDomainID mocks base method.
+func (m *MockReservedCodeRepository) ListByDomainID(ctx context.Context, domainID *uint64) ([]model.ReservedCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByDomainID", ctx, domainID)
+	ret0, _ := ret[0].([]model.ReservedCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByDomainID indicates an expected call of ListByDomainID.
+func (mr *MockReservedCodeRepositoryMockRecorder) ListByDomainID(ctx, domainID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByDomainID", reflect.TypeOf((*MockReservedCodeRepository)(nil).ListByDomainID), ctx, domainID)
+}
+
+// Match mocks base method.
+func (m *MockReservedCodeRepository) Match(ctx context.Context, domainID *uint64, code string) ([]model.ReservedCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Match", ctx, domainID, code)
+	ret0, _ := ret[0].([]model.ReservedCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Match indicates an expected call of Match.
+func (mr *MockReservedCodeRepositoryMockRecorder) Match(ctx, domainID, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Match", reflect.TypeOf((*MockReservedCodeRepository)(nil).Match), ctx, domainID, code)
+}
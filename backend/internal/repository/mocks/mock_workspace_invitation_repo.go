@@ -0,0 +1,129 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/SeaCodeBase/urlshortener/internal/repository (interfaces: WorkspaceInvitationRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mock_workspace_invitation_repo.go -package=mocks . WorkspaceInvitationRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/SeaCodeBase/urlshortener/internal/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWorkspaceInvitationRepository is a mock of WorkspaceInvitationRepository interface.
+type MockWorkspaceInvitationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkspaceInvitationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockWorkspaceInvitationRepositoryMockRecorder is the mock recorder for MockWorkspaceInvitationRepository.
+type MockWorkspaceInvitationRepositoryMockRecorder struct {
+	mock *MockWorkspaceInvitationRepository
+}
+
+// NewMockWorkspaceInvitationRepository creates a new mock instance.
+func NewMockWorkspaceInvitationRepository(ctrl *gomock.Controller) *MockWorkspaceInvitationRepository {
+	mock := &MockWorkspaceInvitationRepository{ctrl: ctrl}
+	mock.recorder = &MockWorkspaceInvitationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkspaceInvitationRepository) EXPECT() *MockWorkspaceInvitationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockWorkspaceInvitationRepository) Accept(ctx context.Context, id, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockWorkspaceInvitationRepositoryMockRecorder) Accept(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockWorkspaceInvitationRepository)(nil).Accept), ctx, id, userID)
+}
+
+// Create mocks base method.
+func (m *MockWorkspaceInvitationRepository) Create(ctx context.Context, invitation *model.WorkspaceInvitation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, invitation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWorkspaceInvitationRepositoryMockRecorder) Create(ctx, invitation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWorkspaceInvitationRepository)(nil).Create), ctx, invitation)
+}
+
+// Delete mocks base method.
+func (m *MockWorkspaceInvitationRepository) Delete(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWorkspaceInvitationRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWorkspaceInvitationRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockWorkspaceInvitationRepository) GetByID(ctx context.Context, id uint64) (*model.WorkspaceInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*model.WorkspaceInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockWorkspaceInvitationRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockWorkspaceInvitationRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByTokenHash mocks base method.
+func (m *MockWorkspaceInvitationRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*model.WorkspaceInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTokenHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*model.WorkspaceInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTokenHash indicates an expected call of GetByTokenHash.
+func (mr *MockWorkspaceInvitationRepositoryMockRecorder) GetByTokenHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTokenHash", reflect.TypeOf((*MockWorkspaceInvitationRepository)(nil).GetByTokenHash), ctx, tokenHash)
+}
+
+// ListPendingByWorkspaceID mocks base method.
+func (m *MockWorkspaceInvitationRepository) ListPendingByWorkspaceID(ctx context.Context, workspaceID uint64) ([]model.WorkspaceInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingByWorkspaceID", ctx, workspaceID)
+	ret0, _ := ret[0].([]model.WorkspaceInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingByWorkspaceID indicates an expected call of ListPendingByWorkspaceID.
+func (mr *MockWorkspaceInvitationRepositoryMockRecorder) ListPendingByWorkspaceID(ctx, workspaceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingByWorkspaceID", reflect.TypeOf((*MockWorkspaceInvitationRepository)(nil).ListPendingByWorkspaceID), ctx, workspaceID)
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrReservedCodeNotFound = errors.New("reserved code not found")
+	ErrReservedCodeExists   = errors.New("reserved code already exists")
+)
+
+// Compile-time check: ReservedCodeRepositoryImpl implements ReservedCodeRepository
+var _ ReservedCodeRepository = (*ReservedCodeRepositoryImpl)(nil)
+
+type ReservedCodeRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+func NewReservedCodeRepository(db *sqlx.DB) *ReservedCodeRepositoryImpl {
+	return &ReservedCodeRepositoryImpl{db: db}
+}
+
+func (r *ReservedCodeRepositoryImpl) Create(ctx context.Context, reserved *model.ReservedCode) error {
+	query := `INSERT INTO reserved_codes (domain_id, code, kind, reason, created_by) VALUES (?, ?, ?, ?, ?)`
+	result, err := r.db.ExecContext(ctx, query, reserved.DomainID, reserved.Code, reserved.Kind, reserved.Reason, reserved.CreatedBy)
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return ErrReservedCodeExists
+		}
+		logger.Error(ctx, "reserved-code-repo: failed to create reserved code",
+			zap.String("code", reserved.Code),
+			zap.Error(err),
+		)
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error(ctx, "reserved-code-repo: failed to get last insert ID",
+			zap.Error(err),
+		)
+		return err
+	}
+	reserved.ID = uint64(id)
+	return nil
+}
+
+func (r *ReservedCodeRepositoryImpl) GetByID(ctx context.Context, id uint64) (*model.ReservedCode, error) {
+	var reserved model.ReservedCode
+	query := `SELECT id, domain_id, code, kind, reason, created_by, created_at FROM reserved_codes WHERE id = ?`
+	err := r.db.GetContext(ctx, &reserved, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrReservedCodeNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, "reserved-code-repo: failed to get reserved code by ID",
+			zap.Uint64("id", id),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return &reserved, nil
+}
+
+func (r *ReservedCodeRepositoryImpl) ListByDomainID(ctx context.Context, domainID *uint64) ([]model.ReservedCode, error) {
+	var reserved []model.ReservedCode
+	var err error
+	if domainID == nil {
+		query := `SELECT id, domain_id, code, kind, reason, created_by, created_at FROM reserved_codes
+				  WHERE domain_id IS NULL ORDER BY code`
+		err = r.db.SelectContext(ctx, &reserved, query)
+	} else {
+		query := `SELECT id, domain_id, code, kind, reason, created_by, created_at FROM reserved_codes
+				  WHERE domain_id = ? ORDER BY code`
+		err = r.db.SelectContext(ctx, &reserved, query, *domainID)
+	}
+	if err != nil {
+		logger.Error(ctx, "reserved-code-repo: failed to list reserved codes",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if reserved == nil {
+		reserved = []model.ReservedCode{}
+	}
+	return reserved, nil
+}
+
+func (r *ReservedCodeRepositoryImpl) Match(ctx context.Context, domainID *uint64, code string) ([]model.ReservedCode, error) {
+	var reserved []model.ReservedCode
+	var err error
+	if domainID == nil {
+		query := `SELECT id, domain_id, code, kind, reason, created_by, created_at FROM reserved_codes
+				  WHERE domain_id IS NULL AND code = ?`
+		err = r.db.SelectContext(ctx, &reserved, query, code)
+	} else {
+		query := `SELECT id, domain_id, code, kind, reason, created_by, created_at FROM reserved_codes
+				  WHERE (domain_id IS NULL OR domain_id = ?) AND code = ?`
+		err = r.db.SelectContext(ctx, &reserved, query, *domainID, code)
+	}
+	if err != nil {
+		logger.Error(ctx, "reserved-code-repo: failed to match reserved code",
+			zap.String("code", code),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return reserved, nil
+}
+
+func (r *ReservedCodeRepositoryImpl) Delete(ctx context.Context, id uint64) error {
+	query := `DELETE FROM reserved_codes WHERE id = ?`
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		logger.Error(ctx, "reserved-code-repo: failed to delete reserved code",
+			zap.Uint64("id", id),
+			zap.Error(err),
+		)
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		logger.Error(ctx, "reserved-code-repo: failed to get rows affected on delete",
+			zap.Uint64("id", id),
+			zap.Error(err),
+		)
+		return err
+	}
+	if rows == 0 {
+		return ErrReservedCodeNotFound
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+var ErrSessionNotFound = errors.New("session not found")
+
+// Compile-time check: SessionRepositoryImpl implements SessionRepository
+var _ SessionRepository = (*SessionRepositoryImpl)(nil)
+
+const sessionColumns = `id, user_id, refresh_hash, previous_hash, user_agent, ip_address,
+			  created_at, last_used_at, expires_at, revoked_at`
+
+type SessionRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+func NewSessionRepository(db *sqlx.DB) *SessionRepositoryImpl {
+	return &SessionRepositoryImpl{db: db}
+}
+
+func (r *SessionRepositoryImpl) Create(ctx context.Context, session *model.Session) error {
+	query := `INSERT INTO sessions (user_id, refresh_hash, user_agent, ip_address, expires_at) VALUES (?, ?, ?, ?, ?)`
+	result, err := r.db.ExecContext(ctx, query, session.UserID, session.RefreshHash, session.UserAgent, session.IPAddress, session.ExpiresAt)
+	if err != nil {
+		logger.Error(ctx, "session-repo: failed to create session",
+			zap.Uint64("user_id", session.UserID),
+			zap.Error(err),
+		)
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error(ctx, "session-repo: failed to get last insert ID",
+			zap.Error(err),
+		)
+		return err
+	}
+	session.ID = uint64(id)
+	return nil
+}
+
+func (r *SessionRepositoryImpl) GetByID(ctx context.Context, id uint64) (*model.Session, error) {
+	var session model.Session
+	query := `SELECT ` + sessionColumns + ` FROM sessions WHERE id = ?`
+	err := r.db.GetContext(ctx, &session, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSessionNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, "session-repo: failed to get session by ID",
+			zap.Uint64("id", id),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *SessionRepositoryImpl) GetByRefreshHash(ctx context.Context, refreshHash string) (*model.Session, error) {
+	var session model.Session
+	query := `SELECT ` + sessionColumns + ` FROM sessions WHERE refresh_hash = ? OR previous_hash = ? LIMIT 1`
+	err := r.db.GetContext(ctx, &session, query, refreshHash, refreshHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSessionNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, "session-repo: failed to get session by refresh hash",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *SessionRepositoryImpl) Rotate(ctx context.Context, id uint64, oldHash, newHash string, expiresAt time.Time, userAgent, ipAddress string) error {
+	query := `UPDATE sessions
+			  SET previous_hash = refresh_hash, refresh_hash = ?, expires_at = ?,
+			      user_agent = ?, ip_address = ?, last_used_at = NOW()
+			  WHERE id = ? AND refresh_hash = ? AND revoked_at IS NULL`
+	result, err := r.db.ExecContext(ctx, query, newHash, expiresAt, userAgent, ipAddress, id, oldHash)
+	if err != nil {
+		logger.Error(ctx, "session-repo: failed to rotate refresh token",
+			zap.Uint64("id", id),
+			zap.Error(err),
+		)
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		logger.Error(ctx, "session-repo: failed to get rows affected on rotate",
+			zap.Uint64("id", id),
+			zap.Error(err),
+		)
+		return err
+	}
+	if rows == 0 {
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
+func (r *SessionRepositoryImpl) ListActiveByUserID(ctx context.Context, userID uint64) ([]model.Session, error) {
+	var sessions []model.Session
+	query := `SELECT ` + sessionColumns + ` FROM sessions
+			  WHERE user_id = ? AND revoked_at IS NULL AND expires_at > NOW()
+			  ORDER BY last_used_at DESC`
+	err := r.db.SelectContext(ctx, &sessions, query, userID)
+	if err != nil {
+		logger.Error(ctx, "session-repo: failed to list sessions",
+			zap.Uint64("user_id", userID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if sessions == nil {
+		sessions = []model.Session{}
+	}
+	return sessions, nil
+}
+
+func (r *SessionRepositoryImpl) Revoke(ctx context.Context, id uint64) error {
+	query := `UPDATE sessions SET revoked_at = NOW() WHERE id = ? AND revoked_at IS NULL`
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		logger.Error(ctx, "session-repo: failed to revoke session",
+			zap.Uint64("id", id),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
+
+func (r *SessionRepositoryImpl) RevokeByUserID(ctx context.Context, userID, exceptID uint64) error {
+	query := `UPDATE sessions SET revoked_at = NOW() WHERE user_id = ? AND id <> ? AND revoked_at IS NULL`
+	if _, err := r.db.ExecContext(ctx, query, userID, exceptID); err != nil {
+		logger.Error(ctx, "session-repo: failed to revoke sessions",
+			zap.Uint64("user_id", userID),
+			zap.Uint64("except_id", exceptID),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
@@ -53,7 +53,7 @@ func (r *UserRepositoryImpl) Create(ctx context.Context, user *model.User) error
 
 func (r *UserRepositoryImpl) GetByEmail(ctx context.Context, email string) (*model.User, error) {
 	var user model.User
-	query := `SELECT id, email, display_name, password_hash, created_at, updated_at FROM users WHERE email = ?`
+	query := `SELECT id, email, display_name, is_admin, password_hash, created_at, updated_at FROM users WHERE email = ?`
 	err := r.db.GetContext(ctx, &user, query, email)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, ErrUserNotFound
@@ -70,7 +70,7 @@ func (r *UserRepositoryImpl) GetByEmail(ctx context.Context, email string) (*mod
 
 func (r *UserRepositoryImpl) GetByID(ctx context.Context, id uint64) (*model.User, error) {
 	var user model.User
-	query := `SELECT id, email, display_name, password_hash, created_at, updated_at FROM users WHERE id = ?`
+	query := `SELECT id, email, display_name, is_admin, password_hash, created_at, updated_at FROM users WHERE id = ?`
 	err := r.db.GetContext(ctx, &user, query, id)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, ErrUserNotFound
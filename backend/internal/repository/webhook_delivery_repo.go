@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+var ErrDeliveryNotFound = errors.New("webhook delivery not found")
+
+// Compile-time check: WebhookDeliveryRepositoryImpl implements WebhookDeliveryRepository
+var _ WebhookDeliveryRepository = (*WebhookDeliveryRepositoryImpl)(nil)
+
+type WebhookDeliveryRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+func NewWebhookDeliveryRepository(db *sqlx.DB) *WebhookDeliveryRepositoryImpl {
+	return &WebhookDeliveryRepositoryImpl{db: db}
+}
+
+const deliveryColumns = `id, webhook_id, event, payload, status, attempts, next_attempt_at, response_status,
+			  last_error, delivered_at, created_at`
+
+func (r *WebhookDeliveryRepositoryImpl) Create(ctx context.Context, delivery *model.WebhookDelivery) error {
+	query := `INSERT INTO webhook_deliveries (webhook_id, event, payload, status, next_attempt_at) VALUES (?, ?, ?, ?, ?)`
+	result, err := r.db.ExecContext(ctx, query, delivery.WebhookID, delivery.Event, []byte(delivery.Payload),
+		delivery.Status, delivery.NextAttemptAt)
+	if err != nil {
+		logger.Error(ctx, "webhook-delivery-repo: failed to create delivery",
+			zap.Uint64("webhook_id", delivery.WebhookID),
+			zap.String("event", delivery.Event),
+			zap.Error(err),
+		)
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error(ctx, "webhook-delivery-repo: failed to get last insert ID",
+			zap.Error(err),
+		)
+		return err
+	}
+	delivery.ID = uint64(id)
+	return nil
+}
+
+func (r *WebhookDeliveryRepositoryImpl) GetByID(ctx context.Context, id uint64) (*model.WebhookDelivery, error) {
+	var delivery model.WebhookDelivery
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries WHERE id = ?`
+	err := r.db.GetContext(ctx, &delivery, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrDeliveryNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, "webhook-delivery-repo: failed to get delivery by ID",
+			zap.Uint64("id", id),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *WebhookDeliveryRepositoryImpl) ListByWebhook(ctx context.Context, webhookID uint64, limit int) ([]model.WebhookDelivery, error) {
+	var deliveries []model.WebhookDelivery
+	query := `SELECT ` + deliveryColumns + `
+			  FROM webhook_deliveries WHERE webhook_id = ? ORDER BY id DESC LIMIT ?`
+	err := r.db.SelectContext(ctx, &deliveries, query, webhookID, limit)
+	if err != nil {
+		logger.Error(ctx, "webhook-delivery-repo: failed to list deliveries",
+			zap.Uint64("webhook_id", webhookID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if deliveries == nil {
+		deliveries = []model.WebhookDelivery{}
+	}
+	return deliveries, nil
+}
+
+func (r *WebhookDeliveryRepositoryImpl) ListDue(ctx context.Context, now time.Time, limit int) ([]model.WebhookDelivery, error) {
+	var deliveries []model.WebhookDelivery
+	query := `SELECT ` + deliveryColumns + `
+			  FROM webhook_deliveries WHERE status = ? AND next_attempt_at <= ? ORDER BY next_attempt_at LIMIT ?`
+	err := r.db.SelectContext(ctx, &deliveries, query, model.DeliveryPending, now, limit)
+	if err != nil {
+		logger.Error(ctx, "webhook-delivery-repo: failed to list due deliveries",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *WebhookDeliveryRepositoryImpl) Claim(ctx context.Context, delivery *model.WebhookDelivery, leaseUntil time.Time) (bool, error) {
+	// Only the claimer that still sees the old attempt count wins the lease
+	query := `UPDATE webhook_deliveries SET attempts = attempts + 1, next_attempt_at = ?
+			  WHERE id = ? AND status = ? AND attempts = ?`
+	result, err := r.db.ExecContext(ctx, query, leaseUntil, delivery.ID, model.DeliveryPending, delivery.Attempts)
+	if err != nil {
+		logger.Error(ctx, "webhook-delivery-repo: failed to claim delivery",
+			zap.Uint64("id", delivery.ID),
+			zap.Error(err),
+		)
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		logger.Error(ctx, "webhook-delivery-repo: failed to get rows affected on claim",
+			zap.Error(err),
+		)
+		return false, err
+	}
+	if rows == 0 {
+		return false, nil
+	}
+	delivery.Attempts++
+	delivery.NextAttemptAt = leaseUntil
+	return true, nil
+}
+
+func (r *WebhookDeliveryRepositoryImpl) Update(ctx context.Context, delivery *model.WebhookDelivery) error {
+	query := `UPDATE webhook_deliveries SET status = ?, attempts = ?, next_attempt_at = ?, response_status = ?,
+			  last_error = ?, delivered_at = ? WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, delivery.Status, delivery.Attempts, delivery.NextAttemptAt,
+		delivery.ResponseStatus, delivery.LastError, delivery.DeliveredAt, delivery.ID)
+	if err != nil {
+		logger.Error(ctx, "webhook-delivery-repo: failed to update delivery",
+			zap.Uint64("id", delivery.ID),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
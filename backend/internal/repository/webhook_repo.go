@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+// Compile-time check: WebhookRepositoryImpl implements WebhookRepository
+var _ WebhookRepository = (*WebhookRepositoryImpl)(nil)
+
+type WebhookRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+func NewWebhookRepository(db *sqlx.DB) *WebhookRepositoryImpl {
+	return &WebhookRepositoryImpl{db: db}
+}
+
+const webhookColumns = `id, user_id, workspace_id, url, secret, events, is_active, created_at, updated_at`
+
+func (r *WebhookRepositoryImpl) Create(ctx context.Context, webhook *model.Webhook) error {
+	query := `INSERT INTO webhooks (user_id, workspace_id, url, secret, events, is_active) VALUES (?, ?, ?, ?, ?, ?)`
+	result, err := r.db.ExecContext(ctx, query, webhook.UserID, webhook.WorkspaceID, webhook.URL, webhook.Secret,
+		webhook.Events, webhook.IsActive)
+	if err != nil {
+		logger.Error(ctx, "webhook-repo: failed to create webhook",
+			zap.Uint64("user_id", webhook.UserID),
+			zap.Error(err),
+		)
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error(ctx, "webhook-repo: failed to get last insert ID",
+			zap.Error(err),
+		)
+		return err
+	}
+	webhook.ID = uint64(id)
+	return nil
+}
+
+func (r *WebhookRepositoryImpl) GetByID(ctx context.Context, id uint64) (*model.Webhook, error) {
+	var webhook model.Webhook
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE id = ?`
+	err := r.db.GetContext(ctx, &webhook, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrWebhookNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, "webhook-repo: failed to get webhook by ID",
+			zap.Uint64("id", id),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *WebhookRepositoryImpl) ListByUser(ctx context.Context, userID, workspaceID uint64) ([]model.Webhook, error) {
+	var webhooks []model.Webhook
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE user_id = ? AND workspace_id = ? ORDER BY id`
+	err := r.db.SelectContext(ctx, &webhooks, query, userID, workspaceID)
+	if err != nil {
+		logger.Error(ctx, "webhook-repo: failed to list webhooks",
+			zap.Uint64("user_id", userID),
+			zap.Uint64("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if webhooks == nil {
+		webhooks = []model.Webhook{}
+	}
+	return webhooks, nil
+}
+
+func (r *WebhookRepositoryImpl) ListSubscribed(ctx context.Context, workspaceID uint64, event string) ([]model.Webhook, error) {
+	var webhooks []model.Webhook
+	// Joining members drops the webhooks of users who have left the workspace
+	query := `SELECT w.id, w.user_id, w.workspace_id, w.url, w.secret, w.events, w.is_active, w.created_at, w.updated_at
+			  FROM webhooks w
+			  JOIN workspace_members m ON m.workspace_id = w.workspace_id AND m.user_id = w.user_id
+			  WHERE w.workspace_id = ? AND w.is_active = TRUE AND JSON_CONTAINS(w.events, JSON_QUOTE(?))`
+	err := r.db.SelectContext(ctx, &webhooks, query, workspaceID, event)
+	if err != nil {
+		logger.Error(ctx, "webhook-repo: failed to list subscribed webhooks",
+			zap.Uint64("workspace_id", workspaceID),
+			zap.String("event", event),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (r *WebhookRepositoryImpl) Update(ctx context.Context, webhook *model.Webhook) error {
+	query := `UPDATE webhooks SET url = ?, events = ?, is_active = ?, updated_at = NOW() WHERE id = ?`
+	result, err := r.db.ExecContext(ctx, query, webhook.URL, webhook.Events, webhook.IsActive, webhook.ID)
+	if err != nil {
+		logger.Error(ctx, "webhook-repo: failed to update webhook",
+			zap.Uint64("id", webhook.ID),
+			zap.Error(err),
+		)
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		logger.Error(ctx, "webhook-repo: failed to get rows affected on update",
+			zap.Error(err),
+		)
+		return err
+	}
+	if rows == 0 {
+		return ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (r *WebhookRepositoryImpl) Delete(ctx context.Context, id uint64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webhooks WHERE id = ?`, id)
+	if err != nil {
+		logger.Error(ctx, "webhook-repo: failed to delete webhook",
+			zap.Uint64("id", id),
+			zap.Error(err),
+		)
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		logger.Error(ctx, "webhook-repo: failed to get rows affected on delete",
+			zap.Error(err),
+		)
+		return err
+	}
+	if rows == 0 {
+		return ErrWebhookNotFound
+	}
+	return nil
+}
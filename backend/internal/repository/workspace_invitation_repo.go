@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+var ErrInvitationNotFound = errors.New("invitation not found")
+
+// Compile-time check: WorkspaceInvitationRepositoryImpl implements WorkspaceInvitationRepository
+var _ WorkspaceInvitationRepository = (*WorkspaceInvitationRepositoryImpl)(nil)
+
+const invitationColumns = `id, workspace_id, email, role, token_hash, invited_by, expires_at, accepted_at, created_at`
+
+type WorkspaceInvitationRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+func NewWorkspaceInvitationRepository(db *sqlx.DB) *WorkspaceInvitationRepositoryImpl {
+	return &WorkspaceInvitationRepositoryImpl{db: db}
+}
+
+func (r *WorkspaceInvitationRepositoryImpl) Create(ctx context.Context, invitation *model.WorkspaceInvitation) error {
+	query := `INSERT INTO workspace_invitations (workspace_id, email, role, token_hash, invited_by, expires_at)
+			  VALUES (?, ?, ?, ?, ?, ?)`
+	result, err := r.db.ExecContext(ctx, query, invitation.WorkspaceID, invitation.Email, invitation.Role,
+		invitation.TokenHash, invitation.InvitedBy, invitation.ExpiresAt)
+	if err != nil {
+		logger.Error(ctx, "invitation-repo: failed to create invitation",
+			zap.Uint64("workspace_id", invitation.WorkspaceID),
+			zap.Error(err),
+		)
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error(ctx, "invitation-repo: failed to get last insert ID",
+			zap.Error(err),
+		)
+		return err
+	}
+	invitation.ID = uint64(id)
+	return nil
+}
+
+func (r *WorkspaceInvitationRepositoryImpl) GetByID(ctx context.Context, id uint64) (*model.WorkspaceInvitation, error) {
+	var invitation model.WorkspaceInvitation
+	err := r.db.GetContext(ctx, &invitation, `SELECT `+invitationColumns+` FROM workspace_invitations WHERE id = ?`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrInvitationNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, "invitation-repo: failed to get invitation by ID",
+			zap.Uint64("invitation_id", id),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (r *WorkspaceInvitationRepositoryImpl) GetByTokenHash(ctx context.Context, tokenHash string) (*model.WorkspaceInvitation, error) {
+	var invitation model.WorkspaceInvitation
+	err := r.db.GetContext(ctx, &invitation, `SELECT `+invitationColumns+` FROM workspace_invitations WHERE token_hash = ?`, tokenHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrInvitationNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, "invitation-repo: failed to get invitation by token",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (r *WorkspaceInvitationRepositoryImpl) ListPendingByWorkspaceID(ctx context.Context, workspaceID uint64) ([]model.WorkspaceInvitation, error) {
+	var invitations []model.WorkspaceInvitation
+	query := `SELECT ` + invitationColumns + ` FROM workspace_invitations
+			  WHERE workspace_id = ? AND accepted_at IS NULL AND expires_at > NOW() ORDER BY created_at DESC`
+	err := r.db.SelectContext(ctx, &invitations, query, workspaceID)
+	if err != nil {
+		logger.Error(ctx, "invitation-repo: failed to list invitations",
+			zap.Uint64("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if invitations == nil {
+		invitations = []model.WorkspaceInvitation{}
+	}
+	return invitations, nil
+}
+
+func (r *WorkspaceInvitationRepositoryImpl) Accept(ctx context.Context, id, userID uint64) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error(ctx, "invitation-repo: failed to begin transaction",
+			zap.Error(err),
+		)
+		return err
+	}
+	defer tx.Rollback()
+
+	var invitation model.WorkspaceInvitation
+	err = tx.GetContext(ctx, &invitation, `SELECT `+invitationColumns+` FROM workspace_invitations
+			  WHERE id = ? AND accepted_at IS NULL FOR UPDATE`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvitationNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, "invitation-repo: failed to lock invitation",
+			zap.Uint64("invitation_id", id),
+			zap.Error(err),
+		)
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE workspace_invitations SET accepted_at = NOW() WHERE id = ?`, id); err != nil {
+		logger.Error(ctx, "invitation-repo: failed to mark invitation accepted",
+			zap.Uint64("invitation_id", id),
+			zap.Error(err),
+		)
+		return err
+	}
+	// Existing members keep their role
+	if _, err := tx.ExecContext(ctx, `INSERT IGNORE INTO workspace_members (workspace_id, user_id, role) VALUES (?, ?, ?)`,
+		invitation.WorkspaceID, userID, invitation.Role); err != nil {
+		logger.Error(ctx, "invitation-repo: failed to add member from invitation",
+			zap.Uint64("invitation_id", id),
+			zap.Uint64("user_id", userID),
+			zap.Error(err),
+		)
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		logger.Error(ctx, "invitation-repo: failed to commit invitation",
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
+
+func (r *WorkspaceInvitationRepositoryImpl) Delete(ctx context.Context, id uint64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM workspace_invitations WHERE id = ?`, id)
+	if err != nil {
+		logger.Error(ctx, "invitation-repo: failed to delete invitation",
+			zap.Uint64("invitation_id", id),
+			zap.Error(err),
+		)
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrInvitationNotFound
+	}
+	return nil
+}
@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrWorkspaceNotFound       = errors.New("workspace not found")
+	ErrPersonalWorkspaceExists = errors.New("user already has a personal workspace")
+	ErrWorkspaceMemberNotFound = errors.New("workspace member not found")
+	ErrWorkspaceMemberExists   = errors.New("user is already a member of the workspace")
+)
+
+// Compile-time check: WorkspaceRepositoryImpl implements WorkspaceRepository
+var _ WorkspaceRepository = (*WorkspaceRepositoryImpl)(nil)
+
+const workspaceColumns = `w.id, w.name, w.personal_user_id, w.created_at, w.updated_at`
+
+const memberColumns = `m.workspace_id, m.user_id, m.role, u.email, u.display_name, m.created_at`
+
+type WorkspaceRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+func NewWorkspaceRepository(db *sqlx.DB) *WorkspaceRepositoryImpl {
+	return &WorkspaceRepositoryImpl{db: db}
+}
+
+func (r *WorkspaceRepositoryImpl) Create(ctx context.Context, workspace *model.Workspace, ownerID uint64) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error(ctx, "workspace-repo: failed to begin transaction",
+			zap.Error(err),
+		)
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `INSERT INTO workspaces (name, personal_user_id) VALUES (?, ?)`,
+		workspace.Name, workspace.PersonalUserID)
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return ErrPersonalWorkspaceExists
+		}
+		logger.Error(ctx, "workspace-repo: failed to create workspace",
+			zap.Uint64("owner_id", ownerID),
+			zap.Error(err),
+		)
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error(ctx, "workspace-repo: failed to get last insert ID",
+			zap.Error(err),
+		)
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO workspace_members (workspace_id, user_id, role) VALUES (?, ?, ?)`,
+		id, ownerID, model.RoleOwner); err != nil {
+		logger.Error(ctx, "workspace-repo: failed to add workspace owner",
+			zap.Int64("workspace_id", id),
+			zap.Uint64("owner_id", ownerID),
+			zap.Error(err),
+		)
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		logger.Error(ctx, "workspace-repo: failed to commit workspace",
+			zap.Error(err),
+		)
+		return err
+	}
+
+	created, err := r.GetByID(ctx, uint64(id))
+	if err != nil {
+		return err
+	}
+	*workspace = *created
+	return nil
+}
+
+func (r *WorkspaceRepositoryImpl) GetByID(ctx context.Context, id uint64) (*model.Workspace, error) {
+	var workspace model.Workspace
+	query := `SELECT ` + workspaceColumns + ` FROM workspaces w WHERE w.id = ?`
+	err := r.db.GetContext(ctx, &workspace, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrWorkspaceNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, "workspace-repo: failed to get workspace by ID",
+			zap.Uint64("workspace_id", id),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return &workspace, nil
+}
+
+func (r *WorkspaceRepositoryImpl) GetPersonal(ctx context.Context, userID uint64) (*model.Workspace, error) {
+	var workspace model.Workspace
+	query := `SELECT ` + workspaceColumns + ` FROM workspaces w WHERE w.personal_user_id = ?`
+	err := r.db.GetContext(ctx, &workspace, query, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrWorkspaceNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, "workspace-repo: failed to get personal workspace",
+			zap.Uint64("user_id", userID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return &workspace, nil
+}
+
+func (r *WorkspaceRepositoryImpl) ListByUserID(ctx context.Context, userID uint64) ([]model.Workspace, error) {
+	var workspaces []model.Workspace
+	query := `SELECT ` + workspaceColumns + `, m.role FROM workspaces w
+			  JOIN workspace_members m ON m.workspace_id = w.id
+			  WHERE m.user_id = ? ORDER BY w.personal_user_id IS NULL, w.name`
+	err := r.db.SelectContext(ctx, &workspaces, query, userID)
+	if err != nil {
+		logger.Error(ctx, "workspace-repo: failed to list workspaces",
+			zap.Uint64("user_id", userID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if workspaces == nil {
+		workspaces = []model.Workspace{}
+	}
+	return workspaces, nil
+}
+
+func (r *WorkspaceRepositoryImpl) UpdateName(ctx context.Context, id uint64, name string) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE workspaces SET name = ?, updated_at = NOW() WHERE id = ?`, name, id)
+	if err != nil {
+		logger.Error(ctx, "workspace-repo: failed to rename workspace",
+			zap.Uint64("workspace_id", id),
+			zap.Error(err),
+		)
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrWorkspaceNotFound
+	}
+	return nil
+}
+
+func (r *WorkspaceRepositoryImpl) GetMember(ctx context.Context, workspaceID, userID uint64) (*model.WorkspaceMember, error) {
+	var member model.WorkspaceMember
+	query := `SELECT ` + memberColumns + ` FROM workspace_members m JOIN users u ON u.id = m.user_id
+			  WHERE m.workspace_id = ? AND m.user_id = ?`
+	err := r.db.GetContext(ctx, &member, query, workspaceID, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrWorkspaceMemberNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, "workspace-repo: failed to get workspace member",
+			zap.Uint64("workspace_id", workspaceID),
+			zap.Uint64("user_id", userID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (r *WorkspaceRepositoryImpl) ListMembers(ctx context.Context, workspaceID uint64) ([]model.WorkspaceMember, error) {
+	var members []model.WorkspaceMember
+	query := `SELECT ` + memberColumns + ` FROM workspace_members m JOIN users u ON u.id = m.user_id
+			  WHERE m.workspace_id = ? ORDER BY m.created_at`
+	err := r.db.SelectContext(ctx, &members, query, workspaceID)
+	if err != nil {
+		logger.Error(ctx, "workspace-repo: failed to list workspace members",
+			zap.Uint64("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if members == nil {
+		members = []model.WorkspaceMember{}
+	}
+	return members, nil
+}
+
+func (r *WorkspaceRepositoryImpl) AddMember(ctx context.Context, workspaceID, userID uint64, role string) error {
+	_, err := r.db.ExecContext(ctx, `INSERT INTO workspace_members (workspace_id, user_id, role) VALUES (?, ?, ?)`,
+		workspaceID, userID, role)
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return ErrWorkspaceMemberExists
+		}
+		logger.Error(ctx, "workspace-repo: failed to add workspace member",
+			zap.Uint64("workspace_id", workspaceID),
+			zap.Uint64("user_id", userID),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
+
+func (r *WorkspaceRepositoryImpl) UpdateMemberRole(ctx context.Context, workspaceID, userID uint64, role string) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE workspace_members SET role = ? WHERE workspace_id = ? AND user_id = ?`,
+		role, workspaceID, userID)
+	if err != nil {
+		logger.Error(ctx, "workspace-repo: failed to update member role",
+			zap.Uint64("workspace_id", workspaceID),
+			zap.Uint64("user_id", userID),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
+
+func (r *WorkspaceRepositoryImpl) RemoveMember(ctx context.Context, workspaceID, userID uint64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM workspace_members WHERE workspace_id = ? AND user_id = ?`,
+		workspaceID, userID)
+	if err != nil {
+		logger.Error(ctx, "workspace-repo: failed to remove workspace member",
+			zap.Uint64("workspace_id", workspaceID),
+			zap.Uint64("user_id", userID),
+			zap.Error(err),
+		)
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrWorkspaceMemberNotFound
+	}
+	return nil
+}
+
+func (r *WorkspaceRepositoryImpl) CountOwners(ctx context.Context, workspaceID uint64) (int, error) {
+	var count int
+	err := r.db.GetContext(ctx, &count, `SELECT COUNT(*) FROM workspace_members WHERE workspace_id = ? AND role = ?`,
+		workspaceID, model.RoleOwner)
+	if err != nil {
+		logger.Error(ctx, "workspace-repo: failed to count workspace owners",
+			zap.Uint64("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		return 0, err
+	}
+	return count, nil
+}
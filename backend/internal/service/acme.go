@@ -0,0 +1,221 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/crypto/acme"
+)
+
+const (
+	acmeChallengeKeyPrefix = "acme:challenge:"
+	// acmeChallengeTTL bounds how long a key authorization is served when
+	// the order is abandoned before it is cleaned up
+	acmeChallengeTTL = 1 * time.Hour
+)
+
+var ErrChallengeNotFound = errors.New("acme challenge not found")
+
+// ACMEClient obtains certificates from an ACME certificate authority.
+type ACMEClient interface {
+	// Obtain proves control of domain with HTTP-01 challenges published in
+	// challenges and returns the PEM certificate chain for key.
+	Obtain(ctx context.Context, domain string, key crypto.Signer, challenges ChallengeStore) ([]byte, error)
+}
+
+// ChallengeStore holds HTTP-01 key authorizations until the certificate
+// authority fetches them from the redirect server. Every redirect server
+// instance must see the same store.
+type ChallengeStore interface {
+	Put(ctx context.Context, token, keyAuth string) error
+	// Get returns ErrChallengeNotFound for unknown tokens
+	Get(ctx context.Context, token string) (string, error)
+	Delete(ctx context.Context, token string) error
+}
+
+// RedisChallengeStore keeps key authorizations in Redis.
+type RedisChallengeStore struct {
+	rdb *redis.Client
+}
+
+func NewRedisChallengeStore(rdb *redis.Client) *RedisChallengeStore {
+	return &RedisChallengeStore{rdb: rdb}
+}
+
+func (s *RedisChallengeStore) Put(ctx context.Context, token, keyAuth string) error {
+	return s.rdb.Set(ctx, acmeChallengeKeyPrefix+token, keyAuth, acmeChallengeTTL).Err()
+}
+
+func (s *RedisChallengeStore) Get(ctx context.Context, token string) (string, error) {
+	keyAuth, err := s.rdb.Get(ctx, acmeChallengeKeyPrefix+token).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", ErrChallengeNotFound
+	}
+	return keyAuth, err
+}
+
+func (s *RedisChallengeStore) Delete(ctx context.Context, token string) error {
+	return s.rdb.Del(ctx, acmeChallengeKeyPrefix+token).Err()
+}
+
+// acmeClient talks RFC 8555 to a certificate authority such as Let's
+// Encrypt. The account is registered on first use.
+type acmeClient struct {
+	directoryURL string
+	email        string
+	// accountKeyPath keeps the account key across restarts; empty means a
+	// new account for every process
+	accountKeyPath string
+
+	mu     sync.Mutex
+	client *acme.Client
+}
+
+// NewACMEClient returns an ACMEClient for the directory at directoryURL.
+func NewACMEClient(directoryURL, email, accountKeyPath string) ACMEClient {
+	return &acmeClient{directoryURL: directoryURL, email: email, accountKeyPath: accountKeyPath}
+}
+
+func (c *acmeClient) account(ctx context.Context) (*acme.Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.client != nil {
+		return c.client, nil
+	}
+
+	key, err := loadOrCreateAccountKey(c.accountKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	client := &acme.Client{Key: key, DirectoryURL: c.directoryURL}
+	var contact []string
+	if c.email != "" {
+		contact = []string{"mailto:" + c.email}
+	}
+	if _, err := client.Register(ctx, &acme.Account{Contact: contact}, acme.AcceptTOS); err != nil &&
+		!errors.Is(err, acme.ErrAccountAlreadyExists) {
+		return nil, fmt.Errorf("register acme account: %w", err)
+	}
+	c.client = client
+	return client, nil
+}
+
+func (c *acmeClient) Obtain(ctx context.Context, domain string, key crypto.Signer, challenges ChallengeStore) ([]byte, error) {
+	client, err := c.account(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := client.AuthorizeOrder(ctx, acme.DomainIDs(domain))
+	if err != nil {
+		return nil, fmt.Errorf("create order: %w", err)
+	}
+	for _, authzURL := range order.AuthzURLs {
+		if err := c.authorize(ctx, client, authzURL, challenges); err != nil {
+			return nil, err
+		}
+	}
+	order, err = client.WaitOrder(ctx, order.URI)
+	if err != nil {
+		return nil, fmt.Errorf("wait for order: %w", err)
+	}
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{DNSNames: []string{domain}}, key)
+	if err != nil {
+		return nil, err
+	}
+	chain, _, err := client.CreateOrderCert(ctx, order.FinalizeURL, csr, true)
+	if err != nil {
+		return nil, fmt.Errorf("finalize order: %w", err)
+	}
+	var certPEM bytes.Buffer
+	for _, der := range chain {
+		if err := pem.Encode(&certPEM, &pem.Block{Type: "CERTIFICATE", Bytes: der}); err != nil {
+			return nil, err
+		}
+	}
+	return certPEM.Bytes(), nil
+}
+
+// authorize completes the HTTP-01 challenge of one authorization.
+func (c *acmeClient) authorize(ctx context.Context, client *acme.Client, authzURL string, challenges ChallengeStore) error {
+	authz, err := client.GetAuthorization(ctx, authzURL)
+	if err != nil {
+		return fmt.Errorf("get authorization: %w", err)
+	}
+	if authz.Status == acme.StatusValid {
+		return nil
+	}
+
+	var challenge *acme.Challenge
+	for _, ch := range authz.Challenges {
+		if ch.Type == "http-01" {
+			challenge = ch
+			break
+		}
+	}
+	if challenge == nil {
+		return errors.New("certificate authority offered no http-01 challenge")
+	}
+	keyAuth, err := client.HTTP01ChallengeResponse(challenge.Token)
+	if err != nil {
+		return err
+	}
+	if err := challenges.Put(ctx, challenge.Token, keyAuth); err != nil {
+		return err
+	}
+	defer challenges.Delete(context.WithoutCancel(ctx), challenge.Token)
+
+	if _, err := client.Accept(ctx, challenge); err != nil {
+		return fmt.Errorf("accept challenge: %w", err)
+	}
+	if _, err := client.WaitAuthorization(ctx, authz.URI); err != nil {
+		return fmt.Errorf("wait for authorization: %w", err)
+	}
+	return nil
+}
+
+// loadOrCreateAccountKey reads the PEM EC account key at path, creating it
+// when the file does not exist yet.
+func loadOrCreateAccountKey(path string) (crypto.Signer, error) {
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err == nil {
+			block, _ := pem.Decode(data)
+			if block == nil {
+				return nil, fmt.Errorf("acme account key %s is not PEM", path)
+			}
+			return x509.ParseECPrivateKey(block.Bytes)
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	if path != "" {
+		der, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+			return nil, fmt.Errorf("save acme account key: %w", err)
+		}
+	}
+	return key, nil
+}
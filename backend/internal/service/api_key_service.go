@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/internal/repository"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	// APIKeyPrefix starts every API key, telling keys apart from JWTs
+	APIKeyPrefix = "usk_"
+	// apiKeySecretLen is the number of random base62 characters after the prefix
+	apiKeySecretLen = 40
+	// apiKeyDisplayLen is how much of a key is kept in clear to identify it
+	apiKeyDisplayLen = len(APIKeyPrefix) + 8
+	// apiKeyLastUsedInterval limits how often last-used times are written
+	apiKeyLastUsedInterval = time.Minute
+)
+
+var (
+	ErrAPIKeyNotFound  = errors.New("api key not found")
+	ErrInvalidAPIKey   = errors.New("invalid or expired api key")
+	ErrUnknownScope    = errors.New("unknown api key scope")
+	ErrAPIKeyExpiresAt = errors.New("api key expiry must be in the future")
+)
+
+// Compile-time check: APIKeyServiceImpl implements APIKeyService
+var _ APIKeyService = (*APIKeyServiceImpl)(nil)
+
+type APIKeyServiceImpl struct {
+	apiKeyRepo repository.APIKeyRepository
+}
+
+func NewAPIKeyService(apiKeyRepo repository.APIKeyRepository) *APIKeyServiceImpl {
+	return &APIKeyServiceImpl{apiKeyRepo: apiKeyRepo}
+}
+
+type CreateAPIKeyInput struct {
+	Name      string     `json:"name" binding:"required,max=100"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// CreatedAPIKey carries the plaintext key, which is only available at creation.
+type CreatedAPIKey struct {
+	APIKey *model.APIKey `json:"api_key"`
+	Key    string        `json:"key"`
+}
+
+// IsAPIKey reports whether a bearer token looks like an API key rather than a JWT.
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, APIKeyPrefix)
+}
+
+func (s *APIKeyServiceImpl) Create(ctx context.Context, userID uint64, input CreateAPIKeyInput) (*CreatedAPIKey, error) {
+	for _, scope := range input.Scopes {
+		if !model.IsScope(scope) {
+			return nil, ErrUnknownScope
+		}
+	}
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		return nil, ErrAPIKeyExpiresAt
+	}
+
+	secret, err := (&RandomCodeGenerator{Alphabet: alphabet}).Generate(ctx, nil, apiKeySecretLen)
+	if err != nil {
+		logger.Error(ctx, "api-key-service: failed to generate key",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	plaintext := APIKeyPrefix + secret
+
+	key := &model.APIKey{
+		UserID:    userID,
+		Name:      input.Name,
+		Prefix:    plaintext[:apiKeyDisplayLen],
+		KeyHash:   hashSecret(plaintext),
+		Scopes:    model.APIKeyScopes(input.Scopes),
+		ExpiresAt: input.ExpiresAt,
+	}
+	if err := s.apiKeyRepo.Create(ctx, key); err != nil {
+		logger.Error(ctx, "api-key-service: failed to create api key",
+			zap.Uint64("user_id", userID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return &CreatedAPIKey{APIKey: key, Key: plaintext}, nil
+}
+
+func (s *APIKeyServiceImpl) List(ctx context.Context, userID uint64) ([]model.APIKey, error) {
+	return s.apiKeyRepo.ListByUserID(ctx, userID)
+}
+
+func (s *APIKeyServiceImpl) Delete(ctx context.Context, userID, keyID uint64) error {
+	key, err := s.apiKeyRepo.GetByID(ctx, keyID)
+	if errors.Is(err, repository.ErrAPIKeyNotFound) {
+		return ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if key.UserID != userID {
+		return ErrAPIKeyNotFound
+	}
+
+	err = s.apiKeyRepo.Delete(ctx, keyID)
+	if errors.Is(err, repository.ErrAPIKeyNotFound) {
+		return ErrAPIKeyNotFound
+	}
+	return err
+}
+
+func (s *APIKeyServiceImpl) Authenticate(ctx context.Context, plaintext string) (*model.APIKey, error) {
+	key, err := s.apiKeyRepo.GetByHash(ctx, hashSecret(plaintext))
+	if errors.Is(err, repository.ErrAPIKeyNotFound) {
+		return nil, ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if key.ExpiresAt != nil && !key.ExpiresAt.After(now) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apiKeyLastUsedInterval {
+		// Failing to record usage should not fail the request
+		if err := s.apiKeyRepo.UpdateLastUsedAt(ctx, key.ID); err != nil {
+			logger.Warn(ctx, "api-key-service: failed to record key usage",
+				zap.Uint64("api_key_id", key.ID),
+				zap.Error(err),
+			)
+		}
+	}
+	return key, nil
+}
+
+// hashSecret returns the hex SHA-256 digest an API key or refresh token is
+// stored under. Both are long and random, so a fast hash is enough.
+func hashSecret(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,80 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/internal/repository"
+	"github.com/SeaCodeBase/urlshortener/internal/repository/mocks"
+	"github.com/SeaCodeBase/urlshortener/internal/service"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAPIKeyService_Create_StoresOnlyHash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAPIKeyRepository(ctrl)
+	svc := service.NewAPIKeyService(mockRepo)
+
+	var stored *model.APIKey
+	mockRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key *model.APIKey) error {
+			stored = key
+			return nil
+		})
+
+	created, err := svc.Create(context.Background(), 1, service.CreateAPIKeyInput{Name: "ci", Scopes: []string{model.ScopeLinksWrite}})
+	assert.NoError(t, err)
+	assert.True(t, service.IsAPIKey(created.Key))
+	assert.True(t, strings.HasPrefix(created.Key, stored.Prefix))
+	assert.Len(t, stored.KeyHash, 64)
+
+	// The stored hash authenticates the plaintext key
+	mockRepo.EXPECT().GetByHash(gomock.Any(), stored.KeyHash).Return(stored, nil)
+	mockRepo.EXPECT().UpdateLastUsedAt(gomock.Any(), stored.ID).Return(nil)
+	key, err := svc.Authenticate(context.Background(), created.Key)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), key.UserID)
+}
+
+func TestAPIKeyService_Create_RejectsUnknownScope(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := service.NewAPIKeyService(mocks.NewMockAPIKeyRepository(ctrl))
+
+	_, err := svc.Create(context.Background(), 1, service.CreateAPIKeyInput{Name: "ci", Scopes: []string{"links:delete"}})
+	assert.ErrorIs(t, err, service.ErrUnknownScope)
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAPIKeyRepository(ctrl)
+	svc := service.NewAPIKeyService(mockRepo)
+	ctx := context.Background()
+
+	// Unknown key
+	mockRepo.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(nil, repository.ErrAPIKeyNotFound)
+	_, err := svc.Authenticate(ctx, "usk_unknown")
+	assert.ErrorIs(t, err, service.ErrInvalidAPIKey)
+
+	// Expired key
+	expired := time.Now().Add(-time.Hour)
+	mockRepo.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(&model.APIKey{ID: 1, ExpiresAt: &expired}, nil)
+	_, err = svc.Authenticate(ctx, "usk_expired")
+	assert.ErrorIs(t, err, service.ErrInvalidAPIKey)
+
+	// Recently used keys are not written again
+	recent := time.Now().Add(-10 * time.Second)
+	mockRepo.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(&model.APIKey{ID: 2, LastUsedAt: &recent}, nil)
+	_, err = svc.Authenticate(ctx, "usk_recent")
+	assert.NoError(t, err)
+}
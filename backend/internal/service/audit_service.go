@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/internal/repository"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultAuditPageSize = 50
+	// MaxAuditPageSize is the largest page List returns; exports page through
+	// the log at this size
+	MaxAuditPageSize = 500
+)
+
+// AuditEvent describes something to record in the audit log. Zero IDs are
+// stored as NULL. Before and After are the target's state around the change;
+// only the JSON fields that differ are kept.
+type AuditEvent struct {
+	WorkspaceID uint64
+	ActorUserID uint64
+	Action      string
+	TargetType  string
+	TargetID    uint64
+	Before      any
+	After       any
+}
+
+// AuditQuery filters an audit log listing. Zero fields do not filter.
+type AuditQuery struct {
+	ActorUserID uint64
+	// Action matches exactly, or by prefix when it ends in "." (e.g. "link.")
+	Action     string
+	TargetType string
+	TargetID   uint64
+	Since      *time.Time
+	Until      *time.Time
+	// BeforeID returns the page of entries older than this ID
+	BeforeID uint64
+	Limit    int
+}
+
+type requestMetaKey struct{}
+
+// WithRequestMeta stores the client's address and user agent in ctx, where
+// audit entries recorded for the request pick them up.
+func WithRequestMeta(ctx context.Context, meta SessionMeta) context.Context {
+	return context.WithValue(ctx, requestMetaKey{}, meta)
+}
+
+// RequestMetaFrom returns the client details stored by WithRequestMeta.
+func RequestMetaFrom(ctx context.Context) SessionMeta {
+	meta, _ := ctx.Value(requestMetaKey{}).(SessionMeta)
+	return meta
+}
+
+// Compile-time check: AuditServiceImpl implements AuditService
+var _ AuditService = (*AuditServiceImpl)(nil)
+
+type AuditServiceImpl struct {
+	auditRepo     repository.AuditRepository
+	workspaceRepo repository.WorkspaceRepository
+}
+
+func NewAuditService(auditRepo repository.AuditRepository, workspaceRepo repository.WorkspaceRepository) *AuditServiceImpl {
+	return &AuditServiceImpl{
+		auditRepo:     auditRepo,
+		workspaceRepo: workspaceRepo,
+	}
+}
+
+// Record appends an event to the audit log. Failures are logged rather than
+// returned so that auditing never undoes the operation it describes.
+func (s *AuditServiceImpl) Record(ctx context.Context, event AuditEvent) {
+	meta := RequestMetaFrom(ctx)
+	entry := &model.AuditEntry{
+		WorkspaceID: optionalID(event.WorkspaceID),
+		ActorUserID: optionalID(event.ActorUserID),
+		Action:      event.Action,
+		TargetType:  event.TargetType,
+		TargetID:    optionalID(event.TargetID),
+		Changes:     model.DiffOf(event.Before, event.After),
+		IPAddress:   meta.IPAddress,
+		UserAgent:   truncateUserAgent(meta.UserAgent),
+	}
+	if err := s.auditRepo.Create(ctx, entry); err != nil {
+		logger.Error(ctx, "audit-service: failed to record event",
+			zap.String("action", event.Action),
+			zap.Uint64("actor_user_id", event.ActorUserID),
+			zap.Uint64("target_id", event.TargetID),
+			zap.Error(err),
+		)
+	}
+}
+
+// List returns the audit log of the actor's workspace, which requires the
+// admin role. A personal workspace also shows its owner's account events,
+// such as logins and passkey changes.
+func (s *AuditServiceImpl) List(ctx context.Context, actor Actor, query AuditQuery) ([]model.AuditEntry, error) {
+	if !actor.Can(model.RoleAdmin) {
+		return nil, ErrInsufficientRole
+	}
+	workspace, err := s.workspaceRepo.GetByID(ctx, actor.WorkspaceID)
+	if err != nil {
+		logger.Error(ctx, "audit-service: failed to get workspace",
+			zap.Uint64("workspace_id", actor.WorkspaceID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	filter := auditFilter(query)
+	filter.WorkspaceID = workspace.ID
+	if workspace.PersonalUserID != nil {
+		filter.AccountOf = *workspace.PersonalUserID
+	}
+	return s.auditRepo.List(ctx, filter)
+}
+
+// ListAll returns entries from the whole log, for site administrators.
+func (s *AuditServiceImpl) ListAll(ctx context.Context, query AuditQuery) ([]model.AuditEntry, error) {
+	return s.auditRepo.List(ctx, auditFilter(query))
+}
+
+func auditFilter(query AuditQuery) repository.AuditFilter {
+	if query.Limit <= 0 {
+		query.Limit = defaultAuditPageSize
+	} else if query.Limit > MaxAuditPageSize {
+		query.Limit = MaxAuditPageSize
+	}
+	return repository.AuditFilter{
+		ActorUserID: query.ActorUserID,
+		Action:      query.Action,
+		TargetType:  query.TargetType,
+		TargetID:    query.TargetID,
+		Since:       query.Since,
+		Until:       query.Until,
+		BeforeID:    query.BeforeID,
+		Limit:       query.Limit,
+	}
+}
+
+// recordAudit records event when auditing is configured; services built
+// without an audit service (as in most tests) skip it.
+func recordAudit(ctx context.Context, audit AuditService, event AuditEvent) {
+	if audit != nil {
+		audit.Record(ctx, event)
+	}
+}
+
+func optionalID(id uint64) *uint64 {
+	if id == 0 {
+		return nil
+	}
+	return &id
+}
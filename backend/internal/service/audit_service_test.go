@@ -0,0 +1,74 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/internal/repository"
+	"github.com/SeaCodeBase/urlshortener/internal/repository/mocks"
+	"github.com/SeaCodeBase/urlshortener/internal/service"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuditService_Record_StoresRequestMetaAndDiff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuditRepo := mocks.NewMockAuditRepository(ctrl)
+	svc := service.NewAuditService(mockAuditRepo, mocks.NewMockWorkspaceRepository(ctrl))
+
+	mockAuditRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, entry *model.AuditEntry) error {
+			assert.Equal(t, model.AuditPasskeyRenamed, entry.Action)
+			assert.Nil(t, entry.WorkspaceID)
+			assert.Equal(t, uint64(7), *entry.ActorUserID)
+			assert.Equal(t, "203.0.113.9", entry.IPAddress)
+			assert.Equal(t, "curl/8.0", entry.UserAgent)
+			assert.Equal(t, map[string]any{"name": "Laptop"}, entry.Changes.Before)
+			assert.Equal(t, map[string]any{"name": "YubiKey"}, entry.Changes.After)
+			return nil
+		})
+
+	ctx := service.WithRequestMeta(context.Background(), service.SessionMeta{UserAgent: "curl/8.0", IPAddress: "203.0.113.9"})
+	svc.Record(ctx, service.AuditEvent{
+		ActorUserID: 7,
+		Action:      model.AuditPasskeyRenamed,
+		TargetType:  model.AuditTargetPasskey,
+		TargetID:    4,
+		Before:      &model.Passkey{ID: 4, Name: "Laptop"},
+		After:       &model.Passkey{ID: 4, Name: "YubiKey"},
+	})
+}
+
+func TestAuditService_List_RequiresAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := service.NewAuditService(mocks.NewMockAuditRepository(ctrl), mocks.NewMockWorkspaceRepository(ctrl))
+
+	_, err := svc.List(context.Background(), service.Actor{UserID: 2, WorkspaceID: 1, Role: model.RoleEditor}, service.AuditQuery{})
+	assert.ErrorIs(t, err, service.ErrInsufficientRole)
+}
+
+func TestAuditService_List_PersonalWorkspaceIncludesAccountEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuditRepo := mocks.NewMockAuditRepository(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	svc := service.NewAuditService(mockAuditRepo, mockWorkspaceRepo)
+
+	owner := uint64(7)
+	mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), uint64(3)).Return(&model.Workspace{ID: 3, PersonalUserID: &owner}, nil)
+	mockAuditRepo.EXPECT().
+		List(gomock.Any(), repository.AuditFilter{WorkspaceID: 3, AccountOf: 7, Action: "link.", Limit: service.MaxAuditPageSize}).
+		Return([]model.AuditEntry{{ID: 1, Action: model.AuditLinkCreated}}, nil)
+
+	actor := service.Actor{UserID: 7, WorkspaceID: 3, Role: model.RoleOwner}
+	entries, err := svc.List(context.Background(), actor, service.AuditQuery{Action: "link.", Limit: 10000})
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
@@ -3,34 +3,76 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/SeaCodeBase/urlshortener/internal/model"
 	"github.com/SeaCodeBase/urlshortener/internal/repository"
 	"github.com/SeaCodeBase/urlshortener/pkg/logger"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	defaultAccessTokenTTL  = 15 * time.Minute
+	defaultRefreshTokenTTL = 30 * 24 * time.Hour
+	// refreshTokenLen is the number of random base62 characters in a refresh token
+	refreshTokenLen = 48
+	// maxUserAgentLen matches the width of sessions.user_agent
+	maxUserAgentLen = 255
+	// revokedSessionKeyPrefix marks sessions whose access tokens must be
+	// refused. The marker only has to outlive the access tokens already issued.
+	revokedSessionKeyPrefix = "revoked_session:"
+)
+
 var (
-	ErrInvalidCredentials = errors.New("invalid credentials")
-	ErrEmailTaken         = errors.New("email already taken")
-	ErrWrongPassword      = errors.New("current password is incorrect")
+	ErrInvalidCredentials  = errors.New("invalid credentials")
+	ErrEmailTaken          = errors.New("email already taken")
+	ErrWrongPassword       = errors.New("current password is incorrect")
+	ErrInvalidToken        = errors.New("invalid or expired token")
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrSessionNotFound     = errors.New("session not found")
 )
 
 // Compile-time check: AuthServiceImpl implements AuthService
 var _ AuthService = (*AuthServiceImpl)(nil)
 
+// TokenConfig configures the tokens AuthServiceImpl issues. Zero TTLs fall
+// back to 15 minutes for access tokens and 30 days for refresh tokens.
+type TokenConfig struct {
+	Secret     string
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+}
+
 type AuthServiceImpl struct {
-	userRepo  repository.UserRepository
-	jwtSecret []byte
+	userRepo    repository.UserRepository
+	sessionRepo repository.SessionRepository
+	audit       AuditService
+	rdb         *redis.Client
+	jwtSecret   []byte
+	accessTTL   time.Duration
+	refreshTTL  time.Duration
 }
 
-func NewAuthService(userRepo repository.UserRepository, jwtSecret string) *AuthServiceImpl {
+func NewAuthService(userRepo repository.UserRepository, sessionRepo repository.SessionRepository, audit AuditService, rdb *redis.Client, cfg TokenConfig) *AuthServiceImpl {
+	if cfg.AccessTTL <= 0 {
+		cfg.AccessTTL = defaultAccessTokenTTL
+	}
+	if cfg.RefreshTTL <= 0 {
+		cfg.RefreshTTL = defaultRefreshTokenTTL
+	}
 	return &AuthServiceImpl{
-		userRepo:  userRepo,
-		jwtSecret: []byte(jwtSecret),
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		audit:       audit,
+		rdb:         rdb,
+		jwtSecret:   []byte(cfg.Secret),
+		accessTTL:   cfg.AccessTTL,
+		refreshTTL:  cfg.RefreshTTL,
 	}
 }
 
@@ -49,12 +91,27 @@ type ChangePasswordInput struct {
 	NewPassword string `json:"new_password" binding:"required,min=8"`
 }
 
+// SessionMeta describes the device a session is started or refreshed from.
+type SessionMeta struct {
+	UserAgent string
+	IPAddress string
+}
+
+// AuthResponse carries a new access token and the refresh token that renews it.
 type AuthResponse struct {
-	Token string      `json:"token"`
-	User  *model.User `json:"user"`
+	Token          string      `json:"token"`
+	TokenExpiresAt time.Time   `json:"token_expires_at"`
+	RefreshToken   string      `json:"refresh_token"`
+	User           *model.User `json:"user"`
 }
 
-func (s *AuthServiceImpl) Register(ctx context.Context, input RegisterInput) (*AuthResponse, error) {
+// TokenClaims identifies the user and session behind a valid access token.
+type TokenClaims struct {
+	UserID    uint64
+	SessionID uint64
+}
+
+func (s *AuthServiceImpl) Register(ctx context.Context, input RegisterInput, meta SessionMeta) (*AuthResponse, error) {
 	exists, err := s.userRepo.EmailExists(ctx, input.Email)
 	if err != nil {
 		logger.Error(ctx, "auth-service: failed to check email existence",
@@ -87,22 +144,27 @@ func (s *AuthServiceImpl) Register(ctx context.Context, input RegisterInput) (*A
 		)
 		return nil, err
 	}
+	recordAudit(ctx, s.audit, AuditEvent{
+		ActorUserID: user.ID,
+		Action:      model.AuditUserRegistered,
+		TargetType:  model.AuditTargetUser,
+		TargetID:    user.ID,
+		After:       map[string]string{"email": user.Email},
+	})
 
-	token, err := s.generateToken(user.ID)
-	if err != nil {
-		logger.Error(ctx, "auth-service: failed to generate token",
-			zap.Uint64("user_id", user.ID),
-			zap.Error(err),
-		)
-		return nil, err
-	}
-
-	return &AuthResponse{Token: token, User: user}, nil
+	return s.startSession(ctx, user, meta)
 }
 
-func (s *AuthServiceImpl) Login(ctx context.Context, input LoginInput) (*AuthResponse, error) {
+// Login checks the credentials without starting a session, as the user may
+// still have to verify a passkey.
+func (s *AuthServiceImpl) Login(ctx context.Context, input LoginInput) (*model.User, error) {
 	user, err := s.userRepo.GetByEmail(ctx, input.Email)
 	if errors.Is(err, repository.ErrUserNotFound) {
+		recordAudit(ctx, s.audit, AuditEvent{
+			Action:     model.AuditLoginFailed,
+			TargetType: model.AuditTargetUser,
+			After:      map[string]string{"email": input.Email},
+		})
 		return nil, ErrInvalidCredentials
 	}
 	if err != nil {
@@ -114,19 +176,200 @@ func (s *AuthServiceImpl) Login(ctx context.Context, input LoginInput) (*AuthRes
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(input.Password)); err != nil {
+		recordAudit(ctx, s.audit, AuditEvent{
+			ActorUserID: user.ID,
+			Action:      model.AuditLoginFailed,
+			TargetType:  model.AuditTargetUser,
+			TargetID:    user.ID,
+		})
 		return nil, ErrInvalidCredentials
 	}
+	return user, nil
+}
 
-	token, err := s.generateToken(user.ID)
+func (s *AuthServiceImpl) StartSession(ctx context.Context, userID uint64, meta SessionMeta) (*AuthResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		logger.Error(ctx, "auth-service: failed to generate token",
+		logger.Error(ctx, "auth-service: failed to get user for new session",
+			zap.Uint64("user_id", userID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return s.startSession(ctx, user, meta)
+}
+
+func (s *AuthServiceImpl) startSession(ctx context.Context, user *model.User, meta SessionMeta) (*AuthResponse, error) {
+	refreshToken, err := generateRefreshToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	session := &model.Session{
+		UserID:      user.ID,
+		RefreshHash: hashSecret(refreshToken),
+		UserAgent:   truncateUserAgent(meta.UserAgent),
+		IPAddress:   meta.IPAddress,
+		ExpiresAt:   time.Now().Add(s.refreshTTL),
+	}
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		logger.Error(ctx, "auth-service: failed to create session",
 			zap.Uint64("user_id", user.ID),
 			zap.Error(err),
 		)
 		return nil, err
 	}
+	recordAudit(ctx, s.audit, AuditEvent{
+		ActorUserID: user.ID,
+		Action:      model.AuditLoginSucceeded,
+		TargetType:  model.AuditTargetSession,
+		TargetID:    session.ID,
+	})
+	return s.issue(ctx, user, session.ID, refreshToken)
+}
+
+// Refresh trades a refresh token for a new access token and a new refresh
+// token. Presenting a refresh token that was already rotated away means it
+// leaked, so the whole session is revoked.
+func (s *AuthServiceImpl) Refresh(ctx context.Context, refreshToken string, meta SessionMeta) (*AuthResponse, error) {
+	hash := hashSecret(refreshToken)
+	session, err := s.sessionRepo.GetByRefreshHash(ctx, hash)
+	if errors.Is(err, repository.ErrSessionNotFound) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if session.RevokedAt != nil || !session.ExpiresAt.After(time.Now()) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if session.RefreshHash != hash {
+		logger.Warn(ctx, "auth-service: rotated refresh token reused, revoking session",
+			zap.Uint64("user_id", session.UserID),
+			zap.Uint64("session_id", session.ID),
+		)
+		if err := s.revoke(ctx, []uint64{session.ID}, func() error { return s.sessionRepo.Revoke(ctx, session.ID) }); err != nil {
+			return nil, err
+		}
+		recordAudit(ctx, s.audit, AuditEvent{
+			ActorUserID: session.UserID,
+			Action:      model.AuditRefreshReused,
+			TargetType:  model.AuditTargetSession,
+			TargetID:    session.ID,
+		})
+		return nil, ErrInvalidRefreshToken
+	}
+
+	user, err := s.userRepo.GetByID(ctx, session.UserID)
+	if err != nil {
+		logger.Error(ctx, "auth-service: failed to get user for refresh",
+			zap.Uint64("user_id", session.UserID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	next, err := generateRefreshToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = s.sessionRepo.Rotate(ctx, session.ID, hash, hashSecret(next), time.Now().Add(s.refreshTTL), truncateUserAgent(meta.UserAgent), meta.IPAddress)
+	if errors.Is(err, repository.ErrSessionNotFound) {
+		// Another request rotated or revoked the session first
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		logger.Error(ctx, "auth-service: failed to rotate refresh token",
+			zap.Uint64("session_id", session.ID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return s.issue(ctx, user, session.ID, next)
+}
+
+func (s *AuthServiceImpl) ListSessions(ctx context.Context, userID, currentSessionID uint64) ([]model.Session, error) {
+	sessions, err := s.sessionRepo.ListActiveByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == currentSessionID
+	}
+	return sessions, nil
+}
 
-	return &AuthResponse{Token: token, User: user}, nil
+func (s *AuthServiceImpl) RevokeSession(ctx context.Context, userID, sessionID uint64) error {
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if errors.Is(err, repository.ErrSessionNotFound) {
+		return ErrSessionNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if session.UserID != userID || session.RevokedAt != nil {
+		return ErrSessionNotFound
+	}
+	if err := s.revoke(ctx, []uint64{sessionID}, func() error { return s.sessionRepo.Revoke(ctx, sessionID) }); err != nil {
+		return err
+	}
+	recordAudit(ctx, s.audit, AuditEvent{
+		ActorUserID: userID,
+		Action:      model.AuditSessionRevoked,
+		TargetType:  model.AuditTargetSession,
+		TargetID:    sessionID,
+	})
+	return nil
+}
+
+// RevokeAllSessions logs the user out everywhere except exceptSessionID,
+// which may be 0 to end every session.
+func (s *AuthServiceImpl) RevokeAllSessions(ctx context.Context, userID, exceptSessionID uint64) error {
+	sessions, err := s.sessionRepo.ListActiveByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	ids := make([]uint64, 0, len(sessions))
+	for _, session := range sessions {
+		if session.ID != exceptSessionID {
+			ids = append(ids, session.ID)
+		}
+	}
+	if err := s.revoke(ctx, ids, func() error { return s.sessionRepo.RevokeByUserID(ctx, userID, exceptSessionID) }); err != nil {
+		return err
+	}
+	if len(ids) > 0 {
+		recordAudit(ctx, s.audit, AuditEvent{
+			ActorUserID: userID,
+			Action:      model.AuditSessionsRevoked,
+			TargetType:  model.AuditTargetUser,
+			TargetID:    userID,
+			After:       map[string]int{"sessions": len(ids)},
+		})
+	}
+	return nil
+}
+
+// revoke ends sessions in the database, which stops their refresh tokens, and
+// marks them in Redis so their outstanding access tokens are refused too.
+func (s *AuthServiceImpl) revoke(ctx context.Context, sessionIDs []uint64, revokeRows func() error) error {
+	if err := revokeRows(); err != nil {
+		return err
+	}
+	if len(sessionIDs) == 0 {
+		return nil
+	}
+	pipe := s.rdb.Pipeline()
+	for _, id := range sessionIDs {
+		pipe.Set(ctx, revokedSessionKey(id), 1, s.accessTTL)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		logger.Error(ctx, "auth-service: failed to mark sessions revoked",
+			zap.Int("sessions", len(sessionIDs)),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
 }
 
 func (s *AuthServiceImpl) GetUserByID(ctx context.Context, userID uint64) (*model.User, error) {
@@ -141,7 +384,9 @@ func (s *AuthServiceImpl) GetUserByID(ctx context.Context, userID uint64) (*mode
 	return user, nil
 }
 
-func (s *AuthServiceImpl) ChangePassword(ctx context.Context, userID uint64, input ChangePasswordInput) error {
+// ChangePassword sets a new password and ends every other session of the
+// user; currentSessionID is the session making the change, which stays.
+func (s *AuthServiceImpl) ChangePassword(ctx context.Context, userID, currentSessionID uint64, input ChangePasswordInput) error {
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		logger.Error(ctx, "auth-service: failed to get user for password change",
@@ -171,6 +416,19 @@ func (s *AuthServiceImpl) ChangePassword(ctx context.Context, userID uint64, inp
 		)
 		return err
 	}
+	recordAudit(ctx, s.audit, AuditEvent{
+		ActorUserID: userID,
+		Action:      model.AuditPasswordChanged,
+		TargetType:  model.AuditTargetUser,
+		TargetID:    userID,
+	})
+	if err := s.RevokeAllSessions(ctx, userID, currentSessionID); err != nil {
+		logger.Error(ctx, "auth-service: failed to revoke sessions after password change",
+			zap.Uint64("user_id", userID),
+			zap.Error(err),
+		)
+		return err
+	}
 	return nil
 }
 
@@ -185,14 +443,24 @@ func (s *AuthServiceImpl) UpdateDisplayName(ctx context.Context, userID uint64,
 	return nil
 }
 
-func (s *AuthServiceImpl) GenerateToken(userID uint64) (string, error) {
-	return s.generateToken(userID)
+func (s *AuthServiceImpl) issue(ctx context.Context, user *model.User, sessionID uint64, refreshToken string) (*AuthResponse, error) {
+	expiresAt := time.Now().Add(s.accessTTL)
+	token, err := s.generateToken(user.ID, sessionID, expiresAt)
+	if err != nil {
+		logger.Error(ctx, "auth-service: failed to generate token",
+			zap.Uint64("user_id", user.ID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return &AuthResponse{Token: token, TokenExpiresAt: expiresAt, RefreshToken: refreshToken, User: user}, nil
 }
 
-func (s *AuthServiceImpl) generateToken(userID uint64) (string, error) {
+func (s *AuthServiceImpl) generateToken(userID, sessionID uint64, expiresAt time.Time) (string, error) {
 	claims := jwt.MapClaims{
 		"user_id": userID,
-		"exp":     time.Now().Add(24 * time.Hour).Unix(),
+		"sid":     sessionID,
+		"exp":     expiresAt.Unix(),
 		"iat":     time.Now().Unix(),
 	}
 
@@ -200,7 +468,10 @@ func (s *AuthServiceImpl) generateToken(userID uint64) (string, error) {
 	return token.SignedString(s.jwtSecret)
 }
 
-func (s *AuthServiceImpl) ValidateToken(tokenString string) (uint64, error) {
+// ValidateToken checks an access token's signature and expiry and that its
+// session has not been revoked. Tokens without a session cannot be revoked
+// and are refused.
+func (s *AuthServiceImpl) ValidateToken(ctx context.Context, tokenString string) (*TokenClaims, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errors.New("unexpected signing method")
@@ -209,18 +480,58 @@ func (s *AuthServiceImpl) ValidateToken(tokenString string) (uint64, error) {
 	})
 
 	if err != nil {
-		return 0, err
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
 	}
 
 	claims, ok := token.Claims.(jwt.MapClaims)
 	if !ok || !token.Valid {
-		return 0, errors.New("invalid token")
+		return nil, ErrInvalidToken
 	}
 
 	userIDFloat, ok := claims["user_id"].(float64)
 	if !ok {
-		return 0, errors.New("invalid token: missing user_id")
+		return nil, fmt.Errorf("%w: missing user_id", ErrInvalidToken)
 	}
+	sessionIDFloat, ok := claims["sid"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("%w: missing sid", ErrInvalidToken)
+	}
+	sessionID := uint64(sessionIDFloat)
 
-	return uint64(userIDFloat), nil
+	revoked, err := s.rdb.Exists(ctx, revokedSessionKey(sessionID)).Result()
+	if err != nil {
+		logger.Error(ctx, "auth-service: failed to check session revocation",
+			zap.Uint64("session_id", sessionID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if revoked > 0 {
+		return nil, fmt.Errorf("%w: session revoked", ErrInvalidToken)
+	}
+
+	return &TokenClaims{UserID: uint64(userIDFloat), SessionID: sessionID}, nil
+}
+
+func revokedSessionKey(sessionID uint64) string {
+	return revokedSessionKeyPrefix + strconv.FormatUint(sessionID, 10)
+}
+
+func generateRefreshToken(ctx context.Context) (string, error) {
+	token, err := (&RandomCodeGenerator{Alphabet: alphabet}).Generate(ctx, nil, refreshTokenLen)
+	if err != nil {
+		logger.Error(ctx, "auth-service: failed to generate refresh token",
+			zap.Error(err),
+		)
+		return "", err
+	}
+	return token, nil
+}
+
+func truncateUserAgent(userAgent string) string {
+	runes := []rune(userAgent)
+	if len(runes) > maxUserAgentLen {
+		return string(runes[:maxUserAgentLen])
+	}
+	return userAgent
 }
@@ -14,7 +14,8 @@ func TestAuthService_Register(t *testing.T) {
 	defer db.Close()
 
 	userRepo := repository.NewUserRepository(db)
-	authService := service.NewAuthService(userRepo, "test-secret")
+	_, rdb := newTestRedis(t)
+	authService := service.NewAuthService(userRepo, repository.NewSessionRepository(db), nil, rdb, service.TokenConfig{Secret: "test-secret"})
 
 	ctx := context.Background()
 	input := service.RegisterInput{
@@ -22,7 +23,7 @@ func TestAuthService_Register(t *testing.T) {
 		Password: "password123",
 	}
 
-	resp, err := authService.Register(ctx, input)
+	resp, err := authService.Register(ctx, input, service.SessionMeta{})
 	if err != nil {
 		t.Fatalf("Register failed: %v", err)
 	}
@@ -41,7 +42,8 @@ func TestAuthService_Login(t *testing.T) {
 	defer db.Close()
 
 	userRepo := repository.NewUserRepository(db)
-	authService := service.NewAuthService(userRepo, "test-secret")
+	_, rdb := newTestRedis(t)
+	authService := service.NewAuthService(userRepo, repository.NewSessionRepository(db), nil, rdb, service.TokenConfig{Secret: "test-secret"})
 
 	ctx := context.Background()
 
@@ -50,7 +52,7 @@ func TestAuthService_Login(t *testing.T) {
 		Email:    "test@example.com",
 		Password: "password123",
 	}
-	_, _ = authService.Register(ctx, registerInput)
+	_, _ = authService.Register(ctx, registerInput, service.SessionMeta{})
 
 	// Login
 	loginInput := service.LoginInput{
@@ -58,13 +60,13 @@ func TestAuthService_Login(t *testing.T) {
 		Password: "password123",
 	}
 
-	resp, err := authService.Login(ctx, loginInput)
+	user, err := authService.Login(ctx, loginInput)
 	if err != nil {
 		t.Fatalf("Login failed: %v", err)
 	}
 
-	if resp.Token == "" {
-		t.Error("Expected token to be set")
+	if user.Email != loginInput.Email {
+		t.Errorf("Expected email %s, got %s", loginInput.Email, user.Email)
 	}
 }
 
@@ -73,7 +75,8 @@ func TestAuthService_Login_InvalidPassword(t *testing.T) {
 	defer db.Close()
 
 	userRepo := repository.NewUserRepository(db)
-	authService := service.NewAuthService(userRepo, "test-secret")
+	_, rdb := newTestRedis(t)
+	authService := service.NewAuthService(userRepo, repository.NewSessionRepository(db), nil, rdb, service.TokenConfig{Secret: "test-secret"})
 
 	ctx := context.Background()
 
@@ -82,7 +85,7 @@ func TestAuthService_Login_InvalidPassword(t *testing.T) {
 		Email:    "test@example.com",
 		Password: "password123",
 	}
-	_, _ = authService.Register(ctx, registerInput)
+	_, _ = authService.Register(ctx, registerInput, service.SessionMeta{})
 
 	// Login with wrong password
 	loginInput := service.LoginInput{
@@ -101,7 +104,8 @@ func TestAuthService_ValidateToken(t *testing.T) {
 	defer db.Close()
 
 	userRepo := repository.NewUserRepository(db)
-	authService := service.NewAuthService(userRepo, "test-secret")
+	_, rdb := newTestRedis(t)
+	authService := service.NewAuthService(userRepo, repository.NewSessionRepository(db), nil, rdb, service.TokenConfig{Secret: "test-secret"})
 
 	ctx := context.Background()
 
@@ -110,16 +114,16 @@ func TestAuthService_ValidateToken(t *testing.T) {
 		Email:    "test@example.com",
 		Password: "password123",
 	}
-	resp, _ := authService.Register(ctx, input)
+	resp, _ := authService.Register(ctx, input, service.SessionMeta{})
 
 	// Validate token
-	userID, err := authService.ValidateToken(resp.Token)
+	claims, err := authService.ValidateToken(ctx, resp.Token)
 	if err != nil {
 		t.Fatalf("ValidateToken failed: %v", err)
 	}
 
-	if userID != resp.User.ID {
-		t.Errorf("Expected user ID %d, got %d", resp.User.ID, userID)
+	if claims.UserID != resp.User.ID {
+		t.Errorf("Expected user ID %d, got %d", resp.User.ID, claims.UserID)
 	}
 }
 
@@ -128,7 +132,8 @@ func TestAuthService_ChangePassword(t *testing.T) {
 	defer db.Close()
 
 	userRepo := repository.NewUserRepository(db)
-	authService := service.NewAuthService(userRepo, "test-secret")
+	_, rdb := newTestRedis(t)
+	authService := service.NewAuthService(userRepo, repository.NewSessionRepository(db), nil, rdb, service.TokenConfig{Secret: "test-secret"})
 
 	ctx := context.Background()
 
@@ -137,7 +142,7 @@ func TestAuthService_ChangePassword(t *testing.T) {
 		Email:    "test@example.com",
 		Password: "password123",
 	}
-	resp, err := authService.Register(ctx, registerInput)
+	resp, err := authService.Register(ctx, registerInput, service.SessionMeta{})
 	if err != nil {
 		t.Fatalf("Register failed: %v", err)
 	}
@@ -147,7 +152,7 @@ func TestAuthService_ChangePassword(t *testing.T) {
 		OldPassword: "password123",
 		NewPassword: "newpassword456",
 	}
-	err = authService.ChangePassword(ctx, resp.User.ID, changeInput)
+	err = authService.ChangePassword(ctx, resp.User.ID, 0, changeInput)
 	if err != nil {
 		t.Fatalf("ChangePassword failed: %v", err)
 	}
@@ -175,7 +180,8 @@ func TestAuthService_ChangePassword_WrongOldPassword(t *testing.T) {
 	defer db.Close()
 
 	userRepo := repository.NewUserRepository(db)
-	authService := service.NewAuthService(userRepo, "test-secret")
+	_, rdb := newTestRedis(t)
+	authService := service.NewAuthService(userRepo, repository.NewSessionRepository(db), nil, rdb, service.TokenConfig{Secret: "test-secret"})
 
 	ctx := context.Background()
 
@@ -184,14 +190,14 @@ func TestAuthService_ChangePassword_WrongOldPassword(t *testing.T) {
 		Email:    "test@example.com",
 		Password: "password123",
 	}
-	resp, _ := authService.Register(ctx, registerInput)
+	resp, _ := authService.Register(ctx, registerInput, service.SessionMeta{})
 
 	// Try to change with wrong old password
 	changeInput := service.ChangePasswordInput{
 		OldPassword: "wrongpassword",
 		NewPassword: "newpassword456",
 	}
-	err := authService.ChangePassword(ctx, resp.User.ID, changeInput)
+	err := authService.ChangePassword(ctx, resp.User.ID, 0, changeInput)
 	if err != service.ErrWrongPassword {
 		t.Errorf("Expected ErrWrongPassword, got %v", err)
 	}
@@ -2,23 +2,38 @@ package service_test
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"testing"
+	"time"
 
 	"github.com/SeaCodeBase/urlshortener/internal/model"
 	"github.com/SeaCodeBase/urlshortener/internal/repository"
 	"github.com/SeaCodeBase/urlshortener/internal/repository/mocks"
 	"github.com/SeaCodeBase/urlshortener/internal/service"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// newTestRedis starts an in-memory Redis for the session revocation markers
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	return mr, rdb
+}
+
 func TestAuthServiceImpl_ChangePassword_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
-	svc := service.NewAuthService(mockUserRepo, "test-secret")
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mr, rdb := newTestRedis(t)
+	svc := service.NewAuthService(mockUserRepo, mockSessionRepo, nil, rdb, service.TokenConfig{Secret: "test-secret"})
 
 	oldPass := "oldpassword123"
 	hash, _ := bcrypt.GenerateFromPassword([]byte(oldPass), bcrypt.DefaultCost)
@@ -32,13 +47,23 @@ func TestAuthServiceImpl_ChangePassword_Success(t *testing.T) {
 		UpdatePassword(gomock.Any(), uint64(1), gomock.Any()).
 		Return(nil)
 
+	// Every other session is ended; the one changing the password stays
+	mockSessionRepo.EXPECT().
+		ListActiveByUserID(gomock.Any(), uint64(1)).
+		Return([]model.Session{{ID: 10, UserID: 1}, {ID: 11, UserID: 1}}, nil)
+	mockSessionRepo.EXPECT().
+		RevokeByUserID(gomock.Any(), uint64(1), uint64(10)).
+		Return(nil)
+
 	input := service.ChangePasswordInput{
 		OldPassword: oldPass,
 		NewPassword: "newpassword456",
 	}
 
-	err := svc.ChangePassword(context.Background(), uint64(1), input)
+	err := svc.ChangePassword(context.Background(), uint64(1), uint64(10), input)
 	assert.NoError(t, err)
+	assert.False(t, mr.Exists("revoked_session:10"))
+	assert.True(t, mr.Exists("revoked_session:11"))
 }
 
 func TestAuthServiceImpl_ChangePassword_WrongPassword(t *testing.T) {
@@ -46,7 +71,8 @@ func TestAuthServiceImpl_ChangePassword_WrongPassword(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
-	svc := service.NewAuthService(mockUserRepo, "test-secret")
+	_, rdb := newTestRedis(t)
+	svc := service.NewAuthService(mockUserRepo, mocks.NewMockSessionRepository(ctrl), nil, rdb, service.TokenConfig{Secret: "test-secret"})
 
 	hash, _ := bcrypt.GenerateFromPassword([]byte("correctpassword"), bcrypt.DefaultCost)
 	user := &model.User{ID: 1, Email: "test@example.com", PasswordHash: string(hash)}
@@ -60,7 +86,7 @@ func TestAuthServiceImpl_ChangePassword_WrongPassword(t *testing.T) {
 		NewPassword: "newpassword456",
 	}
 
-	err := svc.ChangePassword(context.Background(), uint64(1), input)
+	err := svc.ChangePassword(context.Background(), uint64(1), uint64(10), input)
 	assert.ErrorIs(t, err, service.ErrWrongPassword)
 }
 
@@ -69,7 +95,8 @@ func TestAuthServiceImpl_Login_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
-	svc := service.NewAuthService(mockUserRepo, "test-secret")
+	_, rdb := newTestRedis(t)
+	svc := service.NewAuthService(mockUserRepo, mocks.NewMockSessionRepository(ctrl), nil, rdb, service.TokenConfig{Secret: "test-secret"})
 
 	password := "testpassword123"
 	hash, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -84,10 +111,9 @@ func TestAuthServiceImpl_Login_Success(t *testing.T) {
 		Password: password,
 	}
 
-	resp, err := svc.Login(context.Background(), input)
+	got, err := svc.Login(context.Background(), input)
 	assert.NoError(t, err)
-	assert.NotEmpty(t, resp.Token)
-	assert.Equal(t, user.Email, resp.User.Email)
+	assert.Equal(t, user.Email, got.Email)
 }
 
 func TestAuthServiceImpl_Login_InvalidCredentials(t *testing.T) {
@@ -95,7 +121,8 @@ func TestAuthServiceImpl_Login_InvalidCredentials(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
-	svc := service.NewAuthService(mockUserRepo, "test-secret")
+	_, rdb := newTestRedis(t)
+	svc := service.NewAuthService(mockUserRepo, mocks.NewMockSessionRepository(ctrl), nil, rdb, service.TokenConfig{Secret: "test-secret"})
 
 	mockUserRepo.EXPECT().
 		GetByEmail(gomock.Any(), "notfound@example.com").
@@ -106,9 +133,9 @@ func TestAuthServiceImpl_Login_InvalidCredentials(t *testing.T) {
 		Password: "anypassword",
 	}
 
-	resp, err := svc.Login(context.Background(), input)
+	got, err := svc.Login(context.Background(), input)
 	assert.ErrorIs(t, err, service.ErrInvalidCredentials)
-	assert.Nil(t, resp)
+	assert.Nil(t, got)
 }
 
 func TestAuthServiceImpl_Register_Success(t *testing.T) {
@@ -116,7 +143,9 @@ func TestAuthServiceImpl_Register_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
-	svc := service.NewAuthService(mockUserRepo, "test-secret")
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	_, rdb := newTestRedis(t)
+	svc := service.NewAuthService(mockUserRepo, mockSessionRepo, nil, rdb, service.TokenConfig{Secret: "test-secret"})
 
 	mockUserRepo.EXPECT().
 		EmailExists(gomock.Any(), "new@example.com").
@@ -129,14 +158,24 @@ func TestAuthServiceImpl_Register_Success(t *testing.T) {
 			return nil
 		})
 
+	mockSessionRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, session *model.Session) error {
+			assert.Equal(t, uint64(1), session.UserID)
+			assert.Equal(t, "test-agent", session.UserAgent)
+			session.ID = 5
+			return nil
+		})
+
 	input := service.RegisterInput{
 		Email:    "new@example.com",
 		Password: "password123",
 	}
 
-	resp, err := svc.Register(context.Background(), input)
+	resp, err := svc.Register(context.Background(), input, service.SessionMeta{UserAgent: "test-agent"})
 	assert.NoError(t, err)
 	assert.NotEmpty(t, resp.Token)
+	assert.NotEmpty(t, resp.RefreshToken)
 	assert.Equal(t, "new@example.com", resp.User.Email)
 }
 
@@ -145,7 +184,8 @@ func TestAuthServiceImpl_Register_EmailTaken(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
-	svc := service.NewAuthService(mockUserRepo, "test-secret")
+	_, rdb := newTestRedis(t)
+	svc := service.NewAuthService(mockUserRepo, mocks.NewMockSessionRepository(ctrl), nil, rdb, service.TokenConfig{Secret: "test-secret"})
 
 	mockUserRepo.EXPECT().
 		EmailExists(gomock.Any(), "taken@example.com").
@@ -156,7 +196,112 @@ func TestAuthServiceImpl_Register_EmailTaken(t *testing.T) {
 		Password: "password123",
 	}
 
-	resp, err := svc.Register(context.Background(), input)
+	resp, err := svc.Register(context.Background(), input, service.SessionMeta{})
 	assert.ErrorIs(t, err, service.ErrEmailTaken)
 	assert.Nil(t, resp)
 }
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestAuthServiceImpl_Refresh_RotatesToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	_, rdb := newTestRedis(t)
+	svc := service.NewAuthService(mockUserRepo, mockSessionRepo, nil, rdb, service.TokenConfig{Secret: "test-secret"})
+
+	const refreshToken = "current-refresh-token"
+	session := &model.Session{ID: 5, UserID: 1, RefreshHash: sha256Hex(refreshToken), ExpiresAt: time.Now().Add(time.Hour)}
+
+	mockSessionRepo.EXPECT().GetByRefreshHash(gomock.Any(), sha256Hex(refreshToken)).Return(session, nil)
+	mockUserRepo.EXPECT().GetByID(gomock.Any(), uint64(1)).Return(&model.User{ID: 1}, nil)
+	mockSessionRepo.EXPECT().
+		Rotate(gomock.Any(), uint64(5), sha256Hex(refreshToken), gomock.Any(), gomock.Any(), "test-agent", "192.0.2.1").
+		DoAndReturn(func(ctx context.Context, id uint64, oldHash, newHash string, expiresAt time.Time, userAgent, ipAddress string) error {
+			assert.NotEqual(t, oldHash, newHash)
+			return nil
+		})
+
+	ctx := context.Background()
+	resp, err := svc.Refresh(ctx, refreshToken, service.SessionMeta{UserAgent: "test-agent", IPAddress: "192.0.2.1"})
+	assert.NoError(t, err)
+	assert.NotEqual(t, refreshToken, resp.RefreshToken)
+
+	claims, err := svc.ValidateToken(ctx, resp.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, &service.TokenClaims{UserID: 1, SessionID: 5}, claims)
+}
+
+func TestAuthServiceImpl_Refresh_ReusedTokenRevokesSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mr, rdb := newTestRedis(t)
+	svc := service.NewAuthService(mocks.NewMockUserRepository(ctrl), mockSessionRepo, nil, rdb, service.TokenConfig{Secret: "test-secret"})
+
+	const stolen = "rotated-refresh-token"
+	previous := sha256Hex(stolen)
+	session := &model.Session{ID: 5, UserID: 1, RefreshHash: sha256Hex("newer-token"), PreviousHash: &previous, ExpiresAt: time.Now().Add(time.Hour)}
+
+	mockSessionRepo.EXPECT().GetByRefreshHash(gomock.Any(), previous).Return(session, nil)
+	mockSessionRepo.EXPECT().Revoke(gomock.Any(), uint64(5)).Return(nil)
+
+	_, err := svc.Refresh(context.Background(), stolen, service.SessionMeta{})
+	assert.ErrorIs(t, err, service.ErrInvalidRefreshToken)
+	assert.True(t, mr.Exists("revoked_session:5"))
+}
+
+func TestAuthServiceImpl_Refresh_Expired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	_, rdb := newTestRedis(t)
+	svc := service.NewAuthService(mocks.NewMockUserRepository(ctrl), mockSessionRepo, nil, rdb, service.TokenConfig{Secret: "test-secret"})
+
+	session := &model.Session{ID: 5, UserID: 1, RefreshHash: sha256Hex("token"), ExpiresAt: time.Now().Add(-time.Minute)}
+	mockSessionRepo.EXPECT().GetByRefreshHash(gomock.Any(), gomock.Any()).Return(session, nil)
+
+	_, err := svc.Refresh(context.Background(), "token", service.SessionMeta{})
+	assert.ErrorIs(t, err, service.ErrInvalidRefreshToken)
+}
+
+func TestAuthServiceImpl_RevokeSession_RefusesAccessToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	_, rdb := newTestRedis(t)
+	svc := service.NewAuthService(mockUserRepo, mockSessionRepo, nil, rdb, service.TokenConfig{Secret: "test-secret"})
+	ctx := context.Background()
+
+	mockUserRepo.EXPECT().GetByID(gomock.Any(), uint64(1)).Return(&model.User{ID: 1}, nil)
+	mockSessionRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, session *model.Session) error {
+			session.ID = 5
+			return nil
+		})
+	resp, err := svc.StartSession(ctx, 1, service.SessionMeta{})
+	assert.NoError(t, err)
+
+	_, err = svc.ValidateToken(ctx, resp.Token)
+	assert.NoError(t, err)
+
+	// Another user cannot see or end the session
+	mockSessionRepo.EXPECT().GetByID(gomock.Any(), uint64(5)).Return(&model.Session{ID: 5, UserID: 1}, nil).Times(2)
+	assert.ErrorIs(t, svc.RevokeSession(ctx, 2, 5), service.ErrSessionNotFound)
+
+	mockSessionRepo.EXPECT().Revoke(gomock.Any(), uint64(5)).Return(nil)
+	assert.NoError(t, svc.RevokeSession(ctx, 1, 5))
+
+	_, err = svc.ValidateToken(ctx, resp.Token)
+	assert.ErrorIs(t, err, service.ErrInvalidToken)
+}
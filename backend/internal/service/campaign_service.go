@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/internal/repository"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrCampaignNotFound     = errors.New("campaign not found")
+	ErrNotCampaignOwner     = errors.New("not the owner of this campaign")
+	ErrInvalidCampaignDates = errors.New("campaign end date must be after start date")
+)
+
+// Compile-time check: CampaignServiceImpl implements CampaignService
+var _ CampaignService = (*CampaignServiceImpl)(nil)
+
+type CampaignServiceImpl struct {
+	campaignRepo repository.CampaignRepository
+	linkRepo     repository.LinkRepository
+}
+
+func NewCampaignService(campaignRepo repository.CampaignRepository, linkRepo repository.LinkRepository) *CampaignServiceImpl {
+	return &CampaignServiceImpl{
+		campaignRepo: campaignRepo,
+		linkRepo:     linkRepo,
+	}
+}
+
+type CreateCampaignInput struct {
+	Name        string     `json:"name" binding:"required,max=255"`
+	StartsAt    *time.Time `json:"starts_at,omitempty"`
+	EndsAt      *time.Time `json:"ends_at,omitempty"`
+	BudgetNotes string     `json:"budget_notes,omitempty"`
+	UTMSource   string     `json:"utm_source,omitempty"`
+	UTMMedium   string     `json:"utm_medium,omitempty"`
+	UTMCampaign string     `json:"utm_campaign,omitempty"`
+}
+
+type UpdateCampaignInput struct {
+	Name        string     `json:"name,omitempty" binding:"max=255"`
+	StartsAt    *time.Time `json:"starts_at,omitempty"`
+	EndsAt      *time.Time `json:"ends_at,omitempty"`
+	BudgetNotes *string    `json:"budget_notes,omitempty"`
+	UTMSource   *string    `json:"utm_source,omitempty"`
+	UTMMedium   *string    `json:"utm_medium,omitempty"`
+	UTMCampaign *string    `json:"utm_campaign,omitempty"`
+}
+
+func (s *CampaignServiceImpl) Create(ctx context.Context, actor Actor, input CreateCampaignInput) (*model.Campaign, error) {
+	if !actor.Can(model.RoleEditor) {
+		return nil, ErrInsufficientRole
+	}
+	campaign := &model.Campaign{
+		UserID:      actor.UserID,
+		WorkspaceID: actor.WorkspaceID,
+		Name:        input.Name,
+		BudgetNotes: optionalString(input.BudgetNotes),
+		UTMSource:   optionalString(input.UTMSource),
+		UTMMedium:   optionalString(input.UTMMedium),
+		UTMCampaign: optionalString(input.UTMCampaign),
+	}
+	if input.StartsAt != nil {
+		campaign.StartsAt = model.NullTime{NullTime: sql.NullTime{Time: *input.StartsAt, Valid: true}}
+	}
+	if input.EndsAt != nil {
+		campaign.EndsAt = model.NullTime{NullTime: sql.NullTime{Time: *input.EndsAt, Valid: true}}
+	}
+	if !validCampaignDates(campaign) {
+		return nil, ErrInvalidCampaignDates
+	}
+
+	if err := s.campaignRepo.Create(ctx, campaign); err != nil {
+		logger.Error(ctx, "campaign-service: failed to create campaign",
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return campaign, nil
+}
+
+func (s *CampaignServiceImpl) GetByID(ctx context.Context, actor Actor, campaignID uint64) (*model.Campaign, error) {
+	campaign, err := s.campaignRepo.GetByID(ctx, campaignID)
+	if errors.Is(err, repository.ErrCampaignNotFound) {
+		return nil, ErrCampaignNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, "campaign-service: failed to get campaign by ID",
+			zap.Uint64("campaign_id", campaignID),
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	if campaign.WorkspaceID != actor.WorkspaceID {
+		return nil, ErrNotCampaignOwner
+	}
+
+	return campaign, nil
+}
+
+func (s *CampaignServiceImpl) List(ctx context.Context, actor Actor) ([]model.Campaign, error) {
+	campaigns, err := s.campaignRepo.ListByWorkspaceID(ctx, actor.WorkspaceID)
+	if err != nil {
+		logger.Error(ctx, "campaign-service: failed to list campaigns",
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return campaigns, nil
+}
+
+func (s *CampaignServiceImpl) Update(ctx context.Context, actor Actor, campaignID uint64, input UpdateCampaignInput) (*model.Campaign, error) {
+	if !actor.Can(model.RoleEditor) {
+		return nil, ErrInsufficientRole
+	}
+	campaign, err := s.GetByID(ctx, actor, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != "" {
+		campaign.Name = input.Name
+	}
+	if input.StartsAt != nil {
+		campaign.StartsAt = model.NullTime{NullTime: sql.NullTime{Time: *input.StartsAt, Valid: true}}
+	}
+	if input.EndsAt != nil {
+		campaign.EndsAt = model.NullTime{NullTime: sql.NullTime{Time: *input.EndsAt, Valid: true}}
+	}
+	// Pointer fields distinguish "unchanged" (nil) from "cleared" (empty string)
+	if input.BudgetNotes != nil {
+		campaign.BudgetNotes = optionalString(*input.BudgetNotes)
+	}
+	if input.UTMSource != nil {
+		campaign.UTMSource = optionalString(*input.UTMSource)
+	}
+	if input.UTMMedium != nil {
+		campaign.UTMMedium = optionalString(*input.UTMMedium)
+	}
+	if input.UTMCampaign != nil {
+		campaign.UTMCampaign = optionalString(*input.UTMCampaign)
+	}
+	if !validCampaignDates(campaign) {
+		return nil, ErrInvalidCampaignDates
+	}
+
+	if err := s.campaignRepo.Update(ctx, campaign); err != nil {
+		logger.Error(ctx, "campaign-service: failed to update campaign",
+			zap.Uint64("campaign_id", campaignID),
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return campaign, nil
+}
+
+func (s *CampaignServiceImpl) Delete(ctx context.Context, actor Actor, campaignID uint64) error {
+	if !actor.Can(model.RoleEditor) {
+		return ErrInsufficientRole
+	}
+	campaign, err := s.GetByID(ctx, actor, campaignID)
+	if err != nil {
+		return err
+	}
+
+	// Member links are detached by the FK (ON DELETE SET NULL), not deleted
+	if err := s.campaignRepo.Delete(ctx, campaign.ID); err != nil {
+		logger.Error(ctx, "campaign-service: failed to delete campaign",
+			zap.Uint64("campaign_id", campaignID),
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
+
+func (s *CampaignServiceImpl) ListLinks(ctx context.Context, actor Actor, campaignID uint64) ([]model.Link, error) {
+	if _, err := s.GetByID(ctx, actor, campaignID); err != nil {
+		return nil, err
+	}
+
+	links, err := s.linkRepo.ListByCampaignID(ctx, campaignID)
+	if err != nil {
+		logger.Error(ctx, "campaign-service: failed to list campaign links",
+			zap.Uint64("campaign_id", campaignID),
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if links == nil {
+		links = []model.Link{}
+	}
+	return links, nil
+}
+
+func validCampaignDates(c *model.Campaign) bool {
+	if c.StartsAt.Valid && c.EndsAt.Valid {
+		return c.EndsAt.Time.After(c.StartsAt.Time)
+	}
+	return true
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// resolveLinkDestination returns the URL and expiry a link redirects with.
+// UTM parameters and expiry set on the link win over the campaign defaults,
+// and parameters already present in the destination URL are never overwritten.
+func resolveLinkDestination(link *model.Link, campaign *model.Campaign) (string, model.NullTime) {
+	source, medium, name := link.UTMSource, link.UTMMedium, link.UTMCampaign
+	expiresAt := link.ExpiresAt
+	if campaign != nil {
+		if source == nil {
+			source = campaign.UTMSource
+		}
+		if medium == nil {
+			medium = campaign.UTMMedium
+		}
+		if name == nil {
+			name = campaign.UTMCampaign
+		}
+		if !expiresAt.Valid {
+			expiresAt = campaign.EndsAt
+		}
+	}
+
+	return addUTMParams(link.OriginalURL, source, medium, name), expiresAt
+}
+
+// addUTMParams adds the given UTM parameters to rawURL, skipping unset ones
+// and any the URL already carries.
+func addUTMParams(rawURL string, source, medium, name *string) string {
+	params := []struct {
+		key   string
+		value *string
+	}{
+		{"utm_source", source},
+		{"utm_medium", medium},
+		{"utm_campaign", name},
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	query := u.Query()
+	changed := false
+	for _, p := range params {
+		if p.value == nil || *p.value == "" || query.Has(p.key) {
+			continue
+		}
+		query.Set(p.key, *p.value)
+		changed = true
+	}
+	if !changed {
+		return rawURL
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestResolveLinkDestination(t *testing.T) {
+	campaignEnd := time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)
+	linkExpiry := time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)
+	campaign := &model.Campaign{
+		UTMSource:   strPtr("newsletter"),
+		UTMMedium:   strPtr("email"),
+		UTMCampaign: strPtr("spring"),
+		EndsAt:      model.NullTime{NullTime: sql.NullTime{Time: campaignEnd, Valid: true}},
+	}
+
+	tests := []struct {
+		name       string
+		link       *model.Link
+		campaign   *model.Campaign
+		wantURL    string
+		wantExpiry time.Time
+	}{
+		{
+			name:     "no campaign and no overrides",
+			link:     &model.Link{OriginalURL: "https://example.com/page"},
+			campaign: nil,
+			wantURL:  "https://example.com/page",
+		},
+		{
+			name:       "inherits campaign defaults",
+			link:       &model.Link{OriginalURL: "https://example.com/page"},
+			campaign:   campaign,
+			wantURL:    "https://example.com/page?utm_campaign=spring&utm_medium=email&utm_source=newsletter",
+			wantExpiry: campaignEnd,
+		},
+		{
+			name: "link overrides win",
+			link: &model.Link{
+				OriginalURL: "https://example.com/page",
+				UTMSource:   strPtr("twitter"),
+				ExpiresAt:   model.NullTime{NullTime: sql.NullTime{Time: linkExpiry, Valid: true}},
+			},
+			campaign:   campaign,
+			wantURL:    "https://example.com/page?utm_campaign=spring&utm_medium=email&utm_source=twitter",
+			wantExpiry: linkExpiry,
+		},
+		{
+			name:       "explicit destination params are kept",
+			link:       &model.Link{OriginalURL: "https://example.com/page?utm_source=print&ref=1"},
+			campaign:   campaign,
+			wantURL:    "https://example.com/page?ref=1&utm_campaign=spring&utm_medium=email&utm_source=print",
+			wantExpiry: campaignEnd,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotURL, gotExpiry := resolveLinkDestination(tt.link, tt.campaign)
+			assert.Equal(t, tt.wantURL, gotURL)
+			if tt.wantExpiry.IsZero() {
+				assert.False(t, gotExpiry.Valid)
+			} else {
+				assert.True(t, gotExpiry.Valid)
+				assert.Equal(t, tt.wantExpiry, gotExpiry.Time)
+			}
+		})
+	}
+}
+
+func TestCampaignService_Create_InvalidDates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCampaignRepo := mocks.NewMockCampaignRepository(ctrl)
+	mockLinkRepo := mocks.NewMockLinkRepository(ctrl)
+	svc := NewCampaignService(mockCampaignRepo, mockLinkRepo)
+
+	start := time.Now()
+	end := start.Add(-24 * time.Hour)
+
+	_, err := svc.Create(context.Background(), Actor{UserID: 1, WorkspaceID: 1, Role: model.RoleEditor}, CreateCampaignInput{
+		Name:     "Spring sale",
+		StartsAt: &start,
+		EndsAt:   &end,
+	})
+	assert.ErrorIs(t, err, ErrInvalidCampaignDates)
+}
+
+func TestCampaignService_Update_NotOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCampaignRepo := mocks.NewMockCampaignRepository(ctrl)
+	mockLinkRepo := mocks.NewMockLinkRepository(ctrl)
+	svc := NewCampaignService(mockCampaignRepo, mockLinkRepo)
+
+	mockCampaignRepo.EXPECT().
+		GetByID(gomock.Any(), uint64(5)).
+		Return(&model.Campaign{ID: 5, UserID: 2, WorkspaceID: 2, Name: "Other"}, nil)
+
+	_, err := svc.Update(context.Background(), Actor{UserID: 1, WorkspaceID: 1, Role: model.RoleEditor}, 5, UpdateCampaignInput{Name: "Mine now"})
+	assert.ErrorIs(t, err, ErrNotCampaignOwner)
+}
@@ -0,0 +1,452 @@
+package service
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/internal/repository"
+	"github.com/SeaCodeBase/urlshortener/internal/util"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	// certificateCacheTTL is how long a looked-up certificate, or its absence,
+	// is reused for TLS handshakes; other instances see changes within it
+	certificateCacheTTL = 5 * time.Minute
+	// certificateCacheSize caps the hosts cached on an instance
+	certificateCacheSize = 1024
+)
+
+var (
+	ErrCertificateNotFound = errors.New("certificate not found")
+	ErrInvalidCertificate  = errors.New("invalid certificate")
+	ErrACMEDisabled        = errors.New("certificate requests are not configured")
+	ErrTLSDisabled         = errors.New("certificate storage is not configured")
+	ErrACMEFailed          = errors.New("certificate request failed")
+	ErrNoCertificate       = errors.New("no certificate for this host")
+	ErrACMEWildcard        = errors.New("wildcard certificates must be uploaded")
+)
+
+// Compile-time check: CertificateServiceImpl implements CertificateService
+var _ CertificateService = (*CertificateServiceImpl)(nil)
+
+// CertificateConfig holds the redirect server's TLS settings.
+type CertificateConfig struct {
+	// Fallback is served for the service's own host and names without a
+	// certificate; nil fails those handshakes
+	Fallback *tls.Certificate
+	// BaseHost is the service's own host, which Fallback covers
+	BaseHost string
+	// RenewBefore is how long before expiry certificates are renewed
+	RenewBefore time.Duration
+}
+
+type UploadCertificateInput struct {
+	// Certificate is the PEM leaf certificate followed by its chain
+	Certificate string `json:"certificate" binding:"required"`
+	PrivateKey  string `json:"private_key" binding:"required"`
+}
+
+type cachedCertificate struct {
+	cert    *tls.Certificate
+	expires time.Time
+}
+
+type CertificateServiceImpl struct {
+	certRepo   repository.DomainCertificateRepository
+	domainRepo repository.DomainRepository
+	// cipher is nil when no encryption key is configured; certificates can
+	// then neither be stored nor served
+	cipher *util.SecretCipher
+	// acme is nil when certificate requests are not configured
+	acme       ACMEClient
+	challenges ChallengeStore
+	audit      AuditService
+	config     CertificateConfig
+
+	mu    sync.RWMutex
+	cache map[string]cachedCertificate
+}
+
+func NewCertificateService(certRepo repository.DomainCertificateRepository, domainRepo repository.DomainRepository, cipher *util.SecretCipher,
+	acme ACMEClient, challenges ChallengeStore, audit AuditService, config CertificateConfig) *CertificateServiceImpl {
+	return &CertificateServiceImpl{
+		certRepo:   certRepo,
+		domainRepo: domainRepo,
+		cipher:     cipher,
+		acme:       acme,
+		challenges: challenges,
+		audit:      audit,
+		config:     config,
+		cache:      make(map[string]cachedCertificate),
+	}
+}
+
+func (s *CertificateServiceImpl) Get(ctx context.Context, actor Actor, domainID uint64) (*model.DomainCertificate, error) {
+	if _, err := s.domain(ctx, actor, domainID); err != nil {
+		return nil, err
+	}
+	cert, err := s.certRepo.GetByDomainID(ctx, domainID)
+	if errors.Is(err, repository.ErrCertificateNotFound) {
+		return nil, ErrCertificateNotFound
+	}
+	return cert, err
+}
+
+// Upload stores a certificate brought by the domain's owner. It must match
+// the key, cover the domain and not have expired.
+func (s *CertificateServiceImpl) Upload(ctx context.Context, actor Actor, domainID uint64, input UploadCertificateInput) (*model.DomainCertificate, error) {
+	if s.cipher == nil {
+		return nil, ErrTLSDisabled
+	}
+	domain, err := s.managedDomain(ctx, actor, domainID)
+	if err != nil {
+		return nil, err
+	}
+
+	certPEM, keyPEM := []byte(strings.TrimSpace(input.Certificate)), []byte(strings.TrimSpace(input.PrivateKey))
+	leaf, err := checkCertificate(certPEM, keyPEM, domain.Domain, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	cert, err := s.save(ctx, domain, model.CertificateUploaded, certPEM, keyPEM, leaf)
+	if err != nil {
+		return nil, err
+	}
+	s.record(ctx, actor.UserID, model.AuditDomainCertificateSet, domain, cert)
+	return cert, nil
+}
+
+// Request obtains a certificate for the domain from the ACME certificate
+// authority, replacing any it had.
+func (s *CertificateServiceImpl) Request(ctx context.Context, actor Actor, domainID uint64) (*model.DomainCertificate, error) {
+	if s.cipher == nil {
+		return nil, ErrTLSDisabled
+	}
+	if s.acme == nil {
+		return nil, ErrACMEDisabled
+	}
+	domain, err := s.managedDomain(ctx, actor, domainID)
+	if err != nil {
+		return nil, err
+	}
+	// HTTP-01 challenges cannot prove control of a wildcard
+	if domain.IsWildcard() {
+		return nil, ErrACMEWildcard
+	}
+
+	cert, err := s.obtain(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+	s.record(ctx, actor.UserID, model.AuditDomainCertificateSet, domain, cert)
+	return cert, nil
+}
+
+func (s *CertificateServiceImpl) Delete(ctx context.Context, actor Actor, domainID uint64) error {
+	domain, err := s.domain(ctx, actor, domainID)
+	if err != nil {
+		return err
+	}
+	if !actor.Can(model.RoleAdmin) {
+		return ErrInsufficientRole
+	}
+
+	if err := s.certRepo.DeleteByDomainID(ctx, domainID); err != nil {
+		if errors.Is(err, repository.ErrCertificateNotFound) {
+			return ErrCertificateNotFound
+		}
+		return err
+	}
+	s.forget()
+	s.record(ctx, actor.UserID, model.AuditDomainCertificateDeleted, domain, nil)
+	return nil
+}
+
+// GetCertificate picks the certificate for a TLS handshake by its server
+// name; it is meant for tls.Config.GetCertificate.
+func (s *CertificateServiceImpl) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	host := util.NormalizeHost(hello.ServerName)
+	if s.config.Fallback != nil && host == util.NormalizeHost(s.config.BaseHost) {
+		return s.config.Fallback, nil
+	}
+	if host != "" {
+		cert, err := s.certificateFor(hello.Context(), host)
+		if err != nil {
+			return nil, err
+		}
+		if cert != nil {
+			return cert, nil
+		}
+	}
+	if s.config.Fallback != nil {
+		return s.config.Fallback, nil
+	}
+	return nil, ErrNoCertificate
+}
+
+// HasCertificate reports whether HTTPS requests for host get a certificate
+// made for it.
+func (s *CertificateServiceImpl) HasCertificate(ctx context.Context, host string) bool {
+	host = util.NormalizeHost(host)
+	if s.config.Fallback != nil && host == util.NormalizeHost(s.config.BaseHost) {
+		return true
+	}
+	cert, err := s.certificateFor(ctx, host)
+	return err == nil && cert != nil
+}
+
+func (s *CertificateServiceImpl) ChallengeResponse(ctx context.Context, token string) (string, error) {
+	return s.challenges.Get(ctx, token)
+}
+
+// Renew requests new ACME certificates for those expiring within
+// RenewBefore. Uploaded certificates cannot be renewed here, so they are
+// reported instead. It returns how many certificates were renewed.
+func (s *CertificateServiceImpl) Renew(ctx context.Context, limit int) (int, error) {
+	certs, err := s.certRepo.ListExpiring(ctx, time.Now().Add(s.config.RenewBefore), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	renewed := 0
+	for _, cert := range certs {
+		domain, err := s.domainRepo.GetByID(ctx, cert.DomainID)
+		if err != nil {
+			logger.Error(ctx, "certificate-service: failed to get domain for renewal",
+				zap.Uint64("domain_id", cert.DomainID),
+				zap.Error(err),
+			)
+			continue
+		}
+		if cert.Source != model.CertificateACME || s.acme == nil {
+			logger.Warn(ctx, "certificate-service: uploaded certificate expires soon",
+				zap.String("domain", domain.Domain),
+				zap.Time("not_after", cert.NotAfter),
+			)
+			continue
+		}
+
+		renewedCert, err := s.obtain(ctx, domain)
+		if err != nil {
+			logger.Warn(ctx, "certificate-service: failed to renew certificate",
+				zap.String("domain", domain.Domain),
+				zap.Time("not_after", cert.NotAfter),
+				zap.Error(err),
+			)
+			if err := s.certRepo.SetError(ctx, cert.ID, err.Error()); err != nil {
+				return renewed, err
+			}
+			continue
+		}
+		s.record(ctx, 0, model.AuditDomainCertificateSet, domain, renewedCert)
+		renewed++
+	}
+	return renewed, nil
+}
+
+// obtain requests a certificate for a new key from the ACME certificate
+// authority and stores it.
+func (s *CertificateServiceImpl) obtain(ctx context.Context, domain *model.Domain) (*model.DomainCertificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	certPEM, err := s.acme.Obtain(ctx, domain.Domain, key, s.challenges)
+	if err != nil {
+		logger.Warn(ctx, "certificate-service: acme request failed",
+			zap.String("domain", domain.Domain),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("%w: %v", ErrACMEFailed, err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	leaf, err := checkCertificate(certPEM, keyPEM, domain.Domain, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrACMEFailed, err)
+	}
+	return s.save(ctx, domain, model.CertificateACME, certPEM, keyPEM, leaf)
+}
+
+func (s *CertificateServiceImpl) save(ctx context.Context, domain *model.Domain, source string, certPEM, keyPEM []byte, leaf *x509.Certificate) (*model.DomainCertificate, error) {
+	encryptedKey, err := s.cipher.Encrypt(keyPEM)
+	if err != nil {
+		return nil, err
+	}
+	cert := &model.DomainCertificate{
+		DomainID:       domain.ID,
+		Source:         source,
+		CertificatePEM: string(certPEM),
+		PrivateKey:     encryptedKey,
+		Issuer:         leaf.Issuer.CommonName,
+		NotBefore:      leaf.NotBefore,
+		NotAfter:       leaf.NotAfter,
+	}
+	if err := s.certRepo.Save(ctx, cert); err != nil {
+		return nil, err
+	}
+	s.forget()
+	return cert, nil
+}
+
+// certificateFor returns the certificate of the verified domain on host,
+// falling back to a wildcard domain matching it, or nil when neither has one.
+// Misses are cached too, so handshakes for unknown names do not reach the
+// database; the cache's size cap keeps arbitrary SNI names from growing it.
+func (s *CertificateServiceImpl) certificateFor(ctx context.Context, host string) (*tls.Certificate, error) {
+	if s.cipher == nil {
+		return nil, nil
+	}
+	s.mu.RLock()
+	cached, ok := s.cache[host]
+	s.mu.RUnlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.cert, nil
+	}
+
+	for _, pattern := range util.HostPatterns(host) {
+		stored, err := s.certRepo.GetByHost(ctx, pattern)
+		if errors.Is(err, repository.ErrCertificateNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		cert, err := s.keyPair(stored)
+		if err != nil {
+			// Served as missing; the owner has to replace it
+			logger.Error(ctx, "certificate-service: stored certificate is unusable",
+				zap.Uint64("domain_id", stored.DomainID),
+				zap.Error(err),
+			)
+		}
+		s.remember(host, cert)
+		return cert, nil
+	}
+	s.remember(host, nil)
+	return nil, nil
+}
+
+// remember caches a host's certificate. When the cache is full, expired
+// entries are swept first and an arbitrary entry is evicted if none were.
+func (s *CertificateServiceImpl) remember(host string, cert *tls.Certificate) {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.cache[host]; !ok && len(s.cache) >= certificateCacheSize {
+		for cachedHost, cached := range s.cache {
+			if !now.Before(cached.expires) {
+				delete(s.cache, cachedHost)
+			}
+		}
+		for cachedHost := range s.cache {
+			if len(s.cache) < certificateCacheSize {
+				break
+			}
+			delete(s.cache, cachedHost)
+		}
+	}
+	s.cache[host] = cachedCertificate{cert: cert, expires: now.Add(certificateCacheTTL)}
+}
+
+func (s *CertificateServiceImpl) keyPair(stored *model.DomainCertificate) (*tls.Certificate, error) {
+	keyPEM, err := s.cipher.Decrypt(stored.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	pair, err := tls.X509KeyPair([]byte(stored.CertificatePEM), keyPEM)
+	if err != nil {
+		return nil, err
+	}
+	return &pair, nil
+}
+
+// forget drops the cached certificates on this instance. A wildcard
+// domain's certificate is cached under every host it served.
+func (s *CertificateServiceImpl) forget() {
+	s.mu.Lock()
+	s.cache = make(map[string]cachedCertificate)
+	s.mu.Unlock()
+}
+
+// domain returns the actor's domain.
+func (s *CertificateServiceImpl) domain(ctx context.Context, actor Actor, domainID uint64) (*model.Domain, error) {
+	domain, err := s.domainRepo.GetByID(ctx, domainID)
+	if errors.Is(err, repository.ErrDomainNotFound) {
+		return nil, ErrDomainNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if domain.WorkspaceID != actor.WorkspaceID {
+		return nil, ErrDomainNotFound
+	}
+	return domain, nil
+}
+
+// managedDomain returns the actor's domain when they may set its
+// certificate: it takes an admin, and only verified domains are served.
+func (s *CertificateServiceImpl) managedDomain(ctx context.Context, actor Actor, domainID uint64) (*model.Domain, error) {
+	domain, err := s.domain(ctx, actor, domainID)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.Can(model.RoleAdmin) {
+		return nil, ErrInsufficientRole
+	}
+	if !domain.IsVerified() {
+		return nil, ErrDomainNotVerified
+	}
+	return domain, nil
+}
+
+func (s *CertificateServiceImpl) record(ctx context.Context, actorUserID uint64, action string, domain *model.Domain, cert *model.DomainCertificate) {
+	event := AuditEvent{
+		WorkspaceID: domain.WorkspaceID,
+		ActorUserID: actorUserID,
+		Action:      action,
+		TargetType:  model.AuditTargetDomain,
+		TargetID:    domain.ID,
+	}
+	if cert != nil {
+		event.After = map[string]any{"source": cert.Source, "issuer": cert.Issuer, "not_after": cert.NotAfter}
+	}
+	recordAudit(ctx, s.audit, event)
+}
+
+// checkCertificate parses a PEM certificate chain and its key and returns
+// the leaf, which must be valid for domain at now. A wildcard domain needs a
+// certificate for the same wildcard.
+func checkCertificate(certPEM, keyPEM []byte, domain string, now time.Time) (*x509.Certificate, error) {
+	pair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCertificate, err)
+	}
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCertificate, err)
+	}
+	if err := leaf.VerifyHostname(domain); err != nil {
+		return nil, fmt.Errorf("%w: not valid for %s", ErrInvalidCertificate, domain)
+	}
+	if now.After(leaf.NotAfter) {
+		return nil, fmt.Errorf("%w: expired on %s", ErrInvalidCertificate, leaf.NotAfter.Format(time.RFC3339))
+	}
+	return leaf, nil
+}
@@ -0,0 +1,259 @@
+package service_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/internal/repository"
+	"github.com/SeaCodeBase/urlshortener/internal/repository/mocks"
+	"github.com/SeaCodeBase/urlshortener/internal/service"
+	"github.com/SeaCodeBase/urlshortener/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// selfSigned issues a certificate for name signed by key itself.
+func selfSigned(t *testing.T, name string, key crypto.Signer, notAfter time.Time) []byte {
+	t.Helper()
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func newKeyPEM(t *testing.T) (*ecdsa.PrivateKey, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return key, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+// fakeACME stands in for a certificate authority: it publishes a challenge
+// and issues a self-signed certificate, or fails with the domain's error.
+type fakeACME struct {
+	t         *testing.T
+	errs      map[string]error
+	requested []string
+	published map[string]string
+}
+
+func (a *fakeACME) Obtain(ctx context.Context, domain string, key crypto.Signer, challenges service.ChallengeStore) ([]byte, error) {
+	a.requested = append(a.requested, domain)
+	if err := a.errs[domain]; err != nil {
+		return nil, err
+	}
+	if err := challenges.Put(ctx, "token-"+domain, "key-auth"); err != nil {
+		return nil, err
+	}
+	a.published["token-"+domain], _ = challenges.Get(ctx, "token-"+domain)
+	return selfSigned(a.t, domain, key, time.Now().Add(90*24*time.Hour)), nil
+}
+
+// memoryChallenges is a ChallengeStore in a map.
+type memoryChallenges map[string]string
+
+func (m memoryChallenges) Put(ctx context.Context, token, keyAuth string) error {
+	m[token] = keyAuth
+	return nil
+}
+
+func (m memoryChallenges) Get(ctx context.Context, token string) (string, error) {
+	keyAuth, ok := m[token]
+	if !ok {
+		return "", service.ErrChallengeNotFound
+	}
+	return keyAuth, nil
+}
+
+func (m memoryChallenges) Delete(ctx context.Context, token string) error {
+	delete(m, token)
+	return nil
+}
+
+func newCertificateService(t *testing.T, ctrl *gomock.Controller, acme service.ACMEClient) (*service.CertificateServiceImpl, *mocks.MockDomainCertificateRepository, *mocks.MockDomainRepository) {
+	t.Helper()
+	mockCertRepo := mocks.NewMockDomainCertificateRepository(ctrl)
+	mockDomainRepo := mocks.NewMockDomainRepository(ctrl)
+	cipher, err := util.NewSecretCipher("test-secret")
+	require.NoError(t, err)
+	svc := service.NewCertificateService(mockCertRepo, mockDomainRepo, cipher, acme, memoryChallenges{}, nil,
+		service.CertificateConfig{BaseHost: "s.example.com", RenewBefore: 30 * 24 * time.Hour})
+	return svc, mockCertRepo, mockDomainRepo
+}
+
+func TestCertificateService_Upload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc, mockCertRepo, mockDomainRepo := newCertificateService(t, ctrl, nil)
+	verifiedAt := time.Now()
+	domain := &model.Domain{ID: 5, WorkspaceID: 1, Domain: "go.example.com", VerifiedAt: &verifiedAt}
+	mockDomainRepo.EXPECT().GetByID(gomock.Any(), uint64(5)).Return(domain, nil).AnyTimes()
+
+	key, keyPEM := newKeyPEM(t)
+	_, otherKeyPEM := newKeyPEM(t)
+	valid := selfSigned(t, "go.example.com", key, time.Now().Add(24*time.Hour))
+
+	tests := []struct {
+		name string
+		cert []byte
+		key  []byte
+	}{
+		{"key does not match", valid, otherKeyPEM},
+		{"other host", selfSigned(t, "other.example.com", key, time.Now().Add(24*time.Hour)), keyPEM},
+		{"expired", selfSigned(t, "go.example.com", key, time.Now().Add(-time.Minute)), keyPEM},
+		{"not PEM", []byte("certificate"), keyPEM},
+	}
+	for _, tt := range tests {
+		_, err := svc.Upload(context.Background(), domainAdmin, 5, service.UploadCertificateInput{
+			Certificate: string(tt.cert), PrivateKey: string(tt.key),
+		})
+		assert.ErrorIs(t, err, service.ErrInvalidCertificate, tt.name)
+	}
+
+	_, err := svc.Upload(context.Background(), editor, 5, service.UploadCertificateInput{Certificate: string(valid), PrivateKey: string(keyPEM)})
+	assert.ErrorIs(t, err, service.ErrInsufficientRole)
+
+	// The stored key is encrypted and the certificate is served by SNI
+	var saved *model.DomainCertificate
+	mockCertRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, cert *model.DomainCertificate) error {
+		saved = cert
+		return nil
+	})
+	cert, err := svc.Upload(context.Background(), domainAdmin, 5, service.UploadCertificateInput{Certificate: string(valid), PrivateKey: string(keyPEM)})
+	require.NoError(t, err)
+	assert.Equal(t, model.CertificateUploaded, cert.Source)
+	assert.Equal(t, "go.example.com", cert.Issuer)
+	assert.NotContains(t, string(saved.PrivateKey), "PRIVATE KEY")
+
+	mockCertRepo.EXPECT().GetByHost(gomock.Any(), "go.example.com").Return(saved, nil)
+	served, err := svc.GetCertificate(&tls.ClientHelloInfo{ServerName: "Go.Example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, cert.NotAfter, served.Leaf.NotAfter)
+	assert.True(t, svc.HasCertificate(context.Background(), "go.example.com:80"), "lookup is cached")
+
+	// Names without a certificate fail the handshake when there is no fallback
+	mockCertRepo.EXPECT().GetByHost(gomock.Any(), "unknown.example.com").Return(nil, repository.ErrCertificateNotFound)
+	mockCertRepo.EXPECT().GetByHost(gomock.Any(), "*.example.com").Return(nil, repository.ErrCertificateNotFound)
+	_, err = svc.GetCertificate(&tls.ClientHelloInfo{ServerName: "unknown.example.com"})
+	assert.ErrorIs(t, err, service.ErrNoCertificate)
+
+	// and the miss is cached like a hit
+	assert.False(t, svc.HasCertificate(context.Background(), "unknown.example.com"))
+}
+
+func TestCertificateService_UploadWildcard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc, mockCertRepo, mockDomainRepo := newCertificateService(t, ctrl, &fakeACME{t: t, published: map[string]string{}})
+	verifiedAt := time.Now()
+	mockDomainRepo.EXPECT().GetByID(gomock.Any(), uint64(5)).
+		Return(&model.Domain{ID: 5, WorkspaceID: 1, Domain: "*.links.example.com", VerifiedAt: &verifiedAt}, nil).AnyTimes()
+
+	// A wildcard domain needs a wildcard certificate, which HTTP-01 cannot issue
+	key, keyPEM := newKeyPEM(t)
+	_, err := svc.Upload(context.Background(), domainAdmin, 5, service.UploadCertificateInput{
+		Certificate: string(selfSigned(t, "a.links.example.com", key, time.Now().Add(24*time.Hour))), PrivateKey: string(keyPEM),
+	})
+	assert.ErrorIs(t, err, service.ErrInvalidCertificate)
+	_, err = svc.Request(context.Background(), domainAdmin, 5)
+	assert.ErrorIs(t, err, service.ErrACMEWildcard)
+
+	var saved *model.DomainCertificate
+	mockCertRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, cert *model.DomainCertificate) error {
+		saved = cert
+		return nil
+	})
+	_, err = svc.Upload(context.Background(), domainAdmin, 5, service.UploadCertificateInput{
+		Certificate: string(selfSigned(t, "*.links.example.com", key, time.Now().Add(24*time.Hour))), PrivateKey: string(keyPEM),
+	})
+	require.NoError(t, err)
+
+	// Hosts below the wildcard are served its certificate
+	mockCertRepo.EXPECT().GetByHost(gomock.Any(), "team.links.example.com").Return(nil, repository.ErrCertificateNotFound)
+	mockCertRepo.EXPECT().GetByHost(gomock.Any(), "*.links.example.com").Return(saved, nil)
+	served, err := svc.GetCertificate(&tls.ClientHelloInfo{ServerName: "team.links.example.com"})
+	require.NoError(t, err)
+	assert.NoError(t, served.Leaf.VerifyHostname("team.links.example.com"))
+}
+
+func TestCertificateService_Request(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	disabled, _, _ := newCertificateService(t, ctrl, nil)
+	_, err := disabled.Request(context.Background(), domainAdmin, 5)
+	assert.ErrorIs(t, err, service.ErrACMEDisabled)
+
+	acme := &fakeACME{t: t, published: map[string]string{}}
+	svc, mockCertRepo, mockDomainRepo := newCertificateService(t, ctrl, acme)
+	verifiedAt := time.Now()
+	mockDomainRepo.EXPECT().GetByID(gomock.Any(), uint64(5)).
+		Return(&model.Domain{ID: 5, WorkspaceID: 1, Domain: "go.example.com", VerifiedAt: &verifiedAt}, nil).AnyTimes()
+	mockDomainRepo.EXPECT().GetByID(gomock.Any(), uint64(6)).
+		Return(&model.Domain{ID: 6, WorkspaceID: 1, Domain: "pending.example.com", VerificationToken: "token"}, nil)
+
+	_, err = svc.Request(context.Background(), domainAdmin, 6)
+	assert.ErrorIs(t, err, service.ErrDomainNotVerified)
+
+	mockCertRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+	cert, err := svc.Request(context.Background(), domainAdmin, 5)
+	require.NoError(t, err)
+	assert.Equal(t, model.CertificateACME, cert.Source)
+	assert.Equal(t, []string{"go.example.com"}, acme.requested)
+	assert.Equal(t, "key-auth", acme.published["token-go.example.com"])
+
+	acme.errs = map[string]error{"go.example.com": errors.New("rate limited")}
+	_, err = svc.Request(context.Background(), domainAdmin, 5)
+	assert.ErrorIs(t, err, service.ErrACMEFailed)
+}
+
+func TestCertificateService_Renew(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	acme := &fakeACME{t: t, published: map[string]string{}, errs: map[string]error{"failing.example.com": errors.New("connection refused")}}
+	svc, mockCertRepo, mockDomainRepo := newCertificateService(t, ctrl, acme)
+	verifiedAt := time.Now()
+	for id, name := range map[uint64]string{5: "acme.example.com", 6: "uploaded.example.com", 7: "failing.example.com"} {
+		mockDomainRepo.EXPECT().GetByID(gomock.Any(), id).
+			Return(&model.Domain{ID: id, WorkspaceID: 1, Domain: name, VerifiedAt: &verifiedAt}, nil)
+	}
+	soon := time.Now().Add(7 * 24 * time.Hour)
+	mockCertRepo.EXPECT().ListExpiring(gomock.Any(), gomock.Any(), 10).Return([]*model.DomainCertificate{
+		{ID: 1, DomainID: 5, Source: model.CertificateACME, NotAfter: soon},
+		{ID: 2, DomainID: 6, Source: model.CertificateUploaded, NotAfter: soon},
+		{ID: 3, DomainID: 7, Source: model.CertificateACME, NotAfter: soon},
+	}, nil)
+
+	// Only ACME certificates are renewed; a failed renewal is recorded
+	mockCertRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+	mockCertRepo.EXPECT().SetError(gomock.Any(), uint64(3), "certificate request failed: connection refused").Return(nil)
+
+	renewed, err := svc.Renew(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, renewed)
+	assert.ElementsMatch(t, []string{"acme.example.com", "failing.example.com"}, acme.requested)
+}
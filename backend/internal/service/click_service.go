@@ -14,6 +14,7 @@ import (
 
 type ClickEvent struct {
 	LinkID      uint64    `json:"link_id"`
+	AliasID     *uint64   `json:"alias_id,omitempty"`
 	ClickedAt   time.Time `json:"clicked_at"`
 	IPHash      string    `json:"ip_hash"`
 	IPAddress   string    `json:"ip_address"`
@@ -24,6 +25,17 @@ type ClickEvent struct {
 	UTMCampaign string    `json:"utm_campaign,omitempty"`
 }
 
+// MissEvent is a request on a custom domain that found no link to follow.
+type MissEvent struct {
+	DomainID uint64    `json:"domain_id"`
+	Reason   string    `json:"reason"`
+	Code     string    `json:"code"`
+	At       time.Time `json:"at"`
+}
+
+// maxMissCodeLength bounds the codes kept for misses, which can be any path
+const maxMissCodeLength = 64
+
 type ClickService struct {
 	rdb *redis.Client
 }
@@ -56,6 +68,21 @@ func (s *ClickService) RecordClick(ctx context.Context, event ClickEvent) error
 	return nil
 }
 
+// RecordMiss buffers a miss in Redis for ClickFlusher to count.
+func (s *ClickService) RecordMiss(ctx context.Context, event MissEvent) error {
+	if runes := []rune(event.Code); len(runes) > maxMissCodeLength {
+		event.Code = string(runes[:maxMissCodeLength])
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	// Buffer miss event in Redis list
+	bufferKey := "misses:buffer"
+	return s.rdb.LPush(ctx, bufferKey, data).Err()
+}
+
 func (s *ClickService) GetRealtimeCount(ctx context.Context, linkID uint64) (int64, error) {
 	counterKey := fmt.Sprintf("clicks:count:%d", linkID)
 	return s.rdb.Get(ctx, counterKey).Int64()
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"sync"
+)
+
+// BlockSequence serves CodeSequence values from blocks reserved in advance,
+// so an instance only goes to the backing sequence once per block. Values
+// left in a block when the process exits are skipped, and codes from
+// different instances interleave rather than being strictly increasing.
+type BlockSequence struct {
+	seq       CodeSequence
+	blockSize uint64
+
+	mu     sync.Mutex
+	blocks map[string]*sequenceBlock
+}
+
+type sequenceBlock struct {
+	next uint64
+	end  uint64 // exclusive
+}
+
+func NewBlockSequence(seq CodeSequence, blockSize uint64) *BlockSequence {
+	if blockSize == 0 {
+		blockSize = 1
+	}
+	return &BlockSequence{
+		seq:       seq,
+		blockSize: blockSize,
+		blocks:    make(map[string]*sequenceBlock),
+	}
+}
+
+func (b *BlockSequence) Reserve(ctx context.Context, scope string, n uint64) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	block := b.blocks[scope]
+	if block == nil || block.end-block.next < n {
+		size := b.blockSize
+		if n > size {
+			size = n
+		}
+		first, err := b.seq.Reserve(ctx, scope, size)
+		if err != nil {
+			return 0, err
+		}
+		block = &sequenceBlock{next: first, end: first + size}
+		b.blocks[scope] = block
+	}
+
+	first := block.next
+	block.next += n
+	return first, nil
+}
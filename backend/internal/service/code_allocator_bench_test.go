@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SeaCodeBase/urlshortener/internal/repository"
+)
+
+// roundTrip simulates the latency of one database query.
+const roundTrip = 200 * time.Microsecond
+
+// fakeLinkStore is an in-memory links table with a unique index and a fixed
+// delay per query, standing in for MariaDB.
+type fakeLinkStore struct {
+	mu    sync.Mutex
+	codes map[string]bool
+}
+
+func newFakeLinkStore() *fakeLinkStore {
+	return &fakeLinkStore{codes: make(map[string]bool)}
+}
+
+func (f *fakeLinkStore) ShortCodeExistsInDomain(ctx context.Context, domainID *uint64, code string) (bool, error) {
+	time.Sleep(roundTrip)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.codes[code], nil
+}
+
+func (f *fakeLinkStore) insert(code string) error {
+	time.Sleep(roundTrip)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.codes[code] {
+		return repository.ErrShortCodeExists
+	}
+	f.codes[code] = true
+	return nil
+}
+
+// slowSequence is a database-backed sequence with a fixed delay per reservation.
+type slowSequence struct {
+	mu sync.Mutex
+	memorySequence
+}
+
+func (s *slowSequence) Reserve(ctx context.Context, scope string, n uint64) (uint64, error) {
+	time.Sleep(roundTrip)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.memorySequence.Reserve(ctx, scope, n)
+}
+
+// BenchmarkCreateCheckThenInsert is the old path: one existence query per
+// candidate, then the insert.
+func BenchmarkCreateCheckThenInsert(b *testing.B) {
+	store := newFakeLinkStore()
+	generator := &RandomCodeGenerator{Alphabet: alphabet}
+	ctx := context.Background()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			for {
+				code, err := generator.Generate(ctx, nil, defaultLen)
+				if err != nil {
+					b.Fatal(err)
+				}
+				exists, err := store.ShortCodeExistsInDomain(ctx, nil, code)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if exists {
+					continue
+				}
+				if err := store.insert(code); err != nil {
+					b.Fatal(err)
+				}
+				break
+			}
+		}
+	})
+}
+
+// BenchmarkCreateAllocate inserts candidates directly and retries on duplicates.
+func BenchmarkCreateAllocate(b *testing.B) {
+	store := newFakeLinkStore()
+	svc := NewShortCodeService(store, nil, nil, nil, ShortCodeConfig{})
+	ctx := context.Background()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := svc.Allocate(ctx, nil, "", store.insert); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// BenchmarkSequentialAllocate compares reserving each sequence value from the
+// database with reserving blocks per instance.
+func BenchmarkSequentialAllocate(b *testing.B) {
+	for _, blockSize := range []uint64{1, 100, 1000} {
+		b.Run("block="+strconv.FormatUint(blockSize, 10), func(b *testing.B) {
+			store := newFakeLinkStore()
+			generators := NewCodeGenerators(NewBlockSequence(&slowSequence{}, blockSize), "salt")
+			svc := NewShortCodeService(store, nil, nil, generators, ShortCodeConfig{Strategy: CodeStrategySequential})
+			ctx := context.Background()
+
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if _, err := svc.Allocate(ctx, nil, "", store.insert); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		})
+	}
+}
+
+// BenchmarkCodeGenerators measures candidate generation alone for each strategy.
+func BenchmarkCodeGenerators(b *testing.B) {
+	generators := NewCodeGenerators(NewBlockSequence(&memorySequence{}, 1000), "salt")
+	ctx := context.Background()
+	for _, name := range []string{CodeStrategyRandom, CodeStrategyNoLookalike, CodeStrategySequential, CodeStrategyHashids, CodeStrategyWords} {
+		b.Run(name, func(b *testing.B) {
+			generator := generators[name]
+			for i := 0; i < b.N; i++ {
+				if _, err := generator.Generate(ctx, nil, defaultLen); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
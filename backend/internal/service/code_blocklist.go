@@ -0,0 +1,77 @@
+package service
+
+import (
+	_ "embed"
+	"strings"
+)
+
+// builtinReservedCodes are codes that collide with routes on the redirect or
+// API servers, or that users would expect to belong to the service.
+var builtinReservedCodes = []string{
+	"about", "account", "admin", "api", "app", "assets", "auth", "blog", "callback",
+	"dashboard", "docs", "favicon", "health", "help", "home", "login", "logout",
+	"metrics", "oauth", "privacy", "register", "robots", "root", "settings", "signin",
+	"signup", "sitemap", "static", "status", "support", "terms", "www",
+}
+
+//go:embed profanity.txt
+var profanityList string
+
+// leetReplacer undoes common digit-for-letter substitutions before matching profanity.
+var leetReplacer = strings.NewReplacer("0", "o", "1", "i", "3", "e", "4", "a", "5", "s", "7", "t", "8", "b")
+
+// CodeBlocklist rejects reserved words and profanity in short codes. Matching
+// is case-insensitive.
+type CodeBlocklist struct {
+	reserved map[string]bool
+	// whole are profane words matched only against the whole code, partial
+	// are matched anywhere in it
+	whole   map[string]bool
+	partial []string
+}
+
+// NewCodeBlocklist builds a blocklist from the built-in reserved codes, the
+// extra reserved codes given and the embedded profanity list.
+func NewCodeBlocklist(reserved []string) *CodeBlocklist {
+	b := &CodeBlocklist{reserved: make(map[string]bool), whole: make(map[string]bool)}
+	for _, code := range append(builtinReservedCodes, reserved...) {
+		b.reserved[strings.ToLower(code)] = true
+	}
+	for _, line := range strings.Split(profanityList, "\n") {
+		word := strings.TrimSpace(line)
+		switch {
+		case word == "" || strings.HasPrefix(word, "#"):
+		case strings.HasPrefix(word, "="):
+			b.whole[word[1:]] = true
+		default:
+			b.partial = append(b.partial, word)
+		}
+	}
+	return b
+}
+
+// IsReserved reports whether code is a built-in or configured reserved code.
+func (b *CodeBlocklist) IsReserved(code string) bool {
+	return b.reserved[strings.ToLower(code)]
+}
+
+// IsProfane reports whether code is or contains a word from the profanity list.
+func (b *CodeBlocklist) IsProfane(code string) bool {
+	lower := strings.ToLower(code)
+	for _, candidate := range []string{lower, leetReplacer.Replace(lower)} {
+		if b.whole[candidate] {
+			return true
+		}
+		for _, word := range b.partial {
+			if strings.Contains(candidate, word) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Blocks reports whether code may not be used at all.
+func (b *CodeBlocklist) Blocks(code string) bool {
+	return b.IsReserved(code) || b.IsProfane(code)
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Character classes a domain may allow in its short codes.
+const (
+	// CodeCharsetAlnum allows ASCII letters and digits (the default)
+	CodeCharsetAlnum = "alnum"
+	// CodeCharsetSlug also allows '-', '_' and '.' between letters and digits
+	CodeCharsetSlug = "slug"
+	// CodeCharsetUnicode is like slug but with letters and digits from any script
+	CodeCharsetUnicode = "unicode"
+)
+
+// maxPolicyCodeLen is the longest code a domain policy may allow; it matches
+// the width of the code columns.
+const maxPolicyCodeLen = 64
+
+// CodePolicy decides which codes a domain accepts and how they are compared.
+// Codes are stored and looked up in their canonical form, so two codes that
+// canonicalize alike are the same code.
+type CodePolicy struct {
+	CaseInsensitive bool
+	Charset         string
+	MinLength       int
+	MaxLength       int
+}
+
+// DefaultCodePolicy applies to the default domain and to domains without
+// their own settings.
+var DefaultCodePolicy = CodePolicy{Charset: CodeCharsetAlnum, MinLength: minCodeLen, MaxLength: maxCodeLen}
+
+// reservationPolicy accepts anything a domain policy could, folded, as
+// reserved codes are matched case-insensitively on every domain.
+var reservationPolicy = CodePolicy{CaseInsensitive: true, Charset: CodeCharsetUnicode, MinLength: 1, MaxLength: maxPolicyCodeLen}
+
+// IsCodeCharset reports whether name is a known code character class.
+func IsCodeCharset(name string) bool {
+	switch name {
+	case CodeCharsetAlnum, CodeCharsetSlug, CodeCharsetUnicode:
+		return true
+	}
+	return false
+}
+
+// CodePolicyFor returns the policy of a domain; nil means the default domain.
+func CodePolicyFor(domain *model.Domain) CodePolicy {
+	policy := DefaultCodePolicy
+	if domain == nil {
+		return policy
+	}
+	policy.CaseInsensitive = domain.CaseInsensitiveCodes
+	if domain.CodeCharset != nil {
+		policy.Charset = *domain.CodeCharset
+	}
+	if domain.CodeMinLength != nil {
+		policy.MinLength = *domain.CodeMinLength
+	}
+	if domain.CodeMaxLength != nil {
+		policy.MaxLength = *domain.CodeMaxLength
+	}
+	return policy
+}
+
+// Canonical returns the form a code is stored and looked up in: NFC for
+// Unicode codes, case-folded when the policy is case-insensitive.
+func (p CodePolicy) Canonical(code string) string {
+	if p.Charset == CodeCharsetUnicode {
+		code = norm.NFC.String(code)
+	}
+	if p.CaseInsensitive {
+		code = cases.Fold().String(code)
+	}
+	return code
+}
+
+// Allows reports whether a canonical code has an allowed length and only
+// allowed characters. Separators may not start or end a code.
+func (p CodePolicy) Allows(code string) bool {
+	length := utf8.RuneCountInString(code)
+	if length < p.MinLength || length > p.MaxLength {
+		return false
+	}
+	for i, c := range code {
+		if isAlphanumeric(c) {
+			continue
+		}
+		if p.Charset == CodeCharsetUnicode && (unicode.IsLetter(c) || unicode.IsDigit(c) || unicode.IsMark(c)) {
+			continue
+		}
+		if p.Charset != CodeCharsetAlnum && strings.ContainsRune("-_.", c) && i > 0 && i < len(code)-1 {
+			continue
+		}
+		return false
+	}
+	return true
+}
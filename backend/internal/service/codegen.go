@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	_ "embed"
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Code generation strategies selectable per domain or per request.
+const (
+	CodeStrategyRandom      = "random"
+	CodeStrategyNoLookalike = "no-lookalike"
+	CodeStrategySequential  = "sequential"
+	CodeStrategyHashids     = "hashids"
+	CodeStrategyWords       = "words"
+)
+
+// noLookalikeAlphabet is base62 without characters that are easily confused (0/O, 1/l).
+const noLookalikeAlphabet = "23456789ABCDEFGHIJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var (
+	ErrUnknownCodeStrategy = errors.New("unknown code strategy")
+	ErrCodeSpaceExhausted  = errors.New("no free short code up to the maximum length")
+)
+
+//go:embed wordlist.txt
+var wordlist string
+
+// CodeGenerator produces candidate short codes for a domain. Candidates are
+// not guaranteed to be free; ShortCodeService checks and retries.
+// domainID nil means the default domain.
+type CodeGenerator interface {
+	Generate(ctx context.Context, domainID *uint64, length int) (string, error)
+}
+
+// CodeSequence hands out increasing numbers per scope (e.g. per domain).
+type CodeSequence interface {
+	// Reserve reserves n consecutive values and returns the first one.
+	Reserve(ctx context.Context, scope string, n uint64) (uint64, error)
+}
+
+// NewCodeGenerators builds every strategy keyed by name. Sequential and hashids
+// codes draw from seq; hashids codes are obfuscated with salt and left out
+// when salt is empty, since unsalted codes would reveal the sequence.
+func NewCodeGenerators(seq CodeSequence, salt string) map[string]CodeGenerator {
+	generators := map[string]CodeGenerator{
+		CodeStrategyRandom:      &RandomCodeGenerator{Alphabet: alphabet},
+		CodeStrategyNoLookalike: &RandomCodeGenerator{Alphabet: noLookalikeAlphabet},
+		CodeStrategySequential:  &SequentialCodeGenerator{seq: seq},
+		CodeStrategyWords:       NewWordsCodeGenerator(),
+	}
+	if salt != "" {
+		generators[CodeStrategyHashids] = NewHashidsCodeGenerator(seq, salt)
+	}
+	return generators
+}
+
+// RandomCodeGenerator picks each character uniformly from Alphabet.
+type RandomCodeGenerator struct {
+	Alphabet string
+}
+
+func (g *RandomCodeGenerator) Generate(ctx context.Context, domainID *uint64, length int) (string, error) {
+	result := make([]byte, length)
+	alphabetLen := big.NewInt(int64(len(g.Alphabet)))
+
+	for i := 0; i < length; i++ {
+		num, err := rand.Int(rand.Reader, alphabetLen)
+		if err != nil {
+			return "", err
+		}
+		result[i] = g.Alphabet[num.Int64()]
+	}
+
+	return string(result), nil
+}
+
+// SequentialCodeGenerator encodes a per-domain counter in base62, left-padded
+// to the requested length.
+type SequentialCodeGenerator struct {
+	seq CodeSequence
+}
+
+func (g *SequentialCodeGenerator) Generate(ctx context.Context, domainID *uint64, length int) (string, error) {
+	n, err := g.seq.Reserve(ctx, sequenceScope(domainID), 1)
+	if err != nil {
+		return "", err
+	}
+	return padCode(encodeBase(n, alphabet), alphabet[0], length), nil
+}
+
+// HashidsCodeGenerator turns a per-domain counter into a non-sequential looking
+// code, in the style of hashids: a lottery character selects a salted shuffle
+// of the alphabet, and the counter is encoded in that shuffled alphabet.
+// Distinct counter values always give distinct codes of the same length.
+type HashidsCodeGenerator struct {
+	seq      CodeSequence
+	alphabet string
+	salt     string
+}
+
+func NewHashidsCodeGenerator(seq CodeSequence, salt string) *HashidsCodeGenerator {
+	shuffled := []byte(alphabet)
+	consistentShuffle(shuffled, salt)
+	return &HashidsCodeGenerator{seq: seq, alphabet: string(shuffled), salt: salt}
+}
+
+func (g *HashidsCodeGenerator) Generate(ctx context.Context, domainID *uint64, length int) (string, error) {
+	n, err := g.seq.Reserve(ctx, sequenceScope(domainID), 1)
+	if err != nil {
+		return "", err
+	}
+	return g.encode(n, length), nil
+}
+
+func (g *HashidsCodeGenerator) encode(n uint64, length int) string {
+	lottery := g.alphabet[n%uint64(len(g.alphabet))]
+	shuffled := []byte(g.alphabet)
+	consistentShuffle(shuffled, string(lottery)+g.salt)
+	digits := string(shuffled)
+	return string(lottery) + padCode(encodeBase(n, digits), digits[0], length-1)
+}
+
+// WordsCodeGenerator joins capitalized words from an embedded wordlist, e.g.
+// "BraveOtter", until the code is at least the requested length.
+type WordsCodeGenerator struct {
+	words []string
+}
+
+func NewWordsCodeGenerator() *WordsCodeGenerator {
+	return &WordsCodeGenerator{words: strings.Fields(wordlist)}
+}
+
+func (g *WordsCodeGenerator) Generate(ctx context.Context, domainID *uint64, length int) (string, error) {
+	var b strings.Builder
+	wordsLen := big.NewInt(int64(len(g.words)))
+	for b.Len() < length {
+		num, err := rand.Int(rand.Reader, wordsLen)
+		if err != nil {
+			return "", err
+		}
+		word := g.words[num.Int64()]
+		if b.Len() > 0 && b.Len()+len(word) > maxCodeLen {
+			break
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String(), nil
+}
+
+// sequenceScope names the counter used for a domain.
+func sequenceScope(domainID *uint64) string {
+	if domainID == nil {
+		return "default"
+	}
+	return "domain:" + strconv.FormatUint(*domainID, 10)
+}
+
+func encodeBase(n uint64, digits string) string {
+	base := uint64(len(digits))
+	if n == 0 {
+		return digits[:1]
+	}
+	var buf []byte
+	for n > 0 {
+		buf = append(buf, digits[n%base])
+		n /= base
+	}
+	for i, j := 0, len(buf)-1; i < j; i, j = i+1, j-1 {
+		buf[i], buf[j] = buf[j], buf[i]
+	}
+	return string(buf)
+}
+
+// padCode left-pads code with the zero digit, which keeps its numeric value.
+func padCode(code string, zero byte, length int) string {
+	if len(code) >= length {
+		return code
+	}
+	return strings.Repeat(string(zero), length-len(code)) + code
+}
+
+// consistentShuffle deterministically permutes alphabet using salt, as hashids does.
+func consistentShuffle(alphabet []byte, salt string) {
+	if len(salt) == 0 {
+		return
+	}
+	for i, v, p := len(alphabet)-1, 0, 0; i > 0; i, v = i-1, v+1 {
+		v %= len(salt)
+		n := int(salt[v])
+		p += n
+		j := (n + v + p) % i
+		alphabet[i], alphabet[j] = alphabet[j], alphabet[i]
+	}
+}
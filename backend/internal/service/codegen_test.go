@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+)
+
+// memorySequence is an in-memory CodeSequence.
+type memorySequence struct {
+	next map[string]uint64
+}
+
+func (m *memorySequence) Reserve(ctx context.Context, scope string, n uint64) (uint64, error) {
+	if m.next == nil {
+		m.next = make(map[string]uint64)
+	}
+	if m.next[scope] == 0 {
+		m.next[scope] = 1
+	}
+	first := m.next[scope]
+	m.next[scope] += n
+	return first, nil
+}
+
+type staticDomainRepo struct {
+	domain *model.Domain
+}
+
+func (r *staticDomainRepo) GetByID(ctx context.Context, id uint64) (*model.Domain, error) {
+	return r.domain, nil
+}
+
+type noCodesExist struct{}
+
+func (noCodesExist) ShortCodeExistsInDomain(ctx context.Context, domainID *uint64, code string) (bool, error) {
+	return false, nil
+}
+
+func TestSequentialCodeGenerator(t *testing.T) {
+	g := &SequentialCodeGenerator{seq: &memorySequence{}}
+	ctx := context.Background()
+
+	first, _ := g.Generate(ctx, nil, 4)
+	second, _ := g.Generate(ctx, nil, 4)
+	if first != "0001" || second != "0002" {
+		t.Errorf("expected 0001, 0002; got %s, %s", first, second)
+	}
+
+	// Each domain has its own counter
+	domainID := uint64(7)
+	if code, _ := g.Generate(ctx, &domainID, 4); code != "0001" {
+		t.Errorf("expected a fresh counter for the domain, got %s", code)
+	}
+}
+
+func TestHashidsCodeGenerator_UniqueAndFixedLength(t *testing.T) {
+	g := NewHashidsCodeGenerator(&memorySequence{}, "pepper")
+	ctx := context.Background()
+
+	seen := make(map[string]bool)
+	for i := 0; i < 5000; i++ {
+		code, err := g.Generate(ctx, nil, 6)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(code) != 6 {
+			t.Fatalf("expected length 6, got %q", code)
+		}
+		if seen[code] {
+			t.Fatalf("duplicate code %q after %d codes", code, i)
+		}
+		seen[code] = true
+	}
+
+	// A different salt gives different codes for the same counter values
+	other := NewHashidsCodeGenerator(&memorySequence{}, "salt")
+	a, _ := NewHashidsCodeGenerator(&memorySequence{}, "pepper").Generate(ctx, nil, 6)
+	b, _ := other.Generate(ctx, nil, 6)
+	if a == b {
+		t.Errorf("expected salts to change the encoding, both gave %q", a)
+	}
+}
+
+func TestRandomCodeGenerator_NoLookalike(t *testing.T) {
+	g := &RandomCodeGenerator{Alphabet: noLookalikeAlphabet}
+	for i := 0; i < 200; i++ {
+		code, _ := g.Generate(context.Background(), nil, 12)
+		if strings.ContainsAny(code, "0O1l") {
+			t.Fatalf("code %q contains a lookalike character", code)
+		}
+	}
+}
+
+func TestWordsCodeGenerator_ProducesValidCodes(t *testing.T) {
+	g := NewWordsCodeGenerator()
+	for i := 0; i < 200; i++ {
+		code, err := g.Generate(context.Background(), nil, 7)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(code) < 7 || !DefaultCodePolicy.Allows(code) {
+			t.Fatalf("generated invalid code %q", code)
+		}
+	}
+}
+
+func TestShortCodeService_StrategyPrecedence(t *testing.T) {
+	words, length := CodeStrategyWords, 5
+	domainID := uint64(3)
+	generators := NewCodeGenerators(&memorySequence{}, "")
+	svc := NewShortCodeService(noCodesExist{},
+		&staticDomainRepo{domain: &model.Domain{ID: domainID, CodeStrategy: &words, CodeLength: &length}},
+		nil, generators, ShortCodeConfig{Strategy: CodeStrategySequential, Length: 6})
+	ctx := context.Background()
+
+	store := func(string) error { return nil }
+
+	// Default domain uses the configured default
+	if code, _ := svc.Allocate(ctx, nil, "", store); code != "000001" {
+		t.Errorf("expected configured sequential default, got %q", code)
+	}
+	// The request's strategy wins over the domain's, with the domain's length
+	if code, _ := svc.Allocate(ctx, &domainID, CodeStrategySequential, store); code != "00001" {
+		t.Errorf("expected sequential code of domain length, got %q", code)
+	}
+	if _, err := svc.Allocate(ctx, &domainID, "nope", store); err != ErrUnknownCodeStrategy {
+		t.Errorf("expected ErrUnknownCodeStrategy, got %v", err)
+	}
+	// Hashids codes are unavailable without a salt
+	if _, err := svc.Allocate(ctx, nil, CodeStrategyHashids, store); err != ErrUnknownCodeStrategy {
+		t.Errorf("expected ErrUnknownCodeStrategy without a salt, got %v", err)
+	}
+}
+
+func TestBlockSequence_ReservesInBlocks(t *testing.T) {
+	backing := &countingSequence{}
+	seq := NewBlockSequence(backing, 10)
+	ctx := context.Background()
+
+	for want := uint64(1); want <= 25; want++ {
+		got, err := seq.Reserve(ctx, "default", 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Fatalf("expected %d, got %d", want, got)
+		}
+	}
+	if backing.calls != 3 {
+		t.Errorf("expected 3 block reservations for 25 values, got %d", backing.calls)
+	}
+
+	// Other scopes get their own blocks
+	if got, _ := seq.Reserve(ctx, "domain:1", 1); got != 1 {
+		t.Errorf("expected the first value of a new scope, got %d", got)
+	}
+}
+
+type countingSequence struct {
+	memorySequence
+	calls int
+}
+
+func (c *countingSequence) Reserve(ctx context.Context, scope string, n uint64) (uint64, error) {
+	c.calls++
+	return c.memorySequence.Reserve(ctx, scope, n)
+}
@@ -0,0 +1,686 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/internal/repository"
+	"github.com/SeaCodeBase/urlshortener/internal/util"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	// DomainVerificationPrefix names the TXT record that proves control of a
+	// domain: the token is published at DomainVerificationPrefix + domain,
+	// or + the name below the "*" of a wildcard.
+	DomainVerificationPrefix = "_urlshortener-challenge."
+	domainTokenLen           = 32
+	// maxDomainCheckFailures is how many rechecks in a row may miss the
+	// token before a verified domain goes back to pending
+	maxDomainCheckFailures = 3
+)
+
+var (
+	ErrDomainExists        = errors.New("domain already in use")
+	ErrInvalidDomain       = errors.New("invalid domain")
+	ErrReservedDomain      = errors.New("domain is reserved by this service")
+	ErrInvalidRedirectURL  = errors.New("redirect url must be an absolute http or https url")
+	ErrNotFoundPageClash   = errors.New("set either a not-found url or a not-found page, not both")
+	ErrInvalidRedirectCode = errors.New("redirect status must be 301, 302, 307 or 308")
+	ErrDomainNotVerified   = errors.New("domain is not verified")
+	ErrDomainTokenNotFound = errors.New("verification TXT record not found")
+	ErrDomainLookupFailed  = errors.New("dns lookup failed, try again later")
+	ErrUnknownCodeCharset  = errors.New("unknown code charset")
+	ErrCodeLengthRange     = errors.New("code min length exceeds max length")
+
+	ErrUnknownDeleteStrategy = errors.New("strategy must be block, move or archive")
+	ErrDomainHasLinks        = errors.New("domain still has links")
+	ErrMoveTargetRequired    = errors.New("moving links needs another target domain")
+	ErrDomainMoveConflict    = errors.New("some codes cannot be moved to the target domain")
+)
+
+// Domain deletion strategies, saying what happens to the domain's links.
+const (
+	DomainDeleteBlock   = "block"
+	DomainDeleteMove    = "move"
+	DomainDeleteArchive = "archive"
+)
+
+// TXTResolver looks up DNS TXT records; *net.Resolver satisfies it.
+type TXTResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// Compile-time check: DomainServiceImpl implements DomainService
+var _ DomainService = (*DomainServiceImpl)(nil)
+
+type DomainServiceImpl struct {
+	domainRepo repository.DomainRepository
+	linkRepo   repository.LinkRepository
+	aliasRepo  repository.LinkAliasRepository
+	shortCode  ShortCodeService
+	audit      AuditService
+	resolver   TXTResolver
+	// baseHost is the service's own host; it and its subdomains cannot be claimed
+	baseHost string
+}
+
+func NewDomainService(domainRepo repository.DomainRepository, linkRepo repository.LinkRepository, aliasRepo repository.LinkAliasRepository,
+	shortCode ShortCodeService, audit AuditService, resolver TXTResolver, baseHost string) *DomainServiceImpl {
+	return &DomainServiceImpl{
+		domainRepo: domainRepo,
+		linkRepo:   linkRepo,
+		aliasRepo:  aliasRepo,
+		shortCode:  shortCode,
+		audit:      audit,
+		resolver:   resolver,
+		baseHost:   util.NormalizeHost(baseHost),
+	}
+}
+
+type CreateDomainInput struct {
+	// Domain is a host or a wildcard pattern such as *.links.example.com
+	Domain string `json:"domain" binding:"required"`
+	// PathPrefix puts the domain's codes under a path, e.g. "go" for
+	// example.com/go/<code>
+	PathPrefix   string `json:"path_prefix,omitempty"`
+	CodeStrategy string `json:"code_strategy,omitempty"`
+	CodeLength   int    `json:"code_length,omitempty" binding:"omitempty,min=3,max=16"`
+	// Code policy; unset fields use the server default (case-sensitive alnum, 3-16 characters)
+	CaseInsensitiveCodes bool   `json:"case_insensitive_codes,omitempty"`
+	CodeCharset          string `json:"code_charset,omitempty"`
+	CodeMinLength        int    `json:"code_min_length,omitempty" binding:"omitempty,min=1,max=64"`
+	CodeMaxLength        int    `json:"code_max_length,omitempty" binding:"omitempty,min=1,max=64"`
+}
+
+// UpdateDomainInput changes a domain's settings and link defaults. Omitted
+// fields are left as they are; an empty string or 0 clears a setting.
+type UpdateDomainInput struct {
+	RootRedirectURL *string `json:"root_redirect_url"`
+	NotFoundURL     *string `json:"not_found_url"`
+	NotFoundHTML    *string `json:"not_found_html" binding:"omitempty,max=65536"`
+	ExpiredURL      *string `json:"expired_url"`
+
+	UseHTTPS       *bool   `json:"use_https"`
+	RedirectStatus *int    `json:"redirect_status"`
+	CodeStrategy   *string `json:"code_strategy"`
+	CodeLength     *int    `json:"code_length" binding:"omitempty,eq=0|min=3,max=16"`
+	// Defaults for links on the domain
+	DefaultExpiryDays  *int    `json:"default_expiry_days" binding:"omitempty,min=0,max=3650"`
+	DefaultUTMSource   *string `json:"default_utm_source" binding:"omitempty,max=255"`
+	DefaultUTMMedium   *string `json:"default_utm_medium" binding:"omitempty,max=255"`
+	DefaultUTMCampaign *string `json:"default_utm_campaign" binding:"omitempty,max=255"`
+	// IsDefault makes this the workspace's domain for new links; only a
+	// verified domain can be the default
+	IsDefault *bool `json:"is_default"`
+}
+
+type DeleteDomainInput struct {
+	Strategy string `form:"strategy" binding:"required"`
+	// TargetDomainID is where the move strategy puts the links; 0 is the service's own domain
+	TargetDomainID *uint64 `form:"target_domain_id"`
+}
+
+type DeleteDomainResult struct {
+	Strategy string `json:"strategy"`
+	Moved    int64  `json:"moved"`
+	Archived int64  `json:"archived"`
+}
+
+// CodeConflict is a link, or one of its aliases, whose code cannot be moved
+// to another domain.
+type CodeConflict struct {
+	LinkID uint64 `json:"link_id"`
+	// AliasID is set when the code is an alias's rather than the link's own
+	AliasID   uint64 `json:"alias_id,omitempty"`
+	ShortCode string `json:"short_code"`
+	Reason    string `json:"reason"`
+}
+
+// DomainMoveConflictError lists the links that kept a move from happening.
+// It matches ErrDomainMoveConflict.
+type DomainMoveConflictError struct {
+	Conflicts []CodeConflict
+}
+
+func (e *DomainMoveConflictError) Error() string {
+	return fmt.Sprintf("%s: %d links", ErrDomainMoveConflict, len(e.Conflicts))
+}
+
+func (e *DomainMoveConflictError) Is(target error) bool {
+	return target == ErrDomainMoveConflict
+}
+
+// redirectStatuses are the statuses a domain's redirects may use
+var redirectStatuses = map[int]bool{
+	http.StatusMovedPermanently:  true,
+	http.StatusFound:             true,
+	http.StatusTemporaryRedirect: true,
+	http.StatusPermanentRedirect: true,
+}
+
+// Create adds a pending domain to the actor's workspace. It serves no
+// redirects until Verify finds its token in DNS.
+func (s *DomainServiceImpl) Create(ctx context.Context, actor Actor, input CreateDomainInput) (*model.Domain, error) {
+	if !actor.Can(model.RoleAdmin) {
+		return nil, ErrInsufficientRole
+	}
+	if input.CodeStrategy != "" && !s.shortCode.HasStrategy(input.CodeStrategy) {
+		return nil, ErrUnknownCodeStrategy
+	}
+	if input.CodeCharset != "" && !IsCodeCharset(input.CodeCharset) {
+		return nil, ErrUnknownCodeCharset
+	}
+	name, err := s.normalize(input.Domain)
+	if err != nil {
+		return nil, err
+	}
+	prefix, err := util.NormalizePathPrefix(input.PathPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDomain, err)
+	}
+
+	domain := &model.Domain{
+		UserID:               actor.UserID,
+		WorkspaceID:          actor.WorkspaceID,
+		Domain:               name,
+		PathPrefix:           prefix,
+		CaseInsensitiveCodes: input.CaseInsensitiveCodes,
+	}
+	if input.CodeCharset != "" {
+		domain.CodeCharset = &input.CodeCharset
+	}
+	if input.CodeMinLength != 0 {
+		domain.CodeMinLength = &input.CodeMinLength
+	}
+	if input.CodeMaxLength != 0 {
+		domain.CodeMaxLength = &input.CodeMaxLength
+	}
+	if policy := CodePolicyFor(domain); policy.MinLength > policy.MaxLength {
+		return nil, ErrCodeLengthRange
+	}
+	if input.CodeStrategy != "" {
+		domain.CodeStrategy = &input.CodeStrategy
+	}
+	if input.CodeLength != 0 {
+		domain.CodeLength = &input.CodeLength
+	}
+
+	// A name and prefix someone has already verified cannot be claimed again
+	if _, err := s.domainRepo.GetVerifiedByDomain(ctx, domain.Key()); err == nil {
+		return nil, ErrDomainExists
+	} else if !errors.Is(err, repository.ErrDomainNotFound) {
+		logger.Error(ctx, "domain-service: failed to look up domain",
+			zap.String("domain", domain.Domain),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	token, err := (&RandomCodeGenerator{Alphabet: alphabet}).Generate(ctx, nil, domainTokenLen)
+	if err != nil {
+		logger.Error(ctx, "domain-service: failed to generate verification token",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	domain.VerificationToken = token
+
+	if err := s.domainRepo.Create(ctx, domain); err != nil {
+		if errors.Is(err, repository.ErrDomainExists) {
+			return nil, ErrDomainExists
+		}
+		logger.Error(ctx, "domain-service: failed to create domain",
+			zap.String("domain", domain.Domain),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	s.record(ctx, actor.UserID, model.AuditDomainCreated, domain, nil, domain)
+	return domain, nil
+}
+
+func (s *DomainServiceImpl) List(ctx context.Context, actor Actor) ([]*model.Domain, error) {
+	return s.domainRepo.ListByWorkspaceID(ctx, actor.WorkspaceID)
+}
+
+// Get returns a domain of the actor's workspace.
+func (s *DomainServiceImpl) Get(ctx context.Context, actor Actor, id uint64) (*model.Domain, error) {
+	domain, err := s.domainRepo.GetByID(ctx, id)
+	if errors.Is(err, repository.ErrDomainNotFound) {
+		return nil, ErrDomainNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, "domain-service: failed to get domain",
+			zap.Uint64("domain_id", id),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if domain.WorkspaceID != actor.WorkspaceID {
+		return nil, ErrDomainNotFound
+	}
+	return domain, nil
+}
+
+func (s *DomainServiceImpl) Update(ctx context.Context, actor Actor, id uint64, input UpdateDomainInput) (*model.Domain, error) {
+	domain, err := s.Get(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.Can(model.RoleAdmin) {
+		return nil, ErrInsufficientRole
+	}
+
+	before := *domain
+	if err := applyRedirectSetting(&domain.RootRedirectURL, input.RootRedirectURL, true); err != nil {
+		return nil, err
+	}
+	if err := applyRedirectSetting(&domain.NotFoundURL, input.NotFoundURL, true); err != nil {
+		return nil, err
+	}
+	if err := applyRedirectSetting(&domain.NotFoundHTML, input.NotFoundHTML, false); err != nil {
+		return nil, err
+	}
+	if err := applyRedirectSetting(&domain.ExpiredURL, input.ExpiredURL, true); err != nil {
+		return nil, err
+	}
+	if domain.NotFoundURL != nil && domain.NotFoundHTML != nil {
+		return nil, ErrNotFoundPageClash
+	}
+
+	if input.UseHTTPS != nil {
+		domain.UseHTTPS = *input.UseHTTPS
+	}
+	if input.RedirectStatus != nil && *input.RedirectStatus != 0 && !redirectStatuses[*input.RedirectStatus] {
+		return nil, ErrInvalidRedirectCode
+	}
+	applyIntSetting(&domain.RedirectStatus, input.RedirectStatus)
+	if input.CodeStrategy != nil && *input.CodeStrategy != "" && !s.shortCode.HasStrategy(*input.CodeStrategy) {
+		return nil, ErrUnknownCodeStrategy
+	}
+	applyStringSetting(&domain.CodeStrategy, input.CodeStrategy)
+	applyIntSetting(&domain.CodeLength, input.CodeLength)
+	applyIntSetting(&domain.DefaultExpiryDays, input.DefaultExpiryDays)
+	applyStringSetting(&domain.DefaultUTMSource, input.DefaultUTMSource)
+	applyStringSetting(&domain.DefaultUTMMedium, input.DefaultUTMMedium)
+	applyStringSetting(&domain.DefaultUTMCampaign, input.DefaultUTMCampaign)
+	if input.IsDefault != nil {
+		if *input.IsDefault && !domain.IsVerified() {
+			return nil, ErrDomainNotVerified
+		}
+		domain.IsDefault = *input.IsDefault
+	}
+
+	if err := s.domainRepo.Update(ctx, domain); err != nil {
+		logger.Error(ctx, "domain-service: failed to update domain",
+			zap.Uint64("domain_id", id),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	s.record(ctx, actor.UserID, model.AuditDomainUpdated, domain, &before, domain)
+	return domain, nil
+}
+
+// Delete removes a domain, first dealing with its links as the strategy
+// says: block refuses while it has any, move puts them on another domain
+// unless a code is taken there, and archive trashes them and keeps the
+// domain as a tombstone that no longer serves.
+func (s *DomainServiceImpl) Delete(ctx context.Context, actor Actor, id uint64, input DeleteDomainInput) (*DeleteDomainResult, error) {
+	domain, err := s.Get(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.Can(model.RoleAdmin) {
+		return nil, ErrInsufficientRole
+	}
+
+	result := &DeleteDomainResult{Strategy: input.Strategy}
+	switch input.Strategy {
+	case DomainDeleteBlock:
+		links, err := s.linkRepo.ListByDomainID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if len(links) > 0 {
+			return nil, fmt.Errorf("%w: %d links", ErrDomainHasLinks, len(links))
+		}
+	case DomainDeleteMove:
+		moved, err := s.moveLinks(ctx, actor, domain, input.TargetDomainID)
+		if err != nil {
+			return nil, err
+		}
+		result.Moved = moved
+	case DomainDeleteArchive:
+		archived, err := s.linkRepo.TrashByDomainID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.domainRepo.Archive(ctx, id); err != nil {
+			if errors.Is(err, repository.ErrDomainNotFound) {
+				return nil, ErrDomainNotFound
+			}
+			return nil, err
+		}
+		result.Archived = archived
+		s.record(ctx, actor.UserID, model.AuditDomainDeleted, domain, domain, result)
+		return result, nil
+	default:
+		return nil, ErrUnknownDeleteStrategy
+	}
+
+	if err := s.domainRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrDomainNotFound) {
+			return nil, ErrDomainNotFound
+		}
+		// A link was added after the check or the move
+		if errors.Is(err, repository.ErrDomainInUse) {
+			return nil, ErrDomainHasLinks
+		}
+		logger.Error(ctx, "domain-service: failed to delete domain",
+			zap.Uint64("domain_id", id),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	s.record(ctx, actor.UserID, model.AuditDomainDeleted, domain, domain, result)
+	return result, nil
+}
+
+// moveLinks moves every link and alias on domain to the target domain, or
+// reports each code that cannot be used there and moves nothing.
+func (s *DomainServiceImpl) moveLinks(ctx context.Context, actor Actor, domain *model.Domain, targetID *uint64) (int64, error) {
+	if targetID == nil || *targetID == domain.ID {
+		return 0, ErrMoveTargetRequired
+	}
+	// Target 0 is the service's own domain
+	var target *uint64
+	if *targetID != 0 {
+		targetDomain, err := s.Get(ctx, actor, *targetID)
+		if err != nil {
+			return 0, err
+		}
+		if !targetDomain.IsVerified() {
+			return 0, ErrDomainNotVerified
+		}
+		target = &targetDomain.ID
+	}
+
+	links, err := s.linkRepo.ListByDomainID(ctx, domain.ID)
+	if err != nil {
+		return 0, err
+	}
+	// Aliases on the domain would be dropped with it, so they move too
+	aliases, err := s.aliasRepo.ListActiveByDomainID(ctx, domain.ID)
+	if err != nil {
+		return 0, err
+	}
+	codes := make([]CodeConflict, 0, len(links)+len(aliases))
+	for _, link := range links {
+		codes = append(codes, CodeConflict{LinkID: link.ID, ShortCode: link.ShortCode})
+	}
+	for _, alias := range aliases {
+		codes = append(codes, CodeConflict{LinkID: alias.LinkID, AliasID: alias.ID, ShortCode: alias.Code})
+	}
+
+	var conflicts []CodeConflict
+	for _, entry := range codes {
+		code, err := s.shortCode.Canonicalize(ctx, target, entry.ShortCode)
+		if errors.Is(err, ErrInvalidShortCode) || (err == nil && code != entry.ShortCode) {
+			entry.Reason = "not allowed on the target domain"
+			conflicts = append(conflicts, entry)
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		available, err := s.shortCode.IsAvailable(ctx, actor.WorkspaceID, target, code)
+		if err != nil {
+			return 0, err
+		}
+		if !available {
+			entry.Reason = "taken on the target domain"
+			conflicts = append(conflicts, entry)
+		}
+	}
+	if len(conflicts) > 0 {
+		return 0, &DomainMoveConflictError{Conflicts: conflicts}
+	}
+
+	moved, err := s.linkRepo.MoveDomain(ctx, domain.ID, target)
+	if errors.Is(err, repository.ErrShortCodeExists) {
+		// A code was taken on the target after the check
+		return 0, &DomainMoveConflictError{}
+	}
+	return moved, err
+}
+
+// Verify looks for the domain's token in DNS and marks the domain verified
+// when it is there.
+func (s *DomainServiceImpl) Verify(ctx context.Context, actor Actor, id uint64) (*model.Domain, error) {
+	domain, err := s.Get(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.Can(model.RoleAdmin) {
+		return nil, ErrInsufficientRole
+	}
+	if domain.VerificationToken == "" {
+		// Added before verification existed; it was grandfathered in
+		return domain, nil
+	}
+
+	if err := s.lookupToken(ctx, domain); err != nil {
+		return nil, err
+	}
+
+	wasVerified := domain.IsVerified()
+	now := time.Now()
+	if !wasVerified {
+		domain.VerifiedAt = &now
+	}
+	domain.LastCheckedAt = &now
+	domain.CheckFailures = 0
+	if err := s.domainRepo.UpdateVerification(ctx, domain); err != nil {
+		if errors.Is(err, repository.ErrDomainExists) {
+			return nil, ErrDomainExists
+		}
+		logger.Error(ctx, "domain-service: failed to save verification",
+			zap.Uint64("domain_id", id),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if !wasVerified {
+		s.record(ctx, actor.UserID, model.AuditDomainVerified, domain, nil, nil)
+	}
+	return domain, nil
+}
+
+// Recheck re-verifies up to limit verified domains last checked before
+// checkedBefore. A domain whose token is missing maxDomainCheckFailures times
+// in a row goes back to pending; lookups that fail for other reasons (such
+// as timeouts) are not counted. It returns how many domains were checked.
+func (s *DomainServiceImpl) Recheck(ctx context.Context, checkedBefore time.Time, limit int) (int, error) {
+	domains, err := s.domainRepo.ListDueForRecheck(ctx, checkedBefore, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	checked := 0
+	for _, domain := range domains {
+		err := s.lookupToken(ctx, domain)
+		if errors.Is(err, ErrDomainLookupFailed) {
+			continue
+		}
+		checked++
+
+		now := time.Now()
+		domain.LastCheckedAt = &now
+		lost := false
+		if err == nil {
+			domain.CheckFailures = 0
+		} else {
+			domain.CheckFailures++
+			if domain.CheckFailures >= maxDomainCheckFailures {
+				domain.VerifiedAt = nil
+				domain.CheckFailures = 0
+				lost = true
+			}
+		}
+		if err := s.domainRepo.UpdateVerification(ctx, domain); err != nil {
+			logger.Error(ctx, "domain-service: failed to save recheck",
+				zap.Uint64("domain_id", domain.ID),
+				zap.Error(err),
+			)
+			continue
+		}
+		if lost {
+			logger.Warn(ctx, "domain-service: domain lost verification",
+				zap.Uint64("domain_id", domain.ID),
+				zap.String("domain", domain.Domain),
+			)
+			s.record(ctx, 0, model.AuditDomainUnverified, domain, nil, nil)
+		}
+	}
+	return checked, nil
+}
+
+// NormalizeExisting rewrites domain names stored before names were
+// validated into their canonical form. Names that are invalid or collide
+// with a domain already in canonical form are logged and left alone; they
+// never match a request host. It returns how many domains were renamed.
+func (s *DomainServiceImpl) NormalizeExisting(ctx context.Context) (int, error) {
+	domains, err := s.domainRepo.ListAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	renamed := 0
+	for _, domain := range domains {
+		name, err := util.NormalizeDomainPattern(domain.Domain)
+		if err != nil {
+			logger.Warn(ctx, "domain-service: stored domain is invalid",
+				zap.Uint64("domain_id", domain.ID),
+				zap.String("domain", domain.Domain),
+				zap.Error(err),
+			)
+			continue
+		}
+		if name == domain.Domain {
+			continue
+		}
+		if err := s.domainRepo.Rename(ctx, domain.ID, name); err != nil {
+			logger.Warn(ctx, "domain-service: failed to normalize stored domain",
+				zap.Uint64("domain_id", domain.ID),
+				zap.String("domain", domain.Domain),
+				zap.String("normalized", name),
+				zap.Error(err),
+			)
+			continue
+		}
+		renamed++
+	}
+	return renamed, nil
+}
+
+// normalize validates a domain name or wildcard pattern submitted by a user
+// and returns its canonical form.
+func (s *DomainServiceImpl) normalize(raw string) (string, error) {
+	name, err := util.NormalizeDomainPattern(raw)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidDomain, err)
+	}
+	// A wildcard must not cover the service's own host either
+	if s.baseHost != "" && (util.IsSameOrSubdomain(util.WildcardParent(name), s.baseHost) ||
+		util.MatchesDomainPattern(name, s.baseHost)) {
+		return "", ErrReservedDomain
+	}
+	return name, nil
+}
+
+// applyRedirectSetting is applyStringSetting for redirect settings; isURL
+// requires the new value to be an http or https URL.
+func applyRedirectSetting(field **string, value *string, isURL bool) error {
+	if isURL && value != nil && *value != "" && !isRedirectURL(*value) {
+		return ErrInvalidRedirectURL
+	}
+	applyStringSetting(field, value)
+	return nil
+}
+
+// applyStringSetting sets field from an update: nil leaves it, an empty
+// string clears it and anything else replaces it.
+func applyStringSetting(field **string, value *string) {
+	if value == nil {
+		return
+	}
+	if *value == "" {
+		*field = nil
+		return
+	}
+	v := *value
+	*field = &v
+}
+
+// applyIntSetting is applyStringSetting for numbers, with 0 clearing.
+func applyIntSetting(field **int, value *int) {
+	if value == nil {
+		return
+	}
+	if *value == 0 {
+		*field = nil
+		return
+	}
+	v := *value
+	*field = &v
+}
+
+func isRedirectURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// lookupToken returns nil when the domain's TXT record holds its token,
+// ErrDomainTokenNotFound when DNS answers without it, and
+// ErrDomainLookupFailed when DNS could not be asked.
+func (s *DomainServiceImpl) lookupToken(ctx context.Context, domain *model.Domain) error {
+	records, err := s.resolver.LookupTXT(ctx, DomainVerificationPrefix+domain.VerificationHost())
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return ErrDomainTokenNotFound
+		}
+		logger.Warn(ctx, "domain-service: TXT lookup failed",
+			zap.String("domain", domain.Domain),
+			zap.Error(err),
+		)
+		return ErrDomainLookupFailed
+	}
+	for _, record := range records {
+		if record == domain.VerificationToken {
+			return nil
+		}
+	}
+	return ErrDomainTokenNotFound
+}
+
+func (s *DomainServiceImpl) record(ctx context.Context, actorUserID uint64, action string, domain *model.Domain, before, after any) {
+	recordAudit(ctx, s.audit, AuditEvent{
+		WorkspaceID: domain.WorkspaceID,
+		ActorUserID: actorUserID,
+		Action:      action,
+		TargetType:  model.AuditTargetDomain,
+		TargetID:    domain.ID,
+		Before:      before,
+		After:       after,
+	})
+}
@@ -0,0 +1,378 @@
+package service_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/internal/repository"
+	"github.com/SeaCodeBase/urlshortener/internal/repository/mocks"
+	"github.com/SeaCodeBase/urlshortener/internal/service"
+	servicemocks "github.com/SeaCodeBase/urlshortener/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// fakeResolver serves TXT records from a map; names missing from it are
+// NXDOMAIN.
+type fakeResolver struct {
+	records map[string][]string
+	err     error
+}
+
+func (r *fakeResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	records, ok := r.records[name]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	return records, nil
+}
+
+var domainAdmin = service.Actor{UserID: 1, WorkspaceID: 1, Role: model.RoleAdmin}
+
+func TestDomainService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDomainRepo := mocks.NewMockDomainRepository(ctrl)
+	mockShortCode := servicemocks.NewMockShortCodeService(ctrl)
+	svc := service.NewDomainService(mockDomainRepo, nil, nil, mockShortCode, nil, &fakeResolver{}, "s.example.com")
+
+	_, err := svc.Create(context.Background(), editor, service.CreateDomainInput{Domain: "go.example.com"})
+	assert.ErrorIs(t, err, service.ErrInsufficientRole)
+
+	// Strategies without a generator, e.g. hashids with no salt, are refused
+	mockShortCode.EXPECT().HasStrategy(service.CodeStrategyHashids).Return(false)
+	_, err = svc.Create(context.Background(), domainAdmin, service.CreateDomainInput{Domain: "go.example.com", CodeStrategy: service.CodeStrategyHashids})
+	assert.ErrorIs(t, err, service.ErrUnknownCodeStrategy)
+
+	// Someone else already verified it
+	verifiedAt := time.Now()
+	mockDomainRepo.EXPECT().
+		GetVerifiedByDomain(gomock.Any(), "taken.example.com").
+		Return(&model.Domain{ID: 9, WorkspaceID: 2, Domain: "taken.example.com", VerifiedAt: &verifiedAt}, nil)
+	_, err = svc.Create(context.Background(), domainAdmin, service.CreateDomainInput{Domain: "taken.example.com"})
+	assert.ErrorIs(t, err, service.ErrDomainExists)
+
+	// Malformed names and the service's own domain are refused before any lookup
+	for _, name := range []string{"https://go.example.com/", "go.example.com:8080", "localhost"} {
+		_, err = svc.Create(context.Background(), domainAdmin, service.CreateDomainInput{Domain: name})
+		assert.ErrorIs(t, err, service.ErrInvalidDomain, name)
+	}
+	for _, name := range []string{"S.Example.com.", "links.s.example.com"} {
+		_, err = svc.Create(context.Background(), domainAdmin, service.CreateDomainInput{Domain: name})
+		assert.ErrorIs(t, err, service.ErrReservedDomain, name)
+	}
+
+	mockDomainRepo.EXPECT().
+		GetVerifiedByDomain(gomock.Any(), "go.example.com").
+		Return(nil, repository.ErrDomainNotFound)
+	mockDomainRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	domain, err := svc.Create(context.Background(), domainAdmin, service.CreateDomainInput{Domain: "Go.Example.com."})
+	assert.NoError(t, err)
+	assert.Equal(t, "go.example.com", domain.Domain)
+	assert.False(t, domain.IsVerified())
+	assert.Len(t, domain.VerificationToken, 32)
+}
+
+func TestDomainService_Create_PatternsAndPrefixes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDomainRepo := mocks.NewMockDomainRepository(ctrl)
+	svc := service.NewDomainService(mockDomainRepo, nil, nil, nil, nil, &fakeResolver{}, "s.example.com")
+
+	// A wildcard may not cover the service's own host, and prefixes are path segments
+	for _, name := range []string{"*.example.com", "*.s.example.com"} {
+		_, err := svc.Create(context.Background(), domainAdmin, service.CreateDomainInput{Domain: name})
+		assert.ErrorIs(t, err, service.ErrReservedDomain, name)
+	}
+	for _, prefix := range []string{"go?x", "a/b/c/d", "-go"} {
+		_, err := svc.Create(context.Background(), domainAdmin, service.CreateDomainInput{Domain: "go.example.com", PathPrefix: prefix})
+		assert.ErrorIs(t, err, service.ErrInvalidDomain, prefix)
+	}
+	_, err := svc.Create(context.Background(), domainAdmin, service.CreateDomainInput{Domain: "*.com"})
+	assert.ErrorIs(t, err, service.ErrInvalidDomain)
+
+	// A host and prefix is claimed as one
+	mockDomainRepo.EXPECT().
+		GetVerifiedByDomain(gomock.Any(), "*.links.example.com/go").
+		Return(nil, repository.ErrDomainNotFound)
+	mockDomainRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	domain, err := svc.Create(context.Background(), domainAdmin, service.CreateDomainInput{Domain: "*.Links.Example.com", PathPrefix: "/Go/"})
+	assert.NoError(t, err)
+	assert.Equal(t, "*.links.example.com", domain.Domain)
+	assert.Equal(t, "go", domain.PathPrefix)
+	assert.Equal(t, "http://*.links.example.com/go/abc", domain.ShortURL("abc"))
+}
+
+func TestDomainService_Verify(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDomainRepo := mocks.NewMockDomainRepository(ctrl)
+	resolver := &fakeResolver{records: map[string][]string{}}
+	svc := service.NewDomainService(mockDomainRepo, nil, nil, nil, nil, resolver, "s.example.com")
+
+	mockDomainRepo.EXPECT().
+		GetByID(gomock.Any(), uint64(5)).
+		DoAndReturn(func(ctx context.Context, id uint64) (*model.Domain, error) {
+			return &model.Domain{ID: 5, WorkspaceID: 1, Domain: "go.example.com", VerificationToken: "token123"}, nil
+		}).
+		Times(4)
+
+	// No record yet
+	_, err := svc.Verify(context.Background(), domainAdmin, 5)
+	assert.ErrorIs(t, err, service.ErrDomainTokenNotFound)
+
+	// A record with some other value
+	resolver.records[service.DomainVerificationPrefix+"go.example.com"] = []string{"v=spf1 -all"}
+	_, err = svc.Verify(context.Background(), domainAdmin, 5)
+	assert.ErrorIs(t, err, service.ErrDomainTokenNotFound)
+
+	// DNS is down: try again later rather than fail the check
+	resolver.err = &net.DNSError{Err: "i/o timeout", IsTimeout: true}
+	_, err = svc.Verify(context.Background(), domainAdmin, 5)
+	assert.ErrorIs(t, err, service.ErrDomainLookupFailed)
+	resolver.err = nil
+
+	resolver.records[service.DomainVerificationPrefix+"go.example.com"] = []string{"v=spf1 -all", "token123"}
+	mockDomainRepo.EXPECT().
+		UpdateVerification(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, domain *model.Domain) error {
+			assert.True(t, domain.IsVerified())
+			assert.NotNil(t, domain.LastCheckedAt)
+			return nil
+		})
+	domain, err := svc.Verify(context.Background(), domainAdmin, 5)
+	assert.NoError(t, err)
+	assert.True(t, domain.IsVerified())
+}
+
+func TestDomainService_Recheck_RevokesAfterRepeatedFailures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDomainRepo := mocks.NewMockDomainRepository(ctrl)
+	svc := service.NewDomainService(mockDomainRepo, nil, nil, nil, nil, &fakeResolver{records: map[string][]string{
+		service.DomainVerificationPrefix + "ok.example.com": {"good"},
+	}}, "s.example.com")
+
+	verifiedAt := time.Now().Add(-48 * time.Hour)
+	mockDomainRepo.EXPECT().
+		ListDueForRecheck(gomock.Any(), gomock.Any(), 10).
+		Return([]*model.Domain{
+			{ID: 1, Domain: "ok.example.com", VerificationToken: "good", VerifiedAt: &verifiedAt, CheckFailures: 1},
+			{ID: 2, Domain: "flaky.example.com", VerificationToken: "gone", VerifiedAt: &verifiedAt},
+			{ID: 3, Domain: "lost.example.com", VerificationToken: "gone", VerifiedAt: &verifiedAt, CheckFailures: 2},
+		}, nil)
+
+	saved := map[uint64]*model.Domain{}
+	mockDomainRepo.EXPECT().
+		UpdateVerification(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, domain *model.Domain) error {
+			saved[domain.ID] = domain
+			return nil
+		}).
+		Times(3)
+
+	checked, err := svc.Recheck(context.Background(), time.Now().Add(-24*time.Hour), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, checked)
+
+	assert.True(t, saved[1].IsVerified())
+	assert.Equal(t, 0, saved[1].CheckFailures)
+	// One miss is tolerated
+	assert.True(t, saved[2].IsVerified())
+	assert.Equal(t, 1, saved[2].CheckFailures)
+	// The third miss in a row sends the domain back to pending
+	assert.False(t, saved[3].IsVerified())
+}
+
+func TestDomainService_NormalizeExisting(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDomainRepo := mocks.NewMockDomainRepository(ctrl)
+	svc := service.NewDomainService(mockDomainRepo, nil, nil, nil, nil, &fakeResolver{}, "s.example.com")
+
+	mockDomainRepo.EXPECT().
+		ListAll(gomock.Any()).
+		Return([]*model.Domain{
+			{ID: 1, Domain: "go.example.com"},
+			{ID: 2, Domain: "Links.Example.com."},
+			{ID: 3, Domain: "bücher.example"},
+			{ID: 4, Domain: "https://bad.example.com/"},
+			{ID: 5, Domain: "GO.example.com"},
+		}, nil)
+	mockDomainRepo.EXPECT().Rename(gomock.Any(), uint64(2), "links.example.com").Return(nil)
+	mockDomainRepo.EXPECT().Rename(gomock.Any(), uint64(3), "xn--bcher-kva.example").Return(nil)
+	// Collides with domain 1, so it keeps its old name
+	mockDomainRepo.EXPECT().Rename(gomock.Any(), uint64(5), "go.example.com").Return(repository.ErrDomainExists)
+
+	renamed, err := svc.NormalizeExisting(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, renamed)
+}
+
+func TestDomainService_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDomainRepo := mocks.NewMockDomainRepository(ctrl)
+	svc := service.NewDomainService(mockDomainRepo, nil, nil, nil, nil, &fakeResolver{}, "s.example.com")
+
+	notFound := "https://example.com/missing"
+	mockDomainRepo.EXPECT().
+		GetByID(gomock.Any(), uint64(5)).
+		DoAndReturn(func(ctx context.Context, id uint64) (*model.Domain, error) {
+			return &model.Domain{ID: 5, WorkspaceID: 1, Domain: "go.example.com", NotFoundURL: &notFound}, nil
+		}).
+		Times(4)
+
+	_, err := svc.Update(context.Background(), editor, 5, service.UpdateDomainInput{})
+	assert.ErrorIs(t, err, service.ErrInsufficientRole)
+
+	bad := "javascript:alert(1)"
+	_, err = svc.Update(context.Background(), domainAdmin, 5, service.UpdateDomainInput{RootRedirectURL: &bad})
+	assert.ErrorIs(t, err, service.ErrInvalidRedirectURL)
+
+	// A custom page cannot be set while the not-found redirect is
+	page := "<h1>Nothing here</h1>"
+	_, err = svc.Update(context.Background(), domainAdmin, 5, service.UpdateDomainInput{NotFoundHTML: &page})
+	assert.ErrorIs(t, err, service.ErrNotFoundPageClash)
+
+	root := "https://example.com"
+	unset := ""
+	mockDomainRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+	domain, err := svc.Update(context.Background(), domainAdmin, 5, service.UpdateDomainInput{
+		RootRedirectURL: &root,
+		NotFoundURL:     &unset,
+		NotFoundHTML:    &page,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, root, *domain.RootRedirectURL)
+	assert.Nil(t, domain.NotFoundURL)
+	assert.Equal(t, page, *domain.NotFoundHTML)
+	assert.Nil(t, domain.ExpiredURL)
+}
+
+func TestDomainService_Update_Defaults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDomainRepo := mocks.NewMockDomainRepository(ctrl)
+	svc := service.NewDomainService(mockDomainRepo, nil, nil, nil, nil, &fakeResolver{}, "s.example.com")
+
+	verifiedAt := time.Now()
+	mockDomainRepo.EXPECT().
+		GetByID(gomock.Any(), uint64(5)).
+		Return(&model.Domain{ID: 5, WorkspaceID: 1, Domain: "go.example.com", VerifiedAt: &verifiedAt}, nil)
+	mockDomainRepo.EXPECT().
+		GetByID(gomock.Any(), uint64(6)).
+		Return(&model.Domain{ID: 6, WorkspaceID: 1, Domain: "pending.example.com", VerificationToken: "token"}, nil).
+		Times(2)
+
+	// Only verified domains can be the default for new links
+	isDefault := true
+	_, err := svc.Update(context.Background(), domainAdmin, 6, service.UpdateDomainInput{IsDefault: &isDefault})
+	assert.ErrorIs(t, err, service.ErrDomainNotVerified)
+
+	status := 303
+	_, err = svc.Update(context.Background(), domainAdmin, 6, service.UpdateDomainInput{RedirectStatus: &status})
+	assert.ErrorIs(t, err, service.ErrInvalidRedirectCode)
+
+	useHTTPS := true
+	status = 301
+	expiry := 90
+	source := "newsletter"
+	mockDomainRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+	domain, err := svc.Update(context.Background(), domainAdmin, 5, service.UpdateDomainInput{
+		UseHTTPS:          &useHTTPS,
+		RedirectStatus:    &status,
+		DefaultExpiryDays: &expiry,
+		DefaultUTMSource:  &source,
+		IsDefault:         &isDefault,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "https", domain.Scheme())
+	assert.Equal(t, 301, *domain.RedirectStatus)
+	assert.Equal(t, 90, *domain.DefaultExpiryDays)
+	assert.Equal(t, "newsletter", *domain.DefaultUTMSource)
+	assert.True(t, domain.IsDefault)
+}
+
+func TestDomainService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDomainRepo := mocks.NewMockDomainRepository(ctrl)
+	mockLinkRepo := mocks.NewMockLinkRepository(ctrl)
+	mockAliasRepo := mocks.NewMockLinkAliasRepository(ctrl)
+	mockShortCode := servicemocks.NewMockShortCodeService(ctrl)
+	svc := service.NewDomainService(mockDomainRepo, mockLinkRepo, mockAliasRepo, mockShortCode, nil, &fakeResolver{}, "s.example.com")
+
+	verifiedAt := time.Now()
+	source := &model.Domain{ID: 5, WorkspaceID: 1, Domain: "go.example.com", VerifiedAt: &verifiedAt}
+	target := &model.Domain{ID: 6, WorkspaceID: 1, Domain: "new.example.com", VerifiedAt: &verifiedAt}
+	mockDomainRepo.EXPECT().GetByID(gomock.Any(), uint64(5)).Return(source, nil).AnyTimes()
+	mockDomainRepo.EXPECT().GetByID(gomock.Any(), uint64(6)).Return(target, nil).AnyTimes()
+	links := []model.Link{{ID: 1, ShortCode: "docs"}, {ID: 2, ShortCode: "blog"}}
+	mockLinkRepo.EXPECT().ListByDomainID(gomock.Any(), uint64(5)).Return(links, nil).AnyTimes()
+	// An alias on the domain for a link elsewhere moves with it
+	aliases := []model.LinkAlias{{ID: 7, LinkID: 3, Code: "old-docs"}}
+	mockAliasRepo.EXPECT().ListActiveByDomainID(gomock.Any(), uint64(5)).Return(aliases, nil).AnyTimes()
+
+	_, err := svc.Delete(context.Background(), domainAdmin, 5, service.DeleteDomainInput{Strategy: "drop"})
+	assert.ErrorIs(t, err, service.ErrUnknownDeleteStrategy)
+
+	// Blocking refuses while the domain has links
+	_, err = svc.Delete(context.Background(), domainAdmin, 5, service.DeleteDomainInput{Strategy: service.DomainDeleteBlock})
+	assert.ErrorIs(t, err, service.ErrDomainHasLinks)
+
+	// Moving onto the same domain makes no sense
+	_, err = svc.Delete(context.Background(), domainAdmin, 5, service.DeleteDomainInput{Strategy: service.DomainDeleteMove, TargetDomainID: &source.ID})
+	assert.ErrorIs(t, err, service.ErrMoveTargetRequired)
+
+	// A taken code, the link's own or an alias's, stops the whole move and is reported
+	mockShortCode.EXPECT().Canonicalize(gomock.Any(), &target.ID, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, domainID *uint64, code string) (string, error) { return code, nil }).
+		Times(3)
+	mockShortCode.EXPECT().IsAvailable(gomock.Any(), uint64(1), &target.ID, "docs").Return(true, nil)
+	mockShortCode.EXPECT().IsAvailable(gomock.Any(), uint64(1), &target.ID, "blog").Return(false, nil)
+	mockShortCode.EXPECT().IsAvailable(gomock.Any(), uint64(1), &target.ID, "old-docs").Return(false, nil)
+	_, err = svc.Delete(context.Background(), domainAdmin, 5, service.DeleteDomainInput{Strategy: service.DomainDeleteMove, TargetDomainID: &target.ID})
+	var conflict *service.DomainMoveConflictError
+	if assert.ErrorAs(t, err, &conflict) {
+		assert.Equal(t, []service.CodeConflict{
+			{LinkID: 2, ShortCode: "blog", Reason: "taken on the target domain"},
+			{LinkID: 3, AliasID: 7, ShortCode: "old-docs", Reason: "taken on the target domain"},
+		}, conflict.Conflicts)
+	}
+	assert.ErrorIs(t, err, service.ErrDomainMoveConflict)
+
+	// Moving onto the service's own domain
+	mockShortCode.EXPECT().Canonicalize(gomock.Any(), nil, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, domainID *uint64, code string) (string, error) { return code, nil }).
+		Times(3)
+	mockShortCode.EXPECT().IsAvailable(gomock.Any(), uint64(1), nil, gomock.Any()).Return(true, nil).Times(3)
+	mockLinkRepo.EXPECT().MoveDomain(gomock.Any(), uint64(5), nil).Return(int64(2), nil)
+	mockDomainRepo.EXPECT().Delete(gomock.Any(), uint64(5)).Return(nil)
+	serviceDomain := uint64(0)
+	result, err := svc.Delete(context.Background(), domainAdmin, 5, service.DeleteDomainInput{Strategy: service.DomainDeleteMove, TargetDomainID: &serviceDomain})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), result.Moved)
+
+	// Archiving trashes the links and keeps the domain as a tombstone
+	mockLinkRepo.EXPECT().TrashByDomainID(gomock.Any(), uint64(5)).Return(int64(2), nil)
+	mockDomainRepo.EXPECT().Archive(gomock.Any(), uint64(5)).Return(nil)
+	result, err = svc.Delete(context.Background(), domainAdmin, 5, service.DeleteDomainInput{Strategy: service.DomainDeleteArchive})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), result.Archived)
+}
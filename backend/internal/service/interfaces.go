@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"crypto/tls"
+	"time"
 
 	"github.com/SeaCodeBase/urlshortener/internal/model"
 	"github.com/go-webauthn/webauthn/protocol"
@@ -9,38 +11,189 @@ import (
 
 //go:generate mockgen -destination=mocks/mock_auth_service.go -package=mocks . AuthService
 type AuthService interface {
-	Register(ctx context.Context, input RegisterInput) (*AuthResponse, error)
-	Login(ctx context.Context, input LoginInput) (*AuthResponse, error)
+	Register(ctx context.Context, input RegisterInput, meta SessionMeta) (*AuthResponse, error)
+	// Login checks credentials only; StartSession issues the tokens
+	Login(ctx context.Context, input LoginInput) (*model.User, error)
+	StartSession(ctx context.Context, userID uint64, meta SessionMeta) (*AuthResponse, error)
+	// Refresh rotates a refresh token and issues a new access token
+	Refresh(ctx context.Context, refreshToken string, meta SessionMeta) (*AuthResponse, error)
 	GetUserByID(ctx context.Context, userID uint64) (*model.User, error)
-	ValidateToken(tokenString string) (uint64, error)
-	ChangePassword(ctx context.Context, userID uint64, input ChangePasswordInput) error
+	ValidateToken(ctx context.Context, tokenString string) (*TokenClaims, error)
+	ChangePassword(ctx context.Context, userID, currentSessionID uint64, input ChangePasswordInput) error
 	UpdateDisplayName(ctx context.Context, userID uint64, displayName string) error
-	GenerateToken(userID uint64) (string, error)
+	ListSessions(ctx context.Context, userID, currentSessionID uint64) ([]model.Session, error)
+	RevokeSession(ctx context.Context, userID, sessionID uint64) error
+	// RevokeAllSessions ends every session but exceptSessionID (0 for none)
+	RevokeAllSessions(ctx context.Context, userID, exceptSessionID uint64) error
+}
+
+//go:generate mockgen -destination=mocks/mock_api_key_service.go -package=mocks . APIKeyService
+type APIKeyService interface {
+	// Create issues a new key; the plaintext key is only returned here
+	Create(ctx context.Context, userID uint64, input CreateAPIKeyInput) (*CreatedAPIKey, error)
+	List(ctx context.Context, userID uint64) ([]model.APIKey, error)
+	Delete(ctx context.Context, userID, keyID uint64) error
+	// Authenticate resolves a plaintext key, rejecting unknown and expired keys
+	Authenticate(ctx context.Context, plaintext string) (*model.APIKey, error)
+}
+
+//go:generate mockgen -destination=mocks/mock_workspace_service.go -package=mocks . WorkspaceService
+type WorkspaceService interface {
+	// Resolve returns the actor for a member of workspaceID; 0 selects the
+	// user's personal workspace. Non-members get ErrWorkspaceNotFound.
+	Resolve(ctx context.Context, userID, workspaceID uint64) (Actor, error)
+	List(ctx context.Context, userID uint64) ([]model.Workspace, error)
+	Create(ctx context.Context, userID uint64, input CreateWorkspaceInput) (*model.Workspace, error)
+	Rename(ctx context.Context, userID, workspaceID uint64, input CreateWorkspaceInput) (*model.Workspace, error)
+	ListMembers(ctx context.Context, userID, workspaceID uint64) ([]model.WorkspaceMember, error)
+	UpdateMemberRole(ctx context.Context, userID, workspaceID, memberID uint64, role string) (*model.WorkspaceMember, error)
+	RemoveMember(ctx context.Context, userID, workspaceID, memberID uint64) error
+	// Invite creates an invitation; the plaintext token is only returned here
+	Invite(ctx context.Context, userID, workspaceID uint64, input InviteMemberInput) (*CreatedInvitation, error)
+	ListInvitations(ctx context.Context, userID, workspaceID uint64) ([]model.WorkspaceInvitation, error)
+	RevokeInvitation(ctx context.Context, userID, workspaceID, invitationID uint64) error
+	AcceptInvitation(ctx context.Context, userID uint64, token string) (*model.Workspace, error)
+}
+
+//go:generate mockgen -destination=mocks/mock_audit_service.go -package=mocks . AuditService
+type AuditService interface {
+	// Record appends an event, taking the client details from the context
+	// (see WithRequestMeta). Failures are logged, not returned.
+	Record(ctx context.Context, event AuditEvent)
+	// List returns the actor's workspace log, newest first; it requires admin
+	List(ctx context.Context, actor Actor, query AuditQuery) ([]model.AuditEntry, error)
+	// ListAll returns entries from the whole log, for site administrators
+	ListAll(ctx context.Context, query AuditQuery) ([]model.AuditEntry, error)
+}
+
+//go:generate mockgen -destination=mocks/mock_webhook_service.go -package=mocks . WebhookService
+type WebhookService interface {
+	// Create registers a webhook of the actor on their current workspace
+	Create(ctx context.Context, actor Actor, input CreateWebhookInput) (*CreatedWebhook, error)
+	// List returns the actor's webhooks on their current workspace
+	List(ctx context.Context, actor Actor) ([]model.Webhook, error)
+	Update(ctx context.Context, actor Actor, id uint64, input UpdateWebhookInput) (*model.Webhook, error)
+	Delete(ctx context.Context, actor Actor, id uint64) error
+	ListDeliveries(ctx context.Context, actor Actor, webhookID uint64) ([]model.WebhookDelivery, error)
+	// Replay queues a new delivery of an earlier delivery's payload
+	Replay(ctx context.Context, actor Actor, webhookID, deliveryID uint64) (*model.WebhookDelivery, error)
+	// EmitLink, EmitClicks and EmitExpired queue deliveries for subscribed
+	// webhooks. Queueing failures are logged, not returned.
+	EmitLink(ctx context.Context, event string, link *model.Link)
+	EmitClicks(ctx context.Context, clicks []model.Click)
+	EmitExpired(ctx context.Context, from, to time.Time) error
+}
+
+//go:generate mockgen -destination=mocks/mock_domain_service.go -package=mocks . DomainService
+type DomainService interface {
+	// Create adds a pending domain to the actor's workspace; it requires admin
+	Create(ctx context.Context, actor Actor, input CreateDomainInput) (*model.Domain, error)
+	List(ctx context.Context, actor Actor) ([]*model.Domain, error)
+	Get(ctx context.Context, actor Actor, id uint64) (*model.Domain, error)
+	// Update changes the domain's settings and link defaults; it requires admin
+	Update(ctx context.Context, actor Actor, id uint64, input UpdateDomainInput) (*model.Domain, error)
+	// Delete removes the domain, handling its links as input.Strategy says
+	Delete(ctx context.Context, actor Actor, id uint64, input DeleteDomainInput) (*DeleteDomainResult, error)
+	// Verify checks DNS for the domain's TXT token and marks it verified
+	Verify(ctx context.Context, actor Actor, id uint64) (*model.Domain, error)
+	// Recheck re-verifies verified domains last checked before checkedBefore
+	Recheck(ctx context.Context, checkedBefore time.Time, limit int) (int, error)
+	// NormalizeExisting rewrites stored domain names into canonical form
+	NormalizeExisting(ctx context.Context) (int, error)
+}
+
+//go:generate mockgen -destination=mocks/mock_certificate_service.go -package=mocks . CertificateService
+type CertificateService interface {
+	// Get returns the domain's certificate without its private key
+	Get(ctx context.Context, actor Actor, domainID uint64) (*model.DomainCertificate, error)
+	// Upload stores a certificate and key for a verified domain; it requires admin
+	Upload(ctx context.Context, actor Actor, domainID uint64, input UploadCertificateInput) (*model.DomainCertificate, error)
+	// Request obtains a certificate for a verified domain through ACME; it requires admin
+	Request(ctx context.Context, actor Actor, domainID uint64) (*model.DomainCertificate, error)
+	Delete(ctx context.Context, actor Actor, domainID uint64) error
+	// GetCertificate selects a certificate by SNI for tls.Config
+	GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error)
+	// HasCertificate reports whether HTTPS on host is served with its own certificate
+	HasCertificate(ctx context.Context, host string) bool
+	// ChallengeResponse returns the key authorization for an HTTP-01 token
+	ChallengeResponse(ctx context.Context, token string) (string, error)
+	// Renew renews ACME certificates close to expiry and reports uploaded ones
+	Renew(ctx context.Context, limit int) (int, error)
 }
 
 //go:generate mockgen -destination=mocks/mock_link_service.go -package=mocks . LinkService
 type LinkService interface {
-	Create(ctx context.Context, userID uint64, input CreateLinkInput) (*model.Link, error)
-	GetByID(ctx context.Context, userID, linkID uint64) (*model.Link, error)
-	List(ctx context.Context, userID uint64, params ListLinksParams) (*ListLinksResult, error)
-	Update(ctx context.Context, userID, linkID uint64, input UpdateLinkInput) (*model.Link, error)
-	Delete(ctx context.Context, userID, linkID uint64) error
+	Create(ctx context.Context, actor Actor, input CreateLinkInput) (*model.Link, error)
+	GetByID(ctx context.Context, actor Actor, linkID uint64) (*model.Link, error)
+	List(ctx context.Context, actor Actor, params ListLinksParams) (*ListLinksResult, error)
+	FindDuplicates(ctx context.Context, actor Actor, ignoreTracking bool) ([]DuplicateGroup, error)
+	Update(ctx context.Context, actor Actor, linkID uint64, input UpdateLinkInput) (*model.Link, error)
+	// Delete moves the link to the trash and returns it
+	Delete(ctx context.Context, actor Actor, linkID uint64) (*model.Link, error)
+	ListTrash(ctx context.Context, actor Actor) ([]model.Link, error)
+	Restore(ctx context.Context, actor Actor, linkID uint64) (*model.Link, error)
+	ListAliases(ctx context.Context, actor Actor, linkID uint64) ([]model.LinkAlias, error)
+	AddAlias(ctx context.Context, actor Actor, linkID uint64, input AddAliasInput) (*model.LinkAlias, error)
+	DeleteAlias(ctx context.Context, actor Actor, linkID, aliasID uint64) error
+	ListHistory(ctx context.Context, actor Actor, linkID uint64) ([]model.LinkRevision, error)
+	Revert(ctx context.Context, actor Actor, linkID uint64, version int) (*model.Link, error)
 }
 
 //go:generate mockgen -destination=mocks/mock_stats_service.go -package=mocks . StatsService
 type StatsService interface {
-	GetLinkStats(ctx context.Context, userID, linkID uint64) (*LinkStatsResponse, error)
+	GetLinkStats(ctx context.Context, actor Actor, linkID uint64) (*LinkStatsResponse, error)
+	GetCampaignStats(ctx context.Context, actor Actor, campaignID uint64) (*CampaignStatsResponse, error)
+	// GetDomainStats aggregates clicks across a domain's links and counts
+	// its not-found and expired-link hits.
+	GetDomainStats(ctx context.Context, actor Actor, domainID uint64) (*DomainStatsResponse, error)
+	// GetOverview aggregates clicks across all of the workspace's links in a
+	// time range.
+	GetOverview(ctx context.Context, actor Actor, query OverviewQuery) (*OverviewStatsResponse, error)
+}
+
+//go:generate mockgen -destination=mocks/mock_campaign_service.go -package=mocks . CampaignService
+type CampaignService interface {
+	Create(ctx context.Context, actor Actor, input CreateCampaignInput) (*model.Campaign, error)
+	GetByID(ctx context.Context, actor Actor, campaignID uint64) (*model.Campaign, error)
+	List(ctx context.Context, actor Actor) ([]model.Campaign, error)
+	Update(ctx context.Context, actor Actor, campaignID uint64, input UpdateCampaignInput) (*model.Campaign, error)
+	Delete(ctx context.Context, actor Actor, campaignID uint64) error
+	ListLinks(ctx context.Context, actor Actor, campaignID uint64) ([]model.Link, error)
 }
 
 //go:generate mockgen -destination=mocks/mock_shortcode_service.go -package=mocks . ShortCodeService
 type ShortCodeService interface {
-	// Generate creates a unique short code within the given domain.
-	// domainID nil means the default domain.
-	Generate(ctx context.Context, domainID *uint64) (string, error)
-	IsValid(code string) bool
-	// IsAvailable checks if a short code is available within the given domain.
+	// Allocate creates a unique short code within the given domain using the
+	// named strategy, or the domain's (then the configured) default when empty.
+	// Each candidate is passed to insert, retrying while insert reports
+	// repository.ErrShortCodeExists. It returns the code that was stored.
 	// domainID nil means the default domain.
-	IsAvailable(ctx context.Context, domainID *uint64, code string) (bool, error)
+	Allocate(ctx context.Context, domainID *uint64, strategy string, insert func(code string) error) (string, error)
+	// Canonicalize checks a custom code against the domain's code policy and
+	// returns the form it is stored and looked up in. It returns
+	// ErrInvalidShortCode for codes the policy does not allow, reserved words
+	// and profanity. domainID nil means the default domain.
+	Canonicalize(ctx context.Context, domainID *uint64, code string) (string, error)
+	// IsAvailable checks if a canonical short code is free and not reserved
+	// within the given domain for workspaceID; the workspace owning a domain
+	// may use the domain's own reservations. domainID nil means the default domain.
+	IsAvailable(ctx context.Context, workspaceID uint64, domainID *uint64, code string) (bool, error)
+	// HasStrategy reports whether codes can be generated with the named
+	// strategy; hashids, for one, is only available when a salt is configured.
+	HasStrategy(name string) bool
+}
+
+//go:generate mockgen -destination=mocks/mock_reserved_code_service.go -package=mocks . ReservedCodeService
+type ReservedCodeService interface {
+	// ListGlobal, AddGlobal and DeleteGlobal manage the admin list applied to every domain
+	ListGlobal(ctx context.Context) ([]model.ReservedCode, error)
+	AddGlobal(ctx context.Context, userID uint64, input ReserveCodeInput) (*model.ReservedCode, error)
+	DeleteGlobal(ctx context.Context, id uint64) error
+	// ListForDomain, AddForDomain and DeleteForDomain manage the reservations
+	// of a domain in the actor's workspace; changing them requires admin
+	ListForDomain(ctx context.Context, actor Actor, domainID uint64) ([]model.ReservedCode, error)
+	AddForDomain(ctx context.Context, actor Actor, domainID uint64, input ReserveCodeInput) (*model.ReservedCode, error)
+	DeleteForDomain(ctx context.Context, actor Actor, domainID, id uint64) error
 }
 
 //go:generate mockgen -destination=mocks/mock_passkey_service.go -package=mocks . PasskeyService
@@ -54,3 +207,12 @@ type PasskeyService interface {
 	Delete(ctx context.Context, userID, passkeyID uint64) error
 	HasPasskeys(ctx context.Context, userID uint64) (bool, error)
 }
+
+//go:generate mockgen -destination=mocks/mock_transfer_service.go -package=mocks . TransferService
+type TransferService interface {
+	Create(ctx context.Context, actor Actor, input CreateTransferInput) (*model.LinkTransfer, error)
+	ListPending(ctx context.Context, userID uint64) ([]model.LinkTransfer, error)
+	Accept(ctx context.Context, actor Actor, transferID uint64) (*AcceptTransferResult, error)
+	Decline(ctx context.Context, userID, transferID uint64) error
+	Cancel(ctx context.Context, userID, transferID uint64) error
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"reflect"
+	"sort"
 	"time"
 
 	"github.com/SeaCodeBase/urlshortener/internal/model"
 	"github.com/SeaCodeBase/urlshortener/internal/repository"
+	"github.com/SeaCodeBase/urlshortener/internal/util"
 	"github.com/SeaCodeBase/urlshortener/pkg/logger"
 	"go.uber.org/zap"
 )
@@ -17,6 +20,9 @@ var (
 	ErrNotLinkOwner     = errors.New("not the owner of this link")
 	ErrInvalidShortCode = errors.New("invalid short code")
 	ErrShortCodeTaken   = errors.New("short code already taken")
+	ErrAliasNotFound    = errors.New("alias not found")
+	ErrRevisionNotFound = errors.New("revision not found")
+	ErrLinkNotInTrash   = errors.New("link is not in the trash")
 )
 
 const maxPageSize = 100
@@ -25,23 +31,53 @@ const maxPageSize = 100
 var _ LinkService = (*LinkServiceImpl)(nil)
 
 type LinkServiceImpl struct {
-	linkRepo  repository.LinkRepository
-	shortCode ShortCodeService
+	linkRepo     repository.LinkRepository
+	campaignRepo repository.CampaignRepository
+	aliasRepo    repository.LinkAliasRepository
+	historyRepo  repository.LinkHistoryRepository
+	domainRepo   repository.DomainRepository
+	shortCode    ShortCodeService
+	audit        AuditService
+	webhooks     WebhookService
+	oldCodeGrace time.Duration
 }
 
-func NewLinkService(linkRepo repository.LinkRepository, shortCode ShortCodeService) *LinkServiceImpl {
+// NewLinkService creates a link service. oldCodeGrace is how long a link's
+// previous code keeps redirecting after the code or domain is changed.
+func NewLinkService(linkRepo repository.LinkRepository, campaignRepo repository.CampaignRepository, aliasRepo repository.LinkAliasRepository, historyRepo repository.LinkHistoryRepository, domainRepo repository.DomainRepository, shortCode ShortCodeService, audit AuditService, webhooks WebhookService, oldCodeGrace time.Duration) *LinkServiceImpl {
 	return &LinkServiceImpl{
-		linkRepo:  linkRepo,
-		shortCode: shortCode,
+		linkRepo:     linkRepo,
+		campaignRepo: campaignRepo,
+		aliasRepo:    aliasRepo,
+		historyRepo:  historyRepo,
+		domainRepo:   domainRepo,
+		shortCode:    shortCode,
+		audit:        audit,
+		webhooks:     webhooks,
+		oldCodeGrace: oldCodeGrace,
 	}
 }
 
 type CreateLinkInput struct {
-	OriginalURL string     `json:"original_url" binding:"required,url"`
-	CustomCode  string     `json:"custom_code,omitempty"`
-	Title       string     `json:"title,omitempty"`
-	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
-	DomainID    *uint64    `json:"domain_id,omitempty"`
+	OriginalURL string `json:"original_url" binding:"required,url"`
+	CustomCode  string `json:"custom_code,omitempty"`
+	// CodeStrategy picks how the code is generated when no custom code is given
+	CodeStrategy string     `json:"code_strategy,omitempty"`
+	Title        string     `json:"title,omitempty"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	// DomainID nil uses the workspace's default domain, 0 the service's own domain
+	DomainID    *uint64 `json:"domain_id,omitempty"`
+	CampaignID  *uint64 `json:"campaign_id,omitempty"`
+	UTMSource   string  `json:"utm_source,omitempty"`
+	UTMMedium   string  `json:"utm_medium,omitempty"`
+	UTMCampaign string  `json:"utm_campaign,omitempty"`
+	// ReuseExisting returns the user's existing active link for the same
+	// destination, domain, campaign and UTM overrides instead of creating a
+	// new one. It has no effect when CustomCode is set.
+	ReuseExisting bool `json:"reuse_existing,omitempty"`
+	// IgnoreTrackingParams treats URLs differing only in utm_* and click-ID
+	// parameters as the same destination when reusing.
+	IgnoreTrackingParams bool `json:"ignore_tracking_params,omitempty"`
 }
 
 type UpdateLinkInput struct {
@@ -49,7 +85,29 @@ type UpdateLinkInput struct {
 	Title       string     `json:"title,omitempty"`
 	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
 	IsActive    *bool      `json:"is_active,omitempty"`
-	DomainID    *uint64    `json:"domain_id,omitempty"`
+	// ShortCode renames the link; the old code keeps redirecting for a grace period.
+	ShortCode string `json:"short_code,omitempty"`
+	// DomainID moves the link to another domain; 0 moves it to the default domain.
+	DomainID *uint64 `json:"domain_id,omitempty"`
+	// CampaignID attaches the link to a campaign; 0 detaches it.
+	CampaignID *uint64 `json:"campaign_id,omitempty"`
+	// UTM overrides: nil leaves the value unchanged, "" falls back to the campaign default.
+	UTMSource   *string `json:"utm_source,omitempty"`
+	UTMMedium   *string `json:"utm_medium,omitempty"`
+	UTMCampaign *string `json:"utm_campaign,omitempty"`
+}
+
+type AddAliasInput struct {
+	Code string `json:"code" binding:"required"`
+	// DomainID places the alias on another domain; nil uses the link's domain, 0 the default domain.
+	DomainID  *uint64    `json:"domain_id,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// DuplicateGroup is a set of links sharing the same normalized destination.
+type DuplicateGroup struct {
+	Destination string       `json:"destination"`
+	Links       []model.Link `json:"links"`
 }
 
 type ListLinksParams struct {
@@ -64,44 +122,66 @@ type ListLinksResult struct {
 	TotalPages int          `json:"total_pages"`
 }
 
-func (s *LinkServiceImpl) Create(ctx context.Context, userID uint64, input CreateLinkInput) (*model.Link, error) {
-	var shortCode string
-	var err error
-
-	if input.CustomCode != "" {
-		if !s.shortCode.IsValid(input.CustomCode) {
-			return nil, ErrInvalidShortCode
+func (s *LinkServiceImpl) Create(ctx context.Context, actor Actor, input CreateLinkInput) (*model.Link, error) {
+	if !actor.Can(model.RoleEditor) {
+		return nil, ErrInsufficientRole
+	}
+	if input.CodeStrategy != "" && !s.shortCode.HasStrategy(input.CodeStrategy) {
+		return nil, ErrUnknownCodeStrategy
+	}
+	var campaign *model.Campaign
+	if input.CampaignID != nil {
+		var err error
+		campaign, err = s.checkCampaignOwner(ctx, actor, *input.CampaignID)
+		if err != nil {
+			return nil, err
 		}
-		available, err := s.shortCode.IsAvailable(ctx, input.DomainID, input.CustomCode)
+	}
+	// No domain means the workspace's default domain, 0 the service's own
+	var domain *model.Domain
+	var err error
+	switch {
+	case input.DomainID == nil:
+		domain, err = s.defaultDomain(ctx, actor)
 		if err != nil {
-			logger.Error(ctx, "link-service: failed to check code availability",
-				zap.Uint64("user_id", userID),
-				zap.String("custom_code", input.CustomCode),
-				zap.Error(err),
-			)
 			return nil, err
 		}
-		if !available {
-			return nil, ErrShortCodeTaken
+		if domain != nil {
+			input.DomainID = &domain.ID
 		}
-		shortCode = input.CustomCode
-	} else {
-		shortCode, err = s.shortCode.Generate(ctx, input.DomainID)
+	case *input.DomainID == 0:
+		input.DomainID = nil
+	default:
+		domain, err = s.checkDomain(ctx, actor, *input.DomainID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if domain != nil {
+		applyDomainUTM(&input, campaign, domain)
+	}
+
+	if input.ReuseExisting && input.CustomCode == "" {
+		existing, err := s.findReusable(ctx, actor, input)
 		if err != nil {
-			logger.Error(ctx, "link-service: failed to generate short code",
-				zap.Uint64("user_id", userID),
-				zap.Error(err),
-			)
 			return nil, err
 		}
+		if existing != nil {
+			return existing, nil
+		}
 	}
 
 	link := &model.Link{
-		UserID:      userID,
-		ShortCode:   shortCode,
+		UserID:      actor.UserID,
+		WorkspaceID: actor.WorkspaceID,
 		OriginalURL: input.OriginalURL,
 		IsActive:    true,
 		DomainID:    input.DomainID,
+		CampaignID:  input.CampaignID,
+		UTMSource:   optionalString(input.UTMSource),
+		UTMMedium:   optionalString(input.UTMMedium),
+		UTMCampaign: optionalString(input.UTMCampaign),
 	}
 
 	if input.Title != "" {
@@ -110,23 +190,143 @@ func (s *LinkServiceImpl) Create(ctx context.Context, userID uint64, input Creat
 
 	if input.ExpiresAt != nil {
 		link.ExpiresAt = model.NullTime{NullTime: sql.NullTime{Time: *input.ExpiresAt, Valid: true}}
+	} else if domain != nil && domain.DefaultExpiryDays != nil {
+		expiresAt := time.Now().AddDate(0, 0, *domain.DefaultExpiryDays)
+		link.ExpiresAt = model.NullTime{NullTime: sql.NullTime{Time: expiresAt, Valid: true}}
 	}
 
-	if err := s.linkRepo.Create(ctx, link); err != nil {
-		if errors.Is(err, repository.ErrShortCodeExists) {
+	if input.CustomCode != "" {
+		code, err := s.shortCode.Canonicalize(ctx, input.DomainID, input.CustomCode)
+		if err != nil {
+			return nil, err
+		}
+		available, err := s.shortCode.IsAvailable(ctx, actor.WorkspaceID, input.DomainID, code)
+		if err != nil {
+			logger.Error(ctx, "link-service: failed to check code availability",
+				zap.Uint64("user_id", actor.UserID),
+				zap.String("custom_code", code),
+				zap.Error(err),
+			)
+			return nil, err
+		}
+		if !available {
 			return nil, ErrShortCodeTaken
 		}
+		link.ShortCode = code
+
+		if err := s.linkRepo.Create(ctx, link); err != nil {
+			if errors.Is(err, repository.ErrShortCodeExists) {
+				return nil, ErrShortCodeTaken
+			}
+			logger.Error(ctx, "link-service: failed to create link",
+				zap.Uint64("user_id", actor.UserID),
+				zap.Error(err),
+			)
+			return nil, err
+		}
+		s.recordLink(ctx, actor, model.AuditLinkCreated, link, nil, model.SnapshotOf(link))
+		emitLink(ctx, s.webhooks, model.WebhookLinkCreated, link)
+		return link, nil
+	}
+
+	// Generated codes are inserted directly; a duplicate just means trying the next candidate
+	_, err = s.shortCode.Allocate(ctx, input.DomainID, input.CodeStrategy, func(code string) error {
+		link.ShortCode = code
+		return s.linkRepo.Create(ctx, link)
+	})
+	if err != nil {
 		logger.Error(ctx, "link-service: failed to create link",
-			zap.Uint64("user_id", userID),
+			zap.Uint64("user_id", actor.UserID),
 			zap.Error(err),
 		)
 		return nil, err
 	}
 
+	s.recordLink(ctx, actor, model.AuditLinkCreated, link, nil, model.SnapshotOf(link))
+	emitLink(ctx, s.webhooks, model.WebhookLinkCreated, link)
 	return link, nil
 }
 
-func (s *LinkServiceImpl) GetByID(ctx context.Context, userID, linkID uint64) (*model.Link, error) {
+// findReusable looks for an active link of the workspace that is interchangeable
+// with the one described by input.
+func (s *LinkServiceImpl) findReusable(ctx context.Context, actor Actor, input CreateLinkInput) (*model.Link, error) {
+	hash := util.DestinationHash(input.OriginalURL, input.IgnoreTrackingParams)
+	links, err := s.linkRepo.ListByDestHash(ctx, actor.WorkspaceID, hash, input.IgnoreTrackingParams)
+	if err != nil {
+		logger.Error(ctx, "link-service: failed to list links for reuse",
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range links {
+		link := &links[i]
+		if !link.IsActive || (link.ExpiresAt.Valid && link.ExpiresAt.Time.Before(now)) {
+			continue
+		}
+		if !sameID(link.DomainID, input.DomainID) || !sameID(link.CampaignID, input.CampaignID) {
+			continue
+		}
+		if !sameString(link.UTMSource, optionalString(input.UTMSource)) ||
+			!sameString(link.UTMMedium, optionalString(input.UTMMedium)) ||
+			!sameString(link.UTMCampaign, optionalString(input.UTMCampaign)) {
+			continue
+		}
+		return link, nil
+	}
+	return nil, nil
+}
+
+// FindDuplicates groups the workspace's links by normalized destination and
+// returns the groups that contain more than one link, largest first.
+func (s *LinkServiceImpl) FindDuplicates(ctx context.Context, actor Actor, ignoreTracking bool) ([]DuplicateGroup, error) {
+	links, err := s.linkRepo.ListDuplicateDestinations(ctx, actor.WorkspaceID, ignoreTracking)
+	if err != nil {
+		logger.Error(ctx, "link-service: failed to list links for duplicates",
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	var order []string
+	byDestination := make(map[string][]model.Link)
+	for _, link := range links {
+		destination := util.NormalizeURL(link.OriginalURL, ignoreTracking)
+		if _, ok := byDestination[destination]; !ok {
+			order = append(order, destination)
+		}
+		byDestination[destination] = append(byDestination[destination], link)
+	}
+
+	groups := []DuplicateGroup{}
+	for _, destination := range order {
+		if members := byDestination[destination]; len(members) > 1 {
+			groups = append(groups, DuplicateGroup{Destination: destination, Links: members})
+		}
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return len(groups[i].Links) > len(groups[j].Links)
+	})
+	return groups, nil
+}
+
+func (s *LinkServiceImpl) GetByID(ctx context.Context, actor Actor, linkID uint64) (*model.Link, error) {
+	link, err := s.getOwned(ctx, actor, linkID)
+	if err != nil {
+		return nil, err
+	}
+	// Trashed links are only reachable through the trash endpoints
+	if link.DeletedAt.Valid {
+		return nil, ErrLinkNotFound
+	}
+	return link, nil
+}
+
+// getOwned loads a link, trashed or not, and checks it belongs to the actor's workspace.
+func (s *LinkServiceImpl) getOwned(ctx context.Context, actor Actor, linkID uint64) (*model.Link, error) {
 	link, err := s.linkRepo.GetByID(ctx, linkID)
 	if errors.Is(err, repository.ErrLinkNotFound) {
 		return nil, ErrLinkNotFound
@@ -134,20 +334,20 @@ func (s *LinkServiceImpl) GetByID(ctx context.Context, userID, linkID uint64) (*
 	if err != nil {
 		logger.Error(ctx, "link-service: failed to get link by ID",
 			zap.Uint64("link_id", linkID),
-			zap.Uint64("user_id", userID),
+			zap.Uint64("user_id", actor.UserID),
 			zap.Error(err),
 		)
 		return nil, err
 	}
 
-	if link.UserID != userID {
+	if link.WorkspaceID != actor.WorkspaceID {
 		return nil, ErrNotLinkOwner
 	}
 
 	return link, nil
 }
 
-func (s *LinkServiceImpl) List(ctx context.Context, userID uint64, params ListLinksParams) (*ListLinksResult, error) {
+func (s *LinkServiceImpl) List(ctx context.Context, actor Actor, params ListLinksParams) (*ListLinksResult, error) {
 	if params.Limit <= 0 {
 		params.Limit = 20
 	} else if params.Limit > maxPageSize {
@@ -159,19 +359,19 @@ func (s *LinkServiceImpl) List(ctx context.Context, userID uint64, params ListLi
 
 	offset := (params.Page - 1) * params.Limit
 
-	links, err := s.linkRepo.ListByUserID(ctx, userID, params.Limit, offset)
+	links, err := s.linkRepo.ListByWorkspaceID(ctx, actor.WorkspaceID, params.Limit, offset)
 	if err != nil {
 		logger.Error(ctx, "link-service: failed to list links",
-			zap.Uint64("user_id", userID),
+			zap.Uint64("user_id", actor.UserID),
 			zap.Error(err),
 		)
 		return nil, err
 	}
 
-	total, err := s.linkRepo.CountByUserID(ctx, userID)
+	total, err := s.linkRepo.CountByWorkspaceID(ctx, actor.WorkspaceID)
 	if err != nil {
 		logger.Error(ctx, "link-service: failed to count links",
-			zap.Uint64("user_id", userID),
+			zap.Uint64("user_id", actor.UserID),
 			zap.Error(err),
 		)
 		return nil, err
@@ -190,11 +390,15 @@ func (s *LinkServiceImpl) List(ctx context.Context, userID uint64, params ListLi
 	}, nil
 }
 
-func (s *LinkServiceImpl) Update(ctx context.Context, userID, linkID uint64, input UpdateLinkInput) (*model.Link, error) {
-	link, err := s.GetByID(ctx, userID, linkID)
+func (s *LinkServiceImpl) Update(ctx context.Context, actor Actor, linkID uint64, input UpdateLinkInput) (*model.Link, error) {
+	if !actor.Can(model.RoleEditor) {
+		return nil, ErrInsufficientRole
+	}
+	link, err := s.GetByID(ctx, actor, linkID)
 	if err != nil {
 		return nil, err
 	}
+	before := model.SnapshotOf(link)
 
 	if input.OriginalURL != "" {
 		link.OriginalURL = input.OriginalURL
@@ -209,34 +413,500 @@ func (s *LinkServiceImpl) Update(ctx context.Context, userID, linkID uint64, inp
 		link.IsActive = *input.IsActive
 	}
 	if input.DomainID != nil {
-		link.DomainID = input.DomainID
+		if *input.DomainID == 0 {
+			link.DomainID = nil
+		} else {
+			if !sameID(link.DomainID, input.DomainID) {
+				if _, err := s.checkDomain(ctx, actor, *input.DomainID); err != nil {
+					return nil, err
+				}
+			}
+			link.DomainID = input.DomainID
+		}
+	}
+	if input.ShortCode != "" {
+		link.ShortCode = input.ShortCode
+	}
+	if input.CampaignID != nil {
+		if *input.CampaignID == 0 {
+			link.CampaignID = nil
+		} else {
+			if _, err := s.checkCampaignOwner(ctx, actor, *input.CampaignID); err != nil {
+				return nil, err
+			}
+			link.CampaignID = input.CampaignID
+		}
+	}
+	if input.UTMSource != nil {
+		link.UTMSource = optionalString(*input.UTMSource)
+	}
+	if input.UTMMedium != nil {
+		link.UTMMedium = optionalString(*input.UTMMedium)
+	}
+	if input.UTMCampaign != nil {
+		link.UTMCampaign = optionalString(*input.UTMCampaign)
 	}
 
-	if err := s.linkRepo.Update(ctx, link); err != nil {
+	if err := s.save(ctx, actor, link, before, model.AuditLinkUpdated); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+func (s *LinkServiceImpl) ListHistory(ctx context.Context, actor Actor, linkID uint64) ([]model.LinkRevision, error) {
+	if _, err := s.GetByID(ctx, actor, linkID); err != nil {
+		return nil, err
+	}
+
+	revs, err := s.historyRepo.ListByLinkID(ctx, linkID)
+	if err != nil {
+		logger.Error(ctx, "link-service: failed to list link history",
+			zap.Uint64("link_id", linkID),
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return revs, nil
+}
+
+// Revert restores the link to the state it had before the given version's
+// change was made. The revert itself is recorded as a new version.
+func (s *LinkServiceImpl) Revert(ctx context.Context, actor Actor, linkID uint64, version int) (*model.Link, error) {
+	if !actor.Can(model.RoleEditor) {
+		return nil, ErrInsufficientRole
+	}
+	link, err := s.GetByID(ctx, actor, linkID)
+	if err != nil {
+		return nil, err
+	}
+	before := model.SnapshotOf(link)
+
+	rev, err := s.historyRepo.GetByVersion(ctx, linkID, version)
+	if errors.Is(err, repository.ErrRevisionNotFound) {
+		return nil, ErrRevisionNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, "link-service: failed to get revision",
+			zap.Uint64("link_id", linkID),
+			zap.Int("version", version),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	target := rev.OldValues
+	if target.CampaignID != nil && !sameID(link.CampaignID, target.CampaignID) {
+		if _, err := s.checkCampaignOwner(ctx, actor, *target.CampaignID); err != nil {
+			return nil, err
+		}
+	}
+	if target.DomainID != nil && !sameID(link.DomainID, target.DomainID) {
+		if _, err := s.checkDomain(ctx, actor, *target.DomainID); err != nil {
+			return nil, err
+		}
+	}
+	target.ApplyTo(link)
+
+	if err := s.save(ctx, actor, link, before, model.AuditLinkReverted); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// save persists an edited link. When the code or domain changed it checks the
+// code against the domain's code policy, checks it is free and keeps the old
+// one as a grace alias; every effective change is recorded as a new history
+// version and audited as action.
+func (s *LinkServiceImpl) save(ctx context.Context, actor Actor, link *model.Link, before model.LinkSnapshot, action string) error {
+	codeChanged := link.ShortCode != before.ShortCode || !sameID(link.DomainID, before.DomainID)
+	if codeChanged {
+		code, err := s.shortCode.Canonicalize(ctx, link.DomainID, link.ShortCode)
+		if err != nil {
+			return err
+		}
+		link.ShortCode = code
+		codeChanged = link.ShortCode != before.ShortCode || !sameID(link.DomainID, before.DomainID)
+	}
+
+	// Moving back onto one of the link's own aliases reclaims that code
+	var reclaimed *model.LinkAlias
+	if codeChanged {
+		alias, err := s.aliasRepo.GetActiveByDomainAndCode(ctx, link.DomainID, link.ShortCode)
+		if err != nil && !errors.Is(err, repository.ErrAliasNotFound) {
+			logger.Error(ctx, "link-service: failed to look up alias",
+				zap.Uint64("link_id", link.ID),
+				zap.String("short_code", link.ShortCode),
+				zap.Error(err),
+			)
+			return err
+		}
+		if alias != nil && alias.LinkID == link.ID {
+			reclaimed = alias
+		} else {
+			available, err := s.shortCode.IsAvailable(ctx, actor.WorkspaceID, link.DomainID, link.ShortCode)
+			if err != nil {
+				logger.Error(ctx, "link-service: failed to check code availability",
+					zap.Uint64("link_id", link.ID),
+					zap.String("short_code", link.ShortCode),
+					zap.Error(err),
+				)
+				return err
+			}
+			if !available {
+				return ErrShortCodeTaken
+			}
+		}
+	}
+
+	var edit repository.LinkEdit
+	if codeChanged {
+		if reclaimed != nil {
+			edit.ReclaimedAliasID = reclaimed.ID
+		}
+		// Keep the old code redirecting (and reserved) for the grace period
+		edit.GraceAlias = &model.LinkAlias{
+			LinkID:    link.ID,
+			DomainID:  before.DomainID,
+			Code:      before.ShortCode,
+			ExpiresAt: model.NullTime{NullTime: sql.NullTime{Time: time.Now().Add(s.oldCodeGrace), Valid: true}},
+		}
+	}
+	after := model.SnapshotOf(link)
+	changed := !reflect.DeepEqual(before, after)
+	if changed {
+		edit.Revision = &model.LinkRevision{
+			LinkID:    link.ID,
+			ChangedBy: actor.UserID,
+			OldValues: before,
+			NewValues: after,
+		}
+	}
+	if err := s.linkRepo.SaveEdit(ctx, link, edit); err != nil {
+		if errors.Is(err, repository.ErrShortCodeExists) {
+			return ErrShortCodeTaken
+		}
 		logger.Error(ctx, "link-service: failed to update link",
+			zap.Uint64("link_id", link.ID),
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	if !changed {
+		return nil
+	}
+
+	// Switching a link off or on is called out, as it changes what visitors get
+	if action == model.AuditLinkUpdated && before.IsActive != after.IsActive {
+		action = model.AuditLinkEnabled
+		if !after.IsActive {
+			action = model.AuditLinkDisabled
+		}
+	}
+	s.recordLink(ctx, actor, action, link, before, after)
+	emitLink(ctx, s.webhooks, model.WebhookLinkUpdated, link)
+	return nil
+}
+
+// Delete moves a link to the trash. It stops redirecting but keeps its short
+// code and analytics until it is restored or purged.
+func (s *LinkServiceImpl) Delete(ctx context.Context, actor Actor, linkID uint64) (*model.Link, error) {
+	if !actor.Can(model.RoleEditor) {
+		return nil, ErrInsufficientRole
+	}
+	link, err := s.GetByID(ctx, actor, linkID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.linkRepo.Trash(ctx, link.ID); err != nil {
+		if errors.Is(err, repository.ErrLinkNotFound) {
+			return nil, ErrLinkNotFound
+		}
+		logger.Error(ctx, "link-service: failed to move link to trash",
 			zap.Uint64("link_id", linkID),
-			zap.Uint64("user_id", userID),
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	s.recordLink(ctx, actor, model.AuditLinkDeleted, link, nil, nil)
+	return link, nil
+}
+
+func (s *LinkServiceImpl) ListTrash(ctx context.Context, actor Actor) ([]model.Link, error) {
+	links, err := s.linkRepo.ListTrashedByWorkspaceID(ctx, actor.WorkspaceID)
+	if err != nil {
+		logger.Error(ctx, "link-service: failed to list trash",
+			zap.Uint64("user_id", actor.UserID),
 			zap.Error(err),
 		)
 		return nil, err
 	}
+	return links, nil
+}
 
+func (s *LinkServiceImpl) Restore(ctx context.Context, actor Actor, linkID uint64) (*model.Link, error) {
+	if !actor.Can(model.RoleEditor) {
+		return nil, ErrInsufficientRole
+	}
+	link, err := s.getOwned(ctx, actor, linkID)
+	if err != nil {
+		return nil, err
+	}
+	if !link.DeletedAt.Valid {
+		return nil, ErrLinkNotInTrash
+	}
+	// Links archived along with their domain have nowhere to come back to
+	if link.DomainID != nil {
+		if _, err := s.domainRepo.GetByID(ctx, *link.DomainID); err != nil {
+			if errors.Is(err, repository.ErrDomainNotFound) {
+				return nil, ErrDomainNotFound
+			}
+			return nil, err
+		}
+	}
+
+	if err := s.linkRepo.Restore(ctx, link.ID); err != nil {
+		if errors.Is(err, repository.ErrLinkNotFound) {
+			return nil, ErrLinkNotInTrash
+		}
+		logger.Error(ctx, "link-service: failed to restore link",
+			zap.Uint64("link_id", linkID),
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	link.DeletedAt = model.NullTime{}
+	s.recordLink(ctx, actor, model.AuditLinkRestored, link, nil, nil)
 	return link, nil
 }
 
-func (s *LinkServiceImpl) Delete(ctx context.Context, userID, linkID uint64) error {
-	link, err := s.GetByID(ctx, userID, linkID)
+func (s *LinkServiceImpl) ListAliases(ctx context.Context, actor Actor, linkID uint64) ([]model.LinkAlias, error) {
+	if _, err := s.GetByID(ctx, actor, linkID); err != nil {
+		return nil, err
+	}
+
+	aliases, err := s.aliasRepo.ListByLinkID(ctx, linkID)
+	if err != nil {
+		logger.Error(ctx, "link-service: failed to list aliases",
+			zap.Uint64("link_id", linkID),
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return aliases, nil
+}
+
+func (s *LinkServiceImpl) AddAlias(ctx context.Context, actor Actor, linkID uint64, input AddAliasInput) (*model.LinkAlias, error) {
+	if !actor.Can(model.RoleEditor) {
+		return nil, ErrInsufficientRole
+	}
+	link, err := s.GetByID(ctx, actor, linkID)
+	if err != nil {
+		return nil, err
+	}
+
+	domainID := link.DomainID
+	if input.DomainID != nil {
+		if *input.DomainID == 0 {
+			domainID = nil
+		} else {
+			if _, err := s.checkDomain(ctx, actor, *input.DomainID); err != nil {
+				return nil, err
+			}
+			domainID = input.DomainID
+		}
+	}
+
+	code, err := s.shortCode.Canonicalize(ctx, domainID, input.Code)
+	if err != nil {
+		return nil, err
+	}
+	available, err := s.shortCode.IsAvailable(ctx, actor.WorkspaceID, domainID, code)
+	if err != nil {
+		logger.Error(ctx, "link-service: failed to check alias availability",
+			zap.Uint64("link_id", linkID),
+			zap.String("code", code),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if !available {
+		return nil, ErrShortCodeTaken
+	}
+
+	alias := &model.LinkAlias{
+		LinkID:   link.ID,
+		DomainID: domainID,
+		Code:     code,
+	}
+	if input.ExpiresAt != nil {
+		alias.ExpiresAt = model.NullTime{NullTime: sql.NullTime{Time: *input.ExpiresAt, Valid: true}}
+	}
+
+	if err := s.aliasRepo.Create(ctx, alias); err != nil {
+		if errors.Is(err, repository.ErrShortCodeExists) {
+			return nil, ErrShortCodeTaken
+		}
+		logger.Error(ctx, "link-service: failed to create alias",
+			zap.Uint64("link_id", linkID),
+			zap.String("code", input.Code),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	s.recordLink(ctx, actor, model.AuditAliasAdded, link, nil, alias)
+	return alias, nil
+}
+
+func (s *LinkServiceImpl) DeleteAlias(ctx context.Context, actor Actor, linkID, aliasID uint64) error {
+	if !actor.Can(model.RoleEditor) {
+		return ErrInsufficientRole
+	}
+	link, err := s.GetByID(ctx, actor, linkID)
+	if err != nil {
+		return err
+	}
+
+	alias, err := s.aliasRepo.GetByID(ctx, aliasID)
+	if errors.Is(err, repository.ErrAliasNotFound) {
+		return ErrAliasNotFound
+	}
 	if err != nil {
+		logger.Error(ctx, "link-service: failed to get alias",
+			zap.Uint64("alias_id", aliasID),
+			zap.Error(err),
+		)
 		return err
 	}
+	if alias.LinkID != linkID {
+		return ErrAliasNotFound
+	}
 
-	if err := s.linkRepo.Delete(ctx, link.ID); err != nil {
-		logger.Error(ctx, "link-service: failed to delete link",
+	if err := s.aliasRepo.Delete(ctx, aliasID); err != nil {
+		logger.Error(ctx, "link-service: failed to delete alias",
+			zap.Uint64("alias_id", aliasID),
 			zap.Uint64("link_id", linkID),
-			zap.Uint64("user_id", userID),
 			zap.Error(err),
 		)
 		return err
 	}
+	s.recordLink(ctx, actor, model.AuditAliasDeleted, link, alias, nil)
 	return nil
 }
+
+// checkCampaignOwner ensures the campaign a link is attached to belongs to the
+// actor's workspace and returns it.
+func (s *LinkServiceImpl) checkCampaignOwner(ctx context.Context, actor Actor, campaignID uint64) (*model.Campaign, error) {
+	campaign, err := s.campaignRepo.GetByID(ctx, campaignID)
+	if errors.Is(err, repository.ErrCampaignNotFound) {
+		return nil, ErrCampaignNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, "link-service: failed to get campaign",
+			zap.Uint64("campaign_id", campaignID),
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if campaign.WorkspaceID != actor.WorkspaceID {
+		return nil, ErrNotCampaignOwner
+	}
+	return campaign, nil
+}
+
+// applyDomainUTM fills the UTM parameters that a new link's input and its
+// campaign leave unset with the domain's defaults.
+func applyDomainUTM(input *CreateLinkInput, campaign *model.Campaign, domain *model.Domain) {
+	var fromCampaign model.Campaign
+	if campaign != nil {
+		fromCampaign = *campaign
+	}
+	fill := func(value *string, campaignValue, domainValue *string) {
+		if *value == "" && campaignValue == nil && domainValue != nil {
+			*value = *domainValue
+		}
+	}
+	fill(&input.UTMSource, fromCampaign.UTMSource, domain.DefaultUTMSource)
+	fill(&input.UTMMedium, fromCampaign.UTMMedium, domain.DefaultUTMMedium)
+	fill(&input.UTMCampaign, fromCampaign.UTMCampaign, domain.DefaultUTMCampaign)
+}
+
+// checkDomain allows links and aliases only on the actor's verified domains.
+func (s *LinkServiceImpl) checkDomain(ctx context.Context, actor Actor, domainID uint64) (*model.Domain, error) {
+	domain, err := s.domainRepo.GetByID(ctx, domainID)
+	if errors.Is(err, repository.ErrDomainNotFound) {
+		return nil, ErrDomainNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, "link-service: failed to get domain",
+			zap.Uint64("domain_id", domainID),
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if domain.WorkspaceID != actor.WorkspaceID {
+		return nil, ErrDomainNotFound
+	}
+	if !domain.IsVerified() {
+		return nil, ErrDomainNotVerified
+	}
+	return domain, nil
+}
+
+// defaultDomain returns the workspace's default domain for new links, or nil
+// when it has none or the default has lost its verification.
+func (s *LinkServiceImpl) defaultDomain(ctx context.Context, actor Actor) (*model.Domain, error) {
+	domain, err := s.domainRepo.GetDefaultByWorkspaceID(ctx, actor.WorkspaceID)
+	if errors.Is(err, repository.ErrDomainNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		logger.Error(ctx, "link-service: failed to get default domain",
+			zap.Uint64("workspace_id", actor.WorkspaceID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if !domain.IsVerified() {
+		return nil, nil
+	}
+	return domain, nil
+}
+
+// recordLink audits an event on a link of the actor's workspace.
+func (s *LinkServiceImpl) recordLink(ctx context.Context, actor Actor, action string, link *model.Link, before, after any) {
+	recordAudit(ctx, s.audit, AuditEvent{
+		WorkspaceID: link.WorkspaceID,
+		ActorUserID: actor.UserID,
+		Action:      action,
+		TargetType:  model.AuditTargetLink,
+		TargetID:    link.ID,
+		Before:      before,
+		After:       after,
+	})
+}
+
+// sameString reports whether two optional strings are both unset or equal.
+func sameString(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// sameID reports whether two optional IDs refer to the same record, e.g. the
+// same domain (nil = default) or campaign (nil = none).
+func sameID(a, b *uint64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
@@ -0,0 +1,402 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/internal/repository"
+	"github.com/SeaCodeBase/urlshortener/internal/repository/mocks"
+	"github.com/SeaCodeBase/urlshortener/internal/service"
+	servicemocks "github.com/SeaCodeBase/urlshortener/internal/service/mocks"
+	"github.com/SeaCodeBase/urlshortener/internal/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// editor acts in workspace 1, which owns the links in these tests.
+var editor = service.Actor{UserID: 1, WorkspaceID: 1, Role: model.RoleEditor}
+
+func TestLinkService_Update_RenameKeepsOldCodeAsAlias(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLinkRepo := mocks.NewMockLinkRepository(ctrl)
+	mockCampaignRepo := mocks.NewMockCampaignRepository(ctrl)
+	mockAliasRepo := mocks.NewMockLinkAliasRepository(ctrl)
+	mockHistoryRepo := mocks.NewMockLinkHistoryRepository(ctrl)
+	mockDomainRepo := mocks.NewMockDomainRepository(ctrl)
+	mockShortCode := servicemocks.NewMockShortCodeService(ctrl)
+	svc := service.NewLinkService(mockLinkRepo, mockCampaignRepo, mockAliasRepo, mockHistoryRepo, mockDomainRepo, mockShortCode, nil, nil, 24*time.Hour)
+
+	mockLinkRepo.EXPECT().
+		GetByID(gomock.Any(), uint64(10)).
+		Return(&model.Link{ID: 10, UserID: 1, WorkspaceID: 1, ShortCode: "q3-reprot", OriginalURL: "https://example.com"}, nil)
+	mockShortCode.EXPECT().Canonicalize(gomock.Any(), nil, "q3report").Return("q3report", nil)
+	mockAliasRepo.EXPECT().
+		GetActiveByDomainAndCode(gomock.Any(), nil, "q3report").
+		Return(nil, repository.ErrAliasNotFound)
+	mockShortCode.EXPECT().IsAvailable(gomock.Any(), uint64(1), nil, "q3report").Return(true, nil)
+	mockLinkRepo.EXPECT().
+		SaveEdit(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, link *model.Link, edit repository.LinkEdit) error {
+			assert.Equal(t, "q3report", link.ShortCode)
+			assert.Zero(t, edit.ReclaimedAliasID)
+			alias := edit.GraceAlias
+			if assert.NotNil(t, alias) {
+				assert.Equal(t, uint64(10), alias.LinkID)
+				assert.Equal(t, "q3-reprot", alias.Code)
+				assert.Nil(t, alias.DomainID)
+				assert.True(t, alias.ExpiresAt.Valid)
+				assert.WithinDuration(t, time.Now().Add(24*time.Hour), alias.ExpiresAt.Time, time.Minute)
+			}
+			rev := edit.Revision
+			if assert.NotNil(t, rev) {
+				assert.Equal(t, uint64(1), rev.ChangedBy)
+				assert.Equal(t, "q3-reprot", rev.OldValues.ShortCode)
+				assert.Equal(t, "q3report", rev.NewValues.ShortCode)
+			}
+			return nil
+		})
+
+	link, err := svc.Update(context.Background(), editor, 10, service.UpdateLinkInput{ShortCode: "q3report"})
+	assert.NoError(t, err)
+	assert.Equal(t, "q3report", link.ShortCode)
+}
+
+func TestLinkService_Update_RenameToTakenCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLinkRepo := mocks.NewMockLinkRepository(ctrl)
+	mockCampaignRepo := mocks.NewMockCampaignRepository(ctrl)
+	mockAliasRepo := mocks.NewMockLinkAliasRepository(ctrl)
+	mockHistoryRepo := mocks.NewMockLinkHistoryRepository(ctrl)
+	mockDomainRepo := mocks.NewMockDomainRepository(ctrl)
+	mockShortCode := servicemocks.NewMockShortCodeService(ctrl)
+	svc := service.NewLinkService(mockLinkRepo, mockCampaignRepo, mockAliasRepo, mockHistoryRepo, mockDomainRepo, mockShortCode, nil, nil, 24*time.Hour)
+
+	domainID := uint64(3)
+	verifiedAt := time.Now()
+	mockLinkRepo.EXPECT().
+		GetByID(gomock.Any(), uint64(10)).
+		Return(&model.Link{ID: 10, UserID: 1, WorkspaceID: 1, ShortCode: "abc1234"}, nil)
+	mockDomainRepo.EXPECT().
+		GetByID(gomock.Any(), domainID).
+		Return(&model.Domain{ID: domainID, WorkspaceID: 1, VerifiedAt: &verifiedAt}, nil)
+	mockShortCode.EXPECT().Canonicalize(gomock.Any(), &domainID, "abc1234").Return("abc1234", nil)
+	mockAliasRepo.EXPECT().
+		GetActiveByDomainAndCode(gomock.Any(), &domainID, "abc1234").
+		Return(nil, repository.ErrAliasNotFound)
+	mockShortCode.EXPECT().IsAvailable(gomock.Any(), uint64(1), &domainID, "abc1234").Return(false, nil)
+
+	_, err := svc.Update(context.Background(), editor, 10, service.UpdateLinkInput{DomainID: &domainID})
+	assert.ErrorIs(t, err, service.ErrShortCodeTaken)
+}
+
+func TestLinkService_AddAlias_DefaultsToLinkDomain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLinkRepo := mocks.NewMockLinkRepository(ctrl)
+	mockCampaignRepo := mocks.NewMockCampaignRepository(ctrl)
+	mockAliasRepo := mocks.NewMockLinkAliasRepository(ctrl)
+	mockHistoryRepo := mocks.NewMockLinkHistoryRepository(ctrl)
+	mockDomainRepo := mocks.NewMockDomainRepository(ctrl)
+	mockShortCode := servicemocks.NewMockShortCodeService(ctrl)
+	svc := service.NewLinkService(mockLinkRepo, mockCampaignRepo, mockAliasRepo, mockHistoryRepo, mockDomainRepo, mockShortCode, nil, nil, 24*time.Hour)
+
+	domainID := uint64(3)
+	mockLinkRepo.EXPECT().
+		GetByID(gomock.Any(), uint64(10)).
+		Return(&model.Link{ID: 10, UserID: 1, WorkspaceID: 1, DomainID: &domainID, ShortCode: "summer"}, nil)
+	mockShortCode.EXPECT().Canonicalize(gomock.Any(), &domainID, "summer-ig").Return("summer-ig", nil)
+	mockShortCode.EXPECT().IsAvailable(gomock.Any(), uint64(1), &domainID, "summer-ig").Return(true, nil)
+	mockAliasRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, alias *model.LinkAlias) error {
+			assert.Equal(t, uint64(10), alias.LinkID)
+			assert.Equal(t, &domainID, alias.DomainID)
+			assert.False(t, alias.ExpiresAt.Valid)
+			return nil
+		})
+
+	alias, err := svc.AddAlias(context.Background(), editor, 10, service.AddAliasInput{Code: "summer-ig"})
+	assert.NoError(t, err)
+	assert.Equal(t, "summer-ig", alias.Code)
+}
+
+func TestLinkService_Revert_RestoresOldCodeFromAlias(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLinkRepo := mocks.NewMockLinkRepository(ctrl)
+	mockCampaignRepo := mocks.NewMockCampaignRepository(ctrl)
+	mockAliasRepo := mocks.NewMockLinkAliasRepository(ctrl)
+	mockHistoryRepo := mocks.NewMockLinkHistoryRepository(ctrl)
+	mockDomainRepo := mocks.NewMockDomainRepository(ctrl)
+	mockShortCode := servicemocks.NewMockShortCodeService(ctrl)
+	svc := service.NewLinkService(mockLinkRepo, mockCampaignRepo, mockAliasRepo, mockHistoryRepo, mockDomainRepo, mockShortCode, nil, nil, 24*time.Hour)
+
+	mockLinkRepo.EXPECT().
+		GetByID(gomock.Any(), uint64(10)).
+		Return(&model.Link{ID: 10, UserID: 1, WorkspaceID: 1, ShortCode: "new", OriginalURL: "https://wrong.example.com", IsActive: true}, nil)
+	mockHistoryRepo.EXPECT().
+		GetByVersion(gomock.Any(), uint64(10), 2).
+		Return(&model.LinkRevision{
+			LinkID:    10,
+			Version:   2,
+			OldValues: model.LinkSnapshot{ShortCode: "old", OriginalURL: "https://example.com", IsActive: true},
+			NewValues: model.LinkSnapshot{ShortCode: "new", OriginalURL: "https://wrong.example.com", IsActive: true},
+		}, nil)
+	mockShortCode.EXPECT().Canonicalize(gomock.Any(), nil, "old").Return("old", nil)
+	// The old code is still held by this link's grace alias, so it is reclaimed
+	mockAliasRepo.EXPECT().
+		GetActiveByDomainAndCode(gomock.Any(), nil, "old").
+		Return(&model.LinkAlias{ID: 7, LinkID: 10, Code: "old"}, nil)
+	mockLinkRepo.EXPECT().
+		SaveEdit(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, link *model.Link, edit repository.LinkEdit) error {
+			assert.Equal(t, uint64(7), edit.ReclaimedAliasID)
+			if assert.NotNil(t, edit.GraceAlias) {
+				assert.Equal(t, "new", edit.GraceAlias.Code)
+			}
+			if assert.NotNil(t, edit.Revision) {
+				assert.Equal(t, "https://wrong.example.com", edit.Revision.OldValues.OriginalURL)
+				assert.Equal(t, "https://example.com", edit.Revision.NewValues.OriginalURL)
+			}
+			return nil
+		})
+
+	link, err := svc.Revert(context.Background(), editor, 10, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "old", link.ShortCode)
+	assert.Equal(t, "https://example.com", link.OriginalURL)
+}
+
+func TestLinkService_Revert_UnknownVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLinkRepo := mocks.NewMockLinkRepository(ctrl)
+	mockCampaignRepo := mocks.NewMockCampaignRepository(ctrl)
+	mockAliasRepo := mocks.NewMockLinkAliasRepository(ctrl)
+	mockHistoryRepo := mocks.NewMockLinkHistoryRepository(ctrl)
+	mockDomainRepo := mocks.NewMockDomainRepository(ctrl)
+	mockShortCode := servicemocks.NewMockShortCodeService(ctrl)
+	svc := service.NewLinkService(mockLinkRepo, mockCampaignRepo, mockAliasRepo, mockHistoryRepo, mockDomainRepo, mockShortCode, nil, nil, 24*time.Hour)
+
+	mockLinkRepo.EXPECT().
+		GetByID(gomock.Any(), uint64(10)).
+		Return(&model.Link{ID: 10, UserID: 1, WorkspaceID: 1, ShortCode: "abc1234"}, nil)
+	mockHistoryRepo.EXPECT().
+		GetByVersion(gomock.Any(), uint64(10), 9).
+		Return(nil, repository.ErrRevisionNotFound)
+
+	_, err := svc.Revert(context.Background(), editor, 10, 9)
+	assert.ErrorIs(t, err, service.ErrRevisionNotFound)
+}
+
+func TestLinkService_Create_ReuseExisting(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLinkRepo := mocks.NewMockLinkRepository(ctrl)
+	mockCampaignRepo := mocks.NewMockCampaignRepository(ctrl)
+	mockAliasRepo := mocks.NewMockLinkAliasRepository(ctrl)
+	mockHistoryRepo := mocks.NewMockLinkHistoryRepository(ctrl)
+	mockDomainRepo := mocks.NewMockDomainRepository(ctrl)
+	mockShortCode := servicemocks.NewMockShortCodeService(ctrl)
+	svc := service.NewLinkService(mockLinkRepo, mockCampaignRepo, mockAliasRepo, mockHistoryRepo, mockDomainRepo, mockShortCode, nil, nil, 24*time.Hour)
+
+	domainID := uint64(3)
+	verifiedAt := time.Now()
+	mockDomainRepo.EXPECT().
+		GetByID(gomock.Any(), domainID).
+		Return(&model.Domain{ID: domainID, WorkspaceID: 1, VerifiedAt: &verifiedAt}, nil)
+	mockLinkRepo.EXPECT().
+		ListByDestHash(gomock.Any(), uint64(1), util.DestinationHash("https://example.com/a", true), true).
+		Return([]model.Link{
+			// Same destination on another domain
+			{ID: 1, UserID: 1, WorkspaceID: 1, ShortCode: "other", OriginalURL: "https://example.com/a", IsActive: true},
+			// Same destination but disabled
+			{ID: 2, UserID: 1, WorkspaceID: 1, DomainID: &domainID, ShortCode: "off", OriginalURL: "https://example.com/a", IsActive: false},
+			{ID: 3, UserID: 1, WorkspaceID: 1, DomainID: &domainID, ShortCode: "keep", OriginalURL: "https://Example.com:443/a?utm_source=x", IsActive: true},
+		}, nil)
+
+	link, err := svc.Create(context.Background(), editor, service.CreateLinkInput{
+		OriginalURL:          "https://example.com/a",
+		DomainID:             &domainID,
+		ReuseExisting:        true,
+		IgnoreTrackingParams: true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), link.ID)
+}
+
+func TestLinkService_WorkspaceAccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLinkRepo := mocks.NewMockLinkRepository(ctrl)
+	svc := service.NewLinkService(mockLinkRepo, mocks.NewMockCampaignRepository(ctrl), mocks.NewMockLinkAliasRepository(ctrl),
+		mocks.NewMockLinkHistoryRepository(ctrl), mocks.NewMockDomainRepository(ctrl), servicemocks.NewMockShortCodeService(ctrl), nil, nil, time.Hour)
+
+	mockLinkRepo.EXPECT().
+		GetByID(gomock.Any(), uint64(10)).
+		Return(&model.Link{ID: 10, UserID: 1, WorkspaceID: 1, ShortCode: "abc1234"}, nil).
+		Times(2)
+
+	// Viewers can read the workspace's links but not change them
+	viewer := service.Actor{UserID: 2, WorkspaceID: 1, Role: model.RoleViewer}
+	link, err := svc.GetByID(context.Background(), viewer, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), link.ID)
+	_, err = svc.Delete(context.Background(), viewer, 10)
+	assert.ErrorIs(t, err, service.ErrInsufficientRole)
+
+	// Members of other workspaces cannot see it, whoever created it
+	_, err = svc.GetByID(context.Background(), service.Actor{UserID: 1, WorkspaceID: 2, Role: model.RoleOwner}, 10)
+	assert.ErrorIs(t, err, service.ErrNotLinkOwner)
+}
+
+func TestLinkService_Update_DisableIsAudited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLinkRepo := mocks.NewMockLinkRepository(ctrl)
+	mockHistoryRepo := mocks.NewMockLinkHistoryRepository(ctrl)
+	mockAudit := servicemocks.NewMockAuditService(ctrl)
+	svc := service.NewLinkService(mockLinkRepo, mocks.NewMockCampaignRepository(ctrl), mocks.NewMockLinkAliasRepository(ctrl),
+		mockHistoryRepo, mocks.NewMockDomainRepository(ctrl), servicemocks.NewMockShortCodeService(ctrl), mockAudit, nil, time.Hour)
+
+	mockLinkRepo.EXPECT().
+		GetByID(gomock.Any(), uint64(10)).
+		Return(&model.Link{ID: 10, UserID: 1, WorkspaceID: 1, ShortCode: "abc1234", IsActive: true}, nil)
+	mockLinkRepo.EXPECT().
+		SaveEdit(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, link *model.Link, edit repository.LinkEdit) error {
+			assert.Nil(t, edit.GraceAlias)
+			assert.NotNil(t, edit.Revision)
+			return nil
+		})
+
+	var recorded service.AuditEvent
+	mockAudit.EXPECT().
+		Record(gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, event service.AuditEvent) { recorded = event })
+
+	inactive := false
+	_, err := svc.Update(context.Background(), editor, 10, service.UpdateLinkInput{IsActive: &inactive})
+	assert.NoError(t, err)
+	assert.Equal(t, model.AuditLinkDisabled, recorded.Action)
+	assert.Equal(t, uint64(1), recorded.WorkspaceID)
+	assert.Equal(t, uint64(10), recorded.TargetID)
+
+	changes := model.DiffOf(recorded.Before, recorded.After)
+	if assert.NotNil(t, changes) {
+		assert.Equal(t, map[string]any{"is_active": true}, changes.Before)
+		assert.Equal(t, map[string]any{"is_active": false}, changes.After)
+	}
+}
+
+func TestLinkService_Create_RejectsUnverifiedDomain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDomainRepo := mocks.NewMockDomainRepository(ctrl)
+	svc := service.NewLinkService(mocks.NewMockLinkRepository(ctrl), mocks.NewMockCampaignRepository(ctrl), mocks.NewMockLinkAliasRepository(ctrl),
+		mocks.NewMockLinkHistoryRepository(ctrl), mockDomainRepo, servicemocks.NewMockShortCodeService(ctrl), nil, nil, time.Hour)
+
+	pending := uint64(3)
+	foreign := uint64(4)
+	mockDomainRepo.EXPECT().
+		GetByID(gomock.Any(), pending).
+		Return(&model.Domain{ID: pending, WorkspaceID: 1, VerificationToken: "token"}, nil)
+	mockDomainRepo.EXPECT().
+		GetByID(gomock.Any(), foreign).
+		Return(&model.Domain{ID: foreign, WorkspaceID: 2}, nil)
+
+	_, err := svc.Create(context.Background(), editor, service.CreateLinkInput{OriginalURL: "https://example.com", DomainID: &pending})
+	assert.ErrorIs(t, err, service.ErrDomainNotVerified)
+	_, err = svc.Create(context.Background(), editor, service.CreateLinkInput{OriginalURL: "https://example.com", DomainID: &foreign})
+	assert.ErrorIs(t, err, service.ErrDomainNotFound)
+}
+
+func TestLinkService_Create_UsesDefaultDomain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLinkRepo := mocks.NewMockLinkRepository(ctrl)
+	mockDomainRepo := mocks.NewMockDomainRepository(ctrl)
+	mockShortCode := servicemocks.NewMockShortCodeService(ctrl)
+	svc := service.NewLinkService(mockLinkRepo, mocks.NewMockCampaignRepository(ctrl), mocks.NewMockLinkAliasRepository(ctrl),
+		mocks.NewMockLinkHistoryRepository(ctrl), mockDomainRepo, mockShortCode, nil, nil, time.Hour)
+
+	verifiedAt := time.Now()
+	expiryDays := 30
+	mockDomainRepo.EXPECT().
+		GetDefaultByWorkspaceID(gomock.Any(), uint64(1)).
+		Return(&model.Domain{ID: 3, WorkspaceID: 1, VerifiedAt: &verifiedAt, IsDefault: true, DefaultExpiryDays: &expiryDays}, nil)
+	mockShortCode.EXPECT().
+		Allocate(gomock.Any(), gomock.Any(), "", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, domainID *uint64, strategy string, insert func(string) error) (string, error) {
+			return "abc1234", insert("abc1234")
+		}).
+		Times(2)
+	mockLinkRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	// No domain given: the workspace default and its expiry apply
+	link, err := svc.Create(context.Background(), editor, service.CreateLinkInput{OriginalURL: "https://example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), *link.DomainID)
+	assert.True(t, link.ExpiresAt.Valid)
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, 30), link.ExpiresAt.Time, time.Minute)
+
+	// Domain 0 asks for the service's own domain
+	own := uint64(0)
+	link, err = svc.Create(context.Background(), editor, service.CreateLinkInput{OriginalURL: "https://example.com", DomainID: &own})
+	assert.NoError(t, err)
+	assert.Nil(t, link.DomainID)
+	assert.False(t, link.ExpiresAt.Valid)
+}
+
+func TestLinkService_Create_AppliesDomainUTMDefaults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLinkRepo := mocks.NewMockLinkRepository(ctrl)
+	mockCampaignRepo := mocks.NewMockCampaignRepository(ctrl)
+	mockDomainRepo := mocks.NewMockDomainRepository(ctrl)
+	mockShortCode := servicemocks.NewMockShortCodeService(ctrl)
+	svc := service.NewLinkService(mockLinkRepo, mockCampaignRepo, mocks.NewMockLinkAliasRepository(ctrl),
+		mocks.NewMockLinkHistoryRepository(ctrl), mockDomainRepo, mockShortCode, nil, nil, time.Hour)
+
+	verifiedAt := time.Now()
+	source, medium, name := "newsletter", "email", "spring"
+	mockDomainRepo.EXPECT().
+		GetDefaultByWorkspaceID(gomock.Any(), uint64(1)).
+		Return(&model.Domain{ID: 3, WorkspaceID: 1, VerifiedAt: &verifiedAt, IsDefault: true,
+			DefaultUTMSource: &source, DefaultUTMMedium: &medium, DefaultUTMCampaign: &name}, nil)
+	campaignMedium := "social"
+	mockCampaignRepo.EXPECT().
+		GetByID(gomock.Any(), uint64(8)).
+		Return(&model.Campaign{ID: 8, WorkspaceID: 1, UTMMedium: &campaignMedium}, nil)
+	mockShortCode.EXPECT().
+		Allocate(gomock.Any(), gomock.Any(), "", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, domainID *uint64, strategy string, insert func(string) error) (string, error) {
+			return "abc1234", insert("abc1234")
+		})
+	mockLinkRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	// The link's own source and the campaign's medium win over the defaults
+	campaignID := uint64(8)
+	link, err := svc.Create(context.Background(), editor, service.CreateLinkInput{
+		OriginalURL: "https://example.com", CampaignID: &campaignID, UTMSource: "ads",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "ads", *link.UTMSource)
+	assert.Nil(t, link.UTMMedium)
+	assert.Equal(t, "spring", *link.UTMCampaign)
+}
@@ -43,17 +43,17 @@ func (m *MockAuthService) EXPECT() *MockAuthServiceMockRecorder {
 }
 
 // ChangePassword mocks base method.
-func (m *MockAuthService) ChangePassword(ctx context.Context, userID uint64, input service.ChangePasswordInput) error {
+func (m *MockAuthService) ChangePassword(ctx context.Context, userID, currentSessionID uint64, input service.ChangePasswordInput) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ChangePassword", ctx, userID, input)
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, userID, currentSessionID, input)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ChangePassword indicates an expected call of ChangePassword.
-func (mr *MockAuthServiceMockRecorder) ChangePassword(ctx, userID, input any) *gomock.Call {
+func (mr *MockAuthServiceMockRecorder) ChangePassword(ctx, userID, currentSessionID, input any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockAuthService)(nil).ChangePassword), ctx, userID, input)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockAuthService)(nil).ChangePassword), ctx, userID, currentSessionID, input)
 }
 
 // GetUserByID mocks base method.
@@ -71,11 +71,26 @@ func (mr *MockAuthServiceMockRecorder) GetUserByID(ctx, userID any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockAuthService)(nil).GetUserByID), ctx, userID)
 }
 
+// ListSessions mocks base method.
+func (m *MockAuthService) ListSessions(ctx context.Context, userID, currentSessionID uint64) ([]model.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", ctx, userID, currentSessionID)
+	ret0, _ := ret[0].([]model.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockAuthServiceMockRecorder) ListSessions(ctx, userID, currentSessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockAuthService)(nil).ListSessions), ctx, userID, currentSessionID)
+}
+
 // Login mocks base method.
-func (m *MockAuthService) Login(ctx context.Context, input service.LoginInput) (*service.AuthResponse, error) {
+func (m *MockAuthService) Login(ctx context.Context, input service.LoginInput) (*model.User, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Login", ctx, input)
-	ret0, _ := ret[0].(*service.AuthResponse)
+	ret0, _ := ret[0].(*model.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -86,32 +101,104 @@ func (mr *MockAuthServiceMockRecorder) Login(ctx, input any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockAuthService)(nil).Login), ctx, input)
 }
 
+// Refresh mocks base method.
+func (m *MockAuthService) Refresh(ctx context.Context, refreshToken string, meta service.SessionMeta) (*service.AuthResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, refreshToken, meta)
+	ret0, _ := ret[0].(*service.AuthResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockAuthServiceMockRecorder) Refresh(ctx, refreshToken, meta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockAuthService)(nil).Refresh), ctx, refreshToken, meta)
+}
+
 // Register mocks base method.
-func (m *MockAuthService) Register(ctx context.Context, input service.RegisterInput) (*service.AuthResponse, error) {
+func (m *MockAuthService) Register(ctx context.Context, input service.RegisterInput, meta service.SessionMeta) (*service.AuthResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Register", ctx, input)
+	ret := m.ctrl.Call(m, "Register", ctx, input, meta)
 	ret0, _ := ret[0].(*service.AuthResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Register indicates an expected call of Register.
-func (mr *MockAuthServiceMockRecorder) Register(ctx, input any) *gomock.Call {
+func (mr *MockAuthServiceMockRecorder) Register(ctx, input, meta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockAuthService)(nil).Register), ctx, input, meta)
+}
+
+// RevokeAllSessions mocks base method.
+func (m *MockAuthService) RevokeAllSessions(ctx context.Context, userID, exceptSessionID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAllSessions", ctx, userID, exceptSessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAllSessions indicates an expected call of RevokeAllSessions.
+func (mr *MockAuthServiceMockRecorder) RevokeAllSessions(ctx, userID, exceptSessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllSessions", reflect.TypeOf((*MockAuthService)(nil).RevokeAllSessions), ctx, userID, exceptSessionID)
+}
+
+// RevokeSession mocks base method.
+func (m *MockAuthService) RevokeSession(ctx context.Context, userID, sessionID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, userID, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockAuthServiceMockRecorder) RevokeSession(ctx, userID, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockAuthService)(nil).RevokeSession), ctx, userID, sessionID)
+}
+
+// StartSession mocks base method.
+func (m *MockAuthService) StartSession(ctx context.Context, userID uint64, meta service.SessionMeta) (*service.AuthResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartSession", ctx, userID, meta)
+	ret0, _ := ret[0].(*service.AuthResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartSession indicates an expected call of StartSession.
+func (mr *MockAuthServiceMockRecorder) StartSession(ctx, userID, meta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartSession", reflect.TypeOf((*MockAuthService)(nil).StartSession), ctx, userID, meta)
+}
+
+// UpdateDisplayName mocks base method.
+func (m *MockAuthService) UpdateDisplayName(ctx context.Context, userID uint64, displayName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDisplayName", ctx, userID, displayName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDisplayName indicates an expected call of UpdateDisplayName.
+func (mr *MockAuthServiceMockRecorder) UpdateDisplayName(ctx, userID, displayName any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockAuthService)(nil).Register), ctx, input)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDisplayName", reflect.TypeOf((*MockAuthService)(nil).UpdateDisplayName), ctx, userID, displayName)
 }
 
 // ValidateToken mocks base method.
-func (m *MockAuthService) ValidateToken(tokenString string) (uint64, error) {
+func (m *MockAuthService) ValidateToken(ctx context.Context, tokenString string) (*service.TokenClaims, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ValidateToken", tokenString)
-	ret0, _ := ret[0].(uint64)
+	ret := m.ctrl.Call(m, "ValidateToken", ctx, tokenString)
+	ret0, _ := ret[0].(*service.TokenClaims)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ValidateToken indicates an expected call of ValidateToken.
-func (mr *MockAuthServiceMockRecorder) ValidateToken(tokenString any) *gomock.Call {
+func (mr *MockAuthServiceMockRecorder) ValidateToken(ctx, tokenString any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateToken", reflect.TypeOf((*MockAuthService)(nil).ValidateToken), tokenString)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateToken", reflect.TypeOf((*MockAuthService)(nil).ValidateToken), ctx, tokenString)
 }
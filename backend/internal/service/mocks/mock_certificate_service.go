@@ -0,0 +1,162 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/SeaCodeBase/urlshortener/internal/service (interfaces: CertificateService)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mock_certificate_service.go -package=mocks . CertificateService
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	tls "crypto/tls"
+	reflect "reflect"
+
+	model "github.com/SeaCodeBase/urlshortener/internal/model"
+	service "github.com/SeaCodeBase/urlshortener/internal/service"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCertificateService is a mock of CertificateService interface.
+type MockCertificateService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCertificateServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockCertificateServiceMockRecorder is the mock recorder for MockCertificateService.
+type MockCertificateServiceMockRecorder struct {
+	mock *MockCertificateService
+}
+
+// NewMockCertificateService creates a new mock instance.
+func NewMockCertificateService(ctrl *gomock.Controller) *MockCertificateService {
+	mock := &MockCertificateService{ctrl: ctrl}
+	mock.recorder = &MockCertificateServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCertificateService) EXPECT() *MockCertificateServiceMockRecorder {
+	return m.recorder
+}
+
+// ChallengeResponse mocks base method.
+func (m *MockCertificateService) ChallengeResponse(ctx context.Context, token string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChallengeResponse", ctx, token)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChallengeResponse indicates an expected call of ChallengeResponse.
+func (mr *MockCertificateServiceMockRecorder) ChallengeResponse(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChallengeResponse", reflect.TypeOf((*MockCertificateService)(nil).ChallengeResponse), ctx, token)
+}
+
+// Delete mocks base method.
+func (m *MockCertificateService) Delete(ctx context.Context, actor service.Actor, domainID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, actor, domainID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCertificateServiceMockRecorder) Delete(ctx, actor, domainID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCertificateService)(nil).Delete), ctx, actor, domainID)
+}
+
+// Get mocks base method.
+func (m *MockCertificateService) Get(ctx context.Context, actor service.Actor, domainID uint64) (*model.DomainCertificate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, actor, domainID)
+	ret0, _ := ret[0].(*model.DomainCertificate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCertificateServiceMockRecorder) Get(ctx, actor, domainID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCertificateService)(nil).Get), ctx, actor, domainID)
+}
+
+// GetCertificate mocks base method.
+func (m *MockCertificateService) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCertificate", hello)
+	ret0, _ := ret[0].(*tls.Certificate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCertificate indicates an expected call of GetCertificate.
+func (mr *MockCertificateServiceMockRecorder) GetCertificate(hello any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCertificate", reflect.TypeOf((*MockCertificateService)(nil).GetCertificate), hello)
+}
+
+// HasCertificate mocks base method.
+func (m *MockCertificateService) HasCertificate(ctx context.Context, host string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasCertificate", ctx, host)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasCertificate indicates an expected call of HasCertificate.
+func (mr *MockCertificateServiceMockRecorder) HasCertificate(ctx, host any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasCertificate", reflect.TypeOf((*MockCertificateService)(nil).HasCertificate), ctx, host)
+}
+
+// Renew mocks base method.
+func (m *MockCertificateService) Renew(ctx context.Context, limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Renew", ctx, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Renew indicates an expected call of Renew.
+func (mr *MockCertificateServiceMockRecorder) Renew(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Renew", reflect.TypeOf((*MockCertificateService)(nil).Renew), ctx, limit)
+}
+
+// Request mocks base method.
+func (m *MockCertificateService) Request(ctx context.Context, actor service.Actor, domainID uint64) (*model.DomainCertificate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Request", ctx, actor, domainID)
+	ret0, _ := ret[0].(*model.DomainCertificate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Request indicates an expected call of Request.
+func (mr *MockCertificateServiceMockRecorder) Request(ctx, actor, domainID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Request", reflect.TypeOf((*MockCertificateService)(nil).Request), ctx, actor, domainID)
+}
+
+// Upload mocks base method.
+func (m *MockCertificateService) Upload(ctx context.Context, actor service.Actor, domainID uint64, input service.UploadCertificateInput) (*model.DomainCertificate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", ctx, actor, domainID, input)
+	ret0, _ := ret[0].(*model.DomainCertificate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockCertificateServiceMockRecorder) Upload(ctx, actor, domainID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockCertificateService)(nil).Upload), ctx, actor, domainID, input)
+}
@@ -0,0 +1,164 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/SeaCodeBase/urlshortener/internal/service (interfaces: DomainService)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mock_domain_service.go -package=mocks . DomainService
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	model "github.com/SeaCodeBase/urlshortener/internal/model"
+	service "github.com/SeaCodeBase/urlshortener/internal/service"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDomainService is a mock of DomainService interface.
+type MockDomainService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDomainServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockDomainServiceMockRecorder is the mock recorder for MockDomainService.
+type MockDomainServiceMockRecorder struct {
+	mock *MockDomainService
+}
+
+// NewMockDomainService creates a new mock instance.
+func NewMockDomainService(ctrl *gomock.Controller) *MockDomainService {
+	mock := &MockDomainService{ctrl: ctrl}
+	mock.recorder = &MockDomainServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDomainService) EXPECT() *MockDomainServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockDomainService) Create(ctx context.Context, actor service.Actor, input service.CreateDomainInput) (*model.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, actor, input)
+	ret0, _ := ret[0].(*model.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDomainServiceMockRecorder) Create(ctx, actor, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDomainService)(nil).Create), ctx, actor, input)
+}
+
+// Delete mocks base method.
+func (m *MockDomainService) Delete(ctx context.Context, actor service.Actor, id uint64, input service.DeleteDomainInput) (*service.DeleteDomainResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, actor, id, input)
+	ret0, _ := ret[0].(*service.DeleteDomainResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDomainServiceMockRecorder) Delete(ctx, actor, id, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDomainService)(nil).Delete), ctx, actor, id, input)
+}
+
+// Get mocks base method.
+func (m *MockDomainService) Get(ctx context.Context, actor service.Actor, id uint64) (*model.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, actor, id)
+	ret0, _ := ret[0].(*model.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockDomainServiceMockRecorder) Get(ctx, actor, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDomainService)(nil).Get), ctx, actor, id)
+}
+
+// List mocks base method.
+func (m *MockDomainService) List(ctx context.Context, actor service.Actor) ([]*model.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, actor)
+	ret0, _ := ret[0].([]*model.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockDomainServiceMockRecorder) List(ctx, actor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDomainService)(nil).List), ctx, actor)
+}
+
+// NormalizeExisting mocks base method.
+func (m *MockDomainService) NormalizeExisting(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NormalizeExisting", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NormalizeExisting indicates an expected call of NormalizeExisting.
+func (mr *MockDomainServiceMockRecorder) NormalizeExisting(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NormalizeExisting", reflect.TypeOf((*MockDomainService)(nil).NormalizeExisting), ctx)
+}
+
+// Recheck mocks base method.
+func (m *MockDomainService) Recheck(ctx context.Context, checkedBefore time.Time, limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recheck", ctx, checkedBefore, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recheck indicates an expected call of Recheck.
+func (mr *MockDomainServiceMockRecorder) Recheck(ctx, checkedBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recheck", reflect.TypeOf((*MockDomainService)(nil).Recheck), ctx, checkedBefore, limit)
+}
+
+// Update mocks base method.
+func (m *MockDomainService) Update(ctx context.Context, actor service.Actor, id uint64, input service.UpdateDomainInput) (*model.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, actor, id, input)
+	ret0, _ := ret[0].(*model.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockDomainServiceMockRecorder) Update(ctx, actor, id, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDomainService)(nil).Update), ctx, actor, id, input)
+}
+
+// Verify mocks base method.
+func (m *MockDomainService) Verify(ctx context.Context, actor service.Actor, id uint64) (*model.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, actor, id)
+	ret0, _ := ret[0].(*model.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockDomainServiceMockRecorder) Verify(ctx, actor, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockDomainService)(nil).Verify), ctx, actor, id)
+}
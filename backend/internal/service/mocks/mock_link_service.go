@@ -42,76 +42,196 @@ func (m *MockLinkService) EXPECT() *MockLinkServiceMockRecorder {
 	return m.recorder
 }
 
+// AddAlias mocks base method.
+func (m *MockLinkService) AddAlias(ctx context.Context, actor service.Actor, linkID uint64, input service.AddAliasInput) (*model.LinkAlias, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAlias", ctx, actor, linkID, input)
+	ret0, _ := ret[0].(*model.LinkAlias)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAlias indicates an expected call of AddAlias.
+func (mr *MockLinkServiceMockRecorder) AddAlias(ctx, actor, linkID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAlias", reflect.TypeOf((*MockLinkService)(nil).AddAlias), ctx, actor, linkID, input)
+}
+
 // Create mocks base method.
-func (m *MockLinkService) Create(ctx context.Context, userID uint64, input service.CreateLinkInput) (*model.Link, error) {
+func (m *MockLinkService) Create(ctx context.Context, actor service.Actor, input service.CreateLinkInput) (*model.Link, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, userID, input)
+	ret := m.ctrl.Call(m, "Create", ctx, actor, input)
 	ret0, _ := ret[0].(*model.Link)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockLinkServiceMockRecorder) Create(ctx, userID, input any) *gomock.Call {
+func (mr *MockLinkServiceMockRecorder) Create(ctx, actor, input any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLinkService)(nil).Create), ctx, userID, input)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLinkService)(nil).Create), ctx, actor, input)
 }
 
 // Delete mocks base method.
-func (m *MockLinkService) Delete(ctx context.Context, userID, linkID uint64) error {
+func (m *MockLinkService) Delete(ctx context.Context, actor service.Actor, linkID uint64) (*model.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, actor, linkID)
+	ret0, _ := ret[0].(*model.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockLinkServiceMockRecorder) Delete(ctx, actor, linkID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockLinkService)(nil).Delete), ctx, actor, linkID)
+}
+
+// DeleteAlias mocks base method.
+func (m *MockLinkService) DeleteAlias(ctx context.Context, actor service.Actor, linkID, aliasID uint64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, userID, linkID)
+	ret := m.ctrl.Call(m, "DeleteAlias", ctx, actor, linkID, aliasID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Delete indicates an expected call of Delete.
-func (mr *MockLinkServiceMockRecorder) Delete(ctx, userID, linkID any) *gomock.Call {
+// DeleteAlias indicates an expected call of DeleteAlias.
+func (mr *MockLinkServiceMockRecorder) DeleteAlias(ctx, actor, linkID, aliasID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAlias", reflect.TypeOf((*MockLinkService)(nil).DeleteAlias), ctx, actor, linkID, aliasID)
+}
+
+// FindDuplicates mocks base method.
+func (m *MockLinkService) FindDuplicates(ctx context.Context, actor service.Actor, ignoreTracking bool) ([]service.DuplicateGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDuplicates", ctx, actor, ignoreTracking)
+	ret0, _ := ret[0].([]service.DuplicateGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDuplicates indicates an expected call of FindDuplicates.
+func (mr *MockLinkServiceMockRecorder) FindDuplicates(ctx, actor, ignoreTracking any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockLinkService)(nil).Delete), ctx, userID, linkID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDuplicates", reflect.TypeOf((*MockLinkService)(nil).FindDuplicates), ctx, actor, ignoreTracking)
 }
 
 // GetByID mocks base method.
-func (m *MockLinkService) GetByID(ctx context.Context, userID, linkID uint64) (*model.Link, error) {
+func (m *MockLinkService) GetByID(ctx context.Context, actor service.Actor, linkID uint64) (*model.Link, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", ctx, userID, linkID)
+	ret := m.ctrl.Call(m, "GetByID", ctx, actor, linkID)
 	ret0, _ := ret[0].(*model.Link)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByID indicates an expected call of GetByID.
-func (mr *MockLinkServiceMockRecorder) GetByID(ctx, userID, linkID any) *gomock.Call {
+func (mr *MockLinkServiceMockRecorder) GetByID(ctx, actor, linkID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockLinkService)(nil).GetByID), ctx, userID, linkID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockLinkService)(nil).GetByID), ctx, actor, linkID)
 }
 
 // List mocks base method.
-func (m *MockLinkService) List(ctx context.Context, userID uint64, params service.ListLinksParams) (*service.ListLinksResult, error) {
+func (m *MockLinkService) List(ctx context.Context, actor service.Actor, params service.ListLinksParams) (*service.ListLinksResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, userID, params)
+	ret := m.ctrl.Call(m, "List", ctx, actor, params)
 	ret0, _ := ret[0].(*service.ListLinksResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // List indicates an expected call of List.
-func (mr *MockLinkServiceMockRecorder) List(ctx, userID, params any) *gomock.Call {
+func (mr *MockLinkServiceMockRecorder) List(ctx, actor, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockLinkService)(nil).List), ctx, actor, params)
+}
+
+// ListAliases mocks base method.
+func (m *MockLinkService) ListAliases(ctx context.Context, actor service.Actor, linkID uint64) ([]model.LinkAlias, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAliases", ctx, actor, linkID)
+	ret0, _ := ret[0].([]model.LinkAlias)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAliases indicates an expected call of ListAliases.
+func (mr *MockLinkServiceMockRecorder) ListAliases(ctx, actor, linkID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAliases", reflect.TypeOf((*MockLinkService)(nil).ListAliases), ctx, actor, linkID)
+}
+
+// ListHistory mocks base method.
+func (m *MockLinkService) ListHistory(ctx context.Context, actor service.Actor, linkID uint64) ([]model.LinkRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHistory", ctx, actor, linkID)
+	ret0, _ := ret[0].([]model.LinkRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHistory indicates an expected call of ListHistory.
+func (mr *MockLinkServiceMockRecorder) ListHistory(ctx, actor, linkID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHistory", reflect.TypeOf((*MockLinkService)(nil).ListHistory), ctx, actor, linkID)
+}
+
+// ListTrash mocks base method.
+func (m *MockLinkService) ListTrash(ctx context.Context, actor service.Actor) ([]model.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrash", ctx, actor)
+	ret0, _ := ret[0].([]model.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockLinkServiceMockRecorder) ListTrash(ctx, actor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockLinkService)(nil).ListTrash), ctx, actor)
+}
+
+// Restore mocks base method.
+func (m *MockLinkService) Restore(ctx context.Context, actor service.Actor, linkID uint64) (*model.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, actor, linkID)
+	ret0, _ := ret[0].(*model.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockLinkServiceMockRecorder) Restore(ctx, actor, linkID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockLinkService)(nil).Restore), ctx, actor, linkID)
+}
+
+// Revert mocks base method.
+func (m *MockLinkService) Revert(ctx context.Context, actor service.Actor, linkID uint64, version int) (*model.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revert", ctx, actor, linkID, version)
+	ret0, _ := ret[0].(*model.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revert indicates an expected call of Revert.
+func (mr *MockLinkServiceMockRecorder) Revert(ctx, actor, linkID, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockLinkService)(nil).List), ctx, userID, params)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revert", reflect.TypeOf((*MockLinkService)(nil).Revert), ctx, actor, linkID, version)
 }
 
 // Update mocks base method.
-func (m *MockLinkService) Update(ctx context.Context, userID, linkID uint64, input service.UpdateLinkInput) (*model.Link, error) {
+func (m *MockLinkService) Update(ctx context.Context, actor service.Actor, linkID uint64, input service.UpdateLinkInput) (*model.Link, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, userID, linkID, input)
+	ret := m.ctrl.Call(m, "Update", ctx, actor, linkID, input)
 	ret0, _ := ret[0].(*model.Link)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockLinkServiceMockRecorder) Update(ctx, userID, linkID, input any) *gomock.Call {
+func (mr *MockLinkServiceMockRecorder) Update(ctx, actor, linkID, input any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockLinkService)(nil).Update), ctx, userID, linkID, input)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockLinkService)(nil).Update), ctx, actor, linkID, input)
 }
@@ -0,0 +1,162 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/SeaCodeBase/urlshortener/internal/service (interfaces: PasskeyService)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mock_passkey_service.go -package=mocks . PasskeyService
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/SeaCodeBase/urlshortener/internal/model"
+	protocol "github.com/go-webauthn/webauthn/protocol"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPasskeyService is a mock of PasskeyService interface.
+type MockPasskeyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasskeyServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockPasskeyServiceMockRecorder is the mock recorder for MockPasskeyService.
+type MockPasskeyServiceMockRecorder struct {
+	mock *MockPasskeyService
+}
+
+// NewMockPasskeyService creates a new mock instance.
+func NewMockPasskeyService(ctrl *gomock.Controller) *MockPasskeyService {
+	mock := &MockPasskeyService{ctrl: ctrl}
+	mock.recorder = &MockPasskeyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasskeyService) EXPECT() *MockPasskeyServiceMockRecorder {
+	return m.recorder
+}
+
+// BeginLogin mocks base method.
+func (m *MockPasskeyService) BeginLogin(ctx context.Context, userID uint64) (*protocol.CredentialAssertion, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginLogin", ctx, userID)
+	ret0, _ := ret[0].(*protocol.CredentialAssertion)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// BeginLogin indicates an expected call of BeginLogin.
+func (mr *MockPasskeyServiceMockRecorder) BeginLogin(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginLogin", reflect.TypeOf((*MockPasskeyService)(nil).BeginLogin), ctx, userID)
+}
+
+// BeginRegistration mocks base method.
+func (m *MockPasskeyService) BeginRegistration(ctx context.Context, userID uint64) (*protocol.CredentialCreation, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginRegistration", ctx, userID)
+	ret0, _ := ret[0].(*protocol.CredentialCreation)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// BeginRegistration indicates an expected call of BeginRegistration.
+func (mr *MockPasskeyServiceMockRecorder) BeginRegistration(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginRegistration", reflect.TypeOf((*MockPasskeyService)(nil).BeginRegistration), ctx, userID)
+}
+
+// Delete mocks base method.
+func (m *MockPasskeyService) Delete(ctx context.Context, userID, passkeyID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userID, passkeyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPasskeyServiceMockRecorder) Delete(ctx, userID, passkeyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPasskeyService)(nil).Delete), ctx, userID, passkeyID)
+}
+
+// FinishLogin mocks base method.
+func (m *MockPasskeyService) FinishLogin(ctx context.Context, userID uint64, sessionData string, credentialJSON []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishLogin", ctx, userID, sessionData, credentialJSON)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishLogin indicates an expected call of FinishLogin.
+func (mr *MockPasskeyServiceMockRecorder) FinishLogin(ctx, userID, sessionData, credentialJSON any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishLogin", reflect.TypeOf((*MockPasskeyService)(nil).FinishLogin), ctx, userID, sessionData, credentialJSON)
+}
+
+// FinishRegistration mocks base method.
+func (m *MockPasskeyService) FinishRegistration(ctx context.Context, userID uint64, sessionData string, credentialJSON []byte, name string) (*model.Passkey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishRegistration", ctx, userID, sessionData, credentialJSON, name)
+	ret0, _ := ret[0].(*model.Passkey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishRegistration indicates an expected call of FinishRegistration.
+func (mr *MockPasskeyServiceMockRecorder) FinishRegistration(ctx, userID, sessionData, credentialJSON, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishRegistration", reflect.TypeOf((*MockPasskeyService)(nil).FinishRegistration), ctx, userID, sessionData, credentialJSON, name)
+}
+
+// HasPasskeys mocks base method.
+func (m *MockPasskeyService) HasPasskeys(ctx context.Context, userID uint64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasPasskeys", ctx, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasPasskeys indicates an expected call of HasPasskeys.
+func (mr *MockPasskeyServiceMockRecorder) HasPasskeys(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPasskeys", reflect.TypeOf((*MockPasskeyService)(nil).HasPasskeys), ctx, userID)
+}
+
+// List mocks base method.
+func (m *MockPasskeyService) List(ctx context.Context, userID uint64) ([]model.Passkey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, userID)
+	ret0, _ := ret[0].([]model.Passkey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockPasskeyServiceMockRecorder) List(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPasskeyService)(nil).List), ctx, userID)
+}
+
+// Rename mocks base method.
+func (m *MockPasskeyService) Rename(ctx context.Context, userID, passkeyID uint64, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rename", ctx, userID, passkeyID, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rename indicates an expected call of Rename.
+func (mr *MockPasskeyServiceMockRecorder) Rename(ctx, userID, passkeyID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rename", reflect.TypeOf((*MockPasskeyService)(nil).Rename), ctx, userID, passkeyID, name)
+}
@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/SeaCodeBase/urlshortener/internal/service (interfaces: ReservedCodeService)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mock_reserved_code_service.go -package=mocks . ReservedCodeService
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/SeaCodeBase/urlshortener/internal/model"
+	service "github.com/SeaCodeBase/urlshortener/internal/service"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReservedCodeService is a mock of ReservedCodeService interface.
+type MockReservedCodeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReservedCodeServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockReservedCodeServiceMockRecorder is the mock recorder for MockReservedCodeService.
+type MockReservedCodeServiceMockRecorder struct {
+	mock *MockReservedCodeService
+}
+
+// NewMockReservedCodeService creates a new mock instance.
+func NewMockReservedCodeService(ctrl *gomock.Controller) *MockReservedCodeService {
+	mock := &MockReservedCodeService{ctrl: ctrl}
+	mock.recorder = &MockReservedCodeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReservedCodeService) EXPECT() *MockReservedCodeServiceMockRecorder {
+	return m.recorder
+}
+
+// AddForDomain mocks base method.
+func (m *MockReservedCodeService) AddForDomain(ctx context.Context, actor service.Actor, domainID uint64, input service.ReserveCodeInput) (*model.ReservedCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddForDomain", ctx, actor, domainID, input)
+	ret0, _ := ret[0].(*model.ReservedCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddForDomain indicates an expected call of AddForDomain.
+func (mr *MockReservedCodeServiceMockRecorder) AddForDomain(ctx, actor, domainID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddForDomain", reflect.TypeOf((*MockReservedCodeService)(nil).AddForDomain), ctx, actor, domainID, input)
+}
+
+// AddGlobal mocks base method.
+func (m *MockReservedCodeService) AddGlobal(ctx context.Context, userID uint64, input service.ReserveCodeInput) (*model.ReservedCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddGlobal", ctx, userID, input)
+	ret0, _ := ret[0].(*model.ReservedCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddGlobal indicates an expected call of AddGlobal.
+func (mr *MockReservedCodeServiceMockRecorder) AddGlobal(ctx, userID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGlobal", reflect.TypeOf((*MockReservedCodeService)(nil).AddGlobal), ctx, userID, input)
+}
+
+// DeleteForDomain mocks base method.
+func (m *MockReservedCodeService) DeleteForDomain(ctx context.Context, actor service.Actor, domainID, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteForDomain", ctx, actor, domainID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteForDomain indicates an expected call of DeleteForDomain.
+func (mr *MockReservedCodeServiceMockRecorder) DeleteForDomain(ctx, actor, domainID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteForDomain", reflect.TypeOf((*MockReservedCodeService)(nil).DeleteForDomain), ctx, actor, domainID, id)
+}
+
+// DeleteGlobal mocks base method.
+func (m *MockReservedCodeService) DeleteGlobal(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGlobal", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGlobal indicates an expected call of DeleteGlobal.
+func (mr *MockReservedCodeServiceMockRecorder) DeleteGlobal(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGlobal", reflect.TypeOf((*MockReservedCodeService)(nil).DeleteGlobal), ctx, id)
+}
+
+// ListForDomain mocks base method.
+func (m *MockReservedCodeService) ListForDomain(ctx context.Context, actor service.Actor, domainID uint64) ([]model.ReservedCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListForDomain", ctx, actor, domainID)
+	ret0, _ := ret[0].([]model.ReservedCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListForDomain indicates an expected call of ListForDomain.
+func (mr *MockReservedCodeServiceMockRecorder) ListForDomain(ctx, actor, domainID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListForDomain", reflect.TypeOf((*MockReservedCodeService)(nil).ListForDomain), ctx, actor, domainID)
+}
+
+// ListGlobal mocks base method.
+func (m *MockReservedCodeService) ListGlobal(ctx context.Context) ([]model.ReservedCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGlobal", ctx)
+	ret0, _ := ret[0].([]model.ReservedCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGlobal indicates an expected call of ListGlobal.
+func (mr *MockReservedCodeServiceMockRecorder) ListGlobal(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGlobal", reflect.TypeOf((*MockReservedCodeService)(nil).ListGlobal), ctx)
+}
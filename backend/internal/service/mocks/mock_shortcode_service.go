@@ -40,46 +40,61 @@ func (m *MockShortCodeService) EXPECT() *MockShortCodeServiceMockRecorder {
 	return m.recorder
 }
 
-// Generate mocks base method.
-func (m *MockShortCodeService) Generate(ctx context.Context) (string, error) {
+// Allocate mocks base method.
+func (m *MockShortCodeService) Allocate(ctx context.Context, domainID *uint64, strategy string, insert func(string) error) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Generate", ctx)
+	ret := m.ctrl.Call(m, "Allocate", ctx, domainID, strategy, insert)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Generate indicates an expected call of Generate.
-func (mr *MockShortCodeServiceMockRecorder) Generate(ctx any) *gomock.Call {
+// Allocate indicates an expected call of Allocate.
+func (mr *MockShortCodeServiceMockRecorder) Allocate(ctx, domainID, strategy, insert any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockShortCodeService)(nil).Generate), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allocate", reflect.TypeOf((*MockShortCodeService)(nil).Allocate), ctx, domainID, strategy, insert)
 }
 
-// IsAvailable mocks base method.
-func (m *MockShortCodeService) IsAvailable(ctx context.Context, code string) (bool, error) {
+// Canonicalize mocks base method.
+func (m *MockShortCodeService) Canonicalize(ctx context.Context, domainID *uint64, code string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsAvailable", ctx, code)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "Canonicalize", ctx, domainID, code)
+	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// IsAvailable indicates an expected call of IsAvailable.
-func (mr *MockShortCodeServiceMockRecorder) IsAvailable(ctx, code any) *gomock.Call {
+// Canonicalize indicates an expected call of Canonicalize.
+func (mr *MockShortCodeServiceMockRecorder) Canonicalize(ctx, domainID, code any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAvailable", reflect.TypeOf((*MockShortCodeService)(nil).IsAvailable), ctx, code)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Canonicalize", reflect.TypeOf((*MockShortCodeService)(nil).Canonicalize), ctx, domainID, code)
 }
 
-// IsValid mocks base method.
-func (m *MockShortCodeService) IsValid(code string) bool {
+// HasStrategy mocks base method.
+func (m *MockShortCodeService) HasStrategy(name string) bool {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsValid", code)
+	ret := m.ctrl.Call(m, "HasStrategy", name)
 	ret0, _ := ret[0].(bool)
 	return ret0
 }
 
-// IsValid indicates an expected call of IsValid.
-func (mr *MockShortCodeServiceMockRecorder) IsValid(code any) *gomock.Call {
+// HasStrategy indicates an expected call of HasStrategy.
+func (mr *MockShortCodeServiceMockRecorder) HasStrategy(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasStrategy", reflect.TypeOf((*MockShortCodeService)(nil).HasStrategy), name)
+}
+
+// IsAvailable mocks base method.
+func (m *MockShortCodeService) IsAvailable(ctx context.Context, workspaceID uint64, domainID *uint64, code string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAvailable", ctx, workspaceID, domainID, code)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsAvailable indicates an expected call of IsAvailable.
+func (mr *MockShortCodeServiceMockRecorder) IsAvailable(ctx, workspaceID, domainID, code any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsValid", reflect.TypeOf((*MockShortCodeService)(nil).IsValid), code)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAvailable", reflect.TypeOf((*MockShortCodeService)(nil).IsAvailable), ctx, workspaceID, domainID, code)
 }
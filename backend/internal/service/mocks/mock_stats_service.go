@@ -41,17 +41,62 @@ func (m *MockStatsService) EXPECT() *MockStatsServiceMockRecorder {
 	return m.recorder
 }
 
+// GetCampaignStats mocks base method.
+func (m *MockStatsService) GetCampaignStats(ctx context.Context, actor service.Actor, campaignID uint64) (*service.CampaignStatsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCampaignStats", ctx, actor, campaignID)
+	ret0, _ := ret[0].(*service.CampaignStatsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCampaignStats indicates an expected call of GetCampaignStats.
+func (mr *MockStatsServiceMockRecorder) GetCampaignStats(ctx, actor, campaignID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCampaignStats", reflect.TypeOf((*MockStatsService)(nil).GetCampaignStats), ctx, actor, campaignID)
+}
+
+// GetDomainStats mocks base method.
+func (m *MockStatsService) GetDomainStats(ctx context.Context, actor service.Actor, domainID uint64) (*service.DomainStatsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDomainStats", ctx, actor, domainID)
+	ret0, _ := ret[0].(*service.DomainStatsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDomainStats indicates an expected call of GetDomainStats.
+func (mr *MockStatsServiceMockRecorder) GetDomainStats(ctx, actor, domainID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDomainStats", reflect.TypeOf((*MockStatsService)(nil).GetDomainStats), ctx, actor, domainID)
+}
+
 // GetLinkStats mocks base method.
-func (m *MockStatsService) GetLinkStats(ctx context.Context, userID, linkID uint64) (*service.LinkStatsResponse, error) {
+func (m *MockStatsService) GetLinkStats(ctx context.Context, actor service.Actor, linkID uint64) (*service.LinkStatsResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLinkStats", ctx, userID, linkID)
+	ret := m.ctrl.Call(m, "GetLinkStats", ctx, actor, linkID)
 	ret0, _ := ret[0].(*service.LinkStatsResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetLinkStats indicates an expected call of GetLinkStats.
-func (mr *MockStatsServiceMockRecorder) GetLinkStats(ctx, userID, linkID any) *gomock.Call {
+func (mr *MockStatsServiceMockRecorder) GetLinkStats(ctx, actor, linkID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinkStats", reflect.TypeOf((*MockStatsService)(nil).GetLinkStats), ctx, actor, linkID)
+}
+
+// GetOverview mocks base method.
+func (m *MockStatsService) GetOverview(ctx context.Context, actor service.Actor, query service.OverviewQuery) (*service.OverviewStatsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverview", ctx, actor, query)
+	ret0, _ := ret[0].(*service.OverviewStatsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverview indicates an expected call of GetOverview.
+func (mr *MockStatsServiceMockRecorder) GetOverview(ctx, actor, query any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinkStats", reflect.TypeOf((*MockStatsService)(nil).GetLinkStats), ctx, userID, linkID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverview", reflect.TypeOf((*MockStatsService)(nil).GetOverview), ctx, actor, query)
 }
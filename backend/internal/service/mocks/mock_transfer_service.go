@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/SeaCodeBase/urlshortener/internal/service (interfaces: TransferService)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mock_transfer_service.go -package=mocks . TransferService
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/SeaCodeBase/urlshortener/internal/model"
+	service "github.com/SeaCodeBase/urlshortener/internal/service"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTransferService is a mock of TransferService interface.
+type MockTransferService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransferServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockTransferServiceMockRecorder is the mock recorder for MockTransferService.
+type MockTransferServiceMockRecorder struct {
+	mock *MockTransferService
+}
+
+// NewMockTransferService creates a new mock instance.
+func NewMockTransferService(ctrl *gomock.Controller) *MockTransferService {
+	mock := &MockTransferService{ctrl: ctrl}
+	mock.recorder = &MockTransferServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransferService) EXPECT() *MockTransferServiceMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockTransferService) Accept(ctx context.Context, actor service.Actor, transferID uint64) (*service.AcceptTransferResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", ctx, actor, transferID)
+	ret0, _ := ret[0].(*service.AcceptTransferResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockTransferServiceMockRecorder) Accept(ctx, actor, transferID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockTransferService)(nil).Accept), ctx, actor, transferID)
+}
+
+// Cancel mocks base method.
+func (m *MockTransferService) Cancel(ctx context.Context, userID, transferID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, userID, transferID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockTransferServiceMockRecorder) Cancel(ctx, userID, transferID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockTransferService)(nil).Cancel), ctx, userID, transferID)
+}
+
+// Create mocks base method.
+func (m *MockTransferService) Create(ctx context.Context, actor service.Actor, input service.CreateTransferInput) (*model.LinkTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, actor, input)
+	ret0, _ := ret[0].(*model.LinkTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTransferServiceMockRecorder) Create(ctx, actor, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTransferService)(nil).Create), ctx, actor, input)
+}
+
+// Decline mocks base method.
+func (m *MockTransferService) Decline(ctx context.Context, userID, transferID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decline", ctx, userID, transferID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Decline indicates an expected call of Decline.
+func (mr *MockTransferServiceMockRecorder) Decline(ctx, userID, transferID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decline", reflect.TypeOf((*MockTransferService)(nil).Decline), ctx, userID, transferID)
+}
+
+// ListPending mocks base method.
+func (m *MockTransferService) ListPending(ctx context.Context, userID uint64) ([]model.LinkTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPending", ctx, userID)
+	ret0, _ := ret[0].([]model.LinkTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPending indicates an expected call of ListPending.
+func (mr *MockTransferServiceMockRecorder) ListPending(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPending", reflect.TypeOf((*MockTransferService)(nil).ListPending), ctx, userID)
+}
@@ -0,0 +1,171 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/SeaCodeBase/urlshortener/internal/service (interfaces: WebhookService)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mock_webhook_service.go -package=mocks . WebhookService
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	model "github.com/SeaCodeBase/urlshortener/internal/model"
+	service "github.com/SeaCodeBase/urlshortener/internal/service"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookService is a mock of WebhookService interface.
+type MockWebhookService struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookServiceMockRecorder is the mock recorder for MockWebhookService.
+type MockWebhookServiceMockRecorder struct {
+	mock *MockWebhookService
+}
+
+// NewMockWebhookService creates a new mock instance.
+func NewMockWebhookService(ctrl *gomock.Controller) *MockWebhookService {
+	mock := &MockWebhookService{ctrl: ctrl}
+	mock.recorder = &MockWebhookServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookService) EXPECT() *MockWebhookServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWebhookService) Create(ctx context.Context, actor service.Actor, input service.CreateWebhookInput) (*service.CreatedWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, actor, input)
+	ret0, _ := ret[0].(*service.CreatedWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookServiceMockRecorder) Create(ctx, actor, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhookService)(nil).Create), ctx, actor, input)
+}
+
+// Delete mocks base method.
+func (m *MockWebhookService) Delete(ctx context.Context, actor service.Actor, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, actor, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWebhookServiceMockRecorder) Delete(ctx, actor, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhookService)(nil).Delete), ctx, actor, id)
+}
+
+// EmitClicks mocks base method.
+func (m *MockWebhookService) EmitClicks(ctx context.Context, clicks []model.Click) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "EmitClicks", ctx, clicks)
+}
+
+// EmitClicks indicates an expected call of EmitClicks.
+func (mr *MockWebhookServiceMockRecorder) EmitClicks(ctx, clicks any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmitClicks", reflect.TypeOf((*MockWebhookService)(nil).EmitClicks), ctx, clicks)
+}
+
+// EmitExpired mocks base method.
+func (m *MockWebhookService) EmitExpired(ctx context.Context, from, to time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EmitExpired", ctx, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EmitExpired indicates an expected call of EmitExpired.
+func (mr *MockWebhookServiceMockRecorder) EmitExpired(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmitExpired", reflect.TypeOf((*MockWebhookService)(nil).EmitExpired), ctx, from, to)
+}
+
+// EmitLink mocks base method.
+func (m *MockWebhookService) EmitLink(ctx context.Context, event string, link *model.Link) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "EmitLink", ctx, event, link)
+}
+
+// EmitLink indicates an expected call of EmitLink.
+func (mr *MockWebhookServiceMockRecorder) EmitLink(ctx, event, link any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmitLink", reflect.TypeOf((*MockWebhookService)(nil).EmitLink), ctx, event, link)
+}
+
+// List mocks base method.
+func (m *MockWebhookService) List(ctx context.Context, actor service.Actor) ([]model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, actor)
+	ret0, _ := ret[0].([]model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockWebhookServiceMockRecorder) List(ctx, actor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockWebhookService)(nil).List), ctx, actor)
+}
+
+// ListDeliveries mocks base method.
+func (m *MockWebhookService) ListDeliveries(ctx context.Context, actor service.Actor, webhookID uint64) ([]model.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeliveries", ctx, actor, webhookID)
+	ret0, _ := ret[0].([]model.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeliveries indicates an expected call of ListDeliveries.
+func (mr *MockWebhookServiceMockRecorder) ListDeliveries(ctx, actor, webhookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeliveries", reflect.TypeOf((*MockWebhookService)(nil).ListDeliveries), ctx, actor, webhookID)
+}
+
+// Replay mocks base method.
+func (m *MockWebhookService) Replay(ctx context.Context, actor service.Actor, webhookID, deliveryID uint64) (*model.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replay", ctx, actor, webhookID, deliveryID)
+	ret0, _ := ret[0].(*model.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Replay indicates an expected call of Replay.
+func (mr *MockWebhookServiceMockRecorder) Replay(ctx, actor, webhookID, deliveryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replay", reflect.TypeOf((*MockWebhookService)(nil).Replay), ctx, actor, webhookID, deliveryID)
+}
+
+// Update mocks base method.
+func (m *MockWebhookService) Update(ctx context.Context, actor service.Actor, id uint64, input service.UpdateWebhookInput) (*model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, actor, id, input)
+	ret0, _ := ret[0].(*model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWebhookServiceMockRecorder) Update(ctx, actor, id, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWebhookService)(nil).Update), ctx, actor, id, input)
+}
@@ -0,0 +1,206 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/SeaCodeBase/urlshortener/internal/service (interfaces: WorkspaceService)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mock_workspace_service.go -package=mocks . WorkspaceService
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/SeaCodeBase/urlshortener/internal/model"
+	service "github.com/SeaCodeBase/urlshortener/internal/service"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWorkspaceService is a mock of WorkspaceService interface.
+type MockWorkspaceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkspaceServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockWorkspaceServiceMockRecorder is the mock recorder for MockWorkspaceService.
+type MockWorkspaceServiceMockRecorder struct {
+	mock *MockWorkspaceService
+}
+
+// NewMockWorkspaceService creates a new mock instance.
+func NewMockWorkspaceService(ctrl *gomock.Controller) *MockWorkspaceService {
+	mock := &MockWorkspaceService{ctrl: ctrl}
+	mock.recorder = &MockWorkspaceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkspaceService) EXPECT() *MockWorkspaceServiceMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvitation mocks base method.
+func (m *MockWorkspaceService) AcceptInvitation(ctx context.Context, userID uint64, token string) (*model.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", ctx, userID, token)
+	ret0, _ := ret[0].(*model.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockWorkspaceServiceMockRecorder) AcceptInvitation(ctx, userID, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockWorkspaceService)(nil).AcceptInvitation), ctx, userID, token)
+}
+
+// Create mocks base method.
+func (m *MockWorkspaceService) Create(ctx context.Context, userID uint64, input service.CreateWorkspaceInput) (*model.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userID, input)
+	ret0, _ := ret[0].(*model.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWorkspaceServiceMockRecorder) Create(ctx, userID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWorkspaceService)(nil).Create), ctx, userID, input)
+}
+
+// Invite mocks base method.
+func (m *MockWorkspaceService) Invite(ctx context.Context, userID, workspaceID uint64, input service.InviteMemberInput) (*service.CreatedInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invite", ctx, userID, workspaceID, input)
+	ret0, _ := ret[0].(*service.CreatedInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Invite indicates an expected call of Invite.
+func (mr *MockWorkspaceServiceMockRecorder) Invite(ctx, userID, workspaceID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invite", reflect.TypeOf((*MockWorkspaceService)(nil).Invite), ctx, userID, workspaceID, input)
+}
+
+// List mocks base method.
+func (m *MockWorkspaceService) List(ctx context.Context, userID uint64) ([]model.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, userID)
+	ret0, _ := ret[0].([]model.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockWorkspaceServiceMockRecorder) List(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockWorkspaceService)(nil).List), ctx, userID)
+}
+
+// ListInvitations mocks base method.
+func (m *MockWorkspaceService) ListInvitations(ctx context.Context, userID, workspaceID uint64) ([]model.WorkspaceInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInvitations", ctx, userID, workspaceID)
+	ret0, _ := ret[0].([]model.WorkspaceInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInvitations indicates an expected call of ListInvitations.
+func (mr *MockWorkspaceServiceMockRecorder) ListInvitations(ctx, userID, workspaceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInvitations", reflect.TypeOf((*MockWorkspaceService)(nil).ListInvitations), ctx, userID, workspaceID)
+}
+
+// ListMembers mocks base method.
+func (m *MockWorkspaceService) ListMembers(ctx context.Context, userID, workspaceID uint64) ([]model.WorkspaceMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMembers", ctx, userID, workspaceID)
+	ret0, _ := ret[0].([]model.WorkspaceMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMembers indicates an expected call of ListMembers.
+func (mr *MockWorkspaceServiceMockRecorder) ListMembers(ctx, userID, workspaceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMembers", reflect.TypeOf((*MockWorkspaceService)(nil).ListMembers), ctx, userID, workspaceID)
+}
+
+// RemoveMember mocks base method.
+func (m *MockWorkspaceService) RemoveMember(ctx context.Context, userID, workspaceID, memberID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", ctx, userID, workspaceID, memberID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockWorkspaceServiceMockRecorder) RemoveMember(ctx, userID, workspaceID, memberID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockWorkspaceService)(nil).RemoveMember), ctx, userID, workspaceID, memberID)
+}
+
+// Rename mocks base method.
+func (m *MockWorkspaceService) Rename(ctx context.Context, userID, workspaceID uint64, input service.CreateWorkspaceInput) (*model.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rename", ctx, userID, workspaceID, input)
+	ret0, _ := ret[0].(*model.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rename indicates an expected call of Rename.
+func (mr *MockWorkspaceServiceMockRecorder) Rename(ctx, userID, workspaceID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rename", reflect.TypeOf((*MockWorkspaceService)(nil).Rename), ctx, userID, workspaceID, input)
+}
+
+// Resolve mocks base method.
+func (m *MockWorkspaceService) Resolve(ctx context.Context, userID, workspaceID uint64) (service.Actor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", ctx, userID, workspaceID)
+	ret0, _ := ret[0].(service.Actor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockWorkspaceServiceMockRecorder) Resolve(ctx, userID, workspaceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockWorkspaceService)(nil).Resolve), ctx, userID, workspaceID)
+}
+
+// RevokeInvitation mocks base method.
+func (m *MockWorkspaceService) RevokeInvitation(ctx context.Context, userID, workspaceID, invitationID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeInvitation", ctx, userID, workspaceID, invitationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeInvitation indicates an expected call of RevokeInvitation.
+func (mr *MockWorkspaceServiceMockRecorder) RevokeInvitation(ctx, userID, workspaceID, invitationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeInvitation", reflect.TypeOf((*MockWorkspaceService)(nil).RevokeInvitation), ctx, userID, workspaceID, invitationID)
+}
+
+// UpdateMemberRole mocks base method.
+func (m *MockWorkspaceService) UpdateMemberRole(ctx context.Context, userID, workspaceID, memberID uint64, role string) (*model.WorkspaceMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMemberRole", ctx, userID, workspaceID, memberID, role)
+	ret0, _ := ret[0].(*model.WorkspaceMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMemberRole indicates an expected call of UpdateMemberRole.
+func (mr *MockWorkspaceServiceMockRecorder) UpdateMemberRole(ctx, userID, workspaceID, memberID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMemberRole", reflect.TypeOf((*MockWorkspaceService)(nil).UpdateMemberRole), ctx, userID, workspaceID, memberID, role)
+}
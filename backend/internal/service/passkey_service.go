@@ -25,10 +25,11 @@ var (
 type passkeyService struct {
 	passkeyRepo repository.PasskeyRepository
 	userRepo    repository.UserRepository
+	audit       AuditService
 	webauthn    *webauthn.WebAuthn
 }
 
-func NewPasskeyService(passkeyRepo repository.PasskeyRepository, userRepo repository.UserRepository, rpID, rpOrigin, rpName string) (PasskeyService, error) {
+func NewPasskeyService(passkeyRepo repository.PasskeyRepository, userRepo repository.UserRepository, audit AuditService, rpID, rpOrigin, rpName string) (PasskeyService, error) {
 	wconfig := &webauthn.Config{
 		RPDisplayName: rpName,
 		RPID:          rpID,
@@ -41,6 +42,7 @@ func NewPasskeyService(passkeyRepo repository.PasskeyRepository, userRepo reposi
 	return &passkeyService{
 		passkeyRepo: passkeyRepo,
 		userRepo:    userRepo,
+		audit:       audit,
 		webauthn:    w,
 	}, nil
 }
@@ -206,6 +208,13 @@ func (s *passkeyService) FinishRegistration(ctx context.Context, userID uint64,
 		)
 		return nil, err
 	}
+	recordAudit(ctx, s.audit, AuditEvent{
+		ActorUserID: userID,
+		Action:      model.AuditPasskeyRegistered,
+		TargetType:  model.AuditTargetPasskey,
+		TargetID:    passkey.ID,
+		After:       map[string]string{"name": name},
+	})
 	return passkey, nil
 }
 
@@ -326,14 +335,14 @@ func (s *passkeyService) Rename(ctx context.Context, userID, passkeyID uint64, n
 		)
 		return err
 	}
-	found := false
-	for _, pk := range passkeys {
-		if pk.ID == passkeyID {
-			found = true
+	var passkey *model.Passkey
+	for i := range passkeys {
+		if passkeys[i].ID == passkeyID {
+			passkey = &passkeys[i]
 			break
 		}
 	}
-	if !found {
+	if passkey == nil {
 		return ErrPasskeyNotOwned
 	}
 	if err := s.passkeyRepo.UpdateName(ctx, passkeyID, name); err != nil {
@@ -343,6 +352,14 @@ func (s *passkeyService) Rename(ctx context.Context, userID, passkeyID uint64, n
 		)
 		return err
 	}
+	recordAudit(ctx, s.audit, AuditEvent{
+		ActorUserID: userID,
+		Action:      model.AuditPasskeyRenamed,
+		TargetType:  model.AuditTargetPasskey,
+		TargetID:    passkeyID,
+		Before:      map[string]string{"name": passkey.Name},
+		After:       map[string]string{"name": name},
+	})
 	return nil
 }
 
@@ -355,14 +372,14 @@ func (s *passkeyService) Delete(ctx context.Context, userID, passkeyID uint64) e
 		)
 		return err
 	}
-	found := false
-	for _, pk := range passkeys {
-		if pk.ID == passkeyID {
-			found = true
+	var passkey *model.Passkey
+	for i := range passkeys {
+		if passkeys[i].ID == passkeyID {
+			passkey = &passkeys[i]
 			break
 		}
 	}
-	if !found {
+	if passkey == nil {
 		return ErrPasskeyNotOwned
 	}
 	if err := s.passkeyRepo.Delete(ctx, passkeyID); err != nil {
@@ -372,6 +389,13 @@ func (s *passkeyService) Delete(ctx context.Context, userID, passkeyID uint64) e
 		)
 		return err
 	}
+	recordAudit(ctx, s.audit, AuditEvent{
+		ActorUserID: userID,
+		Action:      model.AuditPasskeyDeleted,
+		TargetType:  model.AuditTargetPasskey,
+		TargetID:    passkeyID,
+		Before:      map[string]string{"name": passkey.Name},
+	})
 	return nil
 }
 
@@ -19,7 +19,7 @@ func TestBeginRegistration_ExcludesExistingCredentials(t *testing.T) {
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
 
 	// Create service with test config
-	svc, err := service.NewPasskeyService(mockPasskeyRepo, mockUserRepo, "localhost", "http://localhost:3000", "Test App")
+	svc, err := service.NewPasskeyService(mockPasskeyRepo, mockUserRepo, nil, "localhost", "http://localhost:3000", "Test App")
 	assert.NoError(t, err)
 
 	userID := uint64(1)
@@ -69,7 +69,7 @@ func TestBeginRegistration_NoExistingCredentials(t *testing.T) {
 	mockPasskeyRepo := mocks.NewMockPasskeyRepository(ctrl)
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
 
-	svc, err := service.NewPasskeyService(mockPasskeyRepo, mockUserRepo, "localhost", "http://localhost:3000", "Test App")
+	svc, err := service.NewPasskeyService(mockPasskeyRepo, mockUserRepo, nil, "localhost", "http://localhost:3000", "Test App")
 	assert.NoError(t, err)
 
 	userID := uint64(1)
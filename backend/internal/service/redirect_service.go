@@ -5,10 +5,14 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"net/http"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/SeaCodeBase/urlshortener/internal/model"
 	"github.com/SeaCodeBase/urlshortener/internal/repository"
+	"github.com/SeaCodeBase/urlshortener/internal/util"
 	"github.com/SeaCodeBase/urlshortener/pkg/logger"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
@@ -17,24 +21,36 @@ import (
 var (
 	ErrLinkExpired  = errors.New("link has expired")
 	ErrLinkInactive = errors.New("link is not active")
+	ErrLinkDeleted  = errors.New("link has been deleted")
 )
 
 const (
 	linkCacheTTL       = 1 * time.Hour
 	linkCacheKeyPrefix = "link:"
+	// defaultCacheHost keys cache entries for the default domain, which is
+	// served for any host that is not a bound custom domain.
+	defaultCacheHost = "_default"
+	// Domains are cached for less time than links so that a domain losing
+	// verification stops serving soon after
+	domainCacheTTL       = 5 * time.Minute
+	domainCacheKeyPrefix = "domain:"
 )
 
 type RedirectService struct {
-	linkRepo   repository.LinkRepository
-	domainRepo repository.DomainRepository
-	rdb        *redis.Client
+	linkRepo     repository.LinkRepository
+	domainRepo   repository.DomainRepository
+	campaignRepo repository.CampaignRepository
+	aliasRepo    repository.LinkAliasRepository
+	rdb          *redis.Client
 }
 
-func NewRedirectService(linkRepo repository.LinkRepository, domainRepo repository.DomainRepository, rdb *redis.Client) *RedirectService {
+func NewRedirectService(linkRepo repository.LinkRepository, domainRepo repository.DomainRepository, campaignRepo repository.CampaignRepository, aliasRepo repository.LinkAliasRepository, rdb *redis.Client) *RedirectService {
 	return &RedirectService{
-		linkRepo:   linkRepo,
-		domainRepo: domainRepo,
-		rdb:        rdb,
+		linkRepo:     linkRepo,
+		domainRepo:   domainRepo,
+		campaignRepo: campaignRepo,
+		aliasRepo:    aliasRepo,
+		rdb:          rdb,
 	}
 }
 
@@ -43,49 +59,91 @@ type cachedLink struct {
 	ExpiresAt   time.Time `json:"expires_at,omitempty"`
 	IsActive    bool      `json:"is_active"`
 	LinkID      uint64    `json:"link_id"`
+	AliasID     *uint64   `json:"alias_id,omitempty"`
 }
 
-func (s *RedirectService) Resolve(ctx context.Context, host, code string) (string, uint64, error) {
-	// Strip port from host if present (e.g., "example.com:8080" -> "example.com")
-	if colonIdx := strings.LastIndex(host, ":"); colonIdx != -1 {
-		host = host[:colonIdx]
-	}
+// ResolvedLink is the redirect target for a short code.
+type ResolvedLink struct {
+	URL string
+	// StatusCode is the redirect status configured for the domain
+	StatusCode int
+	LinkID     uint64
+	// AliasID is set when the code matched an alias rather than the link's primary code.
+	AliasID *uint64
+}
 
-	// Determine domain ID from host
+// Resolve finds the redirect for a code on a domain picked by Route; domain
+// is nil for the default domain.
+func (s *RedirectService) Resolve(ctx context.Context, domain *model.Domain, code string) (*ResolvedLink, error) {
 	var domainID *uint64
-	domain, err := s.domainRepo.GetByDomain(ctx, host)
-	if err == nil {
+	cacheHost := defaultCacheHost
+	if domain != nil {
 		domainID = &domain.ID
+		cacheHost = domain.Key()
 	}
-	// If domain not found, domainID stays nil (default domain)
+
+	// Codes are stored in the domain policy's canonical form, e.g. case-folded
+	code = CodePolicyFor(domain).Canonical(code)
 
 	// Try cache first (include domain in cache key)
-	cacheKey := linkCacheKeyPrefix + host + ":" + code
+	cacheKey := linkCacheKeyPrefix + cacheHost + ":" + code
 	cached, err := s.rdb.Get(ctx, cacheKey).Result()
 	if err == nil {
 		var cl cachedLink
 		if err := json.Unmarshal([]byte(cached), &cl); err == nil {
-			return s.validateAndReturn(cl)
+			return s.validateAndReturn(cl, domain)
 		}
 	}
 
 	// Cache miss - query DB by domain and short code
 	link, err := s.linkRepo.GetByDomainAndShortCode(ctx, domainID, code)
+	var aliasID *uint64
+	var aliasExpiresAt time.Time
+	if errors.Is(err, repository.ErrLinkNotFound) {
+		// Fall back to aliases, e.g. the old code of a renamed link
+		alias, aliasErr := s.aliasRepo.GetActiveByDomainAndCode(ctx, domainID, code)
+		if errors.Is(aliasErr, repository.ErrAliasNotFound) {
+			return nil, ErrLinkNotFound
+		}
+		if aliasErr != nil {
+			return nil, aliasErr
+		}
+		aliasID = &alias.ID
+		if alias.ExpiresAt.Valid {
+			aliasExpiresAt = alias.ExpiresAt.Time
+		}
+		link, err = s.linkRepo.GetByID(ctx, alias.LinkID)
+	}
 	if errors.Is(err, repository.ErrLinkNotFound) {
-		return "", 0, ErrLinkNotFound
+		return nil, ErrLinkNotFound
 	}
 	if err != nil {
-		return "", 0, err
+		return nil, err
+	}
+	// Trashed links are not cached so a restore takes effect immediately
+	if link.DeletedAt.Valid {
+		return nil, ErrLinkDeleted
 	}
 
+	// Apply campaign defaults (UTM parameters, expiry) the link does not override
+	var campaign *model.Campaign
+	if link.CampaignID != nil {
+		campaign, err = s.campaignRepo.GetByID(ctx, *link.CampaignID)
+		if err != nil && !errors.Is(err, repository.ErrCampaignNotFound) {
+			return nil, err
+		}
+	}
+	destination, expiresAt := resolveLinkDestination(link, campaign)
+
 	// Cache the result
 	cl := cachedLink{
-		OriginalURL: link.OriginalURL,
+		OriginalURL: destination,
 		IsActive:    link.IsActive,
 		LinkID:      link.ID,
+		AliasID:     aliasID,
 	}
-	if link.ExpiresAt.Valid {
-		cl.ExpiresAt = link.ExpiresAt.Time
+	if expiresAt.Valid {
+		cl.ExpiresAt = expiresAt.Time
 	}
 
 	data, err := json.Marshal(cl)
@@ -95,22 +153,206 @@ func (s *RedirectService) Resolve(ctx context.Context, host, code string) (strin
 			zap.Error(err),
 		)
 	} else {
-		s.rdb.Set(ctx, cacheKey, data, linkCacheTTL)
+		// Never cache an alias past the end of its grace period
+		ttl := linkCacheTTL
+		if !aliasExpiresAt.IsZero() && time.Until(aliasExpiresAt) < ttl {
+			ttl = time.Until(aliasExpiresAt)
+		}
+		if ttl > 0 {
+			s.rdb.Set(ctx, cacheKey, data, ttl)
+		}
 	}
 
-	return s.validateAndReturn(cl)
+	return s.validateAndReturn(cl, domain)
 }
 
-func (s *RedirectService) validateAndReturn(cl cachedLink) (string, uint64, error) {
+// Route picks the verified custom domain a request is for from its host and
+// path, and returns the rest of the path with surrounding slashes trimmed.
+// The domain is nil when none matches, meaning the default domain.
+//
+// An exact host takes precedence over a wildcard matching it, and among the
+// domains on a host the longest matching path prefix wins, so example.com/go
+// is tried before example.com. Prefixes match case-insensitively.
+func (s *RedirectService) Route(ctx context.Context, host, path string) (*model.Domain, string, error) {
+	path = strings.Trim(path, "/")
+	domains, err := s.domainsForHost(ctx, host)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, domain := range domains {
+		if domain.PathPrefix == "" {
+			return domain, path, nil
+		}
+		if rest, ok := cutPathPrefix(path, domain.PathPrefix); ok {
+			return domain, rest, nil
+		}
+	}
+	return nil, path, nil
+}
+
+// cutPathPrefix returns path without prefix when it starts with the prefix's
+// whole segments.
+func cutPathPrefix(path, prefix string) (string, bool) {
+	if len(path) < len(prefix) || !strings.EqualFold(path[:len(prefix)], prefix) {
+		return "", false
+	}
+	rest := path[len(prefix):]
+	if rest == "" {
+		return "", true
+	}
+	if rest[0] != '/' {
+		return "", false
+	}
+	return strings.TrimLeft(rest, "/"), true
+}
+
+// domainsForHost returns the verified custom domains that can serve a
+// request host in the order Route tries them. Lookups, including misses,
+// are cached.
+func (s *RedirectService) domainsForHost(ctx context.Context, host string) ([]*model.Domain, error) {
+	// Match the form domains are stored in (e.g., "Go.Example.com.:8080" -> "go.example.com")
+	host = util.NormalizeHost(host)
+	if host == "" {
+		return nil, nil
+	}
+	cacheKey := domainCacheKeyPrefix + host
+
+	if cached, err := s.rdb.Get(ctx, cacheKey).Result(); err == nil {
+		var domains []*model.Domain
+		if err := json.Unmarshal([]byte(cached), &domains); err == nil {
+			return domains, nil
+		}
+	}
+
+	patterns := util.HostPatterns(host)
+	found, err := s.domainRepo.ListVerifiedByPatterns(ctx, patterns)
+	if err != nil {
+		return nil, err
+	}
+	domains := make([]*model.Domain, 0, len(found))
+	for _, domain := range found {
+		if domain.IsVerified() {
+			domains = append(domains, domain)
+		}
+	}
+	specificity := func(d *model.Domain) int {
+		return slices.Index(patterns, d.Domain)
+	}
+	slices.SortStableFunc(domains, func(a, b *model.Domain) int {
+		if c := specificity(a) - specificity(b); c != 0 {
+			return c
+		}
+		return len(b.PathPrefix) - len(a.PathPrefix)
+	})
+
+	// A miss is cached as an empty list
+	data, err := json.Marshal(domains)
+	if err != nil {
+		logger.Warn(ctx, "failed to marshal cached domains",
+			zap.String("host", host),
+			zap.Error(err),
+		)
+	} else {
+		s.rdb.Set(ctx, cacheKey, data, domainCacheTTL)
+	}
+	return domains, nil
+}
+
+// InvalidateDomainCache drops the cached lookups of the hosts a domain name
+// serves, e.g. after its settings or verification changed. A wildcard drops
+// the lookup of every host below it.
+func (s *RedirectService) InvalidateDomainCache(ctx context.Context, domainName string) error {
+	if !util.IsWildcardPattern(domainName) {
+		return s.rdb.Del(ctx, domainCacheKeyPrefix+domainName).Err()
+	}
+	return s.deleteMatching(ctx, domainCacheKeyPrefix+"*."+globEscape(util.WildcardParent(domainName)))
+}
+
+// validateAndReturn checks that a link may be followed and applies the
+// domain's redirect status. domain is nil for the default domain.
+func (s *RedirectService) validateAndReturn(cl cachedLink, domain *model.Domain) (*ResolvedLink, error) {
 	if !cl.IsActive {
-		return "", 0, ErrLinkInactive
+		return nil, ErrLinkInactive
 	}
 	if !cl.ExpiresAt.IsZero() && cl.ExpiresAt.Before(time.Now()) {
-		return "", 0, ErrLinkExpired
+		return nil, ErrLinkExpired
+	}
+	resolved := &ResolvedLink{URL: cl.OriginalURL, StatusCode: http.StatusFound, LinkID: cl.LinkID, AliasID: cl.AliasID}
+	if domain != nil && domain.RedirectStatus != nil {
+		resolved.StatusCode = *domain.RedirectStatus
+	}
+	return resolved, nil
+}
+
+// InvalidateHostCache drops every cached redirect on a domain and the cached
+// lookups of its hosts, e.g. after it was deleted and its links moved.
+func (s *RedirectService) InvalidateHostCache(ctx context.Context, domain *model.Domain) error {
+	if err := s.deleteMatching(ctx, linkCacheKeyPrefix+globEscape(domain.Key())+":*"); err != nil {
+		return err
+	}
+	return s.InvalidateDomainCache(ctx, domain.Domain)
+}
+
+// deleteMatching deletes the keys matching a SCAN pattern.
+func (s *RedirectService) deleteMatching(ctx context.Context, pattern string) error {
+	iter := s.rdb.Scan(ctx, 0, pattern, 100).Iterator()
+	for iter.Next(ctx) {
+		if err := s.rdb.Del(ctx, iter.Val()).Err(); err != nil {
+			return err
+		}
 	}
-	return cl.OriginalURL, cl.LinkID, nil
+	return iter.Err()
+}
+
+// globEscape quotes the characters SCAN patterns give a meaning, such as the
+// "*" of a wildcard domain.
+func globEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(`*?[]\`, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
 }
 
 func (s *RedirectService) InvalidateCache(ctx context.Context, host, code string) error {
 	return s.rdb.Del(ctx, linkCacheKeyPrefix+host+":"+code).Err()
 }
+
+// InvalidateLinkCache drops the cached redirect for a code on a domain.
+// domainID nil means the default domain.
+func (s *RedirectService) InvalidateLinkCache(ctx context.Context, domainID *uint64, code string) error {
+	host := defaultCacheHost
+	if domainID != nil {
+		domain, err := s.domainRepo.GetByID(ctx, *domainID)
+		if errors.Is(err, repository.ErrDomainNotFound) {
+			// The domain is gone and its cache with it
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		host = domain.Key()
+	}
+	return s.InvalidateCache(ctx, host, code)
+}
+
+// InvalidateLinkCaches drops cached redirects for a link's primary code and
+// every alias, e.g. after its destination changed.
+func (s *RedirectService) InvalidateLinkCaches(ctx context.Context, link *model.Link) error {
+	if err := s.InvalidateLinkCache(ctx, link.DomainID, link.ShortCode); err != nil {
+		return err
+	}
+	aliases, err := s.aliasRepo.ListByLinkID(ctx, link.ID)
+	if err != nil {
+		return err
+	}
+	for _, alias := range aliases {
+		if err := s.InvalidateLinkCache(ctx, alias.DomainID, alias.Code); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -2,10 +2,16 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/internal/repository/mocks"
 	"github.com/alicebob/miniredis/v2"
 	"github.com/redis/go-redis/v9"
+	"go.uber.org/mock/gomock"
 )
 
 func TestInvalidateCache(t *testing.T) {
@@ -94,3 +100,253 @@ func TestInvalidateCache_NonExistentKey(t *testing.T) {
 		t.Errorf("InvalidateCache should not error for non-existent key: %v", err)
 	}
 }
+
+func TestResolve_TrashedLinkIsGoneAndNotCached(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	linkRepo := mocks.NewMockLinkRepository(ctrl)
+	s := NewRedirectService(linkRepo, nil, nil, nil, rdb)
+	ctx := context.Background()
+
+	linkRepo.EXPECT().
+		GetByDomainAndShortCode(gomock.Any(), nil, "abc123").
+		Return(&model.Link{
+			ID:          1,
+			ShortCode:   "abc123",
+			OriginalURL: "https://example.com",
+			IsActive:    true,
+			DeletedAt:   model.NullTime{NullTime: sql.NullTime{Time: time.Now(), Valid: true}},
+		}, nil)
+
+	_, err = s.Resolve(ctx, nil, "abc123")
+	if !errors.Is(err, ErrLinkDeleted) {
+		t.Fatalf("expected ErrLinkDeleted, got %v", err)
+	}
+	if mr.Exists(linkCacheKeyPrefix + defaultCacheHost + ":abc123") {
+		t.Error("trashed link should not be cached")
+	}
+}
+
+func TestResolve_CaseInsensitiveDomainFoldsCode(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	linkRepo := mocks.NewMockLinkRepository(ctrl)
+	s := NewRedirectService(linkRepo, nil, nil, nil, rdb)
+	ctx := context.Background()
+
+	domainID := uint64(4)
+	verifiedAt := time.Now()
+	domain := &model.Domain{ID: domainID, Domain: "go.example.com", PathPrefix: "sale", VerifiedAt: &verifiedAt, CaseInsensitiveCodes: true}
+	linkRepo.EXPECT().
+		GetByDomainAndShortCode(gomock.Any(), &domainID, "sale").
+		Return(&model.Link{ID: 1, ShortCode: "sale", OriginalURL: "https://example.com", IsActive: true}, nil)
+
+	resolved, err := s.Resolve(ctx, domain, "Sale")
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if resolved.URL != "https://example.com" {
+		t.Errorf("unexpected destination %q", resolved.URL)
+	}
+	if !mr.Exists(linkCacheKeyPrefix + "go.example.com/sale:sale") {
+		t.Error("expected the link to be cached under its folded code")
+	}
+}
+
+func TestRoute_CachesLookups(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	domainRepo := mocks.NewMockDomainRepository(ctrl)
+	s := NewRedirectService(nil, domainRepo, nil, nil, rdb)
+	ctx := context.Background()
+
+	verifiedAt := time.Now()
+	root := "https://example.com"
+	domainRepo.EXPECT().
+		ListVerifiedByPatterns(gomock.Any(), []string{"go.example.com", "*.example.com"}).
+		Return([]*model.Domain{{ID: 4, Domain: "go.example.com", VerifiedAt: &verifiedAt, RootRedirectURL: &root}}, nil).
+		Times(2)
+	domainRepo.EXPECT().
+		ListVerifiedByPatterns(gomock.Any(), []string{"other.example.com", "*.example.com"}).
+		Return(nil, nil).
+		Times(1)
+
+	// The second lookup of each host, hit or miss, is served from the cache.
+	// The Host header is matched in the form domains are stored in.
+	for i := 0; i < 2; i++ {
+		domain, code, err := s.Route(ctx, "Go.Example.com.:8080", "/abc")
+		if err != nil {
+			t.Fatalf("Route failed: %v", err)
+		}
+		if domain == nil || domain.RootRedirectURL == nil || *domain.RootRedirectURL != root || code != "abc" {
+			t.Fatalf("unexpected route %+v, %q", domain, code)
+		}
+		domain, _, err = s.Route(ctx, "other.example.com", "/abc")
+		if err != nil || domain != nil {
+			t.Fatalf("expected no domain, got %+v, %v", domain, err)
+		}
+	}
+
+	// Invalidating forces a fresh lookup; a wildcard covers every host below it
+	if err := s.InvalidateDomainCache(ctx, "*.example.com"); err != nil {
+		t.Fatalf("InvalidateDomainCache failed: %v", err)
+	}
+	if mr.Exists(domainCacheKeyPrefix+"go.example.com") || mr.Exists(domainCacheKeyPrefix+"other.example.com") {
+		t.Fatal("expected the wildcard to drop the cached lookups below it")
+	}
+	if _, _, err := s.Route(ctx, "go.example.com", "/"); err != nil {
+		t.Fatalf("Route failed: %v", err)
+	}
+}
+
+func TestRoute_Precedence(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	domainRepo := mocks.NewMockDomainRepository(ctrl)
+	s := NewRedirectService(nil, domainRepo, nil, nil, rdb)
+	ctx := context.Background()
+
+	verifiedAt := time.Now()
+	wildcard := &model.Domain{ID: 1, Domain: "*.example.com", VerifiedAt: &verifiedAt}
+	wildcardGo := &model.Domain{ID: 2, Domain: "*.example.com", PathPrefix: "go", VerifiedAt: &verifiedAt}
+	exactGo := &model.Domain{ID: 3, Domain: "a.example.com", PathPrefix: "go", VerifiedAt: &verifiedAt}
+	exactGoTeam := &model.Domain{ID: 4, Domain: "a.example.com", PathPrefix: "go/team", VerifiedAt: &verifiedAt}
+	pending := &model.Domain{ID: 5, Domain: "a.example.com", PathPrefix: "new"}
+	domainRepo.EXPECT().
+		ListVerifiedByPatterns(gomock.Any(), []string{"a.example.com", "*.example.com"}).
+		Return([]*model.Domain{wildcard, wildcardGo, exactGo, exactGoTeam, pending}, nil)
+	domainRepo.EXPECT().
+		ListVerifiedByPatterns(gomock.Any(), []string{"b.example.com", "*.example.com"}).
+		Return([]*model.Domain{wildcard, wildcardGo}, nil)
+
+	tests := []struct {
+		host, path string
+		wantID     uint64
+		wantCode   string
+	}{
+		// The exact host is tried before the wildcard, longest prefix first
+		{"a.example.com", "/go/team/abc", 4, "abc"},
+		{"a.example.com", "/Go/abc", 3, "abc"},
+		{"a.example.com", "/go", 3, ""},
+		{"a.example.com", "/gopher", 1, "gopher"},
+		{"a.example.com", "/new/abc", 1, "new/abc"},
+		{"b.example.com", "/go/abc/", 2, "abc"},
+		{"b.example.com", "/abc", 1, "abc"},
+		{"b.example.com", "/", 1, ""},
+	}
+	for _, tt := range tests {
+		domain, code, err := s.Route(ctx, tt.host, tt.path)
+		if err != nil {
+			t.Fatalf("Route(%q, %q) failed: %v", tt.host, tt.path, err)
+		}
+		if domain == nil || domain.ID != tt.wantID || code != tt.wantCode {
+			t.Errorf("Route(%q, %q) = %+v, %q; want domain %d, %q", tt.host, tt.path, domain, code, tt.wantID, tt.wantCode)
+		}
+	}
+
+	// A host no domain matches falls back to the default domain
+	domainRepo.EXPECT().ListVerifiedByPatterns(gomock.Any(), []string{"example.org"}).Return(nil, nil)
+	domain, code, err := s.Route(ctx, "example.org", "/abc")
+	if err != nil || domain != nil || code != "abc" {
+		t.Errorf("expected the default domain, got %+v, %q, %v", domain, code, err)
+	}
+}
+
+func TestInvalidateHostCache_WildcardDomain(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+
+	s := &RedirectService{rdb: rdb}
+	ctx := context.Background()
+
+	// The "*" of a wildcard domain is not a pattern in its own cache keys
+	mr.Set(linkCacheKeyPrefix+"*.example.com:abc", "{}")
+	mr.Set(linkCacheKeyPrefix+"go.example.com:abc", "{}")
+	mr.Set(domainCacheKeyPrefix+"go.example.com", "[]")
+
+	if err := s.InvalidateHostCache(ctx, &model.Domain{Domain: "*.example.com"}); err != nil {
+		t.Fatalf("InvalidateHostCache failed: %v", err)
+	}
+	if mr.Exists(linkCacheKeyPrefix + "*.example.com:abc") {
+		t.Error("expected the wildcard domain's links to be dropped")
+	}
+	if !mr.Exists(linkCacheKeyPrefix + "go.example.com:abc") {
+		t.Error("expected other domains' links to be kept")
+	}
+	if mr.Exists(domainCacheKeyPrefix + "go.example.com") {
+		t.Error("expected lookups of hosts below the wildcard to be dropped")
+	}
+}
+
+func TestValidateAndReturn_AppliesDomainStatus(t *testing.T) {
+	s := &RedirectService{}
+	status := 301
+	domain := &model.Domain{ID: 4, RedirectStatus: &status}
+
+	// The URL is served as stored; domain UTM defaults are filled in when links are created
+	resolved, err := s.validateAndReturn(cachedLink{OriginalURL: "https://example.com/?utm_source=ads", IsActive: true, LinkID: 1}, domain)
+	if err != nil {
+		t.Fatalf("validateAndReturn failed: %v", err)
+	}
+	if resolved.StatusCode != 301 {
+		t.Errorf("expected status 301, got %d", resolved.StatusCode)
+	}
+	if resolved.URL != "https://example.com/?utm_source=ads" {
+		t.Errorf("unexpected destination %q", resolved.URL)
+	}
+
+	// The default domain redirects with 302
+	resolved, err = s.validateAndReturn(cachedLink{OriginalURL: "https://example.com/", IsActive: true, LinkID: 1}, nil)
+	if err != nil {
+		t.Fatalf("validateAndReturn failed: %v", err)
+	}
+	if resolved.StatusCode != 302 || resolved.URL != "https://example.com/" {
+		t.Errorf("unexpected result %+v", resolved)
+	}
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/internal/repository"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrReservedCodeNotFound = errors.New("reserved code not found")
+	ErrReservedCodeExists   = errors.New("code is already reserved")
+	ErrInvalidReservedKind  = errors.New("reserved code kind must be reserved or blocked")
+	ErrDomainNotFound       = errors.New("domain not found")
+)
+
+// Compile-time check: ReservedCodeServiceImpl implements ReservedCodeService
+var _ ReservedCodeService = (*ReservedCodeServiceImpl)(nil)
+
+type ReservedCodeServiceImpl struct {
+	reservedRepo repository.ReservedCodeRepository
+	domainRepo   repository.DomainRepository
+}
+
+func NewReservedCodeService(reservedRepo repository.ReservedCodeRepository, domainRepo repository.DomainRepository) *ReservedCodeServiceImpl {
+	return &ReservedCodeServiceImpl{
+		reservedRepo: reservedRepo,
+		domainRepo:   domainRepo,
+	}
+}
+
+type ReserveCodeInput struct {
+	Code string `json:"code" binding:"required"`
+	// Kind is reserved (the default) or blocked
+	Kind   string `json:"kind,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+func (s *ReservedCodeServiceImpl) ListGlobal(ctx context.Context) ([]model.ReservedCode, error) {
+	return s.reservedRepo.ListByDomainID(ctx, nil)
+}
+
+func (s *ReservedCodeServiceImpl) AddGlobal(ctx context.Context, userID uint64, input ReserveCodeInput) (*model.ReservedCode, error) {
+	return s.add(ctx, userID, nil, input)
+}
+
+func (s *ReservedCodeServiceImpl) DeleteGlobal(ctx context.Context, id uint64) error {
+	reserved, err := s.reservedRepo.GetByID(ctx, id)
+	if errors.Is(err, repository.ErrReservedCodeNotFound) || (err == nil && reserved.DomainID != nil) {
+		return ErrReservedCodeNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return s.delete(ctx, id)
+}
+
+func (s *ReservedCodeServiceImpl) ListForDomain(ctx context.Context, actor Actor, domainID uint64) ([]model.ReservedCode, error) {
+	if err := s.checkDomainOwner(ctx, actor, domainID, model.RoleViewer); err != nil {
+		return nil, err
+	}
+	return s.reservedRepo.ListByDomainID(ctx, &domainID)
+}
+
+func (s *ReservedCodeServiceImpl) AddForDomain(ctx context.Context, actor Actor, domainID uint64, input ReserveCodeInput) (*model.ReservedCode, error) {
+	if err := s.checkDomainOwner(ctx, actor, domainID, model.RoleAdmin); err != nil {
+		return nil, err
+	}
+	return s.add(ctx, actor.UserID, &domainID, input)
+}
+
+func (s *ReservedCodeServiceImpl) DeleteForDomain(ctx context.Context, actor Actor, domainID, id uint64) error {
+	if err := s.checkDomainOwner(ctx, actor, domainID, model.RoleAdmin); err != nil {
+		return err
+	}
+	reserved, err := s.reservedRepo.GetByID(ctx, id)
+	if errors.Is(err, repository.ErrReservedCodeNotFound) || (err == nil && !sameID(reserved.DomainID, &domainID)) {
+		return ErrReservedCodeNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return s.delete(ctx, id)
+}
+
+func (s *ReservedCodeServiceImpl) add(ctx context.Context, userID uint64, domainID *uint64, input ReserveCodeInput) (*model.ReservedCode, error) {
+	code := reservationPolicy.Canonical(input.Code)
+	if !reservationPolicy.Allows(code) {
+		return nil, ErrInvalidShortCode
+	}
+
+	kind := input.Kind
+	if kind == "" {
+		kind = model.ReservedCodeKindReserved
+	}
+	if kind != model.ReservedCodeKindReserved && kind != model.ReservedCodeKindBlocked {
+		return nil, ErrInvalidReservedKind
+	}
+
+	reserved := &model.ReservedCode{
+		DomainID:  domainID,
+		Code:      code,
+		Kind:      kind,
+		Reason:    optionalString(input.Reason),
+		CreatedBy: &userID,
+	}
+	if err := s.reservedRepo.Create(ctx, reserved); err != nil {
+		if errors.Is(err, repository.ErrReservedCodeExists) {
+			return nil, ErrReservedCodeExists
+		}
+		logger.Error(ctx, "reserved-code-service: failed to reserve code",
+			zap.Uint64("user_id", userID),
+			zap.String("code", input.Code),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return reserved, nil
+}
+
+func (s *ReservedCodeServiceImpl) delete(ctx context.Context, id uint64) error {
+	err := s.reservedRepo.Delete(ctx, id)
+	if errors.Is(err, repository.ErrReservedCodeNotFound) {
+		return ErrReservedCodeNotFound
+	}
+	return err
+}
+
+// checkDomainOwner ensures the domain belongs to the actor's workspace and the
+// actor holds at least role there.
+func (s *ReservedCodeServiceImpl) checkDomainOwner(ctx context.Context, actor Actor, domainID uint64, role string) error {
+	domain, err := s.domainRepo.GetByID(ctx, domainID)
+	if errors.Is(err, repository.ErrDomainNotFound) {
+		return ErrDomainNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, "reserved-code-service: failed to get domain",
+			zap.Uint64("domain_id", domainID),
+			zap.Error(err),
+		)
+		return err
+	}
+	if domain.WorkspaceID != actor.WorkspaceID {
+		return ErrDomainNotFound
+	}
+	if !actor.Can(role) {
+		return ErrInsufficientRole
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/internal/repository/mocks"
+	"github.com/SeaCodeBase/urlshortener/internal/service"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReservedCodeService_AddGlobal_StoresLowercase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReservedRepo := mocks.NewMockReservedCodeRepository(ctrl)
+	mockDomainRepo := mocks.NewMockDomainRepository(ctrl)
+	svc := service.NewReservedCodeService(mockReservedRepo, mockDomainRepo)
+
+	mockReservedRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, reserved *model.ReservedCode) error {
+			assert.Equal(t, "pricing", reserved.Code)
+			assert.Equal(t, model.ReservedCodeKindReserved, reserved.Kind)
+			assert.Nil(t, reserved.DomainID)
+			return nil
+		})
+
+	_, err := svc.AddGlobal(context.Background(), 1, service.ReserveCodeInput{Code: "Pricing"})
+	assert.NoError(t, err)
+
+	_, err = svc.AddGlobal(context.Background(), 1, service.ReserveCodeInput{Code: "x", Kind: "hidden"})
+	assert.ErrorIs(t, err, service.ErrInvalidReservedKind)
+
+	_, err = svc.AddGlobal(context.Background(), 1, service.ReserveCodeInput{Code: "no spaces"})
+	assert.ErrorIs(t, err, service.ErrInvalidShortCode)
+}
+
+func TestReservedCodeService_DomainReservationsRequireOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReservedRepo := mocks.NewMockReservedCodeRepository(ctrl)
+	mockDomainRepo := mocks.NewMockDomainRepository(ctrl)
+	svc := service.NewReservedCodeService(mockReservedRepo, mockDomainRepo)
+
+	mockDomainRepo.EXPECT().GetByID(gomock.Any(), uint64(3)).Return(&model.Domain{ID: 3, UserID: 1, WorkspaceID: 1}, nil).Times(3)
+
+	_, err := svc.AddForDomain(context.Background(), service.Actor{UserID: 2, WorkspaceID: 2, Role: model.RoleOwner}, 3, service.ReserveCodeInput{Code: "launch"})
+	assert.ErrorIs(t, err, service.ErrDomainNotFound)
+
+	// Editors of the owning workspace may not change its reservations
+	_, err = svc.AddForDomain(context.Background(), service.Actor{UserID: 2, WorkspaceID: 1, Role: model.RoleEditor}, 3, service.ReserveCodeInput{Code: "launch"})
+	assert.ErrorIs(t, err, service.ErrInsufficientRole)
+
+	// A global entry cannot be deleted through a domain
+	mockReservedRepo.EXPECT().GetByID(gomock.Any(), uint64(7)).Return(&model.ReservedCode{ID: 7, Code: "api"}, nil)
+	err = svc.DeleteForDomain(context.Background(), service.Actor{UserID: 1, WorkspaceID: 1, Role: model.RoleAdmin}, 3, 7)
+	assert.ErrorIs(t, err, service.ErrReservedCodeNotFound)
+}
@@ -2,9 +2,10 @@ package service
 
 import (
 	"context"
-	"crypto/rand"
-	"math/big"
+	"errors"
 
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/internal/repository"
 	"github.com/SeaCodeBase/urlshortener/pkg/logger"
 	"go.uber.org/zap"
 )
@@ -13,6 +14,8 @@ const (
 	// Base62 alphabet for short codes
 	alphabet            = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
 	defaultLen          = 7
+	minCodeLen          = 3
+	maxCodeLen          = 16
 	maxGenerateAttempts = 10
 )
 
@@ -21,61 +24,222 @@ type ShortCodeRepository interface {
 	ShortCodeExistsInDomain(ctx context.Context, domainID *uint64, code string) (bool, error)
 }
 
+// ShortCodeDomainRepository looks up a domain's code generation settings.
+type ShortCodeDomainRepository interface {
+	GetByID(ctx context.Context, id uint64) (*model.Domain, error)
+}
+
+// ShortCodeReservationRepository looks up reserved and blocked codes.
+type ShortCodeReservationRepository interface {
+	ListByDomainID(ctx context.Context, domainID *uint64) ([]model.ReservedCode, error)
+	Match(ctx context.Context, domainID *uint64, code string) ([]model.ReservedCode, error)
+}
+
+// ShortCodeConfig holds the code generation defaults used when neither the
+// request nor the domain chooses a strategy or length, and the configured
+// reserved codes added to the built-in ones.
+type ShortCodeConfig struct {
+	Strategy string
+	Length   int
+	Reserved []string
+}
+
 // Compile-time check: ShortCodeServiceImpl implements ShortCodeService
 var _ ShortCodeService = (*ShortCodeServiceImpl)(nil)
 
 type ShortCodeServiceImpl struct {
-	linkRepo ShortCodeRepository
+	linkRepo     ShortCodeRepository
+	domainRepo   ShortCodeDomainRepository
+	reservedRepo ShortCodeReservationRepository
+	generators   map[string]CodeGenerator
+	blocklist    *CodeBlocklist
+	cfg          ShortCodeConfig
 }
 
-func NewShortCodeService(linkRepo ShortCodeRepository) *ShortCodeServiceImpl {
-	return &ShortCodeServiceImpl{linkRepo: linkRepo}
+// NewShortCodeService creates a short code service. domainRepo may be nil, in
+// which case domain settings and reservations are ignored; reservedRepo may be
+// nil to only apply the built-in and configured reserved codes; generators
+// defaults to random base62 only.
+func NewShortCodeService(linkRepo ShortCodeRepository, domainRepo ShortCodeDomainRepository, reservedRepo ShortCodeReservationRepository, generators map[string]CodeGenerator, cfg ShortCodeConfig) *ShortCodeServiceImpl {
+	if generators == nil {
+		generators = map[string]CodeGenerator{CodeStrategyRandom: &RandomCodeGenerator{Alphabet: alphabet}}
+	}
+	if cfg.Strategy == "" {
+		cfg.Strategy = CodeStrategyRandom
+	}
+	if cfg.Length <= 0 {
+		cfg.Length = defaultLen
+	}
+	return &ShortCodeServiceImpl{
+		linkRepo:     linkRepo,
+		domainRepo:   domainRepo,
+		reservedRepo: reservedRepo,
+		generators:   generators,
+		blocklist:    NewCodeBlocklist(cfg.Reserved),
+		cfg:          cfg,
+	}
 }
 
-func (s *ShortCodeServiceImpl) Generate(ctx context.Context, domainID *uint64) (string, error) {
-	return s.generateWithLength(ctx, domainID, defaultLen)
+// codeSettings is how codes are generated and checked for one domain.
+type codeSettings struct {
+	strategy string
+	length   int
+	policy   CodePolicy
 }
 
-func (s *ShortCodeServiceImpl) generateWithLength(ctx context.Context, domainID *uint64, length int) (string, error) {
-	for attempts := 0; attempts < maxGenerateAttempts; attempts++ {
-		code, err := generateRandomCode(length)
-		if err != nil {
-			logger.Error(ctx, "shortcode-service: failed to generate random bytes",
-				zap.Error(err),
-			)
-			return "", err
+// settings resolves the strategy and length to use: the request's strategy
+// wins over the domain's, which wins over the configured default. The length
+// is kept within the domain's code policy.
+func (s *ShortCodeServiceImpl) settings(ctx context.Context, domainID *uint64, strategy string) (codeSettings, error) {
+	domain, err := s.domain(ctx, domainID)
+	if err != nil {
+		return codeSettings{}, err
+	}
+	settings := codeSettings{strategy: s.cfg.Strategy, length: s.cfg.Length, policy: CodePolicyFor(domain)}
+	if domain != nil {
+		if domain.CodeStrategy != nil {
+			settings.strategy = *domain.CodeStrategy
+		}
+		if domain.CodeLength != nil {
+			settings.length = *domain.CodeLength
+		}
+	}
+	if strategy != "" {
+		if !s.HasStrategy(strategy) {
+			return codeSettings{}, ErrUnknownCodeStrategy
+		}
+		settings.strategy = strategy
+	}
+	settings.length = max(settings.length, settings.policy.MinLength)
+	settings.length = min(settings.length, settings.policy.MaxLength)
+	return settings, nil
+}
+
+// HasStrategy reports whether codes can be generated with the named strategy,
+// i.e. whether a generator is registered for it.
+func (s *ShortCodeServiceImpl) HasStrategy(name string) bool {
+	_, ok := s.generators[name]
+	return ok
+}
+
+// domain loads a domain's settings; it returns nil for the default domain or
+// when no domain repository is configured.
+func (s *ShortCodeServiceImpl) domain(ctx context.Context, domainID *uint64) (*model.Domain, error) {
+	if domainID == nil || s.domainRepo == nil {
+		return nil, nil
+	}
+	domain, err := s.domainRepo.GetByID(ctx, *domainID)
+	if err != nil {
+		logger.Error(ctx, "shortcode-service: failed to get domain settings",
+			zap.Uint64("domain_id", *domainID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return domain, nil
+}
+
+// Allocate generates codes and hands each to insert until one is stored. insert
+// should return repository.ErrShortCodeExists when the code is taken; the
+// database's unique index, not a prior lookup, decides. Codes grow by one
+// character after maxGenerateAttempts collisions at a length.
+func (s *ShortCodeServiceImpl) Allocate(ctx context.Context, domainID *uint64, strategy string, insert func(code string) error) (string, error) {
+	settings, err := s.settings(ctx, domainID, strategy)
+	if err != nil {
+		return "", err
+	}
+	generator, ok := s.generators[settings.strategy]
+	if !ok {
+		return "", ErrUnknownCodeStrategy
+	}
+	reserved, err := s.reservedCodes(ctx, domainID)
+	if err != nil {
+		return "", err
+	}
+
+	for length := settings.length; length <= settings.policy.MaxLength; length++ {
+		for attempts := 0; attempts < maxGenerateAttempts; attempts++ {
+			code, err := s.generateCandidate(ctx, generator, domainID, settings.policy, reserved, length)
+			if err != nil {
+				return "", err
+			}
+			if code == "" {
+				continue
+			}
+
+			err = insert(code)
+			if err == nil {
+				return code, nil
+			}
+			if !errors.Is(err, repository.ErrShortCodeExists) {
+				return "", err
+			}
 		}
+	}
+	return "", ErrCodeSpaceExhausted
+}
 
-		exists, err := s.linkRepo.ShortCodeExistsInDomain(ctx, domainID, code)
+// generateCandidate returns a generated code in canonical form, or "" when the
+// candidate is not allowed by the policy or is blocked or reserved.
+func (s *ShortCodeServiceImpl) generateCandidate(ctx context.Context, generator CodeGenerator, domainID *uint64, policy CodePolicy, reserved map[string]bool, length int) (string, error) {
+	code, err := generator.Generate(ctx, domainID, length)
+	if err != nil {
+		logger.Error(ctx, "shortcode-service: failed to generate code",
+			zap.Error(err),
+		)
+		return "", err
+	}
+	code = policy.Canonical(code)
+	if !policy.Allows(code) || s.blocklist.Blocks(code) || reserved[reservationPolicy.Canonical(code)] {
+		return "", nil
+	}
+	return code, nil
+}
+
+// reservedCodes loads the global and the domain's stored reservations once
+// per allocation. Generated codes avoid every one of them, including the
+// domain owner's own, so their kind and owner do not matter here.
+func (s *ShortCodeServiceImpl) reservedCodes(ctx context.Context, domainID *uint64) (map[string]bool, error) {
+	codes := make(map[string]bool)
+	if s.reservedRepo == nil {
+		return codes, nil
+	}
+	scopes := []*uint64{nil}
+	if domainID != nil {
+		scopes = append(scopes, domainID)
+	}
+	for _, scope := range scopes {
+		reserved, err := s.reservedRepo.ListByDomainID(ctx, scope)
 		if err != nil {
-			logger.Error(ctx, "shortcode-service: failed to check code availability",
-				zap.String("code", code),
+			logger.Error(ctx, "shortcode-service: failed to load reserved codes",
 				zap.Error(err),
 			)
-			return "", err
+			return nil, err
 		}
-		if !exists {
-			return code, nil
+		for _, entry := range reserved {
+			codes[entry.Code] = true
 		}
 	}
-
-	// If we exhausted attempts, try with longer code (recursively check for collisions)
-	return s.generateWithLength(ctx, domainID, length+1)
+	return codes, nil
 }
 
-func (s *ShortCodeServiceImpl) IsValid(code string) bool {
-	if len(code) < 3 || len(code) > 16 {
-		return false
+// Canonicalize checks a custom code against the domain's code policy and
+// returns it in canonical form. Built-in or configured reserved codes and
+// profanity are rejected as well.
+func (s *ShortCodeServiceImpl) Canonicalize(ctx context.Context, domainID *uint64, code string) (string, error) {
+	domain, err := s.domain(ctx, domainID)
+	if err != nil {
+		return "", err
 	}
-	for _, c := range code {
-		if !isAlphanumeric(c) {
-			return false
-		}
+	policy := CodePolicyFor(domain)
+	code = policy.Canonical(code)
+	if !policy.Allows(code) || s.blocklist.Blocks(code) {
+		return "", ErrInvalidShortCode
 	}
-	return true
+	return code, nil
 }
 
-func (s *ShortCodeServiceImpl) IsAvailable(ctx context.Context, domainID *uint64, code string) (bool, error) {
+func (s *ShortCodeServiceImpl) IsAvailable(ctx context.Context, workspaceID uint64, domainID *uint64, code string) (bool, error) {
 	exists, err := s.linkRepo.ShortCodeExistsInDomain(ctx, domainID, code)
 	if err != nil {
 		logger.Error(ctx, "shortcode-service: failed to check code availability",
@@ -84,22 +248,44 @@ func (s *ShortCodeServiceImpl) IsAvailable(ctx context.Context, domainID *uint64
 		)
 		return false, err
 	}
-	return !exists, nil
+	if exists {
+		return false, nil
+	}
+	return s.reservationAllows(ctx, workspaceID, domainID, code)
 }
 
-func generateRandomCode(length int) (string, error) {
-	result := make([]byte, length)
-	alphabetLen := big.NewInt(int64(len(alphabet)))
-
-	for i := 0; i < length; i++ {
-		num, err := rand.Int(rand.Reader, alphabetLen)
+// reservationAllows checks the stored reservations for a code. Global entries
+// and blocked domain entries exclude everyone; a domain's reserved entries
+// only admit the workspace owning the domain.
+func (s *ShortCodeServiceImpl) reservationAllows(ctx context.Context, workspaceID uint64, domainID *uint64, code string) (bool, error) {
+	if s.reservedRepo == nil {
+		return true, nil
+	}
+	matches, err := s.reservedRepo.Match(ctx, domainID, reservationPolicy.Canonical(code))
+	if err != nil {
+		logger.Error(ctx, "shortcode-service: failed to check reserved codes",
+			zap.String("code", code),
+			zap.Error(err),
+		)
+		return false, err
+	}
+	for _, reserved := range matches {
+		if reserved.DomainID == nil || reserved.Kind == model.ReservedCodeKindBlocked || workspaceID == 0 || s.domainRepo == nil {
+			return false, nil
+		}
+		domain, err := s.domainRepo.GetByID(ctx, *reserved.DomainID)
 		if err != nil {
-			return "", err
+			logger.Error(ctx, "shortcode-service: failed to get reserving domain",
+				zap.Uint64("domain_id", *reserved.DomainID),
+				zap.Error(err),
+			)
+			return false, err
+		}
+		if domain.WorkspaceID != workspaceID {
+			return false, nil
 		}
-		result[i] = alphabet[num.Int64()]
 	}
-
-	return string(result), nil
+	return true, nil
 }
 
 func isAlphanumeric(c rune) bool {
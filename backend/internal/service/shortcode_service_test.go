@@ -5,6 +5,8 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/internal/repository"
 	"github.com/SeaCodeBase/urlshortener/internal/service"
 )
 
@@ -29,8 +31,8 @@ func (m *mockShortCodeRepository) ShortCodeExistsInDomain(ctx context.Context, d
 	return m.existsCodes[code], nil
 }
 
-func TestShortCodeService_IsValid(t *testing.T) {
-	svc := service.NewShortCodeService(newMockRepo())
+func TestShortCodeService_Canonicalize(t *testing.T) {
+	svc := service.NewShortCodeService(newMockRepo(), nil, nil, nil, service.ShortCodeConfig{Reserved: []string{"promo"}})
 
 	tests := []struct {
 		name  string
@@ -47,137 +49,295 @@ func TestShortCodeService_IsValid(t *testing.T) {
 		{"invalid char underscore", "abc_123", false},
 		{"invalid char hyphen", "abc-123", false},
 		{"empty string", "", false},
+		{"built-in reserved", "health", false},
+		{"reserved any case", "API", false},
+		{"configured reserved", "Promo", false},
+		{"profanity", "xFuCkx", false},
+		{"profanity with digits", "5h1t", false},
+		{"whole-word profanity inside a word", "peacock", true},
+		{"reserved word inside a code", "apikey", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := svc.IsValid(tt.code); got != tt.valid {
-				t.Errorf("IsValid(%q) = %v, want %v", tt.code, got, tt.valid)
+			_, err := svc.Canonicalize(context.Background(), nil, tt.code)
+			if got := err == nil; got != tt.valid {
+				t.Errorf("Canonicalize(%q) error = %v, want valid %v", tt.code, err, tt.valid)
 			}
 		})
 	}
 }
 
-func TestShortCodeService_Generate(t *testing.T) {
-	t.Run("generates unique code on first attempt", func(t *testing.T) {
+func TestShortCodeService_IsAvailable(t *testing.T) {
+	t.Run("returns true when code does not exist", func(t *testing.T) {
 		mockRepo := newMockRepo()
-		svc := service.NewShortCodeService(mockRepo)
+		svc := service.NewShortCodeService(mockRepo, nil, nil, nil, service.ShortCodeConfig{})
 
-		code, err := svc.Generate(context.Background(), nil)
+		available, err := svc.IsAvailable(context.Background(), 1, nil, "abc123")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
-		if len(code) != 7 {
-			t.Errorf("expected code length 7, got %d", len(code))
-		}
-		if mockRepo.callCount != 1 {
-			t.Errorf("expected 1 call to ShortCodeExistsInDomain, got %d", mockRepo.callCount)
-		}
-	})
-
-	t.Run("retries when code exists", func(t *testing.T) {
-		// Create a custom mock that returns exists for first 3 calls
-		customMock := &countingMockRepo{existsUntil: 3}
-		svc := service.NewShortCodeService(customMock)
-		code, err := svc.Generate(context.Background(), nil)
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
-		if len(code) != 7 {
-			t.Errorf("expected code length 7, got %d", len(code))
-		}
-		if customMock.callCount != 4 {
-			t.Errorf("expected 4 calls to ShortCodeExistsInDomain, got %d", customMock.callCount)
+		if !available {
+			t.Error("expected code to be available")
 		}
 	})
 
-	t.Run("increases length after max attempts", func(t *testing.T) {
-		// Mock that always returns exists for 7-char codes
-		customMock := &lengthBasedMockRepo{existsForLength: 7}
-		svc := service.NewShortCodeService(customMock)
+	t.Run("returns false when code exists", func(t *testing.T) {
+		mockRepo := newMockRepo()
+		mockRepo.existsCodes["existing"] = true
+		svc := service.NewShortCodeService(mockRepo, nil, nil, nil, service.ShortCodeConfig{})
 
-		code, err := svc.Generate(context.Background(), nil)
+		available, err := svc.IsAvailable(context.Background(), 1, nil, "existing")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
-		if len(code) != 8 {
-			t.Errorf("expected code length 8 after exhausting attempts, got %d", len(code))
+		if available {
+			t.Error("expected code to be unavailable")
 		}
 	})
 
 	t.Run("returns error when repository fails", func(t *testing.T) {
 		mockRepo := newMockRepo()
 		mockRepo.err = errors.New("database error")
-		svc := service.NewShortCodeService(mockRepo)
+		svc := service.NewShortCodeService(mockRepo, nil, nil, nil, service.ShortCodeConfig{})
 
-		_, err := svc.Generate(context.Background(), nil)
+		_, err := svc.IsAvailable(context.Background(), 1, nil, "anycode")
 		if err == nil {
 			t.Fatal("expected error, got nil")
 		}
-		if err.Error() != "database error" {
-			t.Errorf("expected 'database error', got %q", err.Error())
-		}
 	})
 }
 
-func TestShortCodeService_IsAvailable(t *testing.T) {
-	t.Run("returns true when code does not exist", func(t *testing.T) {
+func TestShortCodeService_Allocate(t *testing.T) {
+	t.Run("inserts without checking availability", func(t *testing.T) {
 		mockRepo := newMockRepo()
-		svc := service.NewShortCodeService(mockRepo)
+		svc := service.NewShortCodeService(mockRepo, nil, nil, nil, service.ShortCodeConfig{})
 
-		available, err := svc.IsAvailable(context.Background(), nil, "abc123")
+		var inserted []string
+		code, err := svc.Allocate(context.Background(), nil, "", func(code string) error {
+			inserted = append(inserted, code)
+			return nil
+		})
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
-		if !available {
-			t.Error("expected code to be available")
+		if len(inserted) != 1 || inserted[0] != code {
+			t.Errorf("expected a single insert of %q, got %v", code, inserted)
+		}
+		if mockRepo.callCount != 0 {
+			t.Errorf("expected no calls to ShortCodeExistsInDomain, got %d", mockRepo.callCount)
 		}
 	})
 
-	t.Run("returns false when code exists", func(t *testing.T) {
-		mockRepo := newMockRepo()
-		mockRepo.existsCodes["existing"] = true
-		svc := service.NewShortCodeService(mockRepo)
+	t.Run("retries on unique violation and grows length", func(t *testing.T) {
+		svc := service.NewShortCodeService(newMockRepo(), nil, nil, nil, service.ShortCodeConfig{})
 
-		available, err := svc.IsAvailable(context.Background(), nil, "existing")
+		attempts := 0
+		code, err := svc.Allocate(context.Background(), nil, "", func(code string) error {
+			attempts++
+			if len(code) == 7 {
+				return repository.ErrShortCodeExists
+			}
+			return nil
+		})
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
-		if available {
-			t.Error("expected code to be unavailable")
+		if len(code) != 8 || attempts != 11 {
+			t.Errorf("expected an 8-char code after 11 attempts, got %q after %d", code, attempts)
 		}
 	})
 
-	t.Run("returns error when repository fails", func(t *testing.T) {
-		mockRepo := newMockRepo()
-		mockRepo.err = errors.New("database error")
-		svc := service.NewShortCodeService(mockRepo)
+	t.Run("returns other insert errors", func(t *testing.T) {
+		svc := service.NewShortCodeService(newMockRepo(), nil, nil, nil, service.ShortCodeConfig{})
 
-		_, err := svc.IsAvailable(context.Background(), nil, "anycode")
-		if err == nil {
-			t.Fatal("expected error, got nil")
+		_, err := svc.Allocate(context.Background(), nil, "", func(code string) error {
+			return errors.New("database error")
+		})
+		if err == nil || err.Error() != "database error" {
+			t.Errorf("expected 'database error', got %v", err)
 		}
 	})
 }
 
-// countingMockRepo returns exists=true for the first N calls, then exists=false
-type countingMockRepo struct {
-	callCount   int
-	existsUntil int
+// stubReservations returns fixed reserved codes regardless of domain.
+type stubReservations map[string]model.ReservedCode
+
+func (s stubReservations) ListByDomainID(ctx context.Context, domainID *uint64) ([]model.ReservedCode, error) {
+	var reserved []model.ReservedCode
+	for _, entry := range s {
+		if (entry.DomainID == nil) == (domainID == nil) {
+			reserved = append(reserved, entry)
+		}
+	}
+	return reserved, nil
 }
 
-func (m *countingMockRepo) ShortCodeExistsInDomain(ctx context.Context, domainID *uint64, code string) (bool, error) {
-	m.callCount++
-	return m.callCount <= m.existsUntil, nil
+func (s stubReservations) Match(ctx context.Context, domainID *uint64, code string) ([]model.ReservedCode, error) {
+	if reserved, ok := s[code]; ok {
+		return []model.ReservedCode{reserved}, nil
+	}
+	return nil, nil
 }
 
-// lengthBasedMockRepo returns exists=true for codes of a specific length
-type lengthBasedMockRepo struct {
-	callCount       int
-	existsForLength int
+// listedReservations serves reservations only as lists, failing lookups of
+// single codes.
+type listedReservations struct {
+	stubReservations
 }
 
-func (m *lengthBasedMockRepo) ShortCodeExistsInDomain(ctx context.Context, domainID *uint64, code string) (bool, error) {
-	m.callCount++
-	return len(code) == m.existsForLength, nil
+func (listedReservations) Match(ctx context.Context, domainID *uint64, code string) ([]model.ReservedCode, error) {
+	return nil, errors.New("unexpected per-code reservation lookup")
+}
+
+// stubDomains returns domains owned by workspace 1.
+type stubDomains struct{}
+
+func (stubDomains) GetByID(ctx context.Context, id uint64) (*model.Domain, error) {
+	return &model.Domain{ID: id, UserID: 1, WorkspaceID: 1}, nil
+}
+
+func TestShortCodeService_IsAvailable_Reservations(t *testing.T) {
+	domainID := uint64(3)
+	reservations := stubReservations{
+		"admin":  {Code: "admin", Kind: model.ReservedCodeKindReserved},
+		"launch": {Code: "launch", DomainID: &domainID, Kind: model.ReservedCodeKindReserved},
+		"spam":   {Code: "spam", DomainID: &domainID, Kind: model.ReservedCodeKindBlocked},
+	}
+	svc := service.NewShortCodeService(newMockRepo(), stubDomains{}, reservations, nil, service.ShortCodeConfig{})
+
+	tests := []struct {
+		name        string
+		workspaceID uint64
+		code        string
+		available   bool
+	}{
+		{"global reservation blocks the domain owner", 1, "admin", false},
+		{"domain reservation admits the owning workspace", 1, "launch", true},
+		{"domain reservation excludes other workspaces", 2, "launch", false},
+		{"blocked domain code excludes the owner", 1, "spam", false},
+		{"unreserved code", 2, "other", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			available, err := svc.IsAvailable(context.Background(), tt.workspaceID, &domainID, tt.code)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if available != tt.available {
+				t.Errorf("IsAvailable(%q) for workspace %d = %v, want %v", tt.code, tt.workspaceID, available, tt.available)
+			}
+		})
+	}
+}
+
+// fixedGenerator returns its codes in order, then repeats the last one.
+type fixedGenerator struct {
+	codes []string
+	next  int
+}
+
+func (g *fixedGenerator) Generate(ctx context.Context, domainID *uint64, length int) (string, error) {
+	code := g.codes[g.next]
+	if g.next < len(g.codes)-1 {
+		g.next++
+	}
+	return code, nil
+}
+
+func TestShortCodeService_Allocate_SkipsBlockedCandidates(t *testing.T) {
+	domainID := uint64(3)
+	// Reservations are loaded once rather than matched per candidate
+	reservations := listedReservations{stubReservations{
+		"launch": {Code: "launch", DomainID: &domainID, Kind: model.ReservedCodeKindReserved},
+		"promo1": {Code: "promo1", Kind: model.ReservedCodeKindReserved},
+	}}
+	generators := map[string]service.CodeGenerator{
+		service.CodeStrategyRandom: &fixedGenerator{codes: []string{"login", "sh1tty", "launch", "Promo1", "ok1234"}},
+	}
+	svc := service.NewShortCodeService(newMockRepo(), stubDomains{}, reservations, generators, service.ShortCodeConfig{})
+
+	var inserted []string
+	code, err := svc.Allocate(context.Background(), &domainID, "", func(code string) error {
+		inserted = append(inserted, code)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if code != "ok1234" || len(inserted) != 1 {
+		t.Errorf("expected only ok1234 to be inserted, got %v", inserted)
+	}
+}
+
+func TestShortCodeService_Canonicalize_DomainPolicy(t *testing.T) {
+	slug, unicodeCharset := service.CodeCharsetSlug, service.CodeCharsetUnicode
+	minLength, maxLength := 2, 24
+	domains := staticDomains{
+		1: {ID: 1, UserID: 1, CaseInsensitiveCodes: true, CodeCharset: &slug, CodeMinLength: &minLength, CodeMaxLength: &maxLength},
+		2: {ID: 2, UserID: 1, CodeCharset: &unicodeCharset},
+	}
+	svc := service.NewShortCodeService(newMockRepo(), domains, nil, nil, service.ShortCodeConfig{})
+	marketing, regional := uint64(1), uint64(2)
+
+	tests := []struct {
+		name     string
+		domainID *uint64
+		code     string
+		want     string
+	}{
+		{"default domain keeps case", nil, "Sale", "Sale"},
+		{"default domain rejects hyphens", nil, "summer-sale", ""},
+		{"case-insensitive domain folds", &marketing, "Summer_Sale.2025", "summer_sale.2025"},
+		{"separator may not end a code", &marketing, "sale-", ""},
+		{"domain minimum length", &marketing, "go", "go"},
+		{"domain maximum length", &marketing, "abcdefghijklmnopqrstuvwxy", ""},
+		{"unicode letters", &regional, "café-menü", "café-menü"},
+		{"unicode composes to NFC", &regional, "cafe\u0301", "caf\u00e9"},
+		{"unicode rejects symbols", &regional, "sale★", ""},
+		{"unicode domain keeps case", &regional, "Straße", "Straße"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := svc.Canonicalize(context.Background(), tt.domainID, tt.code)
+			if tt.want == "" {
+				if !errors.Is(err, service.ErrInvalidShortCode) {
+					t.Errorf("Canonicalize(%q) = %q, %v; want ErrInvalidShortCode", tt.code, got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("Canonicalize(%q) = %q, %v; want %q", tt.code, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestShortCodeService_Allocate_FoldsCaseInsensitiveDomains(t *testing.T) {
+	domainID := uint64(1)
+	domains := staticDomains{1: {ID: 1, UserID: 1, CaseInsensitiveCodes: true}}
+	generators := map[string]service.CodeGenerator{
+		service.CodeStrategyRandom: &fixedGenerator{codes: []string{"AbC1234"}},
+	}
+	svc := service.NewShortCodeService(newMockRepo(), domains, nil, generators, service.ShortCodeConfig{})
+
+	code, err := svc.Allocate(context.Background(), &domainID, "", func(code string) error { return nil })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if code != "abc1234" {
+		t.Errorf("expected folded code abc1234, got %q", code)
+	}
+}
+
+// staticDomains serves domains from a map.
+type staticDomains map[uint64]*model.Domain
+
+func (d staticDomains) GetByID(ctx context.Context, id uint64) (*model.Domain, error) {
+	if domain, ok := d[id]; ok {
+		return domain, nil
+	}
+	return nil, repository.ErrDomainNotFound
 }
@@ -2,24 +2,46 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/SeaCodeBase/urlshortener/internal/repository"
 	"github.com/SeaCodeBase/urlshortener/pkg/logger"
 	"go.uber.org/zap"
 )
 
+const (
+	// defaultOverviewRange is the overview's range when none is given
+	defaultOverviewRange = 30 * 24 * time.Hour
+	maxOverviewRange     = 366 * 24 * time.Hour
+	// maxHourlyRange bounds hourly series, and ranges up to
+	// autoHourlyRange get one by default
+	maxHourlyRange  = 7 * 24 * time.Hour
+	autoHourlyRange = 2 * 24 * time.Hour
+	// expiringSoonWindow is how far ahead the overview counts expiring links
+	expiringSoonWindow = 7 * 24 * time.Hour
+)
+
+var ErrInvalidStatsRange = errors.New("invalid stats range")
+
 // Compile-time check: StatsServiceImpl implements StatsService
 var _ StatsService = (*StatsServiceImpl)(nil)
 
 type StatsServiceImpl struct {
-	clickRepo repository.ClickRepository
-	linkRepo  repository.LinkRepository
+	clickRepo    repository.ClickRepository
+	linkRepo     repository.LinkRepository
+	campaignRepo repository.CampaignRepository
+	domainRepo   repository.DomainRepository
 }
 
-func NewStatsService(clickRepo repository.ClickRepository, linkRepo repository.LinkRepository) *StatsServiceImpl {
+func NewStatsService(clickRepo repository.ClickRepository, linkRepo repository.LinkRepository, campaignRepo repository.CampaignRepository,
+	domainRepo repository.DomainRepository) *StatsServiceImpl {
 	return &StatsServiceImpl{
-		clickRepo: clickRepo,
-		linkRepo:  linkRepo,
+		clickRepo:    clickRepo,
+		linkRepo:     linkRepo,
+		campaignRepo: campaignRepo,
+		domainRepo:   domainRepo,
 	}
 }
 
@@ -36,9 +58,10 @@ type LinkStatsResponse struct {
 	DeviceStats    []repository.DeviceStats     `json:"device_stats"`
 	BrowserStats   []repository.BrowserStats    `json:"browser_stats"`
 	Locations      LocationStats                `json:"locations"`
+	Codes          []repository.CodeStats       `json:"codes"`
 }
 
-func (s *StatsServiceImpl) GetLinkStats(ctx context.Context, userID, linkID uint64) (*LinkStatsResponse, error) {
+func (s *StatsServiceImpl) GetLinkStats(ctx context.Context, actor Actor, linkID uint64) (*LinkStatsResponse, error) {
 	// Verify ownership
 	link, err := s.linkRepo.GetByID(ctx, linkID)
 	if err != nil {
@@ -48,7 +71,7 @@ func (s *StatsServiceImpl) GetLinkStats(ctx context.Context, userID, linkID uint
 		)
 		return nil, err
 	}
-	if link.UserID != userID {
+	if link.WorkspaceID != actor.WorkspaceID {
 		return nil, ErrNotLinkOwner
 	}
 
@@ -151,6 +174,18 @@ func (s *StatsServiceImpl) GetLinkStats(ctx context.Context, userID, linkID uint
 		}
 	}
 
+	codes, err := s.clickRepo.GetCodeStats(ctx, linkID)
+	if err != nil {
+		logger.Error(ctx, "stats-service: failed to get code stats",
+			zap.Uint64("link_id", linkID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if codes == nil {
+		codes = []repository.CodeStats{}
+	}
+
 	return &LinkStatsResponse{
 		TotalClicks:    stats.TotalClicks,
 		UniqueVisitors: stats.UniqueVisitors,
@@ -162,9 +197,486 @@ func (s *StatsServiceImpl) GetLinkStats(ctx context.Context, userID, linkID uint
 			Countries: countries,
 			Cities:    cities,
 		},
+		Codes: codes,
+	}, nil
+}
+
+type CampaignStatsResponse struct {
+	CampaignID     uint64                       `json:"campaign_id"`
+	LinkCount      int                          `json:"link_count"`
+	TotalClicks    int64                        `json:"total_clicks"`
+	UniqueVisitors int64                        `json:"unique_visitors"`
+	DailyStats     []repository.DailyClickStats `json:"daily_stats"`
+	TopReferrers   []repository.ReferrerStats   `json:"top_referrers"`
+	DeviceStats    []repository.DeviceStats     `json:"device_stats"`
+	Countries      []repository.CountryStats    `json:"countries"`
+}
+
+func (s *StatsServiceImpl) GetCampaignStats(ctx context.Context, actor Actor, campaignID uint64) (*CampaignStatsResponse, error) {
+	// Verify ownership
+	campaign, err := s.campaignRepo.GetByID(ctx, campaignID)
+	if err != nil {
+		logger.Error(ctx, "stats-service: failed to get campaign",
+			zap.Uint64("campaign_id", campaignID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if campaign.WorkspaceID != actor.WorkspaceID {
+		return nil, ErrNotCampaignOwner
+	}
+
+	linkCount, err := s.linkRepo.CountByCampaignID(ctx, campaignID)
+	if err != nil {
+		logger.Error(ctx, "stats-service: failed to count campaign links",
+			zap.Uint64("campaign_id", campaignID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	scope := repository.ClickScope{CampaignID: campaignID}
+
+	stats, err := s.clickRepo.GetScopedStats(ctx, scope)
+	if err != nil {
+		logger.Error(ctx, "stats-service: failed to get campaign click stats",
+			zap.Uint64("campaign_id", campaignID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	daily, err := s.clickRepo.GetScopedDailyStats(ctx, scope, 30)
+	if err != nil {
+		logger.Error(ctx, "stats-service: failed to get campaign daily stats",
+			zap.Uint64("campaign_id", campaignID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if daily == nil {
+		daily = []repository.DailyClickStats{}
+	}
+
+	referrers, err := s.clickRepo.GetScopedTopReferrers(ctx, scope, 10)
+	if err != nil {
+		logger.Error(ctx, "stats-service: failed to get campaign top referrers",
+			zap.Uint64("campaign_id", campaignID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if referrers == nil {
+		referrers = []repository.ReferrerStats{}
+	}
+
+	devices, err := s.clickRepo.GetScopedDeviceStats(ctx, scope)
+	if err != nil {
+		logger.Error(ctx, "stats-service: failed to get campaign device stats",
+			zap.Uint64("campaign_id", campaignID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if devices == nil {
+		devices = []repository.DeviceStats{}
+	}
+
+	countries, err := s.clickRepo.GetScopedCountryStats(ctx, scope, 10)
+	if err != nil {
+		logger.Error(ctx, "stats-service: failed to get campaign country stats",
+			zap.Uint64("campaign_id", campaignID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if countries == nil {
+		countries = []repository.CountryStats{}
+	}
+	for i := range countries {
+		if stats.TotalClicks > 0 {
+			countries[i].Percentage = float64(countries[i].Count) / float64(stats.TotalClicks) * 100
+		}
+		countries[i].CountryName = getCountryName(countries[i].Country)
+	}
+
+	return &CampaignStatsResponse{
+		CampaignID:     campaignID,
+		LinkCount:      int(linkCount),
+		TotalClicks:    stats.TotalClicks,
+		UniqueVisitors: stats.UniqueVisitors,
+		DailyStats:     daily,
+		TopReferrers:   referrers,
+		DeviceStats:    devices,
+		Countries:      countries,
+	}, nil
+}
+
+type DomainStatsResponse struct {
+	DomainID       uint64                       `json:"domain_id"`
+	TotalClicks    int64                        `json:"total_clicks"`
+	UniqueVisitors int64                        `json:"unique_visitors"`
+	DailyStats     []repository.DailyClickStats `json:"daily_stats"`
+	TopLinks       []repository.LinkClickStats  `json:"top_links"`
+	TopReferrers   []repository.ReferrerStats   `json:"top_referrers"`
+	DeviceStats    []repository.DeviceStats     `json:"device_stats"`
+	Countries      []repository.CountryStats    `json:"countries"`
+	// NotFound and Expired count requests on the domain that found no link
+	// to follow; TopMisses lists the codes they asked for, e.g. a misprint
+	NotFound  int64                  `json:"not_found"`
+	Expired   int64                  `json:"expired"`
+	TopMisses []repository.MissStats `json:"top_misses"`
+}
+
+// GetDomainStats aggregates the clicks of every link on a domain, along with
+// the requests on it that missed.
+func (s *StatsServiceImpl) GetDomainStats(ctx context.Context, actor Actor, domainID uint64) (*DomainStatsResponse, error) {
+	// Verify ownership
+	domain, err := s.domainRepo.GetByID(ctx, domainID)
+	if errors.Is(err, repository.ErrDomainNotFound) {
+		return nil, ErrDomainNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, "stats-service: failed to get domain",
+			zap.Uint64("domain_id", domainID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if domain.WorkspaceID != actor.WorkspaceID {
+		return nil, ErrDomainNotFound
+	}
+
+	scope := repository.ClickScope{DomainID: domainID}
+
+	stats, err := s.clickRepo.GetScopedStats(ctx, scope)
+	if err != nil {
+		logger.Error(ctx, "stats-service: failed to get domain click stats",
+			zap.Uint64("domain_id", domainID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	daily, err := s.clickRepo.GetScopedDailyStats(ctx, scope, 30)
+	if err != nil {
+		logger.Error(ctx, "stats-service: failed to get domain daily stats",
+			zap.Uint64("domain_id", domainID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if daily == nil {
+		daily = []repository.DailyClickStats{}
+	}
+
+	links, err := s.clickRepo.GetScopedTopLinks(ctx, scope, 10)
+	if err != nil {
+		logger.Error(ctx, "stats-service: failed to get domain top links",
+			zap.Uint64("domain_id", domainID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if links == nil {
+		links = []repository.LinkClickStats{}
+	}
+
+	referrers, err := s.clickRepo.GetScopedTopReferrers(ctx, scope, 10)
+	if err != nil {
+		logger.Error(ctx, "stats-service: failed to get domain top referrers",
+			zap.Uint64("domain_id", domainID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if referrers == nil {
+		referrers = []repository.ReferrerStats{}
+	}
+
+	devices, err := s.clickRepo.GetScopedDeviceStats(ctx, scope)
+	if err != nil {
+		logger.Error(ctx, "stats-service: failed to get domain device stats",
+			zap.Uint64("domain_id", domainID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if devices == nil {
+		devices = []repository.DeviceStats{}
+	}
+
+	countries, err := s.clickRepo.GetScopedCountryStats(ctx, scope, 10)
+	if err != nil {
+		logger.Error(ctx, "stats-service: failed to get domain country stats",
+			zap.Uint64("domain_id", domainID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if countries == nil {
+		countries = []repository.CountryStats{}
+	}
+	for i := range countries {
+		if stats.TotalClicks > 0 {
+			countries[i].Percentage = float64(countries[i].Count) / float64(stats.TotalClicks) * 100
+		}
+		countries[i].CountryName = getCountryName(countries[i].Country)
+	}
+
+	misses, err := s.clickRepo.GetMissTotals(ctx, domainID)
+	if err != nil {
+		logger.Error(ctx, "stats-service: failed to get domain miss totals",
+			zap.Uint64("domain_id", domainID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	topMisses, err := s.clickRepo.GetTopMisses(ctx, domainID, 10)
+	if err != nil {
+		logger.Error(ctx, "stats-service: failed to get domain top misses",
+			zap.Uint64("domain_id", domainID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if topMisses == nil {
+		topMisses = []repository.MissStats{}
+	}
+
+	return &DomainStatsResponse{
+		DomainID:       domainID,
+		TotalClicks:    stats.TotalClicks,
+		UniqueVisitors: stats.UniqueVisitors,
+		DailyStats:     daily,
+		TopLinks:       links,
+		TopReferrers:   referrers,
+		DeviceStats:    devices,
+		Countries:      countries,
+		NotFound:       misses.NotFound,
+		Expired:        misses.Expired,
+		TopMisses:      topMisses,
+	}, nil
+}
+
+// OverviewQuery selects the time range of the stats overview.
+type OverviewQuery struct {
+	// From and To bound the range [From, To); zero values mean the 30 days
+	// up to now
+	From time.Time
+	To   time.Time
+	// Interval is the series' repository.SeriesDay or SeriesHour; empty
+	// picks hours for ranges up to two days
+	Interval string
+}
+
+type OverviewStatsResponse struct {
+	From           time.Time                     `json:"from"`
+	To             time.Time                     `json:"to"`
+	Interval       string                        `json:"interval"`
+	TotalClicks    int64                         `json:"total_clicks"`
+	UniqueVisitors int64                         `json:"unique_visitors"`
+	Series         []repository.ClickSeriesPoint `json:"series"`
+	TopLinks       []repository.LinkClickStats   `json:"top_links"`
+	TopReferrers   []repository.ReferrerStats    `json:"top_referrers"`
+	DeviceStats    []repository.DeviceStats      `json:"device_stats"`
+	BrowserStats   []repository.BrowserStats     `json:"browser_stats"`
+	Locations      LocationStats                 `json:"locations"`
+	// LinksCreated counts the links created in the range; ExpiringSoon
+	// counts those expiring in the next 7 days
+	LinksCreated int64 `json:"links_created"`
+	ExpiringSoon int64 `json:"expiring_soon"`
+}
+
+// GetOverview aggregates the clicks of all of the workspace's links in a
+// time range. Clicks are reached through the workspace's links rather than
+// a list of link IDs, so it stays cheap for large workspaces.
+func (s *StatsServiceImpl) GetOverview(ctx context.Context, actor Actor, query OverviewQuery) (*OverviewStatsResponse, error) {
+	now := time.Now().UTC()
+	query, err := normalizeOverviewQuery(query, now)
+	if err != nil {
+		return nil, err
+	}
+	scope := repository.ClickScope{WorkspaceID: actor.WorkspaceID, Since: query.From, Until: query.To}
+
+	stats, err := s.clickRepo.GetScopedStats(ctx, scope)
+	if err != nil {
+		logger.Error(ctx, "stats-service: failed to get overview click stats",
+			zap.Uint64("workspace_id", actor.WorkspaceID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	series, err := s.clickRepo.GetScopedSeries(ctx, scope, query.Interval)
+	if err != nil {
+		logger.Error(ctx, "stats-service: failed to get overview click series",
+			zap.Uint64("workspace_id", actor.WorkspaceID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if series == nil {
+		series = []repository.ClickSeriesPoint{}
+	}
+
+	links, err := s.clickRepo.GetScopedTopLinks(ctx, scope, 10)
+	if err != nil {
+		logger.Error(ctx, "stats-service: failed to get overview top links",
+			zap.Uint64("workspace_id", actor.WorkspaceID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if links == nil {
+		links = []repository.LinkClickStats{}
+	}
+
+	referrers, err := s.clickRepo.GetScopedTopReferrers(ctx, scope, 10)
+	if err != nil {
+		logger.Error(ctx, "stats-service: failed to get overview top referrers",
+			zap.Uint64("workspace_id", actor.WorkspaceID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if referrers == nil {
+		referrers = []repository.ReferrerStats{}
+	}
+
+	devices, err := s.clickRepo.GetScopedDeviceStats(ctx, scope)
+	if err != nil {
+		logger.Error(ctx, "stats-service: failed to get overview device stats",
+			zap.Uint64("workspace_id", actor.WorkspaceID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if devices == nil {
+		devices = []repository.DeviceStats{}
+	}
+
+	browsers, err := s.clickRepo.GetScopedBrowserStats(ctx, scope, 10)
+	if err != nil {
+		logger.Error(ctx, "stats-service: failed to get overview browser stats",
+			zap.Uint64("workspace_id", actor.WorkspaceID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if browsers == nil {
+		browsers = []repository.BrowserStats{}
+	}
+
+	countries, err := s.clickRepo.GetScopedCountryStats(ctx, scope, 10)
+	if err != nil {
+		logger.Error(ctx, "stats-service: failed to get overview country stats",
+			zap.Uint64("workspace_id", actor.WorkspaceID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if countries == nil {
+		countries = []repository.CountryStats{}
+	}
+	for i := range countries {
+		if stats.TotalClicks > 0 {
+			countries[i].Percentage = float64(countries[i].Count) / float64(stats.TotalClicks) * 100
+		}
+		countries[i].CountryName = getCountryName(countries[i].Country)
+	}
+
+	cities, err := s.clickRepo.GetScopedCityStats(ctx, scope, 10)
+	if err != nil {
+		logger.Error(ctx, "stats-service: failed to get overview city stats",
+			zap.Uint64("workspace_id", actor.WorkspaceID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if cities == nil {
+		cities = []repository.CityStats{}
+	}
+	for i := range cities {
+		if stats.TotalClicks > 0 {
+			cities[i].Percentage = float64(cities[i].Count) / float64(stats.TotalClicks) * 100
+		}
+	}
+
+	created, err := s.linkRepo.CountCreatedBetween(ctx, actor.WorkspaceID, query.From, query.To)
+	if err != nil {
+		logger.Error(ctx, "stats-service: failed to count created links",
+			zap.Uint64("workspace_id", actor.WorkspaceID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	expiring, err := s.linkRepo.CountExpiringBetween(ctx, actor.WorkspaceID, now, now.Add(expiringSoonWindow))
+	if err != nil {
+		logger.Error(ctx, "stats-service: failed to count expiring links",
+			zap.Uint64("workspace_id", actor.WorkspaceID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	return &OverviewStatsResponse{
+		From:           query.From,
+		To:             query.To,
+		Interval:       query.Interval,
+		TotalClicks:    stats.TotalClicks,
+		UniqueVisitors: stats.UniqueVisitors,
+		Series:         series,
+		TopLinks:       links,
+		TopReferrers:   referrers,
+		DeviceStats:    devices,
+		BrowserStats:   browsers,
+		Locations: LocationStats{
+			Countries: countries,
+			Cities:    cities,
+		},
+		LinksCreated: created,
+		ExpiringSoon: expiring,
 	}, nil
 }
 
+// normalizeOverviewQuery fills in the defaults of an overview query and
+// checks its range.
+func normalizeOverviewQuery(query OverviewQuery, now time.Time) (OverviewQuery, error) {
+	if query.To.IsZero() {
+		query.To = now
+	}
+	if query.From.IsZero() {
+		query.From = query.To.Add(-defaultOverviewRange)
+	}
+	query.From, query.To = query.From.UTC(), query.To.UTC()
+	length := query.To.Sub(query.From)
+	if length <= 0 {
+		return query, fmt.Errorf("%w: from must be before to", ErrInvalidStatsRange)
+	}
+	if length > maxOverviewRange {
+		return query, fmt.Errorf("%w: the range cannot exceed 366 days", ErrInvalidStatsRange)
+	}
+
+	switch query.Interval {
+	case "":
+		query.Interval = repository.SeriesDay
+		if length <= autoHourlyRange {
+			query.Interval = repository.SeriesHour
+		}
+	case repository.SeriesDay:
+	case repository.SeriesHour:
+		if length > maxHourlyRange {
+			return query, fmt.Errorf("%w: hourly series cannot exceed 7 days", ErrInvalidStatsRange)
+		}
+	default:
+		return query, fmt.Errorf("%w: interval must be day or hour", ErrInvalidStatsRange)
+	}
+	return query, nil
+}
+
 func getCountryName(code string) string {
 	names := map[string]string{
 		"CN": "China", "US": "United States", "JP": "Japan", "GB": "United Kingdom",
@@ -0,0 +1,141 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/internal/repository"
+	"github.com/SeaCodeBase/urlshortener/internal/repository/mocks"
+	"github.com/SeaCodeBase/urlshortener/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestStatsService_GetDomainStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClickRepo := mocks.NewMockClickRepository(ctrl)
+	mockDomainRepo := mocks.NewMockDomainRepository(ctrl)
+	svc := service.NewStatsService(mockClickRepo, nil, nil, mockDomainRepo)
+
+	// Other workspaces' and deleted domains are not found
+	mockDomainRepo.EXPECT().GetByID(gomock.Any(), uint64(9)).Return(&model.Domain{ID: 9, WorkspaceID: 2}, nil)
+	_, err := svc.GetDomainStats(context.Background(), editor, 9)
+	assert.ErrorIs(t, err, service.ErrDomainNotFound)
+	mockDomainRepo.EXPECT().GetByID(gomock.Any(), uint64(8)).Return(nil, repository.ErrDomainNotFound)
+	_, err = svc.GetDomainStats(context.Background(), editor, 8)
+	assert.ErrorIs(t, err, service.ErrDomainNotFound)
+
+	mockDomainRepo.EXPECT().GetByID(gomock.Any(), uint64(5)).Return(&model.Domain{ID: 5, WorkspaceID: 1, Domain: "go.example.com"}, nil)
+	scope := repository.ClickScope{DomainID: 5}
+	mockClickRepo.EXPECT().GetScopedStats(gomock.Any(), scope).Return(&repository.ClickStats{TotalClicks: 40, UniqueVisitors: 12}, nil)
+	mockClickRepo.EXPECT().GetScopedDailyStats(gomock.Any(), scope, 30).Return(nil, nil)
+	mockClickRepo.EXPECT().GetScopedTopLinks(gomock.Any(), scope, 10).
+		Return([]repository.LinkClickStats{{LinkID: 1, ShortCode: "sale", Count: 30}, {LinkID: 2, ShortCode: "menu", Count: 10}}, nil)
+	mockClickRepo.EXPECT().GetScopedTopReferrers(gomock.Any(), scope, 10).Return(nil, nil)
+	mockClickRepo.EXPECT().GetScopedDeviceStats(gomock.Any(), scope).Return(nil, nil)
+	mockClickRepo.EXPECT().GetScopedCountryStats(gomock.Any(), scope, 10).
+		Return([]repository.CountryStats{{Country: "US", Count: 10}}, nil)
+	mockClickRepo.EXPECT().GetMissTotals(gomock.Any(), uint64(5)).Return(&repository.MissTotals{NotFound: 7, Expired: 2}, nil)
+	mockClickRepo.EXPECT().GetTopMisses(gomock.Any(), uint64(5), 10).
+		Return([]repository.MissStats{{Code: "sael", Reason: model.MissNotFound, Count: 6}}, nil)
+
+	stats, err := svc.GetDomainStats(context.Background(), editor, 5)
+	require.NoError(t, err)
+	assert.Equal(t, int64(40), stats.TotalClicks)
+	assert.Equal(t, int64(12), stats.UniqueVisitors)
+	assert.Len(t, stats.TopLinks, 2)
+	assert.Equal(t, "United States", stats.Countries[0].CountryName)
+	assert.Equal(t, 25.0, stats.Countries[0].Percentage)
+	assert.Equal(t, int64(7), stats.NotFound)
+	assert.Equal(t, int64(2), stats.Expired)
+	assert.Equal(t, "sael", stats.TopMisses[0].Code)
+	// Empty breakdowns serialize as lists
+	assert.NotNil(t, stats.DailyStats)
+	assert.NotNil(t, stats.TopReferrers)
+	assert.NotNil(t, stats.DeviceStats)
+}
+
+func TestStatsService_GetCampaignStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClickRepo := mocks.NewMockClickRepository(ctrl)
+	mockLinkRepo := mocks.NewMockLinkRepository(ctrl)
+	mockCampaignRepo := mocks.NewMockCampaignRepository(ctrl)
+	svc := service.NewStatsService(mockClickRepo, mockLinkRepo, mockCampaignRepo, nil)
+
+	mockCampaignRepo.EXPECT().GetByID(gomock.Any(), uint64(9)).Return(&model.Campaign{ID: 9, WorkspaceID: 2}, nil)
+	_, err := svc.GetCampaignStats(context.Background(), editor, 9)
+	assert.ErrorIs(t, err, service.ErrNotCampaignOwner)
+
+	// Links are counted, not loaded
+	mockCampaignRepo.EXPECT().GetByID(gomock.Any(), uint64(3)).Return(&model.Campaign{ID: 3, WorkspaceID: 1}, nil)
+	mockLinkRepo.EXPECT().CountByCampaignID(gomock.Any(), uint64(3)).Return(int64(4), nil)
+	scope := repository.ClickScope{CampaignID: 3}
+	mockClickRepo.EXPECT().GetScopedStats(gomock.Any(), scope).Return(&repository.ClickStats{TotalClicks: 20}, nil)
+	mockClickRepo.EXPECT().GetScopedDailyStats(gomock.Any(), scope, 30).Return(nil, nil)
+	mockClickRepo.EXPECT().GetScopedTopReferrers(gomock.Any(), scope, 10).Return(nil, nil)
+	mockClickRepo.EXPECT().GetScopedDeviceStats(gomock.Any(), scope).Return(nil, nil)
+	mockClickRepo.EXPECT().GetScopedCountryStats(gomock.Any(), scope, 10).Return(nil, nil)
+
+	stats, err := svc.GetCampaignStats(context.Background(), editor, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 4, stats.LinkCount)
+	assert.Equal(t, int64(20), stats.TotalClicks)
+}
+
+func TestStatsService_GetOverview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClickRepo := mocks.NewMockClickRepository(ctrl)
+	mockLinkRepo := mocks.NewMockLinkRepository(ctrl)
+	svc := service.NewStatsService(mockClickRepo, mockLinkRepo, nil, nil)
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	for _, query := range []service.OverviewQuery{
+		{From: from, To: from},
+		{From: from, To: from.AddDate(2, 0, 0)},
+		{From: from, To: from.AddDate(0, 1, 0), Interval: "hour"},
+		{From: from, To: from.AddDate(0, 1, 0), Interval: "week"},
+	} {
+		_, err := svc.GetOverview(context.Background(), editor, query)
+		assert.ErrorIs(t, err, service.ErrInvalidStatsRange, "%+v", query)
+	}
+
+	// A two-day range gets an hourly series of the workspace's clicks
+	to := from.Add(48 * time.Hour)
+	scope := repository.ClickScope{WorkspaceID: 1, Since: from, Until: to}
+	mockClickRepo.EXPECT().GetScopedStats(gomock.Any(), scope).Return(&repository.ClickStats{TotalClicks: 20, UniqueVisitors: 9}, nil)
+	mockClickRepo.EXPECT().GetScopedSeries(gomock.Any(), scope, repository.SeriesHour).
+		Return([]repository.ClickSeriesPoint{{Period: "2026-03-01 09:00", Clicks: 20}}, nil)
+	mockClickRepo.EXPECT().GetScopedTopLinks(gomock.Any(), scope, 10).Return(nil, nil)
+	mockClickRepo.EXPECT().GetScopedTopReferrers(gomock.Any(), scope, 10).Return(nil, nil)
+	mockClickRepo.EXPECT().GetScopedDeviceStats(gomock.Any(), scope).Return(nil, nil)
+	mockClickRepo.EXPECT().GetScopedBrowserStats(gomock.Any(), scope, 10).Return(nil, nil)
+	mockClickRepo.EXPECT().GetScopedCountryStats(gomock.Any(), scope, 10).Return(nil, nil)
+	mockClickRepo.EXPECT().GetScopedCityStats(gomock.Any(), scope, 10).
+		Return([]repository.CityStats{{City: "Berlin", Country: "DE", Count: 5}}, nil)
+	mockLinkRepo.EXPECT().CountCreatedBetween(gomock.Any(), uint64(1), from, to).Return(int64(3), nil)
+	mockLinkRepo.EXPECT().CountExpiringBetween(gomock.Any(), uint64(1), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, workspaceID uint64, after, before time.Time) (int64, error) {
+			assert.Equal(t, 7*24*time.Hour, before.Sub(after))
+			return 2, nil
+		})
+
+	stats, err := svc.GetOverview(context.Background(), editor, service.OverviewQuery{From: from, To: to})
+	require.NoError(t, err)
+	assert.Equal(t, repository.SeriesHour, stats.Interval)
+	assert.Equal(t, int64(20), stats.TotalClicks)
+	assert.Len(t, stats.Series, 1)
+	assert.Equal(t, 25.0, stats.Locations.Cities[0].Percentage)
+	assert.Equal(t, int64(3), stats.LinksCreated)
+	assert.Equal(t, int64(2), stats.ExpiringSoon)
+	assert.NotNil(t, stats.TopLinks)
+	assert.NotNil(t, stats.Locations.Countries)
+}
@@ -0,0 +1,295 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/internal/repository"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrTransferNotFound   = errors.New("transfer not found")
+	ErrRecipientNotFound  = errors.New("recipient not found")
+	ErrSelfTransfer       = errors.New("cannot transfer links to yourself")
+	ErrNoLinksToTransfer  = errors.New("no links selected for transfer")
+	ErrTransferNotAllowed = errors.New("not allowed to act on this transfer")
+)
+
+// Compile-time check: TransferServiceImpl implements TransferService
+var _ TransferService = (*TransferServiceImpl)(nil)
+
+type TransferServiceImpl struct {
+	transferRepo repository.LinkTransferRepository
+	linkRepo     repository.LinkRepository
+	userRepo     repository.UserRepository
+	domainRepo   repository.DomainRepository
+}
+
+func NewTransferService(transferRepo repository.LinkTransferRepository, linkRepo repository.LinkRepository, userRepo repository.UserRepository, domainRepo repository.DomainRepository) *TransferServiceImpl {
+	return &TransferServiceImpl{
+		transferRepo: transferRepo,
+		linkRepo:     linkRepo,
+		userRepo:     userRepo,
+		domainRepo:   domainRepo,
+	}
+}
+
+type CreateTransferInput struct {
+	RecipientEmail string   `json:"recipient_email" binding:"required,email"`
+	LinkIDs        []uint64 `json:"link_ids,omitempty"`
+	// AllLinks transfers every link the sender created in the workspace, as of
+	// the time the recipient accepts.
+	AllLinks bool `json:"all_links,omitempty"`
+}
+
+// SkippedLink is a link that stayed with the sender when a transfer was accepted.
+type SkippedLink struct {
+	LinkID uint64 `json:"link_id"`
+	Reason string `json:"reason"`
+}
+
+type AcceptTransferResult struct {
+	Transfer *model.LinkTransfer `json:"transfer"`
+	Links    []model.Link        `json:"links"`
+	Skipped  []SkippedLink       `json:"skipped"`
+}
+
+func (s *TransferServiceImpl) Create(ctx context.Context, actor Actor, input CreateTransferInput) (*model.LinkTransfer, error) {
+	if !input.AllLinks && len(input.LinkIDs) == 0 {
+		return nil, ErrNoLinksToTransfer
+	}
+	if !actor.Can(model.RoleEditor) {
+		return nil, ErrInsufficientRole
+	}
+
+	recipient, err := s.userRepo.GetByEmail(ctx, input.RecipientEmail)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return nil, ErrRecipientNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, "transfer-service: failed to get recipient",
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if recipient.ID == actor.UserID {
+		return nil, ErrSelfTransfer
+	}
+
+	transfer := &model.LinkTransfer{
+		FromUserID:      actor.UserID,
+		FromWorkspaceID: actor.WorkspaceID,
+		ToUserID:        recipient.ID,
+		AllLinks:        input.AllLinks,
+	}
+	if !input.AllLinks {
+		seen := make(map[uint64]bool, len(input.LinkIDs))
+		for _, linkID := range input.LinkIDs {
+			if seen[linkID] {
+				continue
+			}
+			seen[linkID] = true
+			link, err := s.linkRepo.GetByID(ctx, linkID)
+			if errors.Is(err, repository.ErrLinkNotFound) {
+				return nil, ErrLinkNotFound
+			}
+			if err != nil {
+				logger.Error(ctx, "transfer-service: failed to get link",
+					zap.Uint64("link_id", linkID),
+					zap.Error(err),
+				)
+				return nil, err
+			}
+			if link.WorkspaceID != actor.WorkspaceID {
+				return nil, ErrNotLinkOwner
+			}
+			// Giving away a teammate's link is for the workspace's admins
+			if link.UserID != actor.UserID && !actor.Can(model.RoleAdmin) {
+				return nil, ErrInsufficientRole
+			}
+			transfer.LinkIDs = append(transfer.LinkIDs, linkID)
+		}
+	}
+
+	if err := s.transferRepo.Create(ctx, transfer); err != nil {
+		logger.Error(ctx, "transfer-service: failed to create transfer",
+			zap.Uint64("user_id", actor.UserID),
+			zap.Uint64("recipient_id", recipient.ID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return transfer, nil
+}
+
+func (s *TransferServiceImpl) ListPending(ctx context.Context, userID uint64) ([]model.LinkTransfer, error) {
+	transfers, err := s.transferRepo.ListPendingByUserID(ctx, userID)
+	if err != nil {
+		logger.Error(ctx, "transfer-service: failed to list transfers",
+			zap.Uint64("user_id", userID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return transfers, nil
+}
+
+// Accept moves the transfer's links into the workspace the recipient accepts
+// it in. Links on a custom domain that workspace does not control stay with
+// the sender and are reported as skipped. Click history is keyed by link, so it moves with each link.
+func (s *TransferServiceImpl) Accept(ctx context.Context, actor Actor, transferID uint64) (*AcceptTransferResult, error) {
+	transfer, err := s.getPending(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.ToUserID != actor.UserID {
+		return nil, ErrTransferNotAllowed
+	}
+	if !actor.Can(model.RoleEditor) {
+		return nil, ErrInsufficientRole
+	}
+
+	candidates, err := s.transferCandidates(ctx, transfer)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &AcceptTransferResult{Transfer: transfer, Links: []model.Link{}, Skipped: []SkippedLink{}}
+	domainOwners := make(map[uint64]uint64)
+	var linkIDs []uint64
+	for _, link := range candidates {
+		if link.DomainID != nil {
+			owner, ok := domainOwners[*link.DomainID]
+			if !ok {
+				domain, err := s.domainRepo.GetByID(ctx, *link.DomainID)
+				if err != nil {
+					logger.Error(ctx, "transfer-service: failed to get link domain",
+						zap.Uint64("link_id", link.ID),
+						zap.Error(err),
+					)
+					return nil, err
+				}
+				owner = domain.WorkspaceID
+				domainOwners[*link.DomainID] = owner
+			}
+			if owner != actor.WorkspaceID {
+				result.Skipped = append(result.Skipped, SkippedLink{LinkID: link.ID, Reason: "recipient workspace does not control the link's domain"})
+				continue
+			}
+		}
+		linkIDs = append(linkIDs, link.ID)
+		link.UserID = actor.UserID
+		link.WorkspaceID = actor.WorkspaceID
+		link.CampaignID = nil
+		result.Links = append(result.Links, link)
+	}
+
+	// The transfer is claimed and the links moved together, so a transfer
+	// accepted, declined or cancelled concurrently moves nothing
+	if _, err := s.transferRepo.Accept(ctx, transfer, actor.WorkspaceID, actor.UserID, linkIDs); err != nil {
+		if errors.Is(err, repository.ErrTransferNotFound) {
+			return nil, ErrTransferNotFound
+		}
+		logger.Error(ctx, "transfer-service: failed to accept transfer",
+			zap.Uint64("transfer_id", transferID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	transfer.Status = model.TransferStatusAccepted
+	return result, nil
+}
+
+func (s *TransferServiceImpl) Decline(ctx context.Context, userID, transferID uint64) error {
+	transfer, err := s.getPending(ctx, transferID)
+	if err != nil {
+		return err
+	}
+	if transfer.ToUserID != userID {
+		return ErrTransferNotAllowed
+	}
+	return s.resolve(ctx, transfer, model.TransferStatusDeclined)
+}
+
+func (s *TransferServiceImpl) Cancel(ctx context.Context, userID, transferID uint64) error {
+	transfer, err := s.getPending(ctx, transferID)
+	if err != nil {
+		return err
+	}
+	if transfer.FromUserID != userID {
+		return ErrTransferNotAllowed
+	}
+	return s.resolve(ctx, transfer, model.TransferStatusCancelled)
+}
+
+func (s *TransferServiceImpl) getPending(ctx context.Context, transferID uint64) (*model.LinkTransfer, error) {
+	transfer, err := s.transferRepo.GetByID(ctx, transferID)
+	if errors.Is(err, repository.ErrTransferNotFound) {
+		return nil, ErrTransferNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, "transfer-service: failed to get transfer",
+			zap.Uint64("transfer_id", transferID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if transfer.Status != model.TransferStatusPending {
+		return nil, ErrTransferNotFound
+	}
+	return transfer, nil
+}
+
+// transferCandidates returns the links a transfer would move that are still
+// in the sender's workspace and not trashed; for all_links, only those the
+// sender created.
+func (s *TransferServiceImpl) transferCandidates(ctx context.Context, transfer *model.LinkTransfer) ([]model.Link, error) {
+	if transfer.AllLinks {
+		links, err := s.linkRepo.ListAllByWorkspaceID(ctx, transfer.FromWorkspaceID)
+		if err != nil {
+			return nil, err
+		}
+		var own []model.Link
+		for _, link := range links {
+			if link.UserID == transfer.FromUserID {
+				own = append(own, link)
+			}
+		}
+		return own, nil
+	}
+
+	links := make([]model.Link, 0, len(transfer.LinkIDs))
+	for _, linkID := range transfer.LinkIDs {
+		link, err := s.linkRepo.GetByID(ctx, linkID)
+		if errors.Is(err, repository.ErrLinkNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if link.WorkspaceID == transfer.FromWorkspaceID && !link.DeletedAt.Valid {
+			links = append(links, *link)
+		}
+	}
+	return links, nil
+}
+
+func (s *TransferServiceImpl) resolve(ctx context.Context, transfer *model.LinkTransfer, status string) error {
+	if err := s.transferRepo.Resolve(ctx, transfer.ID, status); err != nil {
+		if errors.Is(err, repository.ErrTransferNotFound) {
+			return ErrTransferNotFound
+		}
+		logger.Error(ctx, "transfer-service: failed to resolve transfer",
+			zap.Uint64("transfer_id", transfer.ID),
+			zap.String("status", status),
+			zap.Error(err),
+		)
+		return err
+	}
+	transfer.Status = status
+	return nil
+}
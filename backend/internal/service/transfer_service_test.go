@@ -0,0 +1,114 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/internal/repository"
+	"github.com/SeaCodeBase/urlshortener/internal/repository/mocks"
+	"github.com/SeaCodeBase/urlshortener/internal/service"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTransferService_Accept_SkipsLinksOnForeignDomains(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransferRepo := mocks.NewMockLinkTransferRepository(ctrl)
+	mockLinkRepo := mocks.NewMockLinkRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockDomainRepo := mocks.NewMockDomainRepository(ctrl)
+	svc := service.NewTransferService(mockTransferRepo, mockLinkRepo, mockUserRepo, mockDomainRepo)
+
+	sharedDomain, senderDomain := uint64(5), uint64(6)
+	campaignID := uint64(9)
+	mockTransferRepo.EXPECT().
+		GetByID(gomock.Any(), uint64(1)).
+		Return(&model.LinkTransfer{ID: 1, FromUserID: 10, FromWorkspaceID: 10, ToUserID: 20, AllLinks: true, Status: model.TransferStatusPending}, nil)
+	mockLinkRepo.EXPECT().
+		ListAllByWorkspaceID(gomock.Any(), uint64(10)).
+		Return([]model.Link{
+			{ID: 100, UserID: 10, WorkspaceID: 10, CampaignID: &campaignID},
+			{ID: 101, UserID: 10, WorkspaceID: 10, DomainID: &sharedDomain},
+			{ID: 102, UserID: 10, WorkspaceID: 10, DomainID: &senderDomain},
+			{ID: 103, UserID: 11, WorkspaceID: 10},
+		}, nil)
+	mockDomainRepo.EXPECT().GetByID(gomock.Any(), sharedDomain).Return(&model.Domain{ID: sharedDomain, UserID: 20, WorkspaceID: 20}, nil)
+	mockDomainRepo.EXPECT().GetByID(gomock.Any(), senderDomain).Return(&model.Domain{ID: senderDomain, UserID: 10, WorkspaceID: 10}, nil)
+	mockTransferRepo.EXPECT().
+		Accept(gomock.Any(), gomock.Any(), uint64(20), uint64(20), []uint64{100, 101}).
+		Return(int64(2), nil)
+
+	result, err := svc.Accept(context.Background(), service.Actor{UserID: 20, WorkspaceID: 20, Role: model.RoleOwner}, 1)
+	assert.NoError(t, err)
+	assert.Len(t, result.Links, 2)
+	assert.Nil(t, result.Links[0].CampaignID)
+	assert.Equal(t, uint64(20), result.Links[1].UserID)
+	assert.Equal(t, uint64(20), result.Links[1].WorkspaceID)
+	assert.Equal(t, []service.SkippedLink{{LinkID: 102, Reason: "recipient workspace does not control the link's domain"}}, result.Skipped)
+	assert.Equal(t, model.TransferStatusAccepted, result.Transfer.Status)
+}
+
+func TestTransferService_Accept_OnlyRecipient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransferRepo := mocks.NewMockLinkTransferRepository(ctrl)
+	svc := service.NewTransferService(mockTransferRepo, mocks.NewMockLinkRepository(ctrl), mocks.NewMockUserRepository(ctrl), mocks.NewMockDomainRepository(ctrl))
+
+	mockTransferRepo.EXPECT().
+		GetByID(gomock.Any(), uint64(1)).
+		Return(&model.LinkTransfer{ID: 1, FromUserID: 10, ToUserID: 20, Status: model.TransferStatusPending}, nil)
+
+	_, err := svc.Accept(context.Background(), service.Actor{UserID: 10, WorkspaceID: 10, Role: model.RoleOwner}, 1)
+	assert.ErrorIs(t, err, service.ErrTransferNotAllowed)
+}
+
+func TestTransferService_Create_TeammateLinksNeedAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransferRepo := mocks.NewMockLinkTransferRepository(ctrl)
+	mockLinkRepo := mocks.NewMockLinkRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	svc := service.NewTransferService(mockTransferRepo, mockLinkRepo, mockUserRepo, mocks.NewMockDomainRepository(ctrl))
+
+	mockUserRepo.EXPECT().GetByEmail(gomock.Any(), "to@example.com").Return(&model.User{ID: 20}, nil).Times(2)
+	mockLinkRepo.EXPECT().GetByID(gomock.Any(), uint64(100)).Return(&model.Link{ID: 100, UserID: 11, WorkspaceID: 10}, nil).Times(2)
+	input := service.CreateTransferInput{RecipientEmail: "to@example.com", LinkIDs: []uint64{100}}
+
+	_, err := svc.Create(context.Background(), service.Actor{UserID: 10, WorkspaceID: 10, Role: model.RoleEditor}, input)
+	assert.ErrorIs(t, err, service.ErrInsufficientRole)
+
+	mockTransferRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	transfer, err := svc.Create(context.Background(), service.Actor{UserID: 10, WorkspaceID: 10, Role: model.RoleAdmin}, input)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{100}, transfer.LinkIDs)
+}
+
+func TestTransferService_Accept_AlreadyResolved(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransferRepo := mocks.NewMockLinkTransferRepository(ctrl)
+	mockLinkRepo := mocks.NewMockLinkRepository(ctrl)
+	svc := service.NewTransferService(mockTransferRepo, mockLinkRepo, mocks.NewMockUserRepository(ctrl), mocks.NewMockDomainRepository(ctrl))
+
+	trashedAt := model.NullTime{NullTime: sql.NullTime{Time: time.Now(), Valid: true}}
+	mockTransferRepo.EXPECT().
+		GetByID(gomock.Any(), uint64(1)).
+		Return(&model.LinkTransfer{ID: 1, FromUserID: 10, FromWorkspaceID: 10, ToUserID: 20, LinkIDs: []uint64{100, 101}, Status: model.TransferStatusPending}, nil)
+	mockLinkRepo.EXPECT().GetByID(gomock.Any(), uint64(100)).Return(&model.Link{ID: 100, UserID: 10, WorkspaceID: 10}, nil)
+	mockLinkRepo.EXPECT().GetByID(gomock.Any(), uint64(101)).Return(&model.Link{ID: 101, UserID: 10, WorkspaceID: 10, DeletedAt: trashedAt}, nil)
+	// Trashed links stay behind; a transfer resolved in the meantime moves nothing
+	mockTransferRepo.EXPECT().
+		Accept(gomock.Any(), gomock.Any(), uint64(20), uint64(20), []uint64{100}).
+		Return(int64(0), repository.ErrTransferNotFound)
+
+	_, err := svc.Accept(context.Background(), service.Actor{UserID: 20, WorkspaceID: 20, Role: model.RoleOwner}, 1)
+	assert.ErrorIs(t, err, service.ErrTransferNotFound)
+}
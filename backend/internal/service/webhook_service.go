@@ -0,0 +1,374 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/internal/repository"
+	"github.com/SeaCodeBase/urlshortener/internal/util"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Headers sent with every webhook delivery. The signature is
+// "sha256=" + hex(HMAC-SHA256(secret, timestamp + "." + body)).
+const (
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+const (
+	// WebhookSecretPrefix starts every signing secret
+	WebhookSecretPrefix = "whsec_"
+	webhookSecretLen    = 32
+	// webhookDeliveryPageSize is how many deliveries the delivery log shows
+	webhookDeliveryPageSize = 100
+)
+
+var (
+	ErrWebhookNotFound     = errors.New("webhook not found")
+	ErrDeliveryNotFound    = errors.New("webhook delivery not found")
+	ErrInvalidWebhookURL   = errors.New("webhook url must be an absolute http or https url on a public host")
+	ErrUnknownWebhookEvent = errors.New("unknown webhook event")
+)
+
+// SignWebhook returns the signature header value for a delivery body sent
+// at timestamp (Unix seconds).
+func SignWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Compile-time check: WebhookServiceImpl implements WebhookService
+var _ WebhookService = (*WebhookServiceImpl)(nil)
+
+type WebhookServiceImpl struct {
+	webhookRepo  repository.WebhookRepository
+	deliveryRepo repository.WebhookDeliveryRepository
+	linkRepo     repository.LinkRepository
+}
+
+func NewWebhookService(webhookRepo repository.WebhookRepository, deliveryRepo repository.WebhookDeliveryRepository, linkRepo repository.LinkRepository) *WebhookServiceImpl {
+	return &WebhookServiceImpl{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		linkRepo:     linkRepo,
+	}
+}
+
+type CreateWebhookInput struct {
+	URL    string   `json:"url" binding:"required,max=2048"`
+	Events []string `json:"events" binding:"required,min=1"`
+}
+
+type UpdateWebhookInput struct {
+	URL      string   `json:"url,omitempty" binding:"max=2048"`
+	Events   []string `json:"events,omitempty"`
+	IsActive *bool    `json:"is_active,omitempty"`
+}
+
+// CreatedWebhook carries the signing secret, which is only shown at creation.
+type CreatedWebhook struct {
+	Webhook *model.Webhook `json:"webhook"`
+	Secret  string         `json:"secret"`
+}
+
+// Create registers a webhook for the actor on their current workspace.
+func (s *WebhookServiceImpl) Create(ctx context.Context, actor Actor, input CreateWebhookInput) (*CreatedWebhook, error) {
+	if err := validateWebhook(input.URL, input.Events); err != nil {
+		return nil, err
+	}
+
+	secret, err := (&RandomCodeGenerator{Alphabet: alphabet}).Generate(ctx, nil, webhookSecretLen)
+	if err != nil {
+		logger.Error(ctx, "webhook-service: failed to generate secret",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	webhook := &model.Webhook{
+		UserID:      actor.UserID,
+		WorkspaceID: actor.WorkspaceID,
+		URL:         input.URL,
+		Secret:      WebhookSecretPrefix + secret,
+		Events:      model.WebhookEvents(input.Events),
+		IsActive:    true,
+	}
+	if err := s.webhookRepo.Create(ctx, webhook); err != nil {
+		logger.Error(ctx, "webhook-service: failed to create webhook",
+			zap.Uint64("user_id", actor.UserID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return &CreatedWebhook{Webhook: webhook, Secret: webhook.Secret}, nil
+}
+
+func (s *WebhookServiceImpl) List(ctx context.Context, actor Actor) ([]model.Webhook, error) {
+	return s.webhookRepo.ListByUser(ctx, actor.UserID, actor.WorkspaceID)
+}
+
+func (s *WebhookServiceImpl) Update(ctx context.Context, actor Actor, id uint64, input UpdateWebhookInput) (*model.Webhook, error) {
+	webhook, err := s.get(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+	if input.URL != "" {
+		webhook.URL = input.URL
+	}
+	if input.Events != nil {
+		webhook.Events = model.WebhookEvents(input.Events)
+	}
+	if input.IsActive != nil {
+		webhook.IsActive = *input.IsActive
+	}
+	if err := validateWebhook(webhook.URL, webhook.Events); err != nil {
+		return nil, err
+	}
+
+	if err := s.webhookRepo.Update(ctx, webhook); err != nil {
+		if errors.Is(err, repository.ErrWebhookNotFound) {
+			return nil, ErrWebhookNotFound
+		}
+		logger.Error(ctx, "webhook-service: failed to update webhook",
+			zap.Uint64("webhook_id", id),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (s *WebhookServiceImpl) Delete(ctx context.Context, actor Actor, id uint64) error {
+	if _, err := s.get(ctx, actor, id); err != nil {
+		return err
+	}
+	if err := s.webhookRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrWebhookNotFound) {
+			return ErrWebhookNotFound
+		}
+		logger.Error(ctx, "webhook-service: failed to delete webhook",
+			zap.Uint64("webhook_id", id),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
+
+// ListDeliveries returns the webhook's most recent deliveries, newest first.
+func (s *WebhookServiceImpl) ListDeliveries(ctx context.Context, actor Actor, webhookID uint64) ([]model.WebhookDelivery, error) {
+	if _, err := s.get(ctx, actor, webhookID); err != nil {
+		return nil, err
+	}
+	return s.deliveryRepo.ListByWebhook(ctx, webhookID, webhookDeliveryPageSize)
+}
+
+// Replay queues a fresh delivery of an earlier delivery's payload, leaving
+// the original in the log.
+func (s *WebhookServiceImpl) Replay(ctx context.Context, actor Actor, webhookID, deliveryID uint64) (*model.WebhookDelivery, error) {
+	if _, err := s.get(ctx, actor, webhookID); err != nil {
+		return nil, err
+	}
+	original, err := s.deliveryRepo.GetByID(ctx, deliveryID)
+	if errors.Is(err, repository.ErrDeliveryNotFound) {
+		return nil, ErrDeliveryNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, "webhook-service: failed to get delivery",
+			zap.Uint64("delivery_id", deliveryID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if original.WebhookID != webhookID {
+		return nil, ErrDeliveryNotFound
+	}
+
+	delivery := newDelivery(webhookID, original.Event, original.Payload)
+	if err := s.deliveryRepo.Create(ctx, delivery); err != nil {
+		logger.Error(ctx, "webhook-service: failed to queue replay",
+			zap.Uint64("delivery_id", deliveryID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// EmitLink queues a link event for the webhooks on the link's workspace.
+func (s *WebhookServiceImpl) EmitLink(ctx context.Context, event string, link *model.Link) {
+	s.emit(ctx, link.WorkspaceID, event, link)
+}
+
+// EmitClicks queues one link.clicked delivery per subscribed webhook for a
+// batch of flushed clicks, each webhook getting the clicks on its workspace.
+func (s *WebhookServiceImpl) EmitClicks(ctx context.Context, clicks []model.Click) {
+	if len(clicks) == 0 {
+		return
+	}
+	seen := make(map[uint64]bool)
+	var linkIDs []uint64
+	for _, click := range clicks {
+		if !seen[click.LinkID] {
+			seen[click.LinkID] = true
+			linkIDs = append(linkIDs, click.LinkID)
+		}
+	}
+	links, err := s.linkRepo.GetByIDs(ctx, linkIDs)
+	if err != nil {
+		logger.Error(ctx, "webhook-service: failed to look up clicked links",
+			zap.Error(err),
+		)
+		return
+	}
+	workspaceOf := make(map[uint64]uint64, len(links))
+	for _, link := range links {
+		workspaceOf[link.ID] = link.WorkspaceID
+	}
+
+	byWorkspace := make(map[uint64][]model.Click)
+	var order []uint64
+	for _, click := range clicks {
+		workspaceID, ok := workspaceOf[click.LinkID]
+		if !ok {
+			continue
+		}
+		if _, ok := byWorkspace[workspaceID]; !ok {
+			order = append(order, workspaceID)
+		}
+		byWorkspace[workspaceID] = append(byWorkspace[workspaceID], click)
+	}
+	for _, workspaceID := range order {
+		s.emit(ctx, workspaceID, model.WebhookLinkClicked, map[string]any{"clicks": byWorkspace[workspaceID]})
+	}
+}
+
+// EmitExpired queues link.expired events for links whose expiry fell in
+// (from, to].
+func (s *WebhookServiceImpl) EmitExpired(ctx context.Context, from, to time.Time) error {
+	links, err := s.linkRepo.ListExpiredBetween(ctx, from, to)
+	if err != nil {
+		logger.Error(ctx, "webhook-service: failed to list expired links",
+			zap.Error(err),
+		)
+		return err
+	}
+	for i := range links {
+		s.EmitLink(ctx, model.WebhookLinkExpired, &links[i])
+	}
+	return nil
+}
+
+// emit queues a delivery of data for every webhook on the workspace that
+// subscribes to event. Failures are logged; they must not fail the change
+// that triggered the event.
+func (s *WebhookServiceImpl) emit(ctx context.Context, workspaceID uint64, event string, data any) {
+	webhooks, err := s.webhookRepo.ListSubscribed(ctx, workspaceID, event)
+	if err != nil {
+		logger.Error(ctx, "webhook-service: failed to list subscribed webhooks",
+			zap.Uint64("workspace_id", workspaceID),
+			zap.String("event", event),
+			zap.Error(err),
+		)
+		return
+	}
+	if len(webhooks) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(model.WebhookPayload{Event: event, CreatedAt: time.Now().UTC(), Data: data})
+	if err != nil {
+		logger.Error(ctx, "webhook-service: failed to encode payload",
+			zap.String("event", event),
+			zap.Error(err),
+		)
+		return
+	}
+	for _, webhook := range webhooks {
+		if err := s.deliveryRepo.Create(ctx, newDelivery(webhook.ID, event, payload)); err != nil {
+			logger.Error(ctx, "webhook-service: failed to queue delivery",
+				zap.Uint64("webhook_id", webhook.ID),
+				zap.String("event", event),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// get loads a webhook the actor owns on their current workspace.
+func (s *WebhookServiceImpl) get(ctx context.Context, actor Actor, id uint64) (*model.Webhook, error) {
+	webhook, err := s.webhookRepo.GetByID(ctx, id)
+	if errors.Is(err, repository.ErrWebhookNotFound) {
+		return nil, ErrWebhookNotFound
+	}
+	if err != nil {
+		logger.Error(ctx, "webhook-service: failed to get webhook",
+			zap.Uint64("webhook_id", id),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if webhook.UserID != actor.UserID || webhook.WorkspaceID != actor.WorkspaceID {
+		return nil, ErrWebhookNotFound
+	}
+	return webhook, nil
+}
+
+func newDelivery(webhookID uint64, event string, payload json.RawMessage) *model.WebhookDelivery {
+	return &model.WebhookDelivery{
+		WebhookID:     webhookID,
+		Event:         event,
+		Payload:       payload,
+		Status:        model.DeliveryPending,
+		NextAttemptAt: time.Now(),
+	}
+}
+
+func validateWebhook(rawURL string, events []string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return ErrInvalidWebhookURL
+	}
+	// Names are checked again after resolution when delivering; this only
+	// rejects receivers that are internal on their face
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrInvalidWebhookURL
+	}
+	if ip := net.ParseIP(host); ip != nil && !util.IsPublicIP(ip) {
+		return ErrInvalidWebhookURL
+	}
+	if len(events) == 0 {
+		return ErrUnknownWebhookEvent
+	}
+	for _, event := range events {
+		if !model.IsWebhookEvent(event) {
+			return ErrUnknownWebhookEvent
+		}
+	}
+	return nil
+}
+
+// emitLink queues a link event when webhooks are configured; services built
+// without a webhook service (as in most tests) skip it.
+func emitLink(ctx context.Context, webhooks WebhookService, event string, link *model.Link) {
+	if webhooks != nil {
+		webhooks.EmitLink(ctx, event, link)
+	}
+}
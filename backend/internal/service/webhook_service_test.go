@@ -0,0 +1,124 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/internal/repository/mocks"
+	"github.com/SeaCodeBase/urlshortener/internal/service"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWebhookService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhookRepo := mocks.NewMockWebhookRepository(ctrl)
+	svc := service.NewWebhookService(mockWebhookRepo, mocks.NewMockWebhookDeliveryRepository(ctrl), mocks.NewMockLinkRepository(ctrl))
+
+	for _, url := range []string{"ftp://example.com", "http://localhost:8080/hook", "http://127.0.0.1/hook", "http://10.0.0.5/hook", "http://[::1]/hook", "http://169.254.169.254/latest"} {
+		_, err := svc.Create(context.Background(), editor, service.CreateWebhookInput{URL: url, Events: []string{model.WebhookLinkCreated}})
+		assert.ErrorIs(t, err, service.ErrInvalidWebhookURL, url)
+	}
+	_, err := svc.Create(context.Background(), editor, service.CreateWebhookInput{URL: "https://example.com/hook", Events: []string{"link.exploded"}})
+	assert.ErrorIs(t, err, service.ErrUnknownWebhookEvent)
+
+	mockWebhookRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, webhook *model.Webhook) error {
+			assert.Equal(t, uint64(1), webhook.UserID)
+			assert.Equal(t, uint64(1), webhook.WorkspaceID)
+			assert.True(t, webhook.IsActive)
+			webhook.ID = 3
+			return nil
+		})
+
+	created, err := svc.Create(context.Background(), editor, service.CreateWebhookInput{
+		URL:    "https://example.com/hook",
+		Events: []string{model.WebhookLinkCreated, model.WebhookLinkClicked},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), created.Webhook.ID)
+	assert.True(t, strings.HasPrefix(created.Secret, service.WebhookSecretPrefix))
+	assert.Equal(t, created.Secret, created.Webhook.Secret)
+}
+
+func TestWebhookService_EmitClicks_BatchesPerWorkspace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhookRepo := mocks.NewMockWebhookRepository(ctrl)
+	mockDeliveryRepo := mocks.NewMockWebhookDeliveryRepository(ctrl)
+	mockLinkRepo := mocks.NewMockLinkRepository(ctrl)
+	svc := service.NewWebhookService(mockWebhookRepo, mockDeliveryRepo, mockLinkRepo)
+
+	mockLinkRepo.EXPECT().
+		GetByIDs(gomock.Any(), []uint64{10, 20}).
+		Return([]model.Link{{ID: 10, WorkspaceID: 1}, {ID: 20, WorkspaceID: 2}}, nil)
+	mockWebhookRepo.EXPECT().
+		ListSubscribed(gomock.Any(), uint64(1), model.WebhookLinkClicked).
+		Return([]model.Webhook{{ID: 7}}, nil)
+	mockWebhookRepo.EXPECT().
+		ListSubscribed(gomock.Any(), uint64(2), model.WebhookLinkClicked).
+		Return(nil, nil)
+	mockDeliveryRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, delivery *model.WebhookDelivery) error {
+			assert.Equal(t, uint64(7), delivery.WebhookID)
+			assert.Equal(t, model.DeliveryPending, delivery.Status)
+
+			var payload struct {
+				Event string `json:"event"`
+				Data  struct {
+					Clicks []model.Click `json:"clicks"`
+				} `json:"data"`
+			}
+			assert.NoError(t, json.Unmarshal(delivery.Payload, &payload))
+			assert.Equal(t, model.WebhookLinkClicked, payload.Event)
+			assert.Len(t, payload.Data.Clicks, 2)
+			return nil
+		})
+
+	svc.EmitClicks(context.Background(), []model.Click{{LinkID: 10}, {LinkID: 20}, {LinkID: 10}})
+}
+
+func TestWebhookService_Replay_OnlyOwnDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhookRepo := mocks.NewMockWebhookRepository(ctrl)
+	mockDeliveryRepo := mocks.NewMockWebhookDeliveryRepository(ctrl)
+	svc := service.NewWebhookService(mockWebhookRepo, mockDeliveryRepo, mocks.NewMockLinkRepository(ctrl))
+
+	mockWebhookRepo.EXPECT().
+		GetByID(gomock.Any(), uint64(7)).
+		Return(&model.Webhook{ID: 7, UserID: 1, WorkspaceID: 1}, nil).
+		Times(2)
+	mockDeliveryRepo.EXPECT().
+		GetByID(gomock.Any(), uint64(50)).
+		Return(&model.WebhookDelivery{ID: 50, WebhookID: 8}, nil)
+	mockDeliveryRepo.EXPECT().
+		GetByID(gomock.Any(), uint64(51)).
+		Return(&model.WebhookDelivery{ID: 51, WebhookID: 7, Event: model.WebhookLinkUpdated, Payload: []byte(`{}`),
+			Status: model.DeliveryFailed, Attempts: 10}, nil)
+	mockDeliveryRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, delivery *model.WebhookDelivery) error {
+			delivery.ID = 52
+			return nil
+		})
+
+	_, err := svc.Replay(context.Background(), editor, 7, 50)
+	assert.ErrorIs(t, err, service.ErrDeliveryNotFound)
+
+	replayed, err := svc.Replay(context.Background(), editor, 7, 51)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(52), replayed.ID)
+	assert.Equal(t, model.DeliveryPending, replayed.Status)
+	assert.Equal(t, 0, replayed.Attempts)
+	assert.Equal(t, model.WebhookLinkUpdated, replayed.Event)
+}
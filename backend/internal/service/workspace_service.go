@@ -0,0 +1,379 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/internal/repository"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	// invitationTTL is how long an invitation token can be accepted
+	invitationTTL = 7 * 24 * time.Hour
+	// invitationTokenLen is the number of random base62 characters in an invitation token
+	invitationTokenLen = 40
+)
+
+var (
+	ErrWorkspaceNotFound  = errors.New("workspace not found")
+	ErrInsufficientRole   = errors.New("your workspace role does not allow this")
+	ErrInvalidRole        = errors.New("role must be owner, admin, editor or viewer")
+	ErrPersonalWorkspace  = errors.New("personal workspaces cannot have other members")
+	ErrLastOwner          = errors.New("a workspace needs at least one owner")
+	ErrMemberNotFound     = errors.New("workspace member not found")
+	ErrInvitationNotFound = errors.New("invitation not found or expired")
+	ErrInvitationEmail    = errors.New("invitation was sent to a different email address")
+)
+
+// Actor is the user a request acts for, inside the workspace it targets.
+// Workspace-owned resources are only visible to actors in their workspace.
+type Actor struct {
+	UserID      uint64
+	WorkspaceID uint64
+	Role        string
+}
+
+// Can reports whether the actor's role grants at least role.
+func (a Actor) Can(role string) bool {
+	return model.RoleAtLeast(a.Role, role)
+}
+
+// Compile-time check: WorkspaceServiceImpl implements WorkspaceService
+var _ WorkspaceService = (*WorkspaceServiceImpl)(nil)
+
+type WorkspaceServiceImpl struct {
+	workspaceRepo  repository.WorkspaceRepository
+	invitationRepo repository.WorkspaceInvitationRepository
+	userRepo       repository.UserRepository
+}
+
+func NewWorkspaceService(workspaceRepo repository.WorkspaceRepository, invitationRepo repository.WorkspaceInvitationRepository, userRepo repository.UserRepository) *WorkspaceServiceImpl {
+	return &WorkspaceServiceImpl{
+		workspaceRepo:  workspaceRepo,
+		invitationRepo: invitationRepo,
+		userRepo:       userRepo,
+	}
+}
+
+type CreateWorkspaceInput struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+type InviteMemberInput struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"required"`
+}
+
+// CreatedInvitation carries the plaintext token, which is only available at creation.
+type CreatedInvitation struct {
+	Invitation *model.WorkspaceInvitation `json:"invitation"`
+	Token      string                     `json:"token"`
+}
+
+func (s *WorkspaceServiceImpl) Resolve(ctx context.Context, userID, workspaceID uint64) (Actor, error) {
+	if workspaceID == 0 {
+		personal, err := s.personal(ctx, userID)
+		if err != nil {
+			return Actor{}, err
+		}
+		workspaceID = personal.ID
+	}
+	member, err := s.member(ctx, userID, workspaceID)
+	if err != nil {
+		return Actor{}, err
+	}
+	return Actor{UserID: userID, WorkspaceID: workspaceID, Role: member.Role}, nil
+}
+
+func (s *WorkspaceServiceImpl) List(ctx context.Context, userID uint64) ([]model.Workspace, error) {
+	if _, err := s.personal(ctx, userID); err != nil {
+		return nil, err
+	}
+	return s.workspaceRepo.ListByUserID(ctx, userID)
+}
+
+func (s *WorkspaceServiceImpl) Create(ctx context.Context, userID uint64, input CreateWorkspaceInput) (*model.Workspace, error) {
+	workspace := &model.Workspace{Name: strings.TrimSpace(input.Name)}
+	if err := s.workspaceRepo.Create(ctx, workspace, userID); err != nil {
+		return nil, err
+	}
+	workspace.Role = model.RoleOwner
+	return workspace, nil
+}
+
+func (s *WorkspaceServiceImpl) Rename(ctx context.Context, userID, workspaceID uint64, input CreateWorkspaceInput) (*model.Workspace, error) {
+	member, err := s.member(ctx, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !model.RoleAtLeast(member.Role, model.RoleAdmin) {
+		return nil, ErrInsufficientRole
+	}
+	if err := s.workspaceRepo.UpdateName(ctx, workspaceID, strings.TrimSpace(input.Name)); err != nil {
+		return nil, err
+	}
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	workspace.Role = member.Role
+	return workspace, nil
+}
+
+func (s *WorkspaceServiceImpl) ListMembers(ctx context.Context, userID, workspaceID uint64) ([]model.WorkspaceMember, error) {
+	if _, err := s.member(ctx, userID, workspaceID); err != nil {
+		return nil, err
+	}
+	return s.workspaceRepo.ListMembers(ctx, workspaceID)
+}
+
+// UpdateMemberRole changes a member's role. Admins manage editors and
+// viewers; only owners may grant or take away the admin and owner roles.
+func (s *WorkspaceServiceImpl) UpdateMemberRole(ctx context.Context, userID, workspaceID, memberID uint64, role string) (*model.WorkspaceMember, error) {
+	if !model.IsRole(role) {
+		return nil, ErrInvalidRole
+	}
+	actor, err := s.member(ctx, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.targetMember(ctx, workspaceID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage(actor.Role, target.Role) || !canManage(actor.Role, role) {
+		return nil, ErrInsufficientRole
+	}
+	if target.Role == model.RoleOwner && role != model.RoleOwner {
+		if err := s.keepAnOwner(ctx, workspaceID); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.workspaceRepo.UpdateMemberRole(ctx, workspaceID, memberID, role); err != nil {
+		return nil, err
+	}
+	target.Role = role
+	return target, nil
+}
+
+// RemoveMember removes a member; any member may remove themselves.
+func (s *WorkspaceServiceImpl) RemoveMember(ctx context.Context, userID, workspaceID, memberID uint64) error {
+	actor, err := s.member(ctx, userID, workspaceID)
+	if err != nil {
+		return err
+	}
+	target, err := s.targetMember(ctx, workspaceID, memberID)
+	if err != nil {
+		return err
+	}
+	if memberID != userID && !canManage(actor.Role, target.Role) {
+		return ErrInsufficientRole
+	}
+	if target.Role == model.RoleOwner {
+		if err := s.keepAnOwner(ctx, workspaceID); err != nil {
+			return err
+		}
+	}
+	if err := s.workspaceRepo.RemoveMember(ctx, workspaceID, memberID); err != nil {
+		if errors.Is(err, repository.ErrWorkspaceMemberNotFound) {
+			return ErrMemberNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *WorkspaceServiceImpl) Invite(ctx context.Context, userID, workspaceID uint64, input InviteMemberInput) (*CreatedInvitation, error) {
+	if !model.IsRole(input.Role) {
+		return nil, ErrInvalidRole
+	}
+	actor, err := s.member(ctx, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage(actor.Role, input.Role) {
+		return nil, ErrInsufficientRole
+	}
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if workspace.IsPersonal() {
+		return nil, ErrPersonalWorkspace
+	}
+
+	token, err := (&RandomCodeGenerator{Alphabet: alphabet}).Generate(ctx, nil, invitationTokenLen)
+	if err != nil {
+		logger.Error(ctx, "workspace-service: failed to generate invitation token",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	invitation := &model.WorkspaceInvitation{
+		WorkspaceID: workspaceID,
+		Email:       strings.TrimSpace(input.Email),
+		Role:        input.Role,
+		TokenHash:   hashSecret(token),
+		InvitedBy:   &userID,
+		ExpiresAt:   time.Now().Add(invitationTTL),
+	}
+	if err := s.invitationRepo.Create(ctx, invitation); err != nil {
+		return nil, err
+	}
+	return &CreatedInvitation{Invitation: invitation, Token: token}, nil
+}
+
+func (s *WorkspaceServiceImpl) ListInvitations(ctx context.Context, userID, workspaceID uint64) ([]model.WorkspaceInvitation, error) {
+	actor, err := s.member(ctx, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !model.RoleAtLeast(actor.Role, model.RoleAdmin) {
+		return nil, ErrInsufficientRole
+	}
+	return s.invitationRepo.ListPendingByWorkspaceID(ctx, workspaceID)
+}
+
+func (s *WorkspaceServiceImpl) RevokeInvitation(ctx context.Context, userID, workspaceID, invitationID uint64) error {
+	actor, err := s.member(ctx, userID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if !model.RoleAtLeast(actor.Role, model.RoleAdmin) {
+		return ErrInsufficientRole
+	}
+	invitation, err := s.invitationRepo.GetByID(ctx, invitationID)
+	if errors.Is(err, repository.ErrInvitationNotFound) {
+		return ErrInvitationNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if invitation.WorkspaceID != workspaceID {
+		return ErrInvitationNotFound
+	}
+	if err := s.invitationRepo.Delete(ctx, invitationID); err != nil {
+		if errors.Is(err, repository.ErrInvitationNotFound) {
+			return ErrInvitationNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// AcceptInvitation adds the user to the invitation's workspace. The user must
+// be signed in with the address the invitation was sent to.
+func (s *WorkspaceServiceImpl) AcceptInvitation(ctx context.Context, userID uint64, token string) (*model.Workspace, error) {
+	invitation, err := s.invitationRepo.GetByTokenHash(ctx, hashSecret(token))
+	if errors.Is(err, repository.ErrInvitationNotFound) {
+		return nil, ErrInvitationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if invitation.AcceptedAt != nil || !invitation.ExpiresAt.After(time.Now()) {
+		return nil, ErrInvitationNotFound
+	}
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		logger.Error(ctx, "workspace-service: failed to get user accepting invitation",
+			zap.Uint64("user_id", userID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if !strings.EqualFold(user.Email, invitation.Email) {
+		return nil, ErrInvitationEmail
+	}
+
+	if err := s.invitationRepo.Accept(ctx, invitation.ID, userID); err != nil {
+		if errors.Is(err, repository.ErrInvitationNotFound) {
+			return nil, ErrInvitationNotFound
+		}
+		return nil, err
+	}
+	workspace, err := s.workspaceRepo.GetByID(ctx, invitation.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	member, err := s.workspaceRepo.GetMember(ctx, invitation.WorkspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	workspace.Role = member.Role
+	return workspace, nil
+}
+
+// personal returns the user's personal workspace, creating it on first use.
+func (s *WorkspaceServiceImpl) personal(ctx context.Context, userID uint64) (*model.Workspace, error) {
+	workspace, err := s.workspaceRepo.GetPersonal(ctx, userID)
+	if !errors.Is(err, repository.ErrWorkspaceNotFound) {
+		return workspace, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		logger.Error(ctx, "workspace-service: failed to get user for personal workspace",
+			zap.Uint64("user_id", userID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	name := user.Email
+	if user.DisplayName != nil && *user.DisplayName != "" {
+		name = *user.DisplayName
+	}
+	workspace = &model.Workspace{Name: name, PersonalUserID: &userID}
+	err = s.workspaceRepo.Create(ctx, workspace, userID)
+	if errors.Is(err, repository.ErrPersonalWorkspaceExists) {
+		// A concurrent request created it first
+		return s.workspaceRepo.GetPersonal(ctx, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return workspace, nil
+}
+
+// member returns the user's membership; non-members get ErrWorkspaceNotFound
+// so workspaces they do not belong to stay invisible.
+func (s *WorkspaceServiceImpl) member(ctx context.Context, userID, workspaceID uint64) (*model.WorkspaceMember, error) {
+	member, err := s.workspaceRepo.GetMember(ctx, workspaceID, userID)
+	if errors.Is(err, repository.ErrWorkspaceMemberNotFound) {
+		return nil, ErrWorkspaceNotFound
+	}
+	return member, err
+}
+
+func (s *WorkspaceServiceImpl) targetMember(ctx context.Context, workspaceID, memberID uint64) (*model.WorkspaceMember, error) {
+	member, err := s.workspaceRepo.GetMember(ctx, workspaceID, memberID)
+	if errors.Is(err, repository.ErrWorkspaceMemberNotFound) {
+		return nil, ErrMemberNotFound
+	}
+	return member, err
+}
+
+func (s *WorkspaceServiceImpl) keepAnOwner(ctx context.Context, workspaceID uint64) error {
+	owners, err := s.workspaceRepo.CountOwners(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return ErrLastOwner
+	}
+	return nil
+}
+
+// canManage reports whether a member with role actor may hand out or take
+// away role target: admins manage editors and viewers, owners everyone.
+func canManage(actor, target string) bool {
+	if target == model.RoleOwner || target == model.RoleAdmin {
+		return actor == model.RoleOwner
+	}
+	return model.RoleAtLeast(actor, model.RoleAdmin)
+}
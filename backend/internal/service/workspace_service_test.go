@@ -0,0 +1,146 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/internal/repository"
+	"github.com/SeaCodeBase/urlshortener/internal/repository/mocks"
+	"github.com/SeaCodeBase/urlshortener/internal/service"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWorkspaceService_Resolve_CreatesPersonalWorkspace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	svc := service.NewWorkspaceService(mockWorkspaceRepo, mocks.NewMockWorkspaceInvitationRepository(ctrl), mockUserRepo)
+
+	mockWorkspaceRepo.EXPECT().GetPersonal(gomock.Any(), uint64(7)).Return(nil, repository.ErrWorkspaceNotFound)
+	mockUserRepo.EXPECT().GetByID(gomock.Any(), uint64(7)).Return(&model.User{ID: 7, Email: "ana@example.com"}, nil)
+	mockWorkspaceRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any(), uint64(7)).
+		DoAndReturn(func(ctx context.Context, ws *model.Workspace, ownerID uint64) error {
+			assert.Equal(t, "ana@example.com", ws.Name)
+			assert.True(t, ws.IsPersonal())
+			ws.ID = 3
+			return nil
+		})
+	mockWorkspaceRepo.EXPECT().
+		GetMember(gomock.Any(), uint64(3), uint64(7)).
+		Return(&model.WorkspaceMember{WorkspaceID: 3, UserID: 7, Role: model.RoleOwner}, nil)
+
+	actor, err := svc.Resolve(context.Background(), 7, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, service.Actor{UserID: 7, WorkspaceID: 3, Role: model.RoleOwner}, actor)
+
+	// Non-members cannot tell the workspace exists
+	mockWorkspaceRepo.EXPECT().GetMember(gomock.Any(), uint64(4), uint64(7)).Return(nil, repository.ErrWorkspaceMemberNotFound)
+	_, err = svc.Resolve(context.Background(), 7, 4)
+	assert.ErrorIs(t, err, service.ErrWorkspaceNotFound)
+}
+
+func TestWorkspaceService_UpdateMemberRole(t *testing.T) {
+	tests := []struct {
+		name      string
+		actorRole string
+		current   string
+		role      string
+		owners    int
+		err       error
+	}{
+		{"admin promotes viewer to editor", model.RoleAdmin, model.RoleViewer, model.RoleEditor, 0, nil},
+		{"admin cannot grant admin", model.RoleAdmin, model.RoleEditor, model.RoleAdmin, 0, service.ErrInsufficientRole},
+		{"admin cannot demote an owner", model.RoleAdmin, model.RoleOwner, model.RoleEditor, 0, service.ErrInsufficientRole},
+		{"editor cannot manage members", model.RoleEditor, model.RoleViewer, model.RoleEditor, 0, service.ErrInsufficientRole},
+		{"owner demotes another owner", model.RoleOwner, model.RoleOwner, model.RoleAdmin, 2, nil},
+		{"last owner stays", model.RoleOwner, model.RoleOwner, model.RoleAdmin, 1, service.ErrLastOwner},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+			svc := service.NewWorkspaceService(mockWorkspaceRepo, mocks.NewMockWorkspaceInvitationRepository(ctrl), mocks.NewMockUserRepository(ctrl))
+
+			mockWorkspaceRepo.EXPECT().GetMember(gomock.Any(), uint64(1), uint64(10)).Return(&model.WorkspaceMember{UserID: 10, Role: tt.actorRole}, nil)
+			mockWorkspaceRepo.EXPECT().GetMember(gomock.Any(), uint64(1), uint64(20)).Return(&model.WorkspaceMember{UserID: 20, Role: tt.current}, nil)
+			if tt.owners > 0 {
+				mockWorkspaceRepo.EXPECT().CountOwners(gomock.Any(), uint64(1)).Return(tt.owners, nil)
+			}
+			if tt.err == nil {
+				mockWorkspaceRepo.EXPECT().UpdateMemberRole(gomock.Any(), uint64(1), uint64(20), tt.role).Return(nil)
+			}
+
+			member, err := svc.UpdateMemberRole(context.Background(), 10, 1, 20, tt.role)
+			assert.ErrorIs(t, err, tt.err)
+			if tt.err == nil {
+				assert.Equal(t, tt.role, member.Role)
+			}
+		})
+	}
+}
+
+func TestWorkspaceService_Invite_NotInPersonalWorkspace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	svc := service.NewWorkspaceService(mockWorkspaceRepo, mocks.NewMockWorkspaceInvitationRepository(ctrl), mocks.NewMockUserRepository(ctrl))
+
+	owner := uint64(10)
+	mockWorkspaceRepo.EXPECT().GetMember(gomock.Any(), uint64(1), owner).Return(&model.WorkspaceMember{UserID: owner, Role: model.RoleOwner}, nil)
+	mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), uint64(1)).Return(&model.Workspace{ID: 1, PersonalUserID: &owner}, nil)
+
+	_, err := svc.Invite(context.Background(), owner, 1, service.InviteMemberInput{Email: "bo@example.com", Role: model.RoleEditor})
+	assert.ErrorIs(t, err, service.ErrPersonalWorkspace)
+}
+
+func TestWorkspaceService_InviteAndAccept(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockInvitationRepo := mocks.NewMockWorkspaceInvitationRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	svc := service.NewWorkspaceService(mockWorkspaceRepo, mockInvitationRepo, mockUserRepo)
+
+	var stored *model.WorkspaceInvitation
+	mockWorkspaceRepo.EXPECT().GetMember(gomock.Any(), uint64(1), uint64(10)).Return(&model.WorkspaceMember{UserID: 10, Role: model.RoleAdmin}, nil)
+	mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), uint64(1)).Return(&model.Workspace{ID: 1, Name: "Marketing"}, nil).Times(2)
+	mockInvitationRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, invitation *model.WorkspaceInvitation) error {
+			invitation.ID = 5
+			stored = invitation
+			return nil
+		})
+
+	created, err := svc.Invite(context.Background(), 10, 1, service.InviteMemberInput{Email: "Bo@example.com", Role: model.RoleEditor})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, created.Token)
+	assert.NotEqual(t, created.Token, stored.TokenHash)
+	assert.True(t, stored.ExpiresAt.After(time.Now()))
+
+	mockInvitationRepo.EXPECT().GetByTokenHash(gomock.Any(), stored.TokenHash).Return(stored, nil).Times(2)
+
+	// Only the invited address may accept
+	mockUserRepo.EXPECT().GetByID(gomock.Any(), uint64(30)).Return(&model.User{ID: 30, Email: "eve@example.com"}, nil)
+	_, err = svc.AcceptInvitation(context.Background(), 30, created.Token)
+	assert.ErrorIs(t, err, service.ErrInvitationEmail)
+
+	mockUserRepo.EXPECT().GetByID(gomock.Any(), uint64(20)).Return(&model.User{ID: 20, Email: "bo@example.com"}, nil)
+	mockInvitationRepo.EXPECT().Accept(gomock.Any(), uint64(5), uint64(20)).Return(nil)
+	mockWorkspaceRepo.EXPECT().GetMember(gomock.Any(), uint64(1), uint64(20)).Return(&model.WorkspaceMember{UserID: 20, Role: model.RoleEditor}, nil)
+
+	workspace, err := svc.AcceptInvitation(context.Background(), 20, created.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, model.RoleEditor, workspace.Role)
+}
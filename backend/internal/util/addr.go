@@ -0,0 +1,33 @@
+package util
+
+import (
+	"errors"
+	"net"
+	"syscall"
+)
+
+// ErrNonPublicAddress is returned when connecting to an address that is not
+// on the public internet.
+var ErrNonPublicAddress = errors.New("address is not public")
+
+// IsPublicIP reports whether ip is reachable on the public internet, i.e.
+// not loopback, private, link-local, multicast or unspecified.
+func IsPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified())
+}
+
+// PublicDialControl is a net.Dialer Control function refusing connections to
+// non-public addresses. It runs after DNS resolution, so names pointing at
+// internal hosts are caught as well as literal addresses.
+func PublicDialControl(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !IsPublicIP(ip) {
+		return ErrNonPublicAddress
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package util
+
+import (
+	"errors"
+	"net"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// domainProfile converts internationalized names to their ASCII (punycode)
+// form, lowercasing them and enforcing DNS label rules on the way.
+var domainProfile = idna.New(
+	idna.MapForLookup(),
+	idna.BidiRule(),
+	idna.ValidateLabels(true),
+	idna.StrictDomainName(true),
+	idna.VerifyDNSLength(true),
+)
+
+// NormalizeDomain validates a bare domain name such as "go.example.com" and
+// returns its canonical form: lowercase ASCII with IDN labels in punycode
+// and no trailing dot. Schemes, paths, ports, IP addresses and single-label
+// names are rejected.
+func NormalizeDomain(raw string) (string, error) {
+	name := strings.TrimSpace(raw)
+	switch {
+	case name == "":
+		return "", errors.New("domain is empty")
+	case strings.Contains(name, "://"):
+		return "", errors.New("domain must not include a scheme")
+	case strings.ContainsAny(name, "/?#"):
+		return "", errors.New("domain must not include a path")
+	case strings.Contains(name, "@"):
+		return "", errors.New("domain must not include user info")
+	case strings.Contains(name, ":"):
+		return "", errors.New("domain must not include a port")
+	}
+
+	name = strings.TrimSuffix(name, ".")
+	if net.ParseIP(name) != nil {
+		return "", errors.New("domain must be a name, not an IP address")
+	}
+	ascii, err := domainProfile.ToASCII(name)
+	if err != nil {
+		return "", errors.New("domain has an invalid label")
+	}
+	labels := strings.Split(ascii, ".")
+	if len(labels) < 2 {
+		return "", errors.New("domain must have at least two labels")
+	}
+	// A numeric top-level label would make the name look like an IPv4 address
+	if strings.Trim(labels[len(labels)-1], "0123456789") == "" {
+		return "", errors.New("domain has a numeric top-level label")
+	}
+	return ascii, nil
+}
+
+// NormalizeHost brings a request Host header into the form NormalizeDomain
+// stores: the port and any trailing dot are dropped, the name is lowercased
+// and IDN labels are converted to punycode. Hosts that are not valid domain
+// names are returned lowercased and otherwise unchanged, so they simply fail
+// to match a domain.
+func NormalizeHost(host string) string {
+	host = strings.TrimSpace(host)
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(host, ".")
+	if ascii, err := domainProfile.ToASCII(host); err == nil {
+		return ascii
+	}
+	return strings.ToLower(host)
+}
+
+// IsSameOrSubdomain reports whether name is parent or a subdomain of it.
+// Both must already be normalized.
+func IsSameOrSubdomain(name, parent string) bool {
+	return name == parent || strings.HasSuffix(name, "."+parent)
+}
+
+// wildcardPrefix starts a domain pattern that matches any single label in
+// its place, such as *.links.example.com.
+const wildcardPrefix = "*."
+
+// NormalizeDomainPattern is NormalizeDomain for names that may also be a
+// wildcard pattern: a leading "*." label followed by a name of at least two
+// labels.
+func NormalizeDomainPattern(raw string) (string, error) {
+	name := strings.TrimSpace(raw)
+	if !strings.HasPrefix(name, wildcardPrefix) {
+		return NormalizeDomain(name)
+	}
+	parent, err := NormalizeDomain(strings.TrimPrefix(name, wildcardPrefix))
+	if err != nil {
+		return "", err
+	}
+	return wildcardPrefix + parent, nil
+}
+
+// IsWildcardPattern reports whether a normalized domain pattern is a wildcard.
+func IsWildcardPattern(pattern string) bool {
+	return strings.HasPrefix(pattern, wildcardPrefix)
+}
+
+// WildcardParent returns the name under a wildcard pattern's "*." label, or
+// the name itself when it is not a wildcard.
+func WildcardParent(pattern string) string {
+	return strings.TrimPrefix(pattern, wildcardPrefix)
+}
+
+// HostPatterns returns the patterns that can match a normalized host, most
+// specific first: the host itself and the wildcard one label up.
+func HostPatterns(host string) []string {
+	patterns := []string{host}
+	if i := strings.IndexByte(host, '.'); i > 0 && strings.Contains(host[i+1:], ".") {
+		patterns = append(patterns, wildcardPrefix+host[i+1:])
+	}
+	return patterns
+}
+
+// MatchesDomainPattern reports whether a normalized host matches a pattern.
+// A wildcard stands for exactly one label.
+func MatchesDomainPattern(pattern, host string) bool {
+	for _, p := range HostPatterns(host) {
+		if p == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+const maxPathPrefixLength = 64
+
+var pathPrefixPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*(/[a-z0-9][a-z0-9_-]*){0,2}$`)
+
+// NormalizePathPrefix validates the path a domain's codes live under, such
+// as "go" for example.com/go/<code>, and returns it lowercased without
+// surrounding slashes. It may have up to three segments of letters, digits,
+// "-" and "_". An empty prefix stays empty.
+func NormalizePathPrefix(raw string) (string, error) {
+	prefix := strings.ToLower(strings.Trim(strings.TrimSpace(raw), "/"))
+	if prefix == "" {
+		return "", nil
+	}
+	if len(prefix) > maxPathPrefixLength {
+		return "", errors.New("path prefix is too long")
+	}
+	if !pathPrefixPattern.MatchString(prefix) {
+		return "", errors.New("path prefix must be up to three segments of letters, digits, - and _")
+	}
+	return prefix, nil
+}
@@ -0,0 +1,135 @@
+package util
+
+import "testing"
+
+func TestNormalizeDomain(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{"lowercases", "Go.Example.COM", "go.example.com", false},
+		{"strips trailing dot", "go.example.com.", "go.example.com", false},
+		{"trims whitespace", "  go.example.com ", "go.example.com", false},
+		{"converts IDN to punycode", "bücher.example", "xn--bcher-kva.example", false},
+		{"keeps punycode", "xn--bcher-kva.example", "xn--bcher-kva.example", false},
+		{"rejects scheme", "https://go.example.com", "", true},
+		{"rejects path", "go.example.com/", "", true},
+		{"rejects port", "go.example.com:8080", "", true},
+		{"rejects user info", "me@go.example.com", "", true},
+		{"rejects single label", "localhost", "", true},
+		{"rejects IPv4", "192.168.1.1", "", true},
+		{"rejects numeric TLD", "example.123", "", true},
+		{"rejects underscore", "go_links.example.com", "", true},
+		{"rejects leading hyphen", "-go.example.com", "", true},
+		{"rejects empty label", "go..example.com", "", true},
+		{"rejects long label", "a123456789012345678901234567890123456789012345678901234567890123.example.com", "", true},
+		{"rejects empty", " ", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeDomain(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeDomain(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeDomain(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeHost(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"go.example.com", "go.example.com"},
+		{"Go.Example.com.:8080", "go.example.com"},
+		{"BÜCHER.example", "xn--bcher-kva.example"},
+		{"[::1]:8080", "::1"},
+		{"localhost:8080", "localhost"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeHost(tt.host); got != tt.want {
+			t.Errorf("NormalizeHost(%q) = %q, want %q", tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeDomainPattern(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{"go.example.com", "go.example.com", false},
+		{"*.Links.Example.com", "*.links.example.com", false},
+		{"*.bücher.example", "*.xn--bcher-kva.example", false},
+		{"*.com", "", true},
+		{"*.*.example.com", "", true},
+		{"go.*.example.com", "", true},
+		{"*example.com", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeDomainPattern(tt.raw)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("NormalizeDomainPattern(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("NormalizeDomainPattern(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestMatchesDomainPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		host    string
+		want    bool
+	}{
+		{"go.example.com", "go.example.com", true},
+		{"*.links.example.com", "team.links.example.com", true},
+		{"*.links.example.com", "links.example.com", false},
+		{"*.links.example.com", "a.team.links.example.com", false},
+		{"*.example.com", "example.com", false},
+	}
+
+	for _, tt := range tests {
+		if got := MatchesDomainPattern(tt.pattern, tt.host); got != tt.want {
+			t.Errorf("MatchesDomainPattern(%q, %q) = %v, want %v", tt.pattern, tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizePathPrefix(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{"", "", false},
+		{"/go/", "go", false},
+		{"Go/Team-1", "go/team-1", false},
+		{"a/b/c", "a/b/c", false},
+		{"a/b/c/d", "", true},
+		{"go//x", "", true},
+		{"go.x", "", true},
+		{"-go", "", true},
+		{"go?x", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizePathPrefix(tt.raw)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("NormalizePathPrefix(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("NormalizePathPrefix(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
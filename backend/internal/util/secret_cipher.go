@@ -0,0 +1,55 @@
+package util
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+)
+
+// SecretCipher encrypts secrets stored in the database, such as private
+// keys, with AES-256-GCM. Each ciphertext carries its own random nonce.
+type SecretCipher struct {
+	aead cipher.AEAD
+}
+
+// NewSecretCipher derives the encryption key from secret, which may be of
+// any length.
+func NewSecretCipher(secret string) (*SecretCipher, error) {
+	if secret == "" {
+		return nil, errors.New("encryption secret is empty")
+	}
+	key := sha256.Sum256([]byte(secret))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &SecretCipher{aead: aead}, nil
+}
+
+// Encrypt returns the nonce followed by the sealed plaintext.
+func (c *SecretCipher) Encrypt(plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return c.aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// Decrypt opens a ciphertext made by Encrypt with the same secret.
+func (c *SecretCipher) Decrypt(ciphertext []byte) ([]byte, error) {
+	size := c.aead.NonceSize()
+	if len(ciphertext) < size {
+		return nil, errors.New("ciphertext is too short")
+	}
+	plaintext, err := c.aead.Open(nil, ciphertext[:size], ciphertext[size:], nil)
+	if err != nil {
+		return nil, errors.New("ciphertext cannot be decrypted with this secret")
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,75 @@
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// trackingParams are query parameters that identify where a click came from
+// rather than what it points to.
+var trackingParams = map[string]bool{
+	"fbclid":  true,
+	"gclid":   true,
+	"dclid":   true,
+	"msclkid": true,
+	"mc_cid":  true,
+	"mc_eid":  true,
+	"igshid":  true,
+	"yclid":   true,
+	"_ga":     true,
+}
+
+// NormalizeURL returns a canonical form of a destination URL for comparing
+// links: scheme and host are lowercased, default ports are stripped, an empty
+// path becomes "/" and query parameters are sorted. With ignoreTracking,
+// utm_* and common click-ID parameters are dropped as well.
+// Unparseable input is returned unchanged.
+func NormalizeURL(raw string, ignoreTracking bool) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return raw
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+	port := u.Port()
+	if (u.Scheme == "http" && port == "80") || (u.Scheme == "https" && port == "443") {
+		port = ""
+	}
+	if port != "" {
+		u.Host = net.JoinHostPort(host, port)
+	} else if strings.Contains(host, ":") {
+		u.Host = "[" + host + "]" // IPv6 literal
+	} else {
+		u.Host = host
+	}
+
+	if u.Path == "" {
+		u.Path = "/"
+	}
+
+	if u.RawQuery != "" {
+		query := u.Query()
+		if ignoreTracking {
+			for key := range query {
+				lower := strings.ToLower(key)
+				if strings.HasPrefix(lower, "utm_") || trackingParams[lower] {
+					query.Del(key)
+				}
+			}
+		}
+		u.RawQuery = query.Encode() // Encode sorts by key
+	}
+
+	return u.String()
+}
+
+// DestinationHash returns the hex SHA-256 of NormalizeURL(raw, ignoreTracking),
+// the indexed key links are matched by destination with.
+func DestinationHash(raw string, ignoreTracking bool) string {
+	sum := sha256.Sum256([]byte(NormalizeURL(raw, ignoreTracking)))
+	return hex.EncodeToString(sum[:])
+}
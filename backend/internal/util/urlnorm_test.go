@@ -0,0 +1,43 @@
+package util
+
+import "testing"
+
+func TestNormalizeURL(t *testing.T) {
+	tests := []struct {
+		name           string
+		raw            string
+		ignoreTracking bool
+		want           string
+	}{
+		{"lowercases scheme and host", "HTTPS://Example.COM/Path", false, "https://example.com/Path"},
+		{"strips default https port", "https://example.com:443/a", false, "https://example.com/a"},
+		{"strips default http port", "http://example.com:80", false, "http://example.com/"},
+		{"keeps non-default port", "https://example.com:8443/a", false, "https://example.com:8443/a"},
+		{"sorts query params", "https://example.com/?b=2&a=1", false, "https://example.com/?a=1&b=2"},
+		{"keeps tracking params by default", "https://example.com/?utm_source=x&id=1", false, "https://example.com/?id=1&utm_source=x"},
+		{"drops tracking params", "https://example.com/?utm_source=x&fbclid=abc&id=1", true, "https://example.com/?id=1"},
+		{"drops query entirely when only tracking", "https://example.com/p?utm_medium=email", true, "https://example.com/p"},
+		{"unparseable input unchanged", "not a url", false, "not a url"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeURL(tt.raw, tt.ignoreTracking); got != tt.want {
+				t.Errorf("NormalizeURL(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDestinationHash(t *testing.T) {
+	tracked := "https://Example.com:443/a?utm_source=x"
+	if DestinationHash(tracked, true) != DestinationHash("https://example.com/a", false) {
+		t.Error("expected equal hashes once tracking parameters are ignored")
+	}
+	if DestinationHash(tracked, false) == DestinationHash("https://example.com/a", false) {
+		t.Error("expected tracking parameters to count when not ignored")
+	}
+	if got := len(DestinationHash(tracked, false)); got != 64 {
+		t.Errorf("expected 64 hex characters, got %d", got)
+	}
+}
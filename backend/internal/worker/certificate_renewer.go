@@ -0,0 +1,72 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/SeaCodeBase/urlshortener/internal/service"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// CertificateRenewer periodically renews ACME certificates of custom
+// domains before they expire and warns about expiring uploaded ones.
+type CertificateRenewer struct {
+	certificateService service.CertificateService
+	interval           time.Duration
+	batchSize          int
+	stopCh             chan struct{}
+	doneCh             chan struct{}
+}
+
+func NewCertificateRenewer(certificateService service.CertificateService) *CertificateRenewer {
+	return &CertificateRenewer{
+		certificateService: certificateService,
+		interval:           12 * time.Hour,
+		batchSize:          50,
+		stopCh:             make(chan struct{}),
+		doneCh:             make(chan struct{}),
+	}
+}
+
+func (r *CertificateRenewer) Start() {
+	go r.run()
+}
+
+func (r *CertificateRenewer) Stop() {
+	close(r.stopCh)
+	<-r.doneCh // Wait for worker to finish
+}
+
+func (r *CertificateRenewer) run() {
+	defer close(r.doneCh) // Signal completion
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.renew()
+		case <-r.stopCh:
+			return
+		}
+	}
+}
+
+func (r *CertificateRenewer) renew() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	renewed, err := r.certificateService.Renew(ctx, r.batchSize)
+	if err != nil {
+		logger.Error(ctx, "failed to renew certificates",
+			zap.Error(err),
+		)
+		return
+	}
+	if renewed > 0 {
+		logger.Info(ctx, "renewed certificates",
+			zap.Int("count", renewed),
+		)
+	}
+}
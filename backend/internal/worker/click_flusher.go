@@ -18,16 +18,20 @@ import (
 type ClickFlusher struct {
 	rdb       *redis.Client
 	clickRepo repository.ClickRepository
+	webhooks  service.WebhookService
 	interval  time.Duration
 	batchSize int
 	stopCh    chan struct{}
 	doneCh    chan struct{}
 }
 
-func NewClickFlusher(rdb *redis.Client, clickRepo repository.ClickRepository) *ClickFlusher {
+// NewClickFlusher creates a flusher; each flushed batch is also sent to
+// link.clicked webhooks when webhooks is not nil.
+func NewClickFlusher(rdb *redis.Client, clickRepo repository.ClickRepository, webhooks service.WebhookService) *ClickFlusher {
 	return &ClickFlusher{
 		rdb:       rdb,
 		clickRepo: clickRepo,
+		webhooks:  webhooks,
 		interval:  30 * time.Second,
 		batchSize: 100,
 		stopCh:    make(chan struct{}),
@@ -53,8 +57,10 @@ func (f *ClickFlusher) run() {
 		select {
 		case <-ticker.C:
 			f.flush()
+			f.flushMisses()
 		case <-f.stopCh:
 			f.flush() // Final flush before stopping
+			f.flushMisses()
 			return
 		}
 	}
@@ -98,6 +104,7 @@ func (f *ClickFlusher) flush() {
 
 			click := model.Click{
 				LinkID:      event.LinkID,
+				AliasID:     event.AliasID,
 				ClickedAt:   event.ClickedAt,
 				IPHash:      event.IPHash,
 				IPAddress:   event.IPAddress,
@@ -133,8 +140,75 @@ func (f *ClickFlusher) flush() {
 			zap.Int("count", len(clicks)),
 		)
 
+		if f.webhooks != nil {
+			f.webhooks.EmitClicks(ctx, clicks)
+		}
+
 		if len(events) < f.batchSize {
 			return // No more events to process
 		}
 	}
 }
+
+// flushMisses counts buffered misses per domain, day, reason and code and
+// adds them to the database.
+func (f *ClickFlusher) flushMisses() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	bufferKey := "misses:buffer"
+
+	for {
+		events, err := f.rdb.LRange(ctx, bufferKey, 0, int64(f.batchSize-1)).Result()
+		if err != nil {
+			logger.Error(ctx, "failed to get miss events from buffer",
+				zap.Error(err),
+			)
+			return
+		}
+		if len(events) == 0 {
+			return
+		}
+
+		misses := make([]model.DomainMiss, 0, len(events))
+		index := make(map[model.DomainMiss]int, len(events))
+		for _, eventData := range events {
+			var event service.MissEvent
+			if err := json.Unmarshal([]byte(eventData), &event); err != nil {
+				logger.Warn(ctx, "failed to unmarshal miss event",
+					zap.Error(err),
+				)
+				continue
+			}
+			key := model.DomainMiss{
+				DomainID: event.DomainID,
+				Date:     event.At.UTC().Truncate(24 * time.Hour),
+				Reason:   event.Reason,
+				Code:     event.Code,
+			}
+			if i, ok := index[key]; ok {
+				misses[i].Count++
+				continue
+			}
+			index[key] = len(misses)
+			key.Count = 1
+			misses = append(misses, key)
+		}
+
+		if err := f.clickRepo.RecordMisses(ctx, misses); err != nil {
+			logger.Error(ctx, "failed to record domain misses",
+				zap.Error(err),
+			)
+			return
+		}
+
+		if err := f.rdb.LTrim(ctx, bufferKey, int64(len(events)), -1).Err(); err != nil {
+			logger.Error(ctx, "failed to trim miss buffer",
+				zap.Error(err),
+			)
+		}
+
+		if len(events) < f.batchSize {
+			return
+		}
+	}
+}
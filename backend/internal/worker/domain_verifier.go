@@ -0,0 +1,75 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/SeaCodeBase/urlshortener/internal/service"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// DomainVerifier periodically rechecks the DNS verification of verified
+// domains, so a domain whose owner has lost control of it stops serving.
+type DomainVerifier struct {
+	domainService service.DomainService
+	// recheckAfter is how long a verification is trusted before it is checked again
+	recheckAfter time.Duration
+	interval     time.Duration
+	batchSize    int
+	stopCh       chan struct{}
+	doneCh       chan struct{}
+}
+
+func NewDomainVerifier(domainService service.DomainService) *DomainVerifier {
+	return &DomainVerifier{
+		domainService: domainService,
+		recheckAfter:  24 * time.Hour,
+		interval:      1 * time.Hour,
+		batchSize:     100,
+		stopCh:        make(chan struct{}),
+		doneCh:        make(chan struct{}),
+	}
+}
+
+func (v *DomainVerifier) Start() {
+	go v.run()
+}
+
+func (v *DomainVerifier) Stop() {
+	close(v.stopCh)
+	<-v.doneCh // Wait for worker to finish
+}
+
+func (v *DomainVerifier) run() {
+	defer close(v.doneCh) // Signal completion
+	ticker := time.NewTicker(v.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			v.recheck()
+		case <-v.stopCh:
+			return
+		}
+	}
+}
+
+func (v *DomainVerifier) recheck() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	checked, err := v.domainService.Recheck(ctx, time.Now().Add(-v.recheckAfter), v.batchSize)
+	if err != nil {
+		logger.Error(ctx, "failed to recheck domain verification",
+			zap.Error(err),
+		)
+		return
+	}
+	if checked > 0 {
+		logger.Info(ctx, "rechecked domain verification",
+			zap.Int("count", checked),
+		)
+	}
+}
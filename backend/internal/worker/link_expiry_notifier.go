@@ -0,0 +1,125 @@
+package worker
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"time"
+
+	"github.com/SeaCodeBase/urlshortener/internal/service"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	// expiryCheckedKey holds the Unix time up to which expired links have been
+	// reported, so a restart picks up where the last sweep stopped.
+	expiryCheckedKey = "webhooks:expiry_checked_at"
+	// expiryLockKey is held by the instance sweeping, so links are reported
+	// once however many instances run; it expires with the sweep's timeout
+	expiryLockKey      = "webhooks:expiry_lock"
+	expirySweepTimeout = time.Minute
+)
+
+// releaseLock deletes a lock only while it still holds this sweep's token,
+// so a sweep that outlived its lock does not release another's.
+var releaseLock = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// LinkExpiryNotifier sends link.expired webhook events for links whose
+// expiry time has passed since its last sweep.
+type LinkExpiryNotifier struct {
+	rdb      *redis.Client
+	webhooks service.WebhookService
+	interval time.Duration
+	stopCh   chan struct{}
+	doneCh   chan struct{}
+}
+
+func NewLinkExpiryNotifier(rdb *redis.Client, webhooks service.WebhookService) *LinkExpiryNotifier {
+	return &LinkExpiryNotifier{
+		rdb:      rdb,
+		webhooks: webhooks,
+		interval: time.Minute,
+		stopCh:   make(chan struct{}),
+		doneCh:   make(chan struct{}),
+	}
+}
+
+func (n *LinkExpiryNotifier) Start() {
+	go n.run()
+}
+
+func (n *LinkExpiryNotifier) Stop() {
+	close(n.stopCh)
+	<-n.doneCh // Wait for worker to finish
+}
+
+func (n *LinkExpiryNotifier) run() {
+	defer close(n.doneCh) // Signal completion
+	ticker := time.NewTicker(n.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			n.sweep()
+		case <-n.stopCh:
+			return
+		}
+	}
+}
+
+func (n *LinkExpiryNotifier) sweep() {
+	ctx, cancel := context.WithTimeout(context.Background(), expirySweepTimeout)
+	defer cancel()
+
+	token := rand.Text()
+	locked, err := n.rdb.SetNX(ctx, expiryLockKey, token, expirySweepTimeout).Result()
+	if err != nil {
+		logger.Error(ctx, "failed to take link expiry lock",
+			zap.Error(err),
+		)
+		return
+	}
+	if !locked {
+		// Another instance is sweeping
+		return
+	}
+	defer func() {
+		if err := releaseLock.Run(context.Background(), n.rdb, []string{expiryLockKey}, token).Err(); err != nil {
+			logger.Error(ctx, "failed to release link expiry lock",
+				zap.Error(err),
+			)
+		}
+	}()
+
+	now := time.Now().Truncate(time.Second)
+
+	// The first sweep ever starts from now rather than reporting history
+	from := now
+	checked, err := n.rdb.Get(ctx, expiryCheckedKey).Int64()
+	if err == nil {
+		from = time.Unix(checked, 0)
+	} else if !errors.Is(err, redis.Nil) {
+		logger.Error(ctx, "failed to read link expiry checkpoint",
+			zap.Error(err),
+		)
+		return
+	}
+
+	if from.Before(now) {
+		if err := n.webhooks.EmitExpired(ctx, from, now); err != nil {
+			return
+		}
+	}
+	if err := n.rdb.Set(ctx, expiryCheckedKey, now.Unix(), 0).Err(); err != nil {
+		logger.Error(ctx, "failed to save link expiry checkpoint",
+			zap.Error(err),
+		)
+	}
+}
@@ -0,0 +1,41 @@
+package worker
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/SeaCodeBase/urlshortener/internal/service/mocks"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestLinkExpiryNotifier_SweepsOnceAcrossInstances(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+	webhooks := mocks.NewMockWebhookService(ctrl)
+	n := NewLinkExpiryNotifier(rdb, webhooks)
+
+	checked := time.Now().Add(-time.Hour).Unix()
+	mr.Set(expiryCheckedKey, strconv.FormatInt(checked, 10))
+
+	// Another instance holds the lock: nothing is reported
+	mr.Set(expiryLockKey, "other")
+	n.sweep()
+	stored, _ := mr.Get(expiryCheckedKey)
+	assert.Equal(t, strconv.FormatInt(checked, 10), stored)
+
+	// Once it is released, the sweep reports from the checkpoint and lets go
+	mr.Del(expiryLockKey)
+	webhooks.EXPECT().EmitExpired(gomock.Any(), time.Unix(checked, 0), gomock.Any()).Return(nil)
+	n.sweep()
+	stored, _ = mr.Get(expiryCheckedKey)
+	assert.NotEqual(t, strconv.FormatInt(checked, 10), stored)
+	assert.False(t, mr.Exists(expiryLockKey))
+}
@@ -0,0 +1,85 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/SeaCodeBase/urlshortener/internal/repository"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// TrashPurger permanently deletes links that have been in the trash longer
+// than the retention period. Their clicks and aliases go with them.
+type TrashPurger struct {
+	linkRepo  repository.LinkRepository
+	retention time.Duration
+	interval  time.Duration
+	batchSize int
+	stopCh    chan struct{}
+	doneCh    chan struct{}
+}
+
+func NewTrashPurger(linkRepo repository.LinkRepository, retention time.Duration) *TrashPurger {
+	return &TrashPurger{
+		linkRepo:  linkRepo,
+		retention: retention,
+		interval:  1 * time.Hour,
+		batchSize: 500,
+		stopCh:    make(chan struct{}),
+		doneCh:    make(chan struct{}),
+	}
+}
+
+func (p *TrashPurger) Start() {
+	go p.run()
+}
+
+func (p *TrashPurger) Stop() {
+	close(p.stopCh)
+	<-p.doneCh // Wait for worker to finish
+}
+
+func (p *TrashPurger) run() {
+	defer close(p.doneCh) // Signal completion
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	p.purge()
+	for {
+		select {
+		case <-ticker.C:
+			p.purge()
+		case <-p.stopCh:
+			return
+		}
+	}
+}
+
+func (p *TrashPurger) purge() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	cutoff := time.Now().Add(-p.retention)
+
+	var total int64
+	for {
+		// Delete in batches to keep each statement (and its click cascade) short
+		purged, err := p.linkRepo.PurgeTrashed(ctx, cutoff, p.batchSize)
+		if err != nil {
+			logger.Error(ctx, "failed to purge trashed links",
+				zap.Error(err),
+			)
+			return
+		}
+		total += purged
+		if purged < int64(p.batchSize) {
+			break
+		}
+	}
+
+	if total > 0 {
+		logger.Info(ctx, "purged trashed links",
+			zap.Int64("count", total),
+		)
+	}
+}
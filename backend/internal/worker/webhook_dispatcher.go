@@ -0,0 +1,218 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/internal/repository"
+	"github.com/SeaCodeBase/urlshortener/internal/service"
+	"github.com/SeaCodeBase/urlshortener/internal/util"
+	"github.com/SeaCodeBase/urlshortener/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxWebhookAttempts is how many times a delivery is tried before it is
+	// marked failed; it can still be replayed from the delivery log
+	maxWebhookAttempts = 10
+	// webhookRetryBase is the delay before the first retry; each retry
+	// doubles it, up to webhookRetryMax
+	webhookRetryBase = 30 * time.Second
+	webhookRetryMax  = 6 * time.Hour
+	// webhookTimeout bounds a single send; the lease outlasts it so a slow
+	// receiver is not sent the same delivery twice
+	webhookTimeout = 10 * time.Second
+	webhookLease   = time.Minute
+	// maxWebhookErrorLen fits webhook_deliveries.last_error
+	maxWebhookErrorLen = 500
+)
+
+// WebhookDispatcher sends queued webhook deliveries, retrying failures with
+// exponential backoff.
+type WebhookDispatcher struct {
+	webhookRepo  repository.WebhookRepository
+	deliveryRepo repository.WebhookDeliveryRepository
+	client       *http.Client
+	interval     time.Duration
+	batchSize    int
+	stopCh       chan struct{}
+	doneCh       chan struct{}
+}
+
+func NewWebhookDispatcher(webhookRepo repository.WebhookRepository, deliveryRepo repository.WebhookDeliveryRepository) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		client:       newWebhookClient(),
+		interval:     5 * time.Second,
+		batchSize:    50,
+		stopCh:       make(chan struct{}),
+		doneCh:       make(chan struct{}),
+	}
+}
+
+func (d *WebhookDispatcher) Start() {
+	go d.run()
+}
+
+func (d *WebhookDispatcher) Stop() {
+	close(d.stopCh)
+	<-d.doneCh // Wait for worker to finish
+}
+
+func (d *WebhookDispatcher) run() {
+	defer close(d.doneCh) // Signal completion
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			d.dispatch()
+		case <-d.stopCh:
+			return
+		}
+	}
+}
+
+func (d *WebhookDispatcher) dispatch() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	deliveries, err := d.deliveryRepo.ListDue(ctx, time.Now(), d.batchSize)
+	if err != nil {
+		logger.Error(ctx, "failed to list due webhook deliveries",
+			zap.Error(err),
+		)
+		return
+	}
+	for i := range deliveries {
+		d.deliver(ctx, &deliveries[i])
+	}
+}
+
+// deliver makes one attempt at a delivery and records the outcome.
+func (d *WebhookDispatcher) deliver(ctx context.Context, delivery *model.WebhookDelivery) {
+	claimed, err := d.deliveryRepo.Claim(ctx, delivery, time.Now().Add(webhookLease))
+	if err != nil || !claimed {
+		return
+	}
+
+	webhook, err := d.webhookRepo.GetByID(ctx, delivery.WebhookID)
+	if errors.Is(err, repository.ErrWebhookNotFound) {
+		return // Deleted since; its deliveries go with it
+	}
+	if err != nil {
+		logger.Error(ctx, "failed to get webhook for delivery",
+			zap.Uint64("delivery_id", delivery.ID),
+			zap.Error(err),
+		)
+		return // The lease expires and the attempt is retried
+	}
+
+	if !webhook.IsActive {
+		delivery.Status = model.DeliveryFailed
+		delivery.LastError = "webhook is disabled"
+	} else {
+		statusCode, err := d.send(ctx, webhook, delivery)
+		if statusCode != 0 {
+			delivery.ResponseStatus = &statusCode
+		}
+		switch {
+		case err == nil:
+			now := time.Now()
+			delivery.Status = model.DeliverySucceeded
+			delivery.LastError = ""
+			delivery.DeliveredAt = &now
+		case delivery.Attempts >= maxWebhookAttempts:
+			delivery.Status = model.DeliveryFailed
+			delivery.LastError = truncateError(err)
+		default:
+			delivery.NextAttemptAt = time.Now().Add(webhookRetryDelay(delivery.Attempts))
+			delivery.LastError = truncateError(err)
+		}
+	}
+
+	if err := d.deliveryRepo.Update(ctx, delivery); err != nil {
+		logger.Error(ctx, "failed to record webhook delivery attempt",
+			zap.Uint64("delivery_id", delivery.ID),
+			zap.Error(err),
+		)
+	}
+}
+
+// send POSTs the signed payload, returning the response status (0 when no
+// response arrived) and an error unless the receiver answered 2xx.
+func (d *WebhookDispatcher) send(ctx context.Context, webhook *model.Webhook, delivery *model.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "urlshortener-webhooks/1.0")
+	req.Header.Set(service.WebhookEventHeader, delivery.Event)
+	req.Header.Set(service.WebhookDeliveryHeader, strconv.FormatUint(delivery.ID, 10))
+	req.Header.Set(service.WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(service.WebhookSignatureHeader, service.SignWebhook(webhook.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("receiver responded %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// newWebhookClient builds the client deliveries are sent with. Receivers are
+// user supplied, so it only connects to public addresses and does not follow
+// redirects, which could lead to internal hosts; a redirect counts as a
+// failed attempt.
+func newWebhookClient() *http.Client {
+	dialer := &net.Dialer{Timeout: webhookTimeout, Control: util.PublicDialControl}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   webhookTimeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// webhookRetryDelay is the wait after the given (1-based) failed attempt.
+func webhookRetryDelay(attempt int) time.Duration {
+	delay := webhookRetryBase
+	for i := 1; i < attempt && delay < webhookRetryMax; i++ {
+		delay *= 2
+	}
+	if delay > webhookRetryMax {
+		delay = webhookRetryMax
+	}
+	return delay
+}
+
+func truncateError(err error) string {
+	msg := err.Error()
+	if len(msg) > maxWebhookErrorLen {
+		msg = msg[:maxWebhookErrorLen]
+	}
+	return msg
+}
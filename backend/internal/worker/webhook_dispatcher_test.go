@@ -0,0 +1,160 @@
+package worker
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/SeaCodeBase/urlshortener/internal/model"
+	"github.com/SeaCodeBase/urlshortener/internal/repository/mocks"
+	"github.com/SeaCodeBase/urlshortener/internal/service"
+	"github.com/SeaCodeBase/urlshortener/internal/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestDispatcher(ctrl *gomock.Controller) (*WebhookDispatcher, *mocks.MockWebhookRepository, *mocks.MockWebhookDeliveryRepository) {
+	webhookRepo := mocks.NewMockWebhookRepository(ctrl)
+	deliveryRepo := mocks.NewMockWebhookDeliveryRepository(ctrl)
+	d := NewWebhookDispatcher(webhookRepo, deliveryRepo)
+	// Test receivers listen on loopback, which the real client refuses
+	d.client = &http.Client{Timeout: webhookTimeout}
+	return d, webhookRepo, deliveryRepo
+}
+
+func expectClaim(deliveryRepo *mocks.MockWebhookDeliveryRepository) {
+	deliveryRepo.EXPECT().
+		Claim(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, delivery *model.WebhookDelivery, leaseUntil time.Time) (bool, error) {
+			delivery.Attempts++
+			delivery.NextAttemptAt = leaseUntil
+			return true, nil
+		})
+}
+
+func TestWebhookDispatcher_SignsAndDelivers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	payload := `{"event":"link.created","created_at":"2026-01-01T00:00:00Z","data":{"id":10}}`
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, err := strconv.ParseInt(r.Header.Get(service.WebhookTimestampHeader), 10, 64)
+		assert.NoError(t, err)
+		assert.Equal(t, payload, string(body))
+		assert.Equal(t, model.WebhookLinkCreated, r.Header.Get(service.WebhookEventHeader))
+		assert.Equal(t, "42", r.Header.Get(service.WebhookDeliveryHeader))
+		assert.Equal(t, service.SignWebhook("whsec_test", timestamp, body), r.Header.Get(service.WebhookSignatureHeader))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	d, webhookRepo, deliveryRepo := newTestDispatcher(ctrl)
+	expectClaim(deliveryRepo)
+	webhookRepo.EXPECT().
+		GetByID(gomock.Any(), uint64(5)).
+		Return(&model.Webhook{ID: 5, URL: receiver.URL, Secret: "whsec_test", IsActive: true}, nil)
+	deliveryRepo.EXPECT().
+		Update(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, delivery *model.WebhookDelivery) error {
+			assert.Equal(t, model.DeliverySucceeded, delivery.Status)
+			assert.Equal(t, 1, delivery.Attempts)
+			assert.Equal(t, http.StatusNoContent, *delivery.ResponseStatus)
+			assert.NotNil(t, delivery.DeliveredAt)
+			return nil
+		})
+
+	d.deliver(context.Background(), &model.WebhookDelivery{
+		ID:        42,
+		WebhookID: 5,
+		Event:     model.WebhookLinkCreated,
+		Payload:   []byte(payload),
+		Status:    model.DeliveryPending,
+	})
+}
+
+func TestWebhookDispatcher_RetriesWithBackoff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	d, webhookRepo, deliveryRepo := newTestDispatcher(ctrl)
+	webhook := &model.Webhook{ID: 5, URL: receiver.URL, Secret: "whsec_test", IsActive: true}
+	webhookRepo.EXPECT().GetByID(gomock.Any(), uint64(5)).Return(webhook, nil).Times(2)
+
+	// The third failed attempt waits 2 minutes before the next one
+	expectClaim(deliveryRepo)
+	deliveryRepo.EXPECT().
+		Update(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, delivery *model.WebhookDelivery) error {
+			assert.Equal(t, model.DeliveryPending, delivery.Status)
+			assert.Equal(t, http.StatusServiceUnavailable, *delivery.ResponseStatus)
+			assert.Contains(t, delivery.LastError, "503")
+			assert.WithinDuration(t, time.Now().Add(2*time.Minute), delivery.NextAttemptAt, 5*time.Second)
+			return nil
+		})
+	d.deliver(context.Background(), &model.WebhookDelivery{ID: 1, WebhookID: 5, Payload: []byte(`{}`),
+		Status: model.DeliveryPending, Attempts: 2})
+
+	// Once attempts run out the delivery is given up on
+	expectClaim(deliveryRepo)
+	deliveryRepo.EXPECT().
+		Update(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, delivery *model.WebhookDelivery) error {
+			assert.Equal(t, model.DeliveryFailed, delivery.Status)
+			assert.Equal(t, maxWebhookAttempts, delivery.Attempts)
+			return nil
+		})
+	d.deliver(context.Background(), &model.WebhookDelivery{ID: 2, WebhookID: 5, Payload: []byte(`{}`),
+		Status: model.DeliveryPending, Attempts: maxWebhookAttempts - 1})
+}
+
+func TestWebhookDispatcher_SkipsDeliveriesClaimedElsewhere(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	d, _, deliveryRepo := newTestDispatcher(ctrl)
+	deliveryRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+
+	d.deliver(context.Background(), &model.WebhookDelivery{ID: 1, WebhookID: 5, Status: model.DeliveryPending})
+}
+
+func TestWebhookClient_RefusesInternalReceivers(t *testing.T) {
+	redirected := false
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		redirected = true
+	}))
+	defer internal.Close()
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, internal.URL, http.StatusFound)
+	}))
+	defer receiver.Close()
+
+	// Loopback addresses are refused after resolution
+	_, err := newWebhookClient().Post(receiver.URL, "application/json", nil)
+	assert.ErrorIs(t, err, util.ErrNonPublicAddress)
+
+	// and redirects are returned rather than followed
+	permissive := newWebhookClient()
+	permissive.Transport = http.DefaultTransport
+	resp, err := permissive.Post(receiver.URL, "application/json", nil)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusFound, resp.StatusCode)
+	assert.False(t, redirected)
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	assert.Equal(t, 30*time.Second, webhookRetryDelay(1))
+	assert.Equal(t, time.Minute, webhookRetryDelay(2))
+	assert.Equal(t, 8*time.Minute, webhookRetryDelay(5))
+	assert.Equal(t, webhookRetryMax, webhookRetryDelay(maxWebhookAttempts+20))
+}
@@ -0,0 +1,26 @@
+-- Campaigns: named groups of links with shared UTM defaults and date range
+
+CREATE TABLE IF NOT EXISTS campaigns (
+    id              BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    user_id         BIGINT UNSIGNED NOT NULL,
+    name            VARCHAR(255) NOT NULL,
+    starts_at       TIMESTAMP NULL,
+    ends_at         TIMESTAMP NULL,
+    budget_notes    TEXT,
+    utm_source      VARCHAR(255),
+    utm_medium      VARCHAR(255),
+    utm_campaign    VARCHAR(255),
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    INDEX idx_campaigns_user_id (user_id)
+);
+
+-- Per-link campaign membership and UTM overrides (NULL = inherit from campaign)
+ALTER TABLE links
+    ADD COLUMN IF NOT EXISTS campaign_id BIGINT UNSIGNED NULL AFTER domain_id,
+    ADD COLUMN IF NOT EXISTS utm_source VARCHAR(255) NULL AFTER campaign_id,
+    ADD COLUMN IF NOT EXISTS utm_medium VARCHAR(255) NULL AFTER utm_source,
+    ADD COLUMN IF NOT EXISTS utm_campaign VARCHAR(255) NULL AFTER utm_medium,
+    ADD INDEX IF NOT EXISTS idx_links_campaign_id (campaign_id),
+    ADD CONSTRAINT fk_links_campaign FOREIGN KEY IF NOT EXISTS (campaign_id) REFERENCES campaigns(id) ON DELETE SET NULL;
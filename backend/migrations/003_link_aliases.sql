@@ -0,0 +1,17 @@
+-- Link aliases: extra (domain, code) pairs resolving to a link.
+-- Old codes of renamed links are kept here with an expiry (grace period).
+
+CREATE TABLE IF NOT EXISTS link_aliases (
+    id              BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    link_id         BIGINT UNSIGNED NOT NULL,
+    domain_id       BIGINT UNSIGNED NULL,
+    code            VARCHAR(16) NOT NULL,
+    expires_at      TIMESTAMP NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (link_id) REFERENCES links(id) ON DELETE CASCADE,
+    FOREIGN KEY (domain_id) REFERENCES domains(id) ON DELETE CASCADE,
+    -- NULL domain_id uniqueness handled in app layer, as for links
+    UNIQUE INDEX idx_alias_domain_code (domain_id, code),
+    INDEX idx_alias_code (code),
+    INDEX idx_alias_link_id (link_id)
+);
@@ -0,0 +1,6 @@
+-- Record which alias a click came through (NULL = the link's primary code).
+-- No FK: clicks keep the alias ID after the alias is deleted.
+
+ALTER TABLE clicks
+    ADD COLUMN IF NOT EXISTS alias_id BIGINT UNSIGNED NULL AFTER link_id,
+    ADD INDEX IF NOT EXISTS idx_clicks_link_alias (link_id, alias_id);
@@ -0,0 +1,13 @@
+-- Versioned snapshots of every link edit, used for history and revert.
+
+CREATE TABLE IF NOT EXISTS link_history (
+    id              BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    link_id         BIGINT UNSIGNED NOT NULL,
+    version         INT UNSIGNED NOT NULL,
+    changed_by      BIGINT UNSIGNED NOT NULL,
+    old_values      JSON NOT NULL,
+    new_values      JSON NOT NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (link_id) REFERENCES links(id) ON DELETE CASCADE,
+    UNIQUE INDEX idx_link_history_version (link_id, version)
+);
@@ -0,0 +1,6 @@
+-- Soft delete: deleted links stay in the trash (keeping their code and clicks)
+-- until the purge worker removes them for good.
+
+ALTER TABLE links
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP NULL AFTER utm_campaign,
+    ADD INDEX IF NOT EXISTS idx_links_deleted_at (deleted_at);
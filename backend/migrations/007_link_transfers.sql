@@ -0,0 +1,24 @@
+-- Link ownership transfers: the sender proposes, the recipient accepts.
+
+CREATE TABLE IF NOT EXISTS link_transfers (
+    id              BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    from_user_id    BIGINT UNSIGNED NOT NULL,
+    to_user_id      BIGINT UNSIGNED NOT NULL,
+    all_links       BOOLEAN NOT NULL DEFAULT FALSE,
+    status          ENUM('pending', 'accepted', 'declined', 'cancelled') NOT NULL DEFAULT 'pending',
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    resolved_at     TIMESTAMP NULL,
+    FOREIGN KEY (from_user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (to_user_id) REFERENCES users(id) ON DELETE CASCADE,
+    INDEX idx_link_transfers_from (from_user_id, status),
+    INDEX idx_link_transfers_to (to_user_id, status)
+);
+
+-- Links selected for a transfer (unused when all_links is set)
+CREATE TABLE IF NOT EXISTS link_transfer_links (
+    transfer_id     BIGINT UNSIGNED NOT NULL,
+    link_id         BIGINT UNSIGNED NOT NULL,
+    PRIMARY KEY (transfer_id, link_id),
+    FOREIGN KEY (transfer_id) REFERENCES link_transfers(id) ON DELETE CASCADE,
+    FOREIGN KEY (link_id) REFERENCES links(id) ON DELETE CASCADE
+);
@@ -0,0 +1,10 @@
+-- Per-domain short code generation settings (NULL = server default)
+ALTER TABLE domains
+    ADD COLUMN IF NOT EXISTS code_strategy VARCHAR(32) NULL AFTER domain,
+    ADD COLUMN IF NOT EXISTS code_length TINYINT UNSIGNED NULL AFTER code_strategy;
+
+-- Counters behind the sequential and hashids strategies, one per scope (domain)
+CREATE TABLE IF NOT EXISTS code_sequences (
+    scope           VARCHAR(64) PRIMARY KEY,
+    next_value      BIGINT UNSIGNED NOT NULL
+);
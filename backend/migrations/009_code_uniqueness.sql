@@ -0,0 +1,22 @@
+-- Let the database enforce short code uniqueness so codes can be allocated
+-- by inserting and retrying on a duplicate instead of checking first.
+
+-- UNIQUE (domain_id, short_code) allows duplicate codes on the default domain
+-- because NULLs never compare equal; domain_key maps NULL to 0.
+ALTER TABLE links
+    ADD COLUMN IF NOT EXISTS domain_key BIGINT UNSIGNED AS (COALESCE(domain_id, 0)) STORED AFTER domain_id,
+    ADD UNIQUE INDEX IF NOT EXISTS idx_domain_key_short_code (domain_key, short_code);
+
+-- Active aliases reserve their code too. The message keeps the "Duplicate entry"
+-- wording so the application treats it like a unique violation.
+DELIMITER //
+CREATE TRIGGER IF NOT EXISTS trg_links_alias_code_insert BEFORE INSERT ON links
+FOR EACH ROW
+BEGIN
+    IF EXISTS (SELECT 1 FROM link_aliases
+               WHERE COALESCE(domain_id, 0) = COALESCE(NEW.domain_id, 0) AND code = NEW.short_code
+                 AND (expires_at IS NULL OR expires_at > NOW())) THEN
+        SIGNAL SQLSTATE '23000' SET MESSAGE_TEXT = 'Duplicate entry: short code is reserved by an alias';
+    END IF;
+END//
+DELIMITER ;
@@ -0,0 +1,22 @@
+-- Admins manage the global reserved and blocked code lists
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS is_admin BOOLEAN NOT NULL DEFAULT FALSE AFTER display_name;
+
+-- Reserved and blocked short codes, stored lowercase and matched case-insensitively.
+-- Rows without a domain apply to every domain and are managed by admins. Domain rows
+-- are reservations by the domain owner: only the owner may use a reserved code there,
+-- and nobody may use a blocked one.
+CREATE TABLE IF NOT EXISTS reserved_codes (
+    id              BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    domain_id       BIGINT UNSIGNED NULL,
+    domain_key      BIGINT UNSIGNED AS (COALESCE(domain_id, 0)) STORED,
+    code            VARCHAR(16) NOT NULL,
+    kind            VARCHAR(16) NOT NULL DEFAULT 'reserved',
+    reason          VARCHAR(255) NULL,
+    created_by      BIGINT UNSIGNED NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (domain_id) REFERENCES domains(id) ON DELETE CASCADE,
+    FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL,
+    UNIQUE INDEX idx_reserved_domain_code (domain_key, code),
+    INDEX idx_reserved_code (code)
+);
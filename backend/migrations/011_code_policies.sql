@@ -0,0 +1,17 @@
+-- Per-domain short code policies (NULL = server default: case-sensitive
+-- ASCII letters and digits, 3 to 16 characters)
+ALTER TABLE domains
+    ADD COLUMN IF NOT EXISTS case_insensitive_codes BOOLEAN NOT NULL DEFAULT FALSE AFTER code_length,
+    ADD COLUMN IF NOT EXISTS code_charset VARCHAR(16) NULL AFTER case_insensitive_codes,
+    ADD COLUMN IF NOT EXISTS code_min_length TINYINT UNSIGNED NULL AFTER code_charset,
+    ADD COLUMN IF NOT EXISTS code_max_length TINYINT UNSIGNED NULL AFTER code_min_length;
+
+-- Codes are compared byte for byte. The application stores and looks up the
+-- canonical form for the domain's policy (Unicode NFC, case-folded on
+-- case-insensitive domains), so the column collation must not fold anything.
+ALTER TABLE links
+    MODIFY short_code VARCHAR(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL;
+ALTER TABLE link_aliases
+    MODIFY code VARCHAR(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL;
+ALTER TABLE reserved_codes
+    MODIFY code VARCHAR(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL;
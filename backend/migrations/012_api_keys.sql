@@ -0,0 +1,16 @@
+-- Long-lived API keys for scripts and CI. Only a SHA-256 hash of each key is
+-- stored; prefix is the key's first characters, kept to tell keys apart.
+CREATE TABLE IF NOT EXISTS api_keys (
+    id              BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    user_id         BIGINT UNSIGNED NOT NULL,
+    name            VARCHAR(100) NOT NULL,
+    prefix          VARCHAR(16) NOT NULL,
+    key_hash        CHAR(64) NOT NULL,
+    scopes          JSON NOT NULL,
+    expires_at      TIMESTAMP NULL,
+    last_used_at    TIMESTAMP NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE INDEX idx_api_keys_hash (key_hash),
+    INDEX idx_api_keys_user_id (user_id)
+);
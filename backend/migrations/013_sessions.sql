@@ -0,0 +1,19 @@
+-- Login sessions behind short-lived access tokens. Each session holds the
+-- SHA-256 hash of its current refresh token; previous_hash keeps the token it
+-- replaced so a replayed, already-rotated token can be recognised as stolen.
+CREATE TABLE IF NOT EXISTS sessions (
+    id              BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    user_id         BIGINT UNSIGNED NOT NULL,
+    refresh_hash    CHAR(64) NOT NULL,
+    previous_hash   CHAR(64) NULL,
+    user_agent      VARCHAR(255) NOT NULL DEFAULT '',
+    ip_address      VARCHAR(45) NOT NULL DEFAULT '',
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_used_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    expires_at      TIMESTAMP NOT NULL,
+    revoked_at      TIMESTAMP NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE INDEX idx_sessions_refresh_hash (refresh_hash),
+    INDEX idx_sessions_previous_hash (previous_hash),
+    INDEX idx_sessions_user_id (user_id)
+);
@@ -0,0 +1,85 @@
+-- Workspaces own links, domains and campaigns; users reach them through a
+-- membership role. Every user has a personal workspace (personal_user_id set),
+-- created here for existing users and on first use for new ones.
+CREATE TABLE IF NOT EXISTS workspaces (
+    id                  BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    name                VARCHAR(100) NOT NULL,
+    personal_user_id    BIGINT UNSIGNED NULL,
+    created_at          TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at          TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (personal_user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE INDEX idx_workspaces_personal_user (personal_user_id)
+);
+
+CREATE TABLE IF NOT EXISTS workspace_members (
+    workspace_id    BIGINT UNSIGNED NOT NULL,
+    user_id         BIGINT UNSIGNED NOT NULL,
+    role            ENUM('owner', 'admin', 'editor', 'viewer') NOT NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (workspace_id, user_id),
+    FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    INDEX idx_workspace_members_user (user_id)
+);
+
+-- Invitations are accepted with a token sent to the invitee; only its
+-- SHA-256 hash is stored.
+CREATE TABLE IF NOT EXISTS workspace_invitations (
+    id              BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    workspace_id    BIGINT UNSIGNED NOT NULL,
+    email           VARCHAR(255) NOT NULL,
+    role            ENUM('owner', 'admin', 'editor', 'viewer') NOT NULL,
+    token_hash      CHAR(64) NOT NULL,
+    invited_by      BIGINT UNSIGNED NULL,
+    expires_at      TIMESTAMP NOT NULL,
+    accepted_at     TIMESTAMP NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE,
+    FOREIGN KEY (invited_by) REFERENCES users(id) ON DELETE SET NULL,
+    UNIQUE INDEX idx_workspace_invitations_token (token_hash),
+    INDEX idx_workspace_invitations_workspace (workspace_id)
+);
+
+-- Personal workspaces for existing users, who own them
+INSERT INTO workspaces (name, personal_user_id)
+SELECT COALESCE(display_name, email), id FROM users
+WHERE id NOT IN (SELECT personal_user_id FROM workspaces WHERE personal_user_id IS NOT NULL);
+
+INSERT IGNORE INTO workspace_members (workspace_id, user_id, role)
+SELECT id, personal_user_id, 'owner' FROM workspaces WHERE personal_user_id IS NOT NULL;
+
+-- Existing links, domains and campaigns move into their owner's personal
+-- workspace. user_id stays as the member who created them.
+ALTER TABLE links
+    ADD COLUMN IF NOT EXISTS workspace_id BIGINT UNSIGNED NULL AFTER user_id;
+ALTER TABLE domains
+    ADD COLUMN IF NOT EXISTS workspace_id BIGINT UNSIGNED NULL AFTER user_id;
+ALTER TABLE campaigns
+    ADD COLUMN IF NOT EXISTS workspace_id BIGINT UNSIGNED NULL AFTER user_id;
+ALTER TABLE link_transfers
+    ADD COLUMN IF NOT EXISTS from_workspace_id BIGINT UNSIGNED NULL AFTER to_user_id;
+
+UPDATE links l JOIN workspaces w ON w.personal_user_id = l.user_id
+SET l.workspace_id = w.id WHERE l.workspace_id IS NULL;
+UPDATE domains d JOIN workspaces w ON w.personal_user_id = d.user_id
+SET d.workspace_id = w.id WHERE d.workspace_id IS NULL;
+UPDATE campaigns c JOIN workspaces w ON w.personal_user_id = c.user_id
+SET c.workspace_id = w.id WHERE c.workspace_id IS NULL;
+UPDATE link_transfers t JOIN workspaces w ON w.personal_user_id = t.from_user_id
+SET t.from_workspace_id = w.id WHERE t.from_workspace_id IS NULL;
+
+ALTER TABLE links
+    MODIFY workspace_id BIGINT UNSIGNED NOT NULL,
+    ADD INDEX IF NOT EXISTS idx_links_workspace_id (workspace_id),
+    ADD CONSTRAINT fk_links_workspace FOREIGN KEY IF NOT EXISTS (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE;
+ALTER TABLE domains
+    MODIFY workspace_id BIGINT UNSIGNED NOT NULL,
+    ADD INDEX IF NOT EXISTS idx_domains_workspace_id (workspace_id),
+    ADD CONSTRAINT fk_domains_workspace FOREIGN KEY IF NOT EXISTS (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE;
+ALTER TABLE campaigns
+    MODIFY workspace_id BIGINT UNSIGNED NOT NULL,
+    ADD INDEX IF NOT EXISTS idx_campaigns_workspace_id (workspace_id),
+    ADD CONSTRAINT fk_campaigns_workspace FOREIGN KEY IF NOT EXISTS (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE;
+ALTER TABLE link_transfers
+    MODIFY from_workspace_id BIGINT UNSIGNED NOT NULL,
+    ADD CONSTRAINT fk_link_transfers_workspace FOREIGN KEY IF NOT EXISTS (from_workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE;
@@ -0,0 +1,19 @@
+-- Append-only record of security and link events. Rows are never updated or
+-- deleted, and carry no foreign keys so entries outlive the users, workspaces
+-- and objects they mention. workspace_id is NULL for account-level events such
+-- as logins and passkey changes.
+CREATE TABLE IF NOT EXISTS audit_log (
+    id              BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    workspace_id    BIGINT UNSIGNED NULL,
+    actor_user_id   BIGINT UNSIGNED NULL,
+    action          VARCHAR(64) NOT NULL,
+    target_type     VARCHAR(32) NOT NULL,
+    target_id       BIGINT UNSIGNED NULL,
+    changes         JSON NULL,
+    ip_address      VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent      VARCHAR(255) NOT NULL DEFAULT '',
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_audit_log_workspace (workspace_id, id),
+    INDEX idx_audit_log_actor (actor_user_id, id),
+    INDEX idx_audit_log_target (target_type, target_id)
+);
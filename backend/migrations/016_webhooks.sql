@@ -0,0 +1,40 @@
+-- Outbound webhooks. Each endpoint belongs to a user and watches one of their
+-- workspaces; secret signs deliveries and so is stored in clear.
+CREATE TABLE IF NOT EXISTS webhooks (
+    id              BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    user_id         BIGINT UNSIGNED NOT NULL,
+    workspace_id    BIGINT UNSIGNED NOT NULL,
+    url             VARCHAR(2048) NOT NULL,
+    secret          VARCHAR(64) NOT NULL,
+    events          JSON NOT NULL,
+    is_active       BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE,
+    INDEX idx_webhooks_workspace (workspace_id),
+    INDEX idx_webhooks_user (user_id)
+);
+
+-- Deliveries double as the durable send queue: pending rows are picked up
+-- once next_attempt_at passes and retried with exponential backoff.
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id              BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    webhook_id      BIGINT UNSIGNED NOT NULL,
+    event           VARCHAR(32) NOT NULL,
+    payload         JSON NOT NULL,
+    status          ENUM('pending', 'succeeded', 'failed') NOT NULL DEFAULT 'pending',
+    attempts        INT UNSIGNED NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    response_status INT NULL,
+    last_error      VARCHAR(500) NOT NULL DEFAULT '',
+    delivered_at    TIMESTAMP NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (webhook_id) REFERENCES webhooks(id) ON DELETE CASCADE,
+    INDEX idx_webhook_deliveries_due (status, next_attempt_at),
+    INDEX idx_webhook_deliveries_webhook (webhook_id, id)
+);
+
+-- link.expired events are found by sweeping recently passed expiry times
+ALTER TABLE links
+    ADD INDEX IF NOT EXISTS idx_links_expires_at (expires_at);
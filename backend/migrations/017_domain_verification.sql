@@ -0,0 +1,22 @@
+-- Domain ownership verification. A new domain is pending until a TXT record
+-- holding its token is found at _urlshortener-challenge.<domain>; verified
+-- domains are rechecked periodically and lose verification when the record
+-- stays missing.
+ALTER TABLE domains
+    ADD COLUMN IF NOT EXISTS verification_token VARCHAR(64) NOT NULL DEFAULT '' AFTER domain,
+    ADD COLUMN IF NOT EXISTS verified_at TIMESTAMP NULL AFTER verification_token,
+    ADD COLUMN IF NOT EXISTS last_checked_at TIMESTAMP NULL AFTER verified_at,
+    ADD COLUMN IF NOT EXISTS check_failures INT UNSIGNED NOT NULL DEFAULT 0 AFTER last_checked_at;
+
+-- Domains added before verification existed keep working. They have no token,
+-- so they are not rechecked until their owner verifies them again.
+UPDATE domains SET verified_at = created_at WHERE verified_at IS NULL AND verification_token = '';
+
+-- Any workspace may claim a pending domain; only one can hold it verified.
+-- verified_domain is NULL while pending, and NULLs never collide.
+ALTER TABLE domains
+    DROP INDEX IF EXISTS domain,
+    ADD COLUMN IF NOT EXISTS verified_domain VARCHAR(255) AS (IF(verified_at IS NULL, NULL, domain)) STORED,
+    ADD UNIQUE INDEX IF NOT EXISTS idx_domains_verified_domain (verified_domain),
+    ADD UNIQUE INDEX IF NOT EXISTS idx_domains_workspace_domain (workspace_id, domain),
+    ADD INDEX IF NOT EXISTS idx_domains_last_checked_at (last_checked_at);
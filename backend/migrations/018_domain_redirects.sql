@@ -0,0 +1,9 @@
+-- Per-domain fallback pages. A request for the bare domain goes to
+-- root_redirect_url; an unknown code goes to not_found_url or is answered
+-- with the not_found_html page; an expired link goes to expired_url.
+-- NULL keeps the redirect server's default response.
+ALTER TABLE domains
+    ADD COLUMN IF NOT EXISTS root_redirect_url VARCHAR(2048) NULL AFTER code_max_length,
+    ADD COLUMN IF NOT EXISTS not_found_url VARCHAR(2048) NULL AFTER root_redirect_url,
+    ADD COLUMN IF NOT EXISTS not_found_html MEDIUMTEXT NULL AFTER not_found_url,
+    ADD COLUMN IF NOT EXISTS expired_url VARCHAR(2048) NULL AFTER not_found_html;
@@ -0,0 +1,19 @@
+-- Per-domain defaults. use_https picks the scheme of short URLs shown for
+-- the domain, redirect_status the HTTP status its redirects use (NULL is
+-- 302), default_expiry_days the expiry given to links created on it, and
+-- the default_utm_* columns UTM parameters added to its links' destinations
+-- when neither the link nor its campaign sets them.
+ALTER TABLE domains
+    ADD COLUMN IF NOT EXISTS use_https BOOLEAN NOT NULL DEFAULT FALSE AFTER code_max_length,
+    ADD COLUMN IF NOT EXISTS redirect_status SMALLINT UNSIGNED NULL AFTER use_https,
+    ADD COLUMN IF NOT EXISTS default_expiry_days INT UNSIGNED NULL AFTER redirect_status,
+    ADD COLUMN IF NOT EXISTS default_utm_source VARCHAR(255) NULL AFTER default_expiry_days,
+    ADD COLUMN IF NOT EXISTS default_utm_medium VARCHAR(255) NULL AFTER default_utm_source,
+    ADD COLUMN IF NOT EXISTS default_utm_campaign VARCHAR(255) NULL AFTER default_utm_medium;
+
+-- A workspace may mark one domain as the default for new links.
+-- default_workspace_id is NULL for every other domain, and NULLs never collide.
+ALTER TABLE domains
+    ADD COLUMN IF NOT EXISTS is_default BOOLEAN NOT NULL DEFAULT FALSE AFTER default_utm_campaign,
+    ADD COLUMN IF NOT EXISTS default_workspace_id BIGINT UNSIGNED AS (IF(is_default, workspace_id, NULL)) STORED,
+    ADD UNIQUE INDEX IF NOT EXISTS idx_domains_default_workspace (default_workspace_id);
@@ -0,0 +1,24 @@
+-- Deleting a domain used to move its links onto the default domain through
+-- ON DELETE SET NULL, where their codes could collide. Deletion now decides
+-- what happens to the links first, so the database refuses to orphan them.
+ALTER TABLE links
+    DROP FOREIGN KEY IF EXISTS links_ibfk_2,
+    ADD CONSTRAINT fk_links_domain FOREIGN KEY IF NOT EXISTS (domain_id) REFERENCES domains(id) ON DELETE RESTRICT;
+
+-- A domain deleted with its links archived stays behind as a tombstone so
+-- the trashed links keep their domain. It serves nothing and frees its name
+-- for verification elsewhere and for being added again.
+ALTER TABLE domains
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP NULL AFTER expired_url;
+
+ALTER TABLE domains
+    DROP INDEX IF EXISTS idx_domains_verified_domain,
+    DROP INDEX IF EXISTS idx_domains_workspace_domain,
+    DROP COLUMN IF EXISTS verified_domain;
+
+ALTER TABLE domains
+    ADD COLUMN IF NOT EXISTS verified_domain VARCHAR(255)
+        AS (IF(verified_at IS NULL OR deleted_at IS NOT NULL, NULL, domain)) STORED,
+    ADD COLUMN IF NOT EXISTS live_domain VARCHAR(255) AS (IF(deleted_at IS NULL, domain, NULL)) STORED,
+    ADD UNIQUE INDEX IF NOT EXISTS idx_domains_verified_domain (verified_domain),
+    ADD UNIQUE INDEX IF NOT EXISTS idx_domains_workspace_domain (workspace_id, live_domain);
@@ -0,0 +1,20 @@
+-- TLS certificates served by the redirect server for custom domains, picked
+-- by SNI. A domain has at most one, either uploaded or obtained through ACME;
+-- the private key is encrypted with the configured server.tls.encryption_key.
+CREATE TABLE IF NOT EXISTS domain_certificates (
+    id              BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    domain_id       BIGINT UNSIGNED NOT NULL,
+    source          ENUM('upload', 'acme') NOT NULL,
+    certificate_pem MEDIUMTEXT NOT NULL,
+    private_key     BLOB NOT NULL,
+    issuer          VARCHAR(255) NOT NULL DEFAULT '',
+    not_before      TIMESTAMP NOT NULL,
+    not_after       TIMESTAMP NOT NULL,
+    -- last_error is the latest failed renewal; it is cleared by a new certificate
+    last_error      VARCHAR(500) NOT NULL DEFAULT '',
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (domain_id) REFERENCES domains(id) ON DELETE CASCADE,
+    UNIQUE INDEX idx_domain_certificates_domain (domain_id),
+    INDEX idx_domain_certificates_not_after (not_after)
+);
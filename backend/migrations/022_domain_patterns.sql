@@ -0,0 +1,24 @@
+-- Domains may be wildcard patterns (*.links.example.com) and may put their
+-- codes under a path prefix (example.com/go/<code>), so several domains can
+-- share a host. A domain is now identified by its host and prefix together:
+-- only one workspace can hold a host and prefix verified, and a workspace
+-- cannot add the same one twice.
+ALTER TABLE domains
+    ADD COLUMN IF NOT EXISTS path_prefix VARCHAR(64) NOT NULL DEFAULT '' AFTER domain;
+
+ALTER TABLE domains
+    DROP INDEX IF EXISTS idx_domains_verified_domain,
+    DROP INDEX IF EXISTS idx_domains_workspace_domain,
+    DROP COLUMN IF EXISTS verified_domain,
+    DROP COLUMN IF EXISTS live_domain;
+
+ALTER TABLE domains
+    ADD COLUMN IF NOT EXISTS verified_domain VARCHAR(320)
+        AS (IF(verified_at IS NULL OR deleted_at IS NOT NULL, NULL,
+               IF(path_prefix = '', domain, CONCAT(domain, '/', path_prefix)))) STORED,
+    ADD COLUMN IF NOT EXISTS live_domain VARCHAR(320)
+        AS (IF(deleted_at IS NOT NULL, NULL,
+               IF(path_prefix = '', domain, CONCAT(domain, '/', path_prefix)))) STORED,
+    ADD UNIQUE INDEX IF NOT EXISTS idx_domains_verified_domain (verified_domain),
+    ADD UNIQUE INDEX IF NOT EXISTS idx_domains_workspace_domain (workspace_id, live_domain),
+    ADD INDEX IF NOT EXISTS idx_domains_domain (domain);
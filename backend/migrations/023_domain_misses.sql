@@ -0,0 +1,12 @@
+-- Requests on a custom domain that found no link to follow, counted per day
+-- and code so that broken printed codes show up in the domain's stats.
+CREATE TABLE IF NOT EXISTS domain_misses (
+    domain_id  BIGINT UNSIGNED NOT NULL,
+    date       DATE NOT NULL,
+    reason     ENUM('not_found', 'expired') NOT NULL,
+    -- code is the path after the domain's prefix, cut to 64 characters
+    code       VARCHAR(64) NOT NULL,
+    count      BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    PRIMARY KEY (domain_id, date, reason, code),
+    FOREIGN KEY (domain_id) REFERENCES domains(id) ON DELETE CASCADE
+);
@@ -0,0 +1,6 @@
+-- The workspace stats overview counts the links created and expiring in a
+-- range; clicks are aggregated through links' workspace index and the
+-- clicks' (link_id, clicked_at) index.
+ALTER TABLE links
+    ADD INDEX IF NOT EXISTS idx_links_workspace_created (workspace_id, created_at),
+    ADD INDEX IF NOT EXISTS idx_links_workspace_expires (workspace_id, expires_at);
@@ -0,0 +1,33 @@
+-- Extend 009's alias check to every way a code can change hands: links
+-- renamed or moved onto another domain, and new aliases. The default domain
+-- (NULL domain_id) is compared as 0, as domain_key does for links.
+
+-- A link may take over one of its own aliases (renaming back to an old code
+-- reclaims it), but not another link's.
+DELIMITER //
+CREATE TRIGGER IF NOT EXISTS trg_links_alias_code_update BEFORE UPDATE ON links
+FOR EACH ROW
+BEGIN
+    IF (NEW.short_code <> OLD.short_code OR NOT (NEW.domain_id <=> OLD.domain_id))
+       AND EXISTS (SELECT 1 FROM link_aliases
+                   WHERE COALESCE(domain_id, 0) = COALESCE(NEW.domain_id, 0) AND code = NEW.short_code
+                     AND link_id <> NEW.id
+                     AND (expires_at IS NULL OR expires_at > NOW())) THEN
+        SIGNAL SQLSTATE '23000' SET MESSAGE_TEXT = 'Duplicate entry: short code is reserved by an alias';
+    END IF;
+END//
+
+-- An alias may not shadow a link's code, nor an active alias on the default
+-- domain, which idx_alias_domain_code lets through because NULLs differ.
+CREATE TRIGGER IF NOT EXISTS trg_link_aliases_code_insert BEFORE INSERT ON link_aliases
+FOR EACH ROW
+BEGIN
+    IF EXISTS (SELECT 1 FROM links
+               WHERE domain_key = COALESCE(NEW.domain_id, 0) AND short_code = NEW.code)
+       OR EXISTS (SELECT 1 FROM link_aliases
+                  WHERE COALESCE(domain_id, 0) = COALESCE(NEW.domain_id, 0) AND code = NEW.code
+                    AND (expires_at IS NULL OR expires_at > NOW())) THEN
+        SIGNAL SQLSTATE '23000' SET MESSAGE_TEXT = 'Duplicate entry: short code is already in use';
+    END IF;
+END//
+DELIMITER ;
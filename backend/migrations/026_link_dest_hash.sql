@@ -0,0 +1,9 @@
+-- Links are matched by normalized destination (reuse on create, duplicate
+-- reports) through indexed hashes instead of loading the whole workspace.
+-- dest_hash keeps tracking parameters, dest_hash_untracked drops them; both
+-- are filled by the application, which backfills existing rows on startup.
+ALTER TABLE links
+    ADD COLUMN IF NOT EXISTS dest_hash CHAR(64) NULL AFTER original_url,
+    ADD COLUMN IF NOT EXISTS dest_hash_untracked CHAR(64) NULL AFTER dest_hash,
+    ADD INDEX IF NOT EXISTS idx_links_workspace_dest_hash (workspace_id, dest_hash),
+    ADD INDEX IF NOT EXISTS idx_links_workspace_dest_hash_untracked (workspace_id, dest_hash_untracked);
@@ -0,0 +1,17 @@
+-- Aliases now move with their domain's links (MoveDomain), so an alias
+-- changing domain or code is checked like a new one (see 025).
+DELIMITER //
+CREATE TRIGGER IF NOT EXISTS trg_link_aliases_code_update BEFORE UPDATE ON link_aliases
+FOR EACH ROW
+BEGIN
+    IF (NEW.code <> OLD.code OR NOT (NEW.domain_id <=> OLD.domain_id))
+       AND (EXISTS (SELECT 1 FROM links
+                    WHERE domain_key = COALESCE(NEW.domain_id, 0) AND short_code = NEW.code)
+            OR EXISTS (SELECT 1 FROM link_aliases
+                       WHERE COALESCE(domain_id, 0) = COALESCE(NEW.domain_id, 0) AND code = NEW.code
+                         AND id <> NEW.id
+                         AND (expires_at IS NULL OR expires_at > NOW()))) THEN
+        SIGNAL SQLSTATE '23000' SET MESSAGE_TEXT = 'Duplicate entry: short code is already in use';
+    END IF;
+END//
+DELIMITER ;
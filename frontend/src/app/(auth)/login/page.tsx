@@ -50,8 +50,7 @@ export default function LoginPage() {
         setUserId(data.user_id)
       } else {
         // No passkeys, login successful
-        setAuth(data.token, data.user)
-        api.setToken(data.token)
+        setAuth(data)
         router.push('/dashboard')
       }
     } catch (err: unknown) {
@@ -93,8 +92,7 @@ export default function LoginPage() {
       const data = await response.json()
 
       // Store auth data and redirect
-      setAuth(data.token, data.user)
-      api.setToken(data.token)
+      setAuth(data)
       router.push('/dashboard')
     } catch (err: unknown) {
       const message = err instanceof Error ? err.message : 'Passkey verification failed'
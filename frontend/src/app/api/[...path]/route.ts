@@ -16,6 +16,11 @@ async function proxyRequest(request: NextRequest, params: Promise<{ path: string
     headers['Authorization'] = auth
   }
 
+  const workspace = request.headers.get('X-Workspace-ID')
+  if (workspace) {
+    headers['X-Workspace-ID'] = workspace
+  }
+
   const fetchOptions: RequestInit = {
     method: request.method,
     headers,
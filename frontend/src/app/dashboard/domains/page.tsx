@@ -32,10 +32,10 @@ export default function DomainsPage() {
     if (!confirm('Are you sure you want to delete this domain?')) return;
 
     try {
-      await api.deleteDomain(id);
+      await api.deleteDomain(id, 'block');
       setDomains(domains.filter(d => d.id !== id));
     } catch (err) {
-      setError('Failed to delete domain');
+      setError(err instanceof Error ? err.message : 'Failed to delete domain');
     }
   };
 
@@ -1,4 +1,4 @@
-import type { AuthResponse, User, Link, LinksListResponse, LinkStats, Passkey, Domain, DomainsListResponse } from '@/types';
+import type { AuthResponse, User, Link, LinksListResponse, LinkStats, OverviewStats, StatsInterval, Passkey, Domain, DomainStats, DomainsListResponse, UpdateDomainRequest, DomainDeleteStrategy, DeleteDomainResult, DomainCertificate, Workspace, WorkspacesListResponse, AuditListResponse, Webhook, CreatedWebhook, WebhookDelivery, WebhookEvent } from '@/types';
 import type { PublicKeyCredentialCreationOptionsJSON, PublicKeyCredentialRequestOptionsJSON } from '@simplewebauthn/browser';
 
 // API requests go through Next.js API route proxy (/api/[...path])
@@ -7,14 +7,60 @@ const API_BASE = '';
 
 class ApiClient {
   private token: string | null = null;
+  private refreshToken: string | null = null;
+  private refreshing: Promise<boolean> | null = null;
+  // Workspace requests act in; null means the personal workspace
+  private workspaceId: number | null = null;
+  // Called whenever a refresh replaces the tokens, so they can be persisted
+  onTokensRefreshed: ((response: AuthResponse) => void) | null = null;
+  // Called when the session can no longer be refreshed
+  onSessionExpired: (() => void) | null = null;
 
   setToken(token: string | null) {
     this.token = token;
   }
 
+  setRefreshToken(refreshToken: string | null) {
+    this.refreshToken = refreshToken;
+  }
+
+  setWorkspace(workspaceId: number | null) {
+    this.workspaceId = workspaceId;
+  }
+
+  // Access tokens are short-lived; trade the refresh token for a new pair.
+  // Concurrent requests share one refresh, as each refresh token works once.
+  private refreshSession(): Promise<boolean> {
+    if (!this.refreshing) {
+      this.refreshing = (async () => {
+        if (!this.refreshToken) return false;
+        const response = await fetch(`${API_BASE}/api/auth/refresh`, {
+          method: 'POST',
+          headers: { 'Content-Type': 'application/json' },
+          body: JSON.stringify({ refresh_token: this.refreshToken }),
+        });
+        if (!response.ok) {
+          this.token = null;
+          this.refreshToken = null;
+          this.onSessionExpired?.();
+          return false;
+        }
+        const data: AuthResponse = await response.json();
+        this.token = data.token;
+        this.refreshToken = data.refresh_token;
+        this.onTokensRefreshed?.(data);
+        return true;
+      })().finally(() => {
+        this.refreshing = null;
+      });
+    }
+    return this.refreshing;
+  }
+
   private async request<T>(
     endpoint: string,
-    options: RequestInit = {}
+    options: RequestInit = {},
+    retried = false
   ): Promise<T> {
     const headers: Record<string, string> = {
       'Content-Type': 'application/json',
@@ -24,6 +70,10 @@ class ApiClient {
       headers['Authorization'] = `Bearer ${this.token}`;
     }
 
+    if (this.workspaceId !== null) {
+      headers['X-Workspace-ID'] = String(this.workspaceId);
+    }
+
     // Merge any additional headers from options
     if (options.headers) {
       const optionHeaders = options.headers as Record<string, string>;
@@ -35,6 +85,10 @@ class ApiClient {
       headers,
     });
 
+    if (response.status === 401 && !retried && this.refreshToken && await this.refreshSession()) {
+      return this.request<T>(endpoint, options, true);
+    }
+
     if (!response.ok) {
       const error = await response.json().catch(() => ({}));
       throw new Error(error.error || 'Request failed');
@@ -62,6 +116,10 @@ class ApiClient {
     });
   }
 
+  async logout() {
+    return this.request<{ message: string }>('/api/auth/logout', { method: 'POST' });
+  }
+
   async me() {
     return this.request<User>('/api/auth/me');
   }
@@ -118,6 +176,16 @@ class ApiClient {
     return this.request<LinkStats>(`/api/links/${id}/stats`);
   }
 
+  // from and to are RFC 3339 timestamps; the server defaults to the last 30 days
+  async getStatsOverview(params: { from?: string; to?: string; interval?: StatsInterval } = {}): Promise<OverviewStats> {
+    const query = new URLSearchParams();
+    if (params.from) query.set('from', params.from);
+    if (params.to) query.set('to', params.to);
+    if (params.interval) query.set('interval', params.interval);
+    const qs = query.toString();
+    return this.request<OverviewStats>(`/api/stats/overview${qs ? `?${qs}` : ''}`);
+  }
+
   // Profile
   async updateProfile(displayName: string): Promise<User> {
     return this.request('/api/auth/me', {
@@ -158,18 +226,111 @@ class ApiClient {
     return this.request<DomainsListResponse>('/api/domains');
   }
 
-  async createDomain(domain: string): Promise<Domain> {
+  async createDomain(domain: string, pathPrefix?: string): Promise<Domain> {
     return this.request<Domain>('/api/domains', {
       method: 'POST',
-      body: JSON.stringify({ domain }),
+      body: JSON.stringify({ domain, path_prefix: pathPrefix }),
     });
   }
 
-  async deleteDomain(id: number): Promise<void> {
-    return this.request<void>(`/api/domains/${id}`, {
+  async getDomainStats(id: number): Promise<DomainStats> {
+    return this.request<DomainStats>(`/api/domains/${id}/stats`);
+  }
+
+  async updateDomain(id: number, data: UpdateDomainRequest): Promise<Domain> {
+    return this.request<Domain>(`/api/domains/${id}`, {
+      method: 'PUT',
+      body: JSON.stringify(data),
+    });
+  }
+
+  // targetDomainId is where 'move' puts the links; 0 is the service's own domain
+  async deleteDomain(id: number, strategy: DomainDeleteStrategy, targetDomainId?: number): Promise<DeleteDomainResult> {
+    const params = new URLSearchParams({ strategy });
+    if (targetDomainId !== undefined) params.set('target_domain_id', String(targetDomainId));
+    return this.request<DeleteDomainResult>(`/api/domains/${id}?${params}`, {
       method: 'DELETE',
     });
   }
+
+  async verifyDomain(id: number): Promise<Domain> {
+    return this.request<Domain>(`/api/domains/${id}/verify`, {
+      method: 'POST',
+    });
+  }
+
+  async getDomainCertificate(id: number): Promise<DomainCertificate> {
+    return this.request<DomainCertificate>(`/api/domains/${id}/certificate`);
+  }
+
+  async uploadDomainCertificate(id: number, certificate: string, privateKey: string): Promise<DomainCertificate> {
+    return this.request<DomainCertificate>(`/api/domains/${id}/certificate`, {
+      method: 'PUT',
+      body: JSON.stringify({ certificate, private_key: privateKey }),
+    });
+  }
+
+  // The domain must already point at the redirect server for the HTTP-01 challenge
+  async requestDomainCertificate(id: number): Promise<DomainCertificate> {
+    return this.request<DomainCertificate>(`/api/domains/${id}/certificate/acme`, {
+      method: 'POST',
+    });
+  }
+
+  async deleteDomainCertificate(id: number): Promise<void> {
+    return this.request<void>(`/api/domains/${id}/certificate`, {
+      method: 'DELETE',
+    });
+  }
+
+  // Workspaces
+  async getWorkspaces(): Promise<WorkspacesListResponse> {
+    return this.request<WorkspacesListResponse>('/api/workspaces');
+  }
+
+  async createWorkspace(name: string): Promise<Workspace> {
+    return this.request<Workspace>('/api/workspaces', {
+      method: 'POST',
+      body: JSON.stringify({ name }),
+    });
+  }
+
+  async acceptInvitation(token: string): Promise<Workspace> {
+    return this.request<Workspace>('/api/invitations/accept', {
+      method: 'POST',
+      body: JSON.stringify({ token }),
+    });
+  }
+
+  // Audit log
+  async getAuditLog(filters: Record<string, string> = {}) {
+    const query = new URLSearchParams(filters).toString();
+    return this.request<AuditListResponse>(`/api/audit${query ? `?${query}` : ''}`);
+  }
+
+  // Webhooks
+  async getWebhooks() {
+    return this.request<{ webhooks: Webhook[] }>('/api/webhooks');
+  }
+
+  async createWebhook(url: string, events: WebhookEvent[]): Promise<CreatedWebhook> {
+    return this.request<CreatedWebhook>('/api/webhooks', {
+      method: 'POST',
+      body: JSON.stringify({ url, events }),
+    });
+  }
+
+  async deleteWebhook(id: number) {
+    return this.request(`/api/webhooks/${id}`, { method: 'DELETE' });
+  }
+
+  async getWebhookDeliveries(id: number) {
+    return this.request<{ deliveries: WebhookDelivery[] }>(`/api/webhooks/${id}/deliveries`);
+  }
+
+  async replayWebhookDelivery(id: number, deliveryId: number): Promise<WebhookDelivery> {
+    return this.request<WebhookDelivery>(`/api/webhooks/${id}/deliveries/${deliveryId}/replay`, { method: 'POST' });
+  }
 }
 
 export const api = new ApiClient();
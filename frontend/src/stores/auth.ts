@@ -1,17 +1,18 @@
 import { create } from 'zustand';
 import { persist } from 'zustand/middleware';
 import { api } from '@/lib/api';
-import type { User } from '@/types';
+import type { AuthResponse, User } from '@/types';
 
 interface AuthState {
   user: User | null;
   token: string | null;
+  refreshToken: string | null;
   isLoading: boolean;
   login: (email: string, password: string) => Promise<void>;
   register: (email: string, password: string) => Promise<void>;
   logout: () => void;
   checkAuth: () => Promise<void>;
-  setAuth: (token: string, user: User) => void;
+  setAuth: (response: AuthResponse) => void;
 }
 
 export const useAuthStore = create<AuthState>()(
@@ -19,49 +20,63 @@ export const useAuthStore = create<AuthState>()(
     (set, get) => ({
       user: null,
       token: null,
+      refreshToken: null,
       isLoading: true,
 
       login: async (email: string, password: string) => {
         const response = await api.login(email, password);
-        api.setToken(response.token);
-        set({ user: response.user, token: response.token });
+        get().setAuth(response);
       },
 
       register: async (email: string, password: string) => {
         const response = await api.register(email, password);
-        api.setToken(response.token);
-        set({ user: response.user, token: response.token });
+        get().setAuth(response);
       },
 
       logout: () => {
+        // End the session server-side too; signing out locally must not wait on it
+        if (get().token) {
+          api.logout().catch(() => {});
+        }
         api.setToken(null);
-        set({ user: null, token: null });
+        api.setRefreshToken(null);
+        set({ user: null, token: null, refreshToken: null });
       },
 
       checkAuth: async () => {
-        const { token } = get();
+        const { token, refreshToken } = get();
         if (!token) {
           set({ isLoading: false });
           return;
         }
 
         api.setToken(token);
+        api.setRefreshToken(refreshToken);
         try {
           const user = await api.me();
           set({ user, isLoading: false });
         } catch {
-          set({ user: null, token: null, isLoading: false });
+          set({ user: null, token: null, refreshToken: null, isLoading: false });
         }
       },
 
-      setAuth: (token: string, user: User) => {
-        api.setToken(token);
-        set({ user, token });
+      setAuth: (response: AuthResponse) => {
+        api.setToken(response.token);
+        api.setRefreshToken(response.refresh_token);
+        set({ user: response.user, token: response.token, refreshToken: response.refresh_token });
       },
     }),
     {
       name: 'auth-storage',
-      partialize: (state) => ({ token: state.token }),
+      partialize: (state) => ({ token: state.token, refreshToken: state.refreshToken }),
     }
   )
 );
+
+api.onTokensRefreshed = (response) => {
+  useAuthStore.setState({ token: response.token, refreshToken: response.refresh_token });
+};
+
+api.onSessionExpired = () => {
+  useAuthStore.setState({ user: null, token: null, refreshToken: null });
+};
@@ -9,6 +9,7 @@ export interface User {
 export interface Link {
   id: number;
   user_id: number;
+  workspace_id: number;
   short_code: string;
   original_url: string;
   title?: string;
@@ -23,16 +24,148 @@ export interface Link {
 export interface Domain {
   id: number;
   user_id: number;
+  workspace_id: number;
+  // A host, or a wildcard such as *.links.example.com
   domain: string;
+  // Codes live at <domain>/<path_prefix>/<code> when set
+  path_prefix: string;
+  // Publish as a TXT record at _urlshortener-challenge.<domain>, without
+  // the "*." of a wildcard
+  verification_token?: string;
+  verified_at?: string;
+  last_checked_at?: string;
+  code_strategy?: string;
+  code_length?: number;
+  use_https: boolean;
+  redirect_status?: number;
+  default_expiry_days?: number;
+  default_utm_source?: string;
+  default_utm_medium?: string;
+  default_utm_campaign?: string;
+  is_default: boolean;
+  root_redirect_url?: string;
+  not_found_url?: string;
+  not_found_html?: string;
+  expired_url?: string;
   created_at: string;
 }
 
+// Omitted fields are left unchanged; an empty string or 0 clears a setting
+export interface UpdateDomainRequest {
+  root_redirect_url?: string;
+  not_found_url?: string;
+  not_found_html?: string;
+  expired_url?: string;
+  use_https?: boolean;
+  redirect_status?: 301 | 302 | 307 | 308 | 0;
+  code_strategy?: string;
+  code_length?: number;
+  default_expiry_days?: number;
+  default_utm_source?: string;
+  default_utm_medium?: string;
+  default_utm_campaign?: string;
+  is_default?: boolean;
+}
+
+export interface DomainCertificate {
+  id: number;
+  domain_id: number;
+  source: 'upload' | 'acme';
+  certificate_pem: string;
+  issuer: string;
+  not_before: string;
+  not_after: string;
+  // Latest failed renewal of an ACME certificate
+  last_error?: string;
+  created_at: string;
+  updated_at: string;
+}
+
+// block refuses while the domain has links, move puts them on another
+// domain, archive trashes them along with the domain
+export type DomainDeleteStrategy = 'block' | 'move' | 'archive';
+
+export interface DeleteDomainResult {
+  strategy: DomainDeleteStrategy;
+  moved: number;
+  archived: number;
+}
+
 export interface DomainsListResponse {
   domains: Domain[];
 }
 
+export type WorkspaceRole = 'owner' | 'admin' | 'editor' | 'viewer';
+
+export interface Workspace {
+  id: number;
+  name: string;
+  personal_user_id?: number;
+  role: WorkspaceRole;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface WorkspacesListResponse {
+  workspaces: Workspace[];
+}
+
+export interface AuditEntry {
+  id: number;
+  workspace_id?: number;
+  actor_user_id?: number;
+  action: string;
+  target_type: string;
+  target_id?: number;
+  changes?: {
+    before?: Record<string, unknown>;
+    after?: Record<string, unknown>;
+  };
+  ip_address: string;
+  user_agent: string;
+  created_at: string;
+}
+
+export interface AuditListResponse {
+  entries: AuditEntry[];
+}
+
+export type WebhookEvent = 'link.created' | 'link.updated' | 'link.expired' | 'link.clicked';
+
+export interface Webhook {
+  id: number;
+  user_id: number;
+  workspace_id: number;
+  url: string;
+  events: WebhookEvent[];
+  is_active: boolean;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface CreatedWebhook {
+  webhook: Webhook;
+  secret: string;
+}
+
+export interface WebhookDelivery {
+  id: number;
+  webhook_id: number;
+  event: WebhookEvent;
+  payload: unknown;
+  status: 'pending' | 'succeeded' | 'failed';
+  attempts: number;
+  next_attempt_at: string;
+  response_status?: number;
+  last_error?: string;
+  delivered_at?: string;
+  created_at: string;
+}
+
 export interface AuthResponse {
   token: string;
+  token_expires_at: string;
+  refresh_token: string;
   user: User;
 }
 
@@ -92,6 +225,61 @@ export interface LinkStats {
   locations: LocationStats;
 }
 
+export interface LinkClickStats {
+  link_id: number;
+  short_code: string;
+  clicks: number;
+}
+
+export type MissReason = 'not_found' | 'expired';
+
+// A code requested on a domain that found no link to follow
+export interface MissStats {
+  code: string;
+  reason: MissReason;
+  count: number;
+  last_seen: string;
+}
+
+export interface DomainStats {
+  domain_id: number;
+  total_clicks: number;
+  unique_visitors: number;
+  daily_stats: DailyStats[];
+  top_links: LinkClickStats[];
+  top_referrers: ReferrerStats[];
+  device_stats: DeviceStats[];
+  countries: CountryStats[];
+  not_found: number;
+  expired: number;
+  top_misses: MissStats[];
+}
+
+export type StatsInterval = 'day' | 'hour';
+
+export interface ClickSeriesPoint {
+  // "2006-01-02" for days, "2006-01-02 15:00" for hours
+  period: string;
+  clicks: number;
+}
+
+export interface OverviewStats {
+  from: string;
+  to: string;
+  interval: StatsInterval;
+  total_clicks: number;
+  unique_visitors: number;
+  series: ClickSeriesPoint[];
+  top_links: LinkClickStats[];
+  top_referrers: ReferrerStats[];
+  device_stats: DeviceStats[];
+  browser_stats: BrowserStats[];
+  locations: LocationStats;
+  links_created: number;
+  // Links expiring in the next 7 days
+  expiring_soon: number;
+}
+
 export interface Passkey {
   id: number;
   user_id: number;